@@ -0,0 +1,143 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/keeee21/commitly/api/usecase"
+)
+
+// sendCircleDigests 配信設定のあるサークルに週次ダイジェストを投稿する
+// 集計期間の開始日ごとにサークルの配信ログを残し、送信済みのサークルには送らない
+func sendCircleDigests(ctx context.Context, deps ISendNotificationsDeps, config SendNotificationsConfig, report *RunReport, now time.Time) error {
+	settings, err := deps.GetCircleNotificationRepo().FindAllEnabled(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get enabled circle notification settings: %w", err)
+	}
+	log.Printf("Found %d circles with weekly digests enabled", len(settings))
+
+	digestUsecase := usecase.NewCircleDigestUsecase(deps.GetCommitStatsRepo())
+	poster := deps.GetCircleDigestPoster()
+
+	for i := range settings {
+		setting := &settings[i]
+		circle := &setting.Circle
+		target := fmt.Sprintf("circle %d (%s)", circle.ID, setting.ChannelType)
+
+		anchor := now
+		if config.DueOnly {
+			slot, err := lastScheduledSlot(setting.Schedule(), "weekly", now)
+			if err != nil {
+				log.Printf("Skipping circle %d: invalid digest schedule: %v", circle.ID, err)
+				continue
+			}
+			if now.Sub(slot) >= ScheduleDueWindow {
+				continue
+			}
+			anchor = slot
+		}
+
+		periodStart := notifier.PeriodStart("weekly", anchor)
+		existing, err := deps.GetCircleDigestLogRepo().FindByPeriodStart(ctx, circle.ID, periodStart)
+		if err != nil {
+			// 送信済みかどうか分からないまま送ると二重送信になるため、このサークルは送らない
+			report.AddFailure(target, fmt.Errorf("failed to get circle digest log: %w", err))
+			continue
+		}
+		if existing != nil && existing.Status == models.NotificationStatusSuccess {
+			log.Printf("Skipping weekly digest to circle %d: already sent", circle.ID)
+			report.AddSkip()
+			continue
+		}
+
+		sentAt := time.Now()
+		payload, sendErr := deliverCircleDigest(ctx, digestUsecase, poster, config, setting, anchor)
+		if sendErr != nil {
+			report.AddFailure(target, sendErr)
+		} else {
+			report.AddSuccess()
+		}
+
+		if config.DryRun {
+			continue
+		}
+		recordCircleDigest(ctx, deps, existing, setting, periodStart, payload, sendErr, sentAt)
+	}
+	return nil
+}
+
+// deliverCircleDigest ダイジェストを集計・レンダリングしてサークルのWebhookに投稿する
+func deliverCircleDigest(
+	ctx context.Context,
+	digestUsecase usecase.ICircleDigestUsecase,
+	poster notifier.ICircleDigestPoster,
+	config SendNotificationsConfig,
+	setting *models.CircleNotificationSetting,
+	anchor time.Time,
+) (models.JSONPayload, error) {
+	digest, err := digestUsecase.BuildDigest(ctx, &setting.Circle, anchor)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build circle digest: %w", err)
+	}
+	message, err := poster.Render(setting, digest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render circle digest: %w", err)
+	}
+
+	if config.DryRun {
+		return message.Payload, writeDryRunRecord(config.DryRunOutput, DryRunRecord{
+			UserID:         setting.Circle.OwnerUserID,
+			GithubUsername: setting.Circle.Owner.GithubUsername,
+			ChannelType:    setting.ChannelType,
+			Period:         fmt.Sprintf("circle_digest:%d", setting.CircleID),
+			Message:        message.Body,
+		})
+	}
+	if err := poster.Post(ctx, setting, message); err != nil {
+		return message.Payload, err
+	}
+
+	log.Printf("Sent weekly digest to circle %d via %s (members: %d)", setting.CircleID, setting.ChannelType, len(digest.Members))
+	return message.Payload, nil
+}
+
+// recordCircleDigest サークルの配信ログを保存する（同じ集計期間の失敗ログがあれば更新する）
+func recordCircleDigest(
+	ctx context.Context,
+	deps ISendNotificationsDeps,
+	existing *models.CircleDigestLog,
+	setting *models.CircleNotificationSetting,
+	periodStart time.Time,
+	payload models.JSONPayload,
+	sendErr error,
+	sentAt time.Time,
+) {
+	digestLog := existing
+	if digestLog == nil {
+		digestLog = &models.CircleDigestLog{
+			CircleID:    setting.CircleID,
+			PeriodStart: periodStart,
+		}
+	}
+	digestLog.ChannelType = setting.ChannelType
+	digestLog.Payload = payload
+	digestLog.SentAt = sentAt
+	digestLog.Status = models.NotificationStatusSuccess
+	digestLog.ErrorMessage = ""
+	if sendErr != nil {
+		digestLog.Status = models.NotificationStatusFailed
+		digestLog.ErrorMessage = sendErr.Error()
+	}
+
+	save := deps.GetCircleDigestLogRepo().Create
+	if existing != nil {
+		save = deps.GetCircleDigestLogRepo().Update
+	}
+	if err := save(ctx, digestLog); err != nil {
+		log.Printf("Failed to save circle digest log for circle %d: %v", setting.CircleID, err)
+	}
+}
@@ -0,0 +1,332 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockCircleNotificationSettingRepository テスト用のモック
+type mockCircleNotificationSettingRepository struct {
+	FindByCircleIDFunc func(ctx context.Context, circleID uint64) (*models.CircleNotificationSetting, error)
+	FindAllEnabledFunc func(ctx context.Context) ([]models.CircleNotificationSetting, error)
+	UpsertFunc         func(ctx context.Context, setting *models.CircleNotificationSetting) error
+	DeleteFunc         func(ctx context.Context, circleID uint64) error
+}
+
+func (m *mockCircleNotificationSettingRepository) FindByCircleID(ctx context.Context, circleID uint64) (*models.CircleNotificationSetting, error) {
+	if m.FindByCircleIDFunc != nil {
+		return m.FindByCircleIDFunc(ctx, circleID)
+	}
+	return nil, nil
+}
+
+func (m *mockCircleNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.CircleNotificationSetting, error) {
+	if m.FindAllEnabledFunc != nil {
+		return m.FindAllEnabledFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockCircleNotificationSettingRepository) Upsert(ctx context.Context, setting *models.CircleNotificationSetting) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, setting)
+	}
+	return nil
+}
+
+func (m *mockCircleNotificationSettingRepository) Delete(ctx context.Context, circleID uint64) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, circleID)
+	}
+	return nil
+}
+
+// mockCircleDigestLogRepository テスト用のモック
+type mockCircleDigestLogRepository struct {
+	FindByPeriodStartFunc func(ctx context.Context, circleID uint64, periodStart time.Time) (*models.CircleDigestLog, error)
+	CreateFunc            func(ctx context.Context, digestLog *models.CircleDigestLog) error
+	UpdateFunc            func(ctx context.Context, digestLog *models.CircleDigestLog) error
+}
+
+func (m *mockCircleDigestLogRepository) FindByPeriodStart(ctx context.Context, circleID uint64, periodStart time.Time) (*models.CircleDigestLog, error) {
+	if m.FindByPeriodStartFunc != nil {
+		return m.FindByPeriodStartFunc(ctx, circleID, periodStart)
+	}
+	return nil, nil
+}
+
+func (m *mockCircleDigestLogRepository) Create(ctx context.Context, digestLog *models.CircleDigestLog) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, digestLog)
+	}
+	return nil
+}
+
+func (m *mockCircleDigestLogRepository) Update(ctx context.Context, digestLog *models.CircleDigestLog) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, digestLog)
+	}
+	return nil
+}
+
+// circleDigestTestDeps メンバー2人のサークル1つ（Slackに投稿）の依存関係を組み立てる
+func circleDigestTestDeps(setting models.CircleNotificationSetting, digestLogRepo *mockCircleDigestLogRepository, slackGateway *mockSlackGateway) *testDeps {
+	setting.Circle = models.Circle{
+		ID:          7,
+		Name:        "勉強会仲間",
+		OwnerUserID: 1,
+		Owner:       models.User{ID: 1, GithubUsername: "owner", Locale: "en"},
+		Members: []models.CircleMember{
+			{CircleID: 7, UserID: 1, User: models.User{ID: 1, GithubUserID: 100, GithubUsername: "owner"}},
+			{CircleID: 7, UserID: 2, User: models.User{ID: 2, GithubUserID: 200, GithubUsername: "member"}},
+		},
+	}
+	return &testDeps{
+		notificationLogRepo: &mockNotificationLogRepository{},
+		circleNotificationRepo: &mockCircleNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.CircleNotificationSetting, error) {
+				return []models.CircleNotificationSetting{setting}, nil
+			},
+		},
+		circleDigestLogRepo: digestLogRepo,
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDsAndDateRangeFunc: func(ctx context.Context, githubUserIDs []uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				return []models.CommitStats{
+					{GithubUserID: 100, Date: endDate, Repository: "owner/app", CommitCount: 2},
+					{GithubUserID: 200, Date: endDate, Repository: "member/lib", CommitCount: 5},
+				}, nil
+			},
+		},
+		slackGateway: slackGateway,
+	}
+}
+
+func TestRunSendNotifications_PostsCircleDigest(t *testing.T) {
+	var sent *gateway.SlackMessage
+	var saved *models.CircleDigestLog
+
+	digestLogRepo := &mockCircleDigestLogRepository{
+		CreateFunc: func(ctx context.Context, digestLog *models.CircleDigestLog) error {
+			saved = digestLog
+			return nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			assert.Equal(t, "https://hooks.slack.com/services/circle", webhookURL)
+			sent = message
+			return nil
+		},
+	}
+	deps := circleDigestTestDeps(models.CircleNotificationSetting{
+		CircleID:    7,
+		ChannelType: models.ChannelTypeSlack,
+		WebhookURL:  "https://hooks.slack.com/services/circle",
+		IsEnabled:   true,
+	}, digestLogRepo, slackGateway)
+
+	report, err := RunSendNotifications(context.Background(), deps, SendNotificationsConfig{Period: "weekly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	if assert.NotNil(t, sent) {
+		assert.Contains(t, sent.Text, "勉強会仲間 Weekly Digest")
+		body, _ := json.Marshal(sent.Blocks)
+		// リーダーボードはコミット数の多い順
+		assert.Contains(t, string(body), `1. member: 5 commits\n2. owner: 2 commits`)
+		assert.Contains(t, string(body), "member/lib")
+	}
+	if assert.NotNil(t, saved) {
+		assert.Equal(t, uint64(7), saved.CircleID)
+		assert.Equal(t, models.NotificationStatusSuccess, saved.Status)
+	}
+}
+
+func TestRunSendNotifications_SkipsCircleDigestAlreadySent(t *testing.T) {
+	digestLogRepo := &mockCircleDigestLogRepository{
+		FindByPeriodStartFunc: func(ctx context.Context, circleID uint64, periodStart time.Time) (*models.CircleDigestLog, error) {
+			return &models.CircleDigestLog{ID: 1, CircleID: circleID, Status: models.NotificationStatusSuccess}, nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			t.Fatal("digest already sent for this week should not be sent again")
+			return nil
+		},
+	}
+	deps := circleDigestTestDeps(models.CircleNotificationSetting{
+		CircleID:    7,
+		ChannelType: models.ChannelTypeSlack,
+		WebhookURL:  "https://hooks.slack.com/services/circle",
+		IsEnabled:   true,
+	}, digestLogRepo, slackGateway)
+
+	report, err := RunSendNotifications(context.Background(), deps, SendNotificationsConfig{Period: "weekly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SkipCount)
+}
+
+func TestRunSendNotifications_RetriesFailedCircleDigest(t *testing.T) {
+	var updated *models.CircleDigestLog
+
+	digestLogRepo := &mockCircleDigestLogRepository{
+		FindByPeriodStartFunc: func(ctx context.Context, circleID uint64, periodStart time.Time) (*models.CircleDigestLog, error) {
+			return &models.CircleDigestLog{ID: 3, CircleID: circleID, Status: models.NotificationStatusFailed, ErrorMessage: "timeout"}, nil
+		},
+		CreateFunc: func(ctx context.Context, digestLog *models.CircleDigestLog) error {
+			t.Fatal("failed log for the same week should be updated instead")
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, digestLog *models.CircleDigestLog) error {
+			updated = digestLog
+			return nil
+		},
+	}
+	deps := circleDigestTestDeps(models.CircleNotificationSetting{
+		CircleID:    7,
+		ChannelType: models.ChannelTypeSlack,
+		WebhookURL:  "https://hooks.slack.com/services/circle",
+		IsEnabled:   true,
+	}, digestLogRepo, &mockSlackGateway{})
+
+	report, err := RunSendNotifications(context.Background(), deps, SendNotificationsConfig{Period: "weekly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	if assert.NotNil(t, updated) {
+		assert.Equal(t, uint64(3), updated.ID)
+		assert.Equal(t, models.NotificationStatusSuccess, updated.Status)
+		assert.Empty(t, updated.ErrorMessage)
+	}
+}
+
+func TestRunSendNotifications_RecordsFailedCircleDigest(t *testing.T) {
+	var saved *models.CircleDigestLog
+
+	digestLogRepo := &mockCircleDigestLogRepository{
+		CreateFunc: func(ctx context.Context, digestLog *models.CircleDigestLog) error {
+			saved = digestLog
+			return nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			return errors.New("webhook error")
+		},
+	}
+	deps := circleDigestTestDeps(models.CircleNotificationSetting{
+		CircleID:    7,
+		ChannelType: models.ChannelTypeSlack,
+		WebhookURL:  "https://hooks.slack.com/services/circle",
+		IsEnabled:   true,
+	}, digestLogRepo, slackGateway)
+
+	report, err := RunSendNotifications(context.Background(), deps, SendNotificationsConfig{Period: "weekly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.FailureCount)
+	if assert.NotNil(t, saved) {
+		assert.Equal(t, models.NotificationStatusFailed, saved.Status)
+		assert.Contains(t, saved.ErrorMessage, "webhook error")
+	}
+}
+
+func TestRunSendNotifications_CircleDigestDueOnly(t *testing.T) {
+	// 配信予定時刻を過ぎたばかりのサークルだけに送る
+	now := time.Now().In(time.UTC)
+	due := models.CircleNotificationSetting{
+		CircleID:     7,
+		ChannelType:  models.ChannelTypeSlack,
+		WebhookURL:   "https://hooks.slack.com/services/circle",
+		Weekday:      int(now.Weekday()),
+		DeliveryTime: now.Add(-time.Hour).Format("15:04"),
+		Timezone:     "UTC",
+		IsEnabled:    true,
+	}
+	if now.Hour() == 0 {
+		// 日付をまたぐと曜日がずれるため、前日の曜日にする
+		due.Weekday = int(now.Add(-time.Hour).Weekday())
+	}
+	notDue := due
+	notDue.Weekday = (due.Weekday + 3) % 7
+
+	for name, tt := range map[string]struct {
+		setting      models.CircleNotificationSetting
+		expectedSent bool
+	}{
+		"due":     {setting: due, expectedSent: true},
+		"not due": {setting: notDue, expectedSent: false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			sent := false
+			slackGateway := &mockSlackGateway{
+				SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+					sent = true
+					return nil
+				},
+			}
+			deps := circleDigestTestDeps(tt.setting, &mockCircleDigestLogRepository{}, slackGateway)
+
+			_, err := RunSendNotifications(context.Background(), deps, SendNotificationsConfig{Period: "weekly", DueOnly: true})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSent, sent)
+		})
+	}
+}
+
+func TestRunSendNotifications_CircleDigestDryRun(t *testing.T) {
+	var out bytes.Buffer
+	digestLogRepo := &mockCircleDigestLogRepository{
+		CreateFunc: func(ctx context.Context, digestLog *models.CircleDigestLog) error {
+			t.Fatal("dry run should not save digest logs")
+			return nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			t.Fatal("dry run should not post the digest")
+			return nil
+		},
+	}
+	deps := circleDigestTestDeps(models.CircleNotificationSetting{
+		CircleID:    7,
+		ChannelType: models.ChannelTypeSlack,
+		WebhookURL:  "https://hooks.slack.com/services/circle",
+		IsEnabled:   true,
+	}, digestLogRepo, slackGateway)
+
+	_, err := RunSendNotifications(context.Background(), deps, SendNotificationsConfig{Period: "weekly", DryRun: true, DryRunOutput: &out})
+
+	assert.NoError(t, err)
+	var record DryRunRecord
+	if assert.NoError(t, json.Unmarshal(out.Bytes(), &record)) {
+		assert.Equal(t, "circle_digest:7", record.Period)
+		assert.Equal(t, "owner", record.GithubUsername)
+	}
+}
+
+func TestRunSendNotifications_MonthlySkipsCircleDigests(t *testing.T) {
+	deps := &testDeps{
+		notificationLogRepo: &mockNotificationLogRepository{},
+		circleNotificationRepo: &mockCircleNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.CircleNotificationSetting, error) {
+				t.Fatal("circle digests are weekly only")
+				return nil, nil
+			},
+		},
+	}
+
+	_, err := RunSendNotifications(context.Background(), deps, SendNotificationsConfig{Period: "monthly"})
+
+	assert.NoError(t, err)
+}
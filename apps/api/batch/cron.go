@@ -0,0 +1,165 @@
+package batch
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField cron式の1フィールドの値の範囲
+type cronField struct {
+	name string
+	min  int
+	max  int
+}
+
+var (
+	cronMinute  = cronField{name: "minute", min: 0, max: 59}
+	cronHour    = cronField{name: "hour", min: 0, max: 23}
+	cronDay     = cronField{name: "day of month", min: 1, max: 31}
+	cronMonth   = cronField{name: "month", min: 1, max: 12}
+	cronWeekday = cronField{name: "day of week", min: 0, max: 7}
+)
+
+// maxCronSearchYears 次回実行日時を探索する上限（存在しない日付指定での無限ループ防止）
+const maxCronSearchYears = 5
+
+// CronSchedule 5フィールド形式（分 時 日 月 曜日）のcron式
+type CronSchedule struct {
+	expr     string
+	minutes  map[int]bool
+	hours    map[int]bool
+	days     map[int]bool
+	months   map[int]bool
+	weekdays map[int]bool
+	// 日と曜日の両方が指定された場合はどちらかに一致すればよい（標準cronと同じ）
+	dayRestricted     bool
+	weekdayRestricted bool
+	location          *time.Location
+}
+
+// ParseCronSchedule cron式をパースする
+// 各フィールドは "*", "5", "1-5", "*/15", "1,15", "0-30/10" の形式に対応する
+func ParseCronSchedule(expr string, location *time.Location) (*CronSchedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+	if location == nil {
+		location = time.UTC
+	}
+
+	schedule := &CronSchedule{expr: expr, location: location}
+	var err error
+	if schedule.minutes, err = parseCronField(fields[0], cronMinute); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	if schedule.hours, err = parseCronField(fields[1], cronHour); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	if schedule.days, err = parseCronField(fields[2], cronDay); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	if schedule.months, err = parseCronField(fields[3], cronMonth); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	if schedule.weekdays, err = parseCronField(fields[4], cronWeekday); err != nil {
+		return nil, fmt.Errorf("invalid cron expression %q: %w", expr, err)
+	}
+	// 曜日は日曜を 0 と 7 のどちらでも指定できる
+	if schedule.weekdays[7] {
+		schedule.weekdays[0] = true
+		delete(schedule.weekdays, 7)
+	}
+	schedule.dayRestricted = fields[2] != "*"
+	schedule.weekdayRestricted = fields[4] != "*"
+
+	return schedule, nil
+}
+
+// String 元のcron式を返す
+func (s *CronSchedule) String() string {
+	return s.expr
+}
+
+// Next 指定日時より後の次回実行日時を返す（見つからない場合はゼロ値）
+func (s *CronSchedule) Next(after time.Time) time.Time {
+	t := after.In(s.location).Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(maxCronSearchYears, 0, 0)
+
+	for t.Before(limit) {
+		if !s.months[int(t.Month())] {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.hours[t.Hour()] {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, s.location)
+			continue
+		}
+		if !s.minutes[t.Minute()] {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *CronSchedule) matchDay(t time.Time) bool {
+	dayMatch := s.days[t.Day()]
+	weekdayMatch := s.weekdays[int(t.Weekday())]
+	if s.dayRestricted && s.weekdayRestricted {
+		return dayMatch || weekdayMatch
+	}
+	return dayMatch && weekdayMatch
+}
+
+func parseCronField(field string, spec cronField) (map[int]bool, error) {
+	values := map[int]bool{}
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			rangePart = part[:i]
+			n, err := strconv.Atoi(part[i+1:])
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step in %s field: %q", spec.name, part)
+			}
+			step = n
+		}
+
+		start, end := spec.min, spec.max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			start, err1 = strconv.Atoi(bounds[0])
+			end, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return nil, fmt.Errorf("invalid range in %s field: %q", spec.name, part)
+			}
+		default:
+			n, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value in %s field: %q", spec.name, part)
+			}
+			start = n
+			if step == 1 {
+				end = n
+			}
+		}
+
+		if start < spec.min || end > spec.max || start > end {
+			return nil, fmt.Errorf("%s field out of range (%d-%d): %q", spec.name, spec.min, spec.max, part)
+		}
+		for v := start; v <= end; v += step {
+			values[v] = true
+		}
+	}
+	return values, nil
+}
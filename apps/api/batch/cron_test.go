@@ -0,0 +1,76 @@
+package batch
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronSchedule_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"too few fields", "0 3 * *"},
+		{"minute out of range", "60 * * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"invalid step", "*/0 * * * *"},
+		{"reversed range", "0 5-3 * * *"},
+		{"not a number", "a * * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseCronSchedule(tt.expr, time.UTC)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestCronSchedule_Next(t *testing.T) {
+	base := time.Date(2025, 6, 4, 10, 30, 15, 0, time.UTC) // 水曜日
+
+	tests := []struct {
+		name string
+		expr string
+		want time.Time
+	}{
+		{"every minute", "* * * * *", time.Date(2025, 6, 4, 10, 31, 0, 0, time.UTC)},
+		{"daily at 3am", "0 3 * * *", time.Date(2025, 6, 5, 3, 0, 0, 0, time.UTC)},
+		{"every 15 minutes", "*/15 * * * *", time.Date(2025, 6, 4, 10, 45, 0, 0, time.UTC)},
+		{"monday 9am", "0 9 * * 1", time.Date(2025, 6, 9, 9, 0, 0, 0, time.UTC)},
+		{"sunday as 7", "0 9 * * 7", time.Date(2025, 6, 8, 9, 0, 0, 0, time.UTC)},
+		{"first of month", "0 9 1 * *", time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)},
+		{"weekdays range", "0 8 * * 1-5", time.Date(2025, 6, 5, 8, 0, 0, 0, time.UTC)},
+		{"day or weekday", "0 0 15 * 5", time.Date(2025, 6, 6, 0, 0, 0, 0, time.UTC)},
+		{"list", "0 12,18 * * *", time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)},
+		{"leap day", "0 0 29 2 *", time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule, err := ParseCronSchedule(tt.expr, time.UTC)
+			assert.NoError(t, err)
+			assert.True(t, tt.want.Equal(schedule.Next(base)), "got %s", schedule.Next(base))
+		})
+	}
+}
+
+func TestCronSchedule_NextInLocation(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	schedule, err := ParseCronSchedule("0 9 * * *", jst)
+	assert.NoError(t, err)
+
+	// UTC 01:00 は JST 10:00 なので次回は翌日の JST 09:00
+	next := schedule.Next(time.Date(2025, 6, 4, 1, 0, 0, 0, time.UTC))
+
+	assert.True(t, time.Date(2025, 6, 5, 0, 0, 0, 0, time.UTC).Equal(next), "got %s", next)
+}
+
+func TestCronSchedule_NextNeverMatches(t *testing.T) {
+	schedule, err := ParseCronSchedule("0 0 31 2 *", time.UTC)
+	assert.NoError(t, err)
+
+	assert.True(t, schedule.Next(time.Now()).IsZero())
+}
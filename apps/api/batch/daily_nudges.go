@@ -0,0 +1,320 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/keeee21/commitly/api/repository"
+	"github.com/keeee21/commitly/api/usecase"
+)
+
+// DailyNudgesConfig 「今日はまだコミットしていない」リマインダーバッチの設定
+type DailyNudgesConfig struct {
+	DryRun       bool      // 同期・送信・記録を行わずメッセージを書き出す
+	DryRunOutput io.Writer // ドライラン時の出力先（nilの場合は標準出力）
+}
+
+// IDailyNudgesDeps リマインダーバッチの依存関係インターフェース
+type IDailyNudgesDeps interface {
+	GetRivalRepo() repository.IRivalRepository
+	GetCommitStatsRepo() repository.ICommitStatsRepository
+	GetNotificationScheduleRepo() repository.INotificationScheduleRepository
+	GetDailyNudgeRepo() repository.IDailyNudgeRepository
+	GetNotifierRegistry() *notifier.Registry
+}
+
+// Args batch_runs に記録する引数
+func (c DailyNudgesConfig) Args() map[string]string {
+	return map[string]string{
+		"dry_run": strconv.FormatBool(c.DryRun),
+	}
+}
+
+// LockName 多重実行を防ぐロック名
+func (c DailyNudgesConfig) LockName() string {
+	return "send-daily-nudges"
+}
+
+// RunDailyNudges ユーザーが選んだ時刻を過ぎても今日まだコミットしていないユーザーに、オプトインしたチャンネルでリマインダーを送る
+// 判定の前にそのユーザーの今日のコミットだけを同期する（syncUsecase が nil の場合とドライランでは同期しない）
+// 1ユーザーにつき1日1回だけ送り、通知を止める時間帯のユーザーは時間帯が終わった後の実行で送る。スヌーズ中のユーザーには送らない
+func RunDailyNudges(ctx context.Context, deps IDailyNudgesDeps, syncUsecase usecase.ISyncCommitsUsecase, config DailyNudgesConfig) (*RunReport, error) {
+	log.Println("Starting send-daily-nudges batch...")
+	report := NewRunReport()
+
+	if config.DryRun {
+		log.Println("Dry-run mode: nudges will be written instead of sent, and nothing will be synced or saved")
+	}
+
+	destinations, err := findNudgeDestinations(ctx, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	userIDs, destinationsByUser := groupDestinationsByUser(destinations)
+	log.Printf("Checking daily nudges for %d users", len(userIDs))
+
+	schedules, err := loadSchedules(ctx, deps.GetNotificationScheduleRepo(), userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, userID := range userIDs {
+		schedule := schedules[userID]
+		today, due, err := nudgeDay(schedule, now)
+		if err != nil {
+			log.Printf("Skipping user %d: invalid notification schedule: %v", userID, err)
+			continue
+		}
+		if !due {
+			continue
+		}
+
+		if schedule.IsSnoozed(now) {
+			log.Printf("Skipping daily nudge for user %d: snoozed", userID)
+			report.AddSkip()
+			continue
+		}
+		// 記録を残さないことで、時間帯が終わった後の実行で送る
+		if until, quiet := schedule.QuietUntil(now); quiet {
+			log.Printf("Deferring daily nudge for user %d until %s: quiet hours", userID, until.Format(time.RFC3339))
+			report.AddSkip()
+			continue
+		}
+
+		if !config.DryRun {
+			handled, err := deps.GetDailyNudgeRepo().FindLogByUserIDAndDate(ctx, userID, today)
+			if err != nil {
+				report.AddFailure(fmt.Sprintf("user %d", userID), fmt.Errorf("failed to get daily nudge log: %w", err))
+				continue
+			}
+			if handled != nil {
+				continue
+			}
+		}
+
+		userDestinations := destinationsByUser[userID]
+		user := userDestinations[0].User
+		if syncUsecase != nil && !config.DryRun {
+			// 同期に失敗しても、保存済みのコミット数で判定する
+			if err := syncUsecase.SyncUser(ctx, user.GithubUserID, user.GithubUsername, &today, &today); err != nil {
+				log.Printf("Failed to sync today's commits for user %d: %v", userID, err)
+			}
+		}
+
+		nudge, committed, err := buildNudge(ctx, deps, userID, user, today)
+		if err != nil {
+			report.AddFailure(fmt.Sprintf("user %d", userID), err)
+			continue
+		}
+
+		if committed {
+			log.Printf("Skipping daily nudge for user %d: already committed today", userID)
+			report.AddSkip()
+			if !config.DryRun {
+				record := &models.DailyNudgeLog{UserID: userID, Date: today, Status: models.DailyNudgeStatusCommitted}
+				if _, err := deps.GetDailyNudgeRepo().CreateLogIfNotExists(ctx, record); err != nil {
+					log.Printf("Failed to save daily nudge log for user %d: %v", userID, err)
+				}
+			}
+			continue
+		}
+
+		if config.DryRun {
+			for _, destination := range userDestinations {
+				writeDailyNudgeDryRun(config, nudgeNotifier(deps, destination), destination, nudge, report)
+			}
+			continue
+		}
+
+		sendDailyNudge(ctx, deps, report, userID, userDestinations, nudge)
+	}
+
+	report.Finish()
+	elapsed := report.FinishedAt.Sub(report.StartedAt)
+	log.Printf("send-daily-nudges batch completed in %s (success: %d, failed: %d, skipped: %d)", elapsed, report.SuccessCount, report.FailureCount, report.SkipCount)
+
+	return report, nil
+}
+
+// findNudgeDestinations 有効な送信先のうち、リマインダーにオプトインしたチャンネルだけを返す
+func findNudgeDestinations(ctx context.Context, deps IDailyNudgesDeps) ([]notifier.Destination, error) {
+	channels, err := deps.GetDailyNudgeRepo().FindAllChannels(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily nudge channels: %w", err)
+	}
+	optedIn := make(map[uint64]map[models.ChannelType]bool)
+	for _, c := range channels {
+		if optedIn[c.UserID] == nil {
+			optedIn[c.UserID] = make(map[models.ChannelType]bool)
+		}
+		optedIn[c.UserID][c.ChannelType] = true
+	}
+	if len(optedIn) == 0 {
+		return nil, nil
+	}
+
+	var destinations []notifier.Destination
+	for _, n := range deps.GetNotifierRegistry().All() {
+		found, err := n.FindEnabledDestinations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range found {
+			if optedIn[d.UserID][d.ChannelType] {
+				destinations = append(destinations, d)
+			}
+		}
+	}
+	return destinations, nil
+}
+
+// nudgeDay ユーザーのタイムゾーンでの今日（統計と同じくローカルタイムゾーンの0時）と、リマインダーの時刻を過ぎたかを返す
+func nudgeDay(schedule *models.NotificationSchedule, now time.Time) (time.Time, bool, error) {
+	loc, err := schedule.Location()
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid timezone %q: %w", schedule.Timezone, err)
+	}
+	hour, minute, err := schedule.DailyNudgeClock()
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	local := now.In(loc)
+	nudgeAt := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	today := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.Local)
+	return today, !local.Before(nudgeAt), nil
+}
+
+// buildNudge 連続コミット日数と今日コミットしたライバルの数を集計する（今日すでにコミットしていれば committed が true）
+func buildNudge(ctx context.Context, deps IDailyNudgesDeps, userID uint64, user models.User, today time.Time) (*notifier.Nudge, bool, error) {
+	userStats, err := deps.GetCommitStatsRepo().FindByGithubUserIDAndDateRange(ctx, user.GithubUserID, today.AddDate(0, 0, -streakLookbackDays), today)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get user commit stats: %w", err)
+	}
+	if notifier.SumCommits(statsSince(userStats, today)) > 0 {
+		return nil, true, nil
+	}
+
+	rivals, err := deps.GetRivalRepo().FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get rivals: %w", err)
+	}
+	rivalIDs := make([]uint64, 0, len(rivals))
+	for _, r := range rivals {
+		rivalIDs = append(rivalIDs, r.RivalGithubUserID)
+	}
+
+	rivalsCommitted := 0
+	if len(rivalIDs) > 0 {
+		rivalStats, err := deps.GetCommitStatsRepo().FindByGithubUserIDsAndDateRange(ctx, rivalIDs, today, today)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to get rival commit stats: %w", err)
+		}
+		committed := make(map[uint64]bool)
+		for _, s := range rivalStats {
+			if s.CommitCount > 0 {
+				committed[s.GithubUserID] = true
+			}
+		}
+		rivalsCommitted = len(committed)
+	}
+
+	return &notifier.Nudge{
+		Username:        user.GithubUsername,
+		Date:            today,
+		Streak:          notifier.BrokenStreak(userStats, today),
+		Rivals:          len(rivals),
+		RivalsCommitted: rivalsCommitted,
+	}, false, nil
+}
+
+// sendDailyNudge リマインダーを記録してユーザーのオプトインした全チャンネルに送る（今日すでに記録済みなら送らない）
+func sendDailyNudge(
+	ctx context.Context,
+	deps IDailyNudgesDeps,
+	report *RunReport,
+	userID uint64,
+	destinations []notifier.Destination,
+	nudge *notifier.Nudge,
+) {
+	nudgeRepo := deps.GetDailyNudgeRepo()
+	record := &models.DailyNudgeLog{
+		UserID:          userID,
+		Date:            nudge.Date,
+		Status:          models.DailyNudgeStatusSent,
+		Streak:          nudge.Streak,
+		RivalsCommitted: nudge.RivalsCommitted,
+	}
+	created, err := nudgeRepo.CreateLogIfNotExists(ctx, record)
+	if err != nil {
+		report.AddFailure(fmt.Sprintf("user %d", userID), fmt.Errorf("failed to save daily nudge log: %w", err))
+		return
+	}
+	if !created {
+		log.Printf("Skipping daily nudge for user %d: already handled today", userID)
+		report.AddSkip()
+		return
+	}
+
+	delivered := false
+	for _, destination := range destinations {
+		if err := deliverDailyNudge(ctx, nudgeNotifier(deps, destination), destination, nudge); err != nil {
+			log.Printf("Failed to send daily nudge to user %d via %s: %v", userID, destination.ChannelType, err)
+			report.AddFailure(fmt.Sprintf("user %d (%s)", userID, destination.ChannelType), err)
+			continue
+		}
+		report.AddSuccess()
+		delivered = true
+	}
+
+	if !delivered {
+		// その日のうちでないと意味がないため再送はしない
+		if err := nudgeRepo.UpdateLogStatus(ctx, record.ID, models.DailyNudgeStatusFailed); err != nil {
+			log.Printf("Failed to update daily nudge log %d: %v", record.ID, err)
+		}
+		return
+	}
+	log.Printf("Sent daily nudge to user %d", userID)
+}
+
+// nudgeNotifier 送信先のチャンネルの Notifier
+func nudgeNotifier(deps IDailyNudgesDeps, destination notifier.Destination) notifier.INotifier {
+	n, _ := deps.GetNotifierRegistry().Get(destination.ChannelType)
+	return n
+}
+
+// deliverDailyNudge リマインダーをチャンネル向けにレンダリングして配信する
+func deliverDailyNudge(ctx context.Context, n notifier.INotifier, destination notifier.Destination, nudge *notifier.Nudge) error {
+	message, err := n.RenderNudge(destination, nudge)
+	if err != nil {
+		return fmt.Errorf("failed to render %s nudge: %w", destination.ChannelType, err)
+	}
+	return n.Deliver(ctx, destination, message)
+}
+
+// writeDailyNudgeDryRun 送る予定のリマインダーを書き出す
+func writeDailyNudgeDryRun(config DailyNudgesConfig, n notifier.INotifier, destination notifier.Destination, nudge *notifier.Nudge, report *RunReport) {
+	message, err := n.RenderNudge(destination, nudge)
+	if err == nil {
+		err = writeDryRunRecord(config.DryRunOutput, DryRunRecord{
+			UserID:         destination.UserID,
+			GithubUsername: destination.User.GithubUsername,
+			ChannelType:    destination.ChannelType,
+			Period:         "daily_nudge",
+			Message:        message.Body,
+		})
+	}
+	if err != nil {
+		report.AddFailure(fmt.Sprintf("user %d (%s)", destination.UserID, destination.ChannelType), err)
+		return
+	}
+	report.AddSuccess()
+}
@@ -0,0 +1,357 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockDailyNudgeRepository テスト用のモック
+type mockDailyNudgeRepository struct {
+	FindChannelsByUserIDFunc   func(ctx context.Context, userID uint64) ([]models.DailyNudgeChannel, error)
+	FindAllChannelsFunc        func(ctx context.Context) ([]models.DailyNudgeChannel, error)
+	ReplaceChannelsFunc        func(ctx context.Context, userID uint64, channelTypes []models.ChannelType) error
+	FindLogByUserIDAndDateFunc func(ctx context.Context, userID uint64, date time.Time) (*models.DailyNudgeLog, error)
+	CreateLogIfNotExistsFunc   func(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error)
+	UpdateLogStatusFunc        func(ctx context.Context, id uint64, status models.DailyNudgeStatus) error
+}
+
+func (m *mockDailyNudgeRepository) FindChannelsByUserID(ctx context.Context, userID uint64) ([]models.DailyNudgeChannel, error) {
+	if m.FindChannelsByUserIDFunc != nil {
+		return m.FindChannelsByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockDailyNudgeRepository) FindAllChannels(ctx context.Context) ([]models.DailyNudgeChannel, error) {
+	if m.FindAllChannelsFunc != nil {
+		return m.FindAllChannelsFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockDailyNudgeRepository) ReplaceChannels(ctx context.Context, userID uint64, channelTypes []models.ChannelType) error {
+	if m.ReplaceChannelsFunc != nil {
+		return m.ReplaceChannelsFunc(ctx, userID, channelTypes)
+	}
+	return nil
+}
+
+func (m *mockDailyNudgeRepository) FindLogByUserIDAndDate(ctx context.Context, userID uint64, date time.Time) (*models.DailyNudgeLog, error) {
+	if m.FindLogByUserIDAndDateFunc != nil {
+		return m.FindLogByUserIDAndDateFunc(ctx, userID, date)
+	}
+	return nil, nil
+}
+
+func (m *mockDailyNudgeRepository) CreateLogIfNotExists(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error) {
+	if m.CreateLogIfNotExistsFunc != nil {
+		return m.CreateLogIfNotExistsFunc(ctx, nudgeLog)
+	}
+	return true, nil
+}
+
+func (m *mockDailyNudgeRepository) UpdateLogStatus(ctx context.Context, id uint64, status models.DailyNudgeStatus) error {
+	if m.UpdateLogStatusFunc != nil {
+		return m.UpdateLogStatusFunc(ctx, id, status)
+	}
+	return nil
+}
+
+// nudgeToday テストのスケジュール（UTC）での今日を、統計と同じローカルタイムゾーンの0時で返す
+func nudgeToday() time.Time {
+	now := time.Now().In(time.UTC)
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+}
+
+// dailyNudgeTestDeps ユーザー1人（Slackを有効にしてリマインダーにオプトイン）とライバル2人の依存関係を組み立てる
+func dailyNudgeTestDeps(userStats, rivalStats []models.CommitStats, nudgeRepo *mockDailyNudgeRepository, slackGateway *mockSlackGateway) *testDeps {
+	if nudgeRepo.FindAllChannelsFunc == nil {
+		nudgeRepo.FindAllChannelsFunc = func(ctx context.Context) ([]models.DailyNudgeChannel, error) {
+			return []models.DailyNudgeChannel{{UserID: 1, ChannelType: models.ChannelTypeSlack}}, nil
+		}
+	}
+	return &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{
+					{
+						UserID:     1,
+						WebhookURL: "https://hooks.slack.com/test",
+						IsEnabled:  true,
+						User:       models.User{ID: 1, GithubUserID: 12345, GithubUsername: "testuser"},
+					},
+				}, nil
+			},
+		},
+		scheduleRepo: &mockNotificationScheduleRepository{
+			FindByUserIDsFunc: func(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+				return []models.NotificationSchedule{{UserID: 1, DeliveryTime: "09:00", Timezone: "UTC", DailyNudgeTime: "00:00"}}, nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{
+			FindByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.Rival, error) {
+				return []models.Rival{
+					{RivalGithubUserID: 67890, RivalGithubUsername: "rival1"},
+					{RivalGithubUserID: 67891, RivalGithubUsername: "rival2"},
+				}, nil
+			},
+		},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				return userStats, nil
+			},
+			FindByGithubUserIDsAndDateRangeFunc: func(ctx context.Context, githubUserIDs []uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				return rivalStats, nil
+			},
+		},
+		dailyNudgeRepo: nudgeRepo,
+		slackGateway:   slackGateway,
+	}
+}
+
+func TestRunDailyNudges_SendsWhenNoCommitsToday(t *testing.T) {
+	today := nudgeToday()
+	var created *models.DailyNudgeLog
+	var sentText string
+	var syncedFrom *time.Time
+
+	nudgeRepo := &mockDailyNudgeRepository{
+		CreateLogIfNotExistsFunc: func(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error) {
+			created = nudgeLog
+			return true, nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			sentText = message.Text
+			return nil
+		},
+	}
+	deps := dailyNudgeTestDeps(
+		[]models.CommitStats{
+			{Date: today.AddDate(0, 0, -1), CommitCount: 2},
+			{Date: today.AddDate(0, 0, -2), CommitCount: 1},
+		},
+		[]models.CommitStats{{GithubUserID: 67890, Date: today, CommitCount: 3}},
+		nudgeRepo, slackGateway,
+	)
+	syncUsecase := &mockSyncCommitsUsecase{
+		SyncUserFunc: func(ctx context.Context, githubUserID uint64, githubUsername string, fromDate, toDate *time.Time) error {
+			syncedFrom = fromDate
+			return nil
+		},
+	}
+
+	report, err := RunDailyNudges(context.Background(), deps, syncUsecase, DailyNudgesConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	if assert.NotNil(t, syncedFrom) {
+		assert.Equal(t, today, *syncedFrom)
+	}
+	if assert.NotNil(t, created) {
+		assert.Equal(t, models.DailyNudgeStatusSent, created.Status)
+		assert.Equal(t, today, created.Date)
+		assert.Equal(t, 2, created.Streak)
+		assert.Equal(t, 1, created.RivalsCommitted)
+	}
+	assert.Contains(t, sentText, "2 日連続")
+	assert.Contains(t, sentText, "ライバル 2 人中 1 人")
+}
+
+func TestRunDailyNudges_SkipsWhenAlreadyCommitted(t *testing.T) {
+	today := nudgeToday()
+	var created *models.DailyNudgeLog
+
+	nudgeRepo := &mockDailyNudgeRepository{
+		CreateLogIfNotExistsFunc: func(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error) {
+			created = nudgeLog
+			return true, nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			t.Fatal("no nudge should be sent after committing today")
+			return nil
+		},
+	}
+	deps := dailyNudgeTestDeps([]models.CommitStats{{Date: today, CommitCount: 1}}, nil, nudgeRepo, slackGateway)
+
+	report, err := RunDailyNudges(context.Background(), deps, nil, DailyNudgesConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SkipCount)
+	if assert.NotNil(t, created) {
+		assert.Equal(t, models.DailyNudgeStatusCommitted, created.Status)
+	}
+}
+
+func TestRunDailyNudges_OnlyOptedInChannels(t *testing.T) {
+	nudgeRepo := &mockDailyNudgeRepository{
+		FindAllChannelsFunc: func(ctx context.Context) ([]models.DailyNudgeChannel, error) {
+			return []models.DailyNudgeChannel{{UserID: 1, ChannelType: models.ChannelTypeDiscord}}, nil
+		},
+		CreateLogIfNotExistsFunc: func(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error) {
+			t.Fatal("no nudge should be recorded for a channel the user did not opt in to")
+			return false, nil
+		},
+	}
+	deps := dailyNudgeTestDeps(nil, nil, nudgeRepo, &mockSlackGateway{})
+
+	report, err := RunDailyNudges(context.Background(), deps, nil, DailyNudgesConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.SuccessCount+report.SkipCount+report.FailureCount)
+}
+
+func TestRunDailyNudges_SkipsAlreadyHandledToday(t *testing.T) {
+	nudgeRepo := &mockDailyNudgeRepository{
+		FindLogByUserIDAndDateFunc: func(ctx context.Context, userID uint64, date time.Time) (*models.DailyNudgeLog, error) {
+			return &models.DailyNudgeLog{ID: 1, UserID: userID, Date: date, Status: models.DailyNudgeStatusSent}, nil
+		},
+	}
+	syncUsecase := &mockSyncCommitsUsecase{
+		SyncUserFunc: func(ctx context.Context, githubUserID uint64, githubUsername string, fromDate, toDate *time.Time) error {
+			t.Fatal("users already handled today should not be synced again")
+			return nil
+		},
+	}
+	deps := dailyNudgeTestDeps(nil, nil, nudgeRepo, &mockSlackGateway{})
+
+	report, err := RunDailyNudges(context.Background(), deps, syncUsecase, DailyNudgesConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.SuccessCount)
+}
+
+func TestRunDailyNudges_NotYetDue(t *testing.T) {
+	nudgeRepo := &mockDailyNudgeRepository{
+		FindLogByUserIDAndDateFunc: func(ctx context.Context, userID uint64, date time.Time) (*models.DailyNudgeLog, error) {
+			t.Fatal("users before their nudge time should not be checked")
+			return nil, nil
+		},
+	}
+	deps := dailyNudgeTestDeps(nil, nil, nudgeRepo, &mockSlackGateway{})
+	deps.scheduleRepo = &mockNotificationScheduleRepository{
+		FindByUserIDsFunc: func(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+			return []models.NotificationSchedule{{UserID: 1, DeliveryTime: "09:00", Timezone: "UTC", DailyNudgeTime: "23:59"}}, nil
+		},
+	}
+	if now := time.Now().In(time.UTC); now.Hour() == 23 && now.Minute() == 59 {
+		t.Skip("nudge time has just passed")
+	}
+
+	report, err := RunDailyNudges(context.Background(), deps, nil, DailyNudgesConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.SuccessCount+report.SkipCount)
+}
+
+func TestRunDailyNudges_QuietHoursAndSnoozeSkip(t *testing.T) {
+	now := time.Now().In(time.UTC)
+	snoozeUntil := now.AddDate(0, 0, 1)
+
+	tests := []struct {
+		name     string
+		schedule models.NotificationSchedule
+	}{
+		{
+			name: "quiet hours",
+			schedule: models.NotificationSchedule{
+				UserID: 1, DeliveryTime: "09:00", Timezone: "UTC", DailyNudgeTime: "00:00",
+				QuietHoursStart: now.Add(-time.Hour).Format("15:04"), QuietHoursEnd: now.Add(time.Hour).Format("15:04"),
+			},
+		},
+		{
+			name:     "snoozed",
+			schedule: models.NotificationSchedule{UserID: 1, DeliveryTime: "09:00", Timezone: "UTC", DailyNudgeTime: "00:00", SnoozeUntil: &snoozeUntil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nudgeRepo := &mockDailyNudgeRepository{
+				CreateLogIfNotExistsFunc: func(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error) {
+					t.Fatal("no nudge should be recorded")
+					return false, nil
+				},
+			}
+			deps := dailyNudgeTestDeps(nil, nil, nudgeRepo, &mockSlackGateway{})
+			deps.scheduleRepo = &mockNotificationScheduleRepository{
+				FindByUserIDsFunc: func(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+					return []models.NotificationSchedule{tt.schedule}, nil
+				},
+			}
+
+			report, err := RunDailyNudges(context.Background(), deps, nil, DailyNudgesConfig{})
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, report.SkipCount)
+		})
+	}
+}
+
+func TestRunDailyNudges_MarksFailedWhenNoChannelDelivered(t *testing.T) {
+	var status models.DailyNudgeStatus
+
+	nudgeRepo := &mockDailyNudgeRepository{
+		CreateLogIfNotExistsFunc: func(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error) {
+			nudgeLog.ID = 7
+			return true, nil
+		},
+		UpdateLogStatusFunc: func(ctx context.Context, id uint64, s models.DailyNudgeStatus) error {
+			assert.Equal(t, uint64(7), id)
+			status = s
+			return nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			return errors.New("slack is down")
+		},
+	}
+	deps := dailyNudgeTestDeps(nil, nil, nudgeRepo, slackGateway)
+
+	report, err := RunDailyNudges(context.Background(), deps, nil, DailyNudgesConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.FailureCount)
+	assert.Equal(t, models.DailyNudgeStatusFailed, status)
+}
+
+func TestRunDailyNudges_DryRun(t *testing.T) {
+	var out bytes.Buffer
+
+	nudgeRepo := &mockDailyNudgeRepository{
+		CreateLogIfNotExistsFunc: func(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error) {
+			t.Fatal("dry run should not record nudges")
+			return false, nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			t.Fatal("dry run should not send nudges")
+			return nil
+		},
+	}
+	syncUsecase := &mockSyncCommitsUsecase{
+		SyncUserFunc: func(ctx context.Context, githubUserID uint64, githubUsername string, fromDate, toDate *time.Time) error {
+			t.Fatal("dry run should not sync")
+			return nil
+		},
+	}
+	deps := dailyNudgeTestDeps(nil, nil, nudgeRepo, slackGateway)
+
+	report, err := RunDailyNudges(context.Background(), deps, syncUsecase, DailyNudgesConfig{DryRun: true, DryRunOutput: &out})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	assert.Contains(t, out.String(), `"period":"daily_nudge"`)
+}
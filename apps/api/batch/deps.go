@@ -0,0 +1,71 @@
+package batch
+
+import (
+	"fmt"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/keeee21/commitly/api/repository"
+	"gorm.io/gorm"
+)
+
+// NewSendNotificationsDeps 通知送信・再送バッチの依存関係を組み立てる（batch コマンドとスケジューラで共通）
+func NewSendNotificationsDeps(database *gorm.DB, getenv func(string) string) (*SendNotificationsDeps, error) {
+	// Initialize repositories
+	slackNotificationRepo := repository.NewSlackNotificationSettingRepository(database)
+	discordNotificationRepo := repository.NewDiscordNotificationSettingRepository(database)
+	teamsNotificationRepo := repository.NewTeamsNotificationSettingRepository(database)
+	mattermostNotificationRepo := repository.NewMattermostNotificationSettingRepository(database)
+	lineNotificationRepo := repository.NewLineNotificationSettingRepository(database)
+	telegramNotificationRepo := repository.NewTelegramNotificationSettingRepository(database)
+	webPushSubscriptionRepo := repository.NewWebPushSubscriptionRepository(database)
+	webhookNotificationRepo := repository.NewWebhookNotificationSettingRepository(database)
+	emailNotificationRepo := repository.NewEmailNotificationSettingRepository(database)
+	inAppNotificationRepo := repository.NewInAppNotificationRepository(database)
+
+	// Initialize gateway
+	slackGateway := gateway.NewSlackGateway()
+	discordGateway := gateway.NewDiscordGateway()
+	teamsGateway := gateway.NewTeamsGateway()
+	mattermostGateway := gateway.NewMattermostGateway()
+	lineGateway := gateway.NewLineGateway(getenv("LINE_API_BASE_URL"), getenv("LINE_CHANNEL_ACCESS_TOKEN"))
+	telegramGateway := gateway.NewTelegramGateway(getenv("TELEGRAM_API_BASE_URL"), getenv("TELEGRAM_BOT_TOKEN"))
+	webhookGateway := gateway.NewWebhookGateway()
+	smtpConfig, err := gateway.LoadSMTPConfig(getenv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load SMTP config: %w", err)
+	}
+	emailGateway := gateway.NewEmailGateway(smtpConfig)
+	vapidKeys, err := gateway.LoadVAPIDKeys(getenv)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load VAPID keys: %w", err)
+	}
+	webPushGateway := gateway.NewWebPushGateway(vapidKeys)
+
+	// Initialize dependencies
+	notifierRegistry := notifier.NewRegistry(
+		notifier.NewSlackNotifier(slackNotificationRepo, slackGateway),
+		notifier.NewDiscordNotifier(discordNotificationRepo, discordGateway),
+		notifier.NewTeamsNotifier(teamsNotificationRepo, teamsGateway),
+		notifier.NewMattermostNotifier(mattermostNotificationRepo, mattermostGateway),
+		notifier.NewLineNotifier(lineNotificationRepo, lineGateway),
+		notifier.NewTelegramNotifier(telegramNotificationRepo, telegramGateway),
+		notifier.NewWebPushNotifier(webPushSubscriptionRepo, webPushGateway),
+		notifier.NewWebhookNotifier(webhookNotificationRepo, webhookGateway),
+		notifier.NewEmailNotifier(emailNotificationRepo, emailGateway, notifier.NewEmailTokenSigner(getenv("EMAIL_TOKEN_SECRET")), getenv("API_BASE_URL")),
+	)
+	return &SendNotificationsDeps{
+		NotificationLogRepo:    repository.NewNotificationLogRepository(database),
+		UserRepo:               repository.NewUserRepository(database),
+		ScheduleRepo:           repository.NewNotificationScheduleRepository(database),
+		RivalRepo:              repository.NewRivalRepository(database),
+		CommitStatsRepo:        repository.NewCommitStatsRepository(database),
+		RivalAlertRepo:         repository.NewRivalAlertRepository(database),
+		CircleNotificationRepo: repository.NewCircleNotificationSettingRepository(database),
+		CircleDigestLogRepo:    repository.NewCircleDigestLogRepository(database),
+		DailyNudgeRepo:         repository.NewDailyNudgeRepository(database),
+		NotifierRegistry:       notifierRegistry,
+		CircleDigestPoster:     notifier.NewCircleDigestPoster(slackGateway, discordGateway),
+		InboxPublisher:         notifier.NewInboxPublisher(inAppNotificationRepo),
+	}, nil
+}
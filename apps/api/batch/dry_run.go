@@ -0,0 +1,42 @@
+package batch
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// DryRunRecord ドライラン時に書き出す1通知分のレコード（JSONLの1行）
+type DryRunRecord struct {
+	UserID         uint64             `json:"user_id"`
+	GithubUsername string             `json:"github_username"`
+	ChannelType    models.ChannelType `json:"channel_type"`
+	Period         string             `json:"period"`
+	Message        interface{}        `json:"message"`
+}
+
+// dryRunWriter ドライランの出力先を返す（未指定の場合は標準出力）
+func dryRunWriter(w io.Writer) io.Writer {
+	if w == nil {
+		return os.Stdout
+	}
+	return w
+}
+
+// writeDryRunRecord レコードをJSONLとして書き出す
+func writeDryRunRecord(w io.Writer, record DryRunRecord) error {
+	return json.NewEncoder(dryRunWriter(w)).Encode(record)
+}
+
+// newDryRunRecord ドライラン用のレコードを組み立てる
+func newDryRunRecord(config SendNotificationsConfig, userID uint64, user models.User, channelType models.ChannelType, message interface{}) DryRunRecord {
+	return DryRunRecord{
+		UserID:         userID,
+		GithubUsername: user.GithubUsername,
+		ChannelType:    channelType,
+		Period:         config.Period,
+		Message:        message,
+	}
+}
@@ -0,0 +1,42 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// ErrAlreadyRunning 同じバッチが他のインスタンスで実行中
+var ErrAlreadyRunning = errors.New("another instance is already running")
+
+// WithLock 名前付きロックを取得してからバッチを実行する
+// wait が false の場合、ロックが取得できなければ ErrAlreadyRunning を返す
+func WithLock(
+	ctx context.Context,
+	lockRepo repository.IBatchLockRepository,
+	name string,
+	wait bool,
+	run func(ctx context.Context) (*RunReport, error),
+) (*RunReport, error) {
+	unlock, acquired, err := lockRepo.TryLock(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire lock for %s: %w", name, err)
+	}
+
+	if !acquired {
+		if !wait {
+			return nil, fmt.Errorf("%s: %w", name, ErrAlreadyRunning)
+		}
+		log.Printf("Another instance of %s is running, waiting for it to finish...", name)
+		unlock, err = lockRepo.Lock(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to acquire lock for %s: %w", name, err)
+		}
+	}
+	defer unlock()
+
+	return run(ctx)
+}
@@ -0,0 +1,110 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockBatchLockRepository テスト用のモック
+type mockBatchLockRepository struct {
+	TryLockFunc func(ctx context.Context, name string) (func(), bool, error)
+	LockFunc    func(ctx context.Context, name string) (func(), error)
+}
+
+func (m *mockBatchLockRepository) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	if m.TryLockFunc != nil {
+		return m.TryLockFunc(ctx, name)
+	}
+	return func() {}, true, nil
+}
+
+func (m *mockBatchLockRepository) Lock(ctx context.Context, name string) (func(), error) {
+	if m.LockFunc != nil {
+		return m.LockFunc(ctx, name)
+	}
+	return func() {}, nil
+}
+
+func TestWithLock_Acquired(t *testing.T) {
+	unlocked := false
+	runCalled := false
+
+	lockRepo := &mockBatchLockRepository{
+		TryLockFunc: func(ctx context.Context, name string) (func(), bool, error) {
+			assert.Equal(t, "sync-commits", name)
+			return func() { unlocked = true }, true, nil
+		},
+	}
+
+	_, err := WithLock(context.Background(), lockRepo, "sync-commits", false, func(ctx context.Context) (*RunReport, error) {
+		runCalled = true
+		assert.False(t, unlocked)
+		return NewRunReport(), nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, runCalled)
+	assert.True(t, unlocked)
+}
+
+func TestWithLock_HeldWithoutWait(t *testing.T) {
+	lockRepo := &mockBatchLockRepository{
+		TryLockFunc: func(ctx context.Context, name string) (func(), bool, error) {
+			return nil, false, nil
+		},
+		LockFunc: func(ctx context.Context, name string) (func(), error) {
+			t.Fatal("must not wait for the lock")
+			return nil, nil
+		},
+	}
+
+	_, err := WithLock(context.Background(), lockRepo, "sync-commits", false, func(ctx context.Context) (*RunReport, error) {
+		t.Fatal("run must not be called")
+		return nil, nil
+	})
+
+	assert.ErrorIs(t, err, ErrAlreadyRunning)
+	assert.Contains(t, err.Error(), "sync-commits")
+}
+
+func TestWithLock_HeldWithWait(t *testing.T) {
+	unlocked := false
+	runCalled := false
+
+	lockRepo := &mockBatchLockRepository{
+		TryLockFunc: func(ctx context.Context, name string) (func(), bool, error) {
+			return nil, false, nil
+		},
+		LockFunc: func(ctx context.Context, name string) (func(), error) {
+			return func() { unlocked = true }, nil
+		},
+	}
+
+	_, err := WithLock(context.Background(), lockRepo, "sync-commits", true, func(ctx context.Context) (*RunReport, error) {
+		runCalled = true
+		return NewRunReport(), nil
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, runCalled)
+	assert.True(t, unlocked)
+}
+
+func TestWithLock_Error(t *testing.T) {
+	lockRepo := &mockBatchLockRepository{
+		TryLockFunc: func(ctx context.Context, name string) (func(), bool, error) {
+			return nil, false, errors.New("connection refused")
+		},
+	}
+
+	_, err := WithLock(context.Background(), lockRepo, "sync-commits", false, func(ctx context.Context) (*RunReport, error) {
+		t.Fatal("run must not be called")
+		return nil, nil
+	})
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrAlreadyRunning)
+}
@@ -0,0 +1,102 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// ScheduleDueWindow 配信予定時刻を過ぎてから配信対象とみなす期間
+// スケジューラが一時的に止まっていても、この期間内なら次の実行で配信する
+const ScheduleDueWindow = 6 * time.Hour
+
+// lastScheduledSlot now 以前で直近の配信予定時刻を、ユーザーのタイムゾーンで返す
+func lastScheduledSlot(schedule *models.NotificationSchedule, period string, now time.Time) (time.Time, error) {
+	loc, err := schedule.Location()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timezone %q: %w", schedule.Timezone, err)
+	}
+	hour, minute, err := schedule.DeliveryClock()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	local := now.In(loc)
+	if period == "weekly" {
+		slot := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+		slot = slot.AddDate(0, 0, -((int(local.Weekday()) - schedule.WeeklyWeekday + 7) % 7))
+		if slot.After(local) {
+			slot = slot.AddDate(0, 0, -7)
+		}
+		return slot, nil
+	}
+
+	slot := monthlySlot(local.Year(), local.Month(), schedule.MonthlyDay, hour, minute, loc)
+	if slot.After(local) {
+		slot = monthlySlot(local.Year(), local.Month()-1, schedule.MonthlyDay, hour, minute, loc)
+	}
+	return slot, nil
+}
+
+// monthlySlot 指定月の配信予定時刻（日が月末を超える場合は月末日）
+func monthlySlot(year int, month time.Month, day, hour, minute int, loc *time.Location) time.Time {
+	lastDay := time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+	if day > lastDay {
+		day = lastDay
+	}
+	return time.Date(year, month, day, hour, minute, 0, 0, loc)
+}
+
+// loadSchedules ユーザーごとの配信スケジュールを取得する（未設定のユーザーはデフォルトのスケジュール）
+func loadSchedules(ctx context.Context, repo repository.INotificationScheduleRepository, userIDs []uint64) (map[uint64]*models.NotificationSchedule, error) {
+	schedules, err := repo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification schedules: %w", err)
+	}
+	schedulesByUser := make(map[uint64]*models.NotificationSchedule, len(userIDs))
+	for i := range schedules {
+		schedulesByUser[schedules[i].UserID] = &schedules[i]
+	}
+	for _, userID := range userIDs {
+		if schedulesByUser[userID] == nil {
+			schedulesByUser[userID] = models.DefaultNotificationSchedule(userID)
+		}
+	}
+	return schedulesByUser, nil
+}
+
+// findDueUsers 配信予定時刻を過ぎたばかりのユーザーと、それぞれの配信予定時刻を返す
+func findDueUsers(schedules map[uint64]*models.NotificationSchedule, period string, userIDs []uint64, now time.Time) ([]uint64, map[uint64]time.Time) {
+	var dueUserIDs []uint64
+	slots := make(map[uint64]time.Time)
+	for _, userID := range userIDs {
+		schedule := schedules[userID]
+		slot, err := lastScheduledSlot(schedule, period, now)
+		if err != nil {
+			log.Printf("Skipping user %d: invalid notification schedule: %v", userID, err)
+			continue
+		}
+		if now.Sub(slot) >= ScheduleDueWindow {
+			continue
+		}
+		dueUserIDs = append(dueUserIDs, userID)
+		slots[userID] = slot
+	}
+	return dueUserIDs, slots
+}
+
+// heldDelivery スヌーズ中・通知を止める時間帯のレポートを、送らずに記録するステータスと再送予定日時を返す
+// スヌーズは集計期間の基準日時（anchor）、時間帯は送信する時刻（now）で判定する
+func heldDelivery(schedule *models.NotificationSchedule, anchor, now time.Time) (models.NotificationStatus, *time.Time, bool) {
+	if schedule.IsSnoozed(anchor) {
+		return models.NotificationStatusSkipped, nil, true
+	}
+	if until, quiet := schedule.QuietUntil(now); quiet {
+		return models.NotificationStatusDeferred, &until, true
+	}
+	return "", nil, false
+}
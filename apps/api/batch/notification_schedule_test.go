@@ -0,0 +1,317 @@
+package batch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLastScheduledSlot(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	newYork, _ := time.LoadLocation("America/New_York")
+
+	tests := []struct {
+		name     string
+		schedule models.NotificationSchedule
+		period   string
+		now      time.Time
+		expected time.Time
+	}{
+		{
+			name:     "weekly slot later today falls back to last week",
+			schedule: models.NotificationSchedule{WeeklyWeekday: int(time.Monday), DeliveryTime: "09:00", Timezone: "Asia/Tokyo"},
+			period:   "weekly",
+			now:      time.Date(2026, 10, 12, 8, 59, 0, 0, tokyo), // 月曜
+			expected: time.Date(2026, 10, 5, 9, 0, 0, 0, tokyo),
+		},
+		{
+			name:     "weekly slot just passed",
+			schedule: models.NotificationSchedule{WeeklyWeekday: int(time.Monday), DeliveryTime: "09:00", Timezone: "Asia/Tokyo"},
+			period:   "weekly",
+			now:      time.Date(2026, 10, 12, 9, 10, 0, 0, tokyo),
+			expected: time.Date(2026, 10, 12, 9, 0, 0, 0, tokyo),
+		},
+		{
+			name:     "weekly slot in user's timezone",
+			schedule: models.NotificationSchedule{WeeklyWeekday: int(time.Friday), DeliveryTime: "18:30", Timezone: "America/New_York"},
+			period:   "weekly",
+			now:      time.Date(2026, 10, 17, 8, 0, 0, 0, tokyo), // ニューヨークでは金曜19:00
+			expected: time.Date(2026, 10, 16, 18, 30, 0, 0, newYork),
+		},
+		{
+			name:     "monthly slot this month",
+			schedule: models.NotificationSchedule{MonthlyDay: 15, DeliveryTime: "07:00", Timezone: "Asia/Tokyo"},
+			period:   "monthly",
+			now:      time.Date(2026, 10, 18, 0, 0, 0, 0, tokyo),
+			expected: time.Date(2026, 10, 15, 7, 0, 0, 0, tokyo),
+		},
+		{
+			name:     "monthly slot clamped to end of month",
+			schedule: models.NotificationSchedule{MonthlyDay: 31, DeliveryTime: "09:00", Timezone: "Asia/Tokyo"},
+			period:   "monthly",
+			now:      time.Date(2026, 3, 1, 0, 0, 0, 0, tokyo),
+			expected: time.Date(2026, 2, 28, 9, 0, 0, 0, tokyo),
+		},
+		{
+			name:     "monthly slot across year",
+			schedule: models.NotificationSchedule{MonthlyDay: 1, DeliveryTime: "09:00", Timezone: "Asia/Tokyo"},
+			period:   "monthly",
+			now:      time.Date(2027, 1, 1, 8, 0, 0, 0, tokyo),
+			expected: time.Date(2026, 12, 1, 9, 0, 0, 0, tokyo),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot, err := lastScheduledSlot(&tt.schedule, tt.period, tt.now)
+
+			assert.NoError(t, err)
+			assert.True(t, tt.expected.Equal(slot), "expected %s, got %s", tt.expected, slot)
+		})
+	}
+}
+
+func TestLastScheduledSlot_InvalidSchedule(t *testing.T) {
+	now := time.Now()
+
+	_, err := lastScheduledSlot(&models.NotificationSchedule{DeliveryTime: "09:00", Timezone: "Mars/Olympus"}, "weekly", now)
+	assert.Error(t, err)
+
+	_, err = lastScheduledSlot(&models.NotificationSchedule{DeliveryTime: "9am", Timezone: "Asia/Tokyo"}, "weekly", now)
+	assert.Error(t, err)
+}
+
+func TestRunSendNotifications_DueOnlySendsToUsersWhoseSlotPassed(t *testing.T) {
+	ctx := context.Background()
+	due := models.User{ID: 1, GithubUserID: 111, GithubUsername: "due"}
+	notDue := models.User{ID: 2, GithubUserID: 222, GithubUsername: "notdue"}
+	now := time.Now()
+
+	// ユーザー1は1時間前、ユーザー2は2日前が配信予定時刻になるようにする
+	dueSlot := now.Add(-time.Hour).In(time.UTC)
+	notDueSlot := now.AddDate(0, 0, -2).In(time.UTC)
+	var delivered []string
+	var created []*models.NotificationLog
+	var statsRanges []notifier.DateRange
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{
+					{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: due},
+					{UserID: 2, WebhookURL: "https://hooks.slack.com/two", User: notDue},
+				}, nil
+			},
+		},
+		scheduleRepo: &mockNotificationScheduleRepository{
+			FindByUserIDsFunc: func(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+				assert.Equal(t, []uint64{1, 2}, userIDs)
+				return []models.NotificationSchedule{
+					{UserID: 1, WeeklyWeekday: int(dueSlot.Weekday()), DeliveryTime: dueSlot.Format("15:04"), Timezone: "UTC"},
+					{UserID: 2, WeeklyWeekday: int(notDueSlot.Weekday()), DeliveryTime: notDueSlot.Format("15:04"), Timezone: "UTC"},
+				}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				created = append(created, log)
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				statsRanges = append(statsRanges, notifier.DateRange{Start: startDate, End: endDate})
+				return nil, nil
+			},
+		},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				delivered = append(delivered, webhookURL)
+				return nil
+			},
+		},
+	}
+
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly", DueOnly: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	assert.Equal(t, []string{"https://hooks.slack.com/one"}, delivered)
+
+	// 集計期間はユーザーのタイムゾーンでの配信予定日の前日までの7日間
+	slotDate := time.Date(dueSlot.Year(), dueSlot.Month(), dueSlot.Day(), 0, 0, 0, 0, time.Local)
+	if assert.Len(t, statsRanges, 1) {
+		assert.Equal(t, slotDate.AddDate(0, 0, -7), statsRanges[0].Start)
+		assert.Equal(t, slotDate.AddDate(0, 0, -1), statsRanges[0].End)
+	}
+	// 冪等キーは集計期間の初日を含む週の月曜日
+	if assert.Len(t, created, 1) && assert.NotNil(t, created[0].PeriodStart) {
+		assert.Equal(t, notifier.PeriodStart("weekly", dueSlot), *created[0].PeriodStart)
+		assert.Equal(t, time.Monday, created[0].PeriodStart.Weekday())
+	}
+}
+
+func TestRunSendNotifications_DueOnlyUsesDefaultSchedule(t *testing.T) {
+	ctx := context.Background()
+	user := models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}
+	var requested []uint64
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: user}}, nil
+			},
+		},
+		scheduleRepo: &mockNotificationScheduleRepository{
+			FindByUserIDsFunc: func(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+				requested = userIDs
+				return nil, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{},
+		rivalRepo:           &mockRivalRepository{},
+		commitStatsRepo:     &mockCommitStatsRepository{},
+		slackGateway:        &mockSlackGateway{},
+	}
+
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly", DueOnly: true})
+
+	// デフォルトのスケジュール（月曜9:00 Asia/Tokyo）で判定される
+	slot, _ := lastScheduledSlot(models.DefaultNotificationSchedule(1), "weekly", time.Now())
+	expected := 0
+	if time.Since(slot) < ScheduleDueWindow {
+		expected = 1
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1}, requested)
+	assert.Equal(t, expected, report.SuccessCount)
+}
+
+func TestHeldDelivery(t *testing.T) {
+	// 2026-10-14 23:30 (Asia/Tokyo)
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	now := time.Date(2026, 10, 14, 23, 30, 0, 0, tokyo)
+	snoozeUntil := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		schedule        models.NotificationSchedule
+		wantHeld        bool
+		wantStatus      models.NotificationStatus
+		wantNextRetryAt *time.Time
+	}{
+		{"no pause", models.NotificationSchedule{Timezone: "Asia/Tokyo"}, false, "", nil},
+		{"quiet hours across midnight", models.NotificationSchedule{Timezone: "Asia/Tokyo", QuietHoursStart: "22:00", QuietHoursEnd: "07:00"},
+			true, models.NotificationStatusDeferred, ptrTime(time.Date(2026, 10, 15, 7, 0, 0, 0, tokyo))},
+		{"outside quiet hours", models.NotificationSchedule{Timezone: "Asia/Tokyo", QuietHoursStart: "00:00", QuietHoursEnd: "07:00"}, false, "", nil},
+		// 期限の日の終わりまではスヌーズ中
+		{"snoozed until today", models.NotificationSchedule{Timezone: "Asia/Tokyo", SnoozeUntil: &snoozeUntil, QuietHoursStart: "22:00", QuietHoursEnd: "07:00"},
+			true, models.NotificationStatusSkipped, nil},
+		{"snooze ended yesterday", models.NotificationSchedule{Timezone: "Asia/Tokyo", SnoozeUntil: ptrTime(snoozeUntil.AddDate(0, 0, -1))}, false, "", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, nextRetryAt, held := heldDelivery(&tt.schedule, now, now)
+
+			assert.Equal(t, tt.wantHeld, held)
+			assert.Equal(t, tt.wantStatus, status)
+			if tt.wantNextRetryAt == nil {
+				assert.Nil(t, nextRetryAt)
+			} else if assert.NotNil(t, nextRetryAt) {
+				assert.True(t, tt.wantNextRetryAt.Equal(*nextRetryAt), "next retry at %s", nextRetryAt)
+			}
+		})
+	}
+}
+
+// pauseTestDeps ユーザー1人（Slackのみ有効）に schedule を設定した依存関係を組み立てる
+func pauseTestDeps(t *testing.T, schedule models.NotificationSchedule, created *[]*models.NotificationLog) *testDeps {
+	return &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: models.User{ID: 1, GithubUsername: "user1"}}}, nil
+			},
+		},
+		scheduleRepo: &mockNotificationScheduleRepository{
+			FindByUserIDsFunc: func(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+				return []models.NotificationSchedule{schedule}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				*created = append(*created, log)
+				return nil
+			},
+		},
+		rivalRepo:       &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				t.Fatal("paused report should not be sent")
+				return nil
+			},
+		},
+	}
+}
+
+func TestRunSendNotifications_SnoozedReportIsSkipped(t *testing.T) {
+	snoozeUntil := time.Now().AddDate(0, 0, 3)
+	var created []*models.NotificationLog
+	deps := pauseTestDeps(t, models.NotificationSchedule{UserID: 1, WeeklyWeekday: 1, MonthlyDay: 1, DeliveryTime: "09:00", Timezone: "UTC", SnoozeUntil: &snoozeUntil}, &created)
+
+	report, err := RunSendNotifications(context.Background(), deps, SendNotificationsConfig{Period: "monthly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.SuccessCount)
+	assert.Equal(t, 1, report.SkipCount)
+	if assert.Len(t, created, 1) {
+		assert.Equal(t, models.NotificationStatusSkipped, created[0].Status)
+		assert.Nil(t, created[0].NextRetryAt)
+		assert.Equal(t, "monthly", created[0].Period)
+	}
+}
+
+func TestRunSendNotifications_QuietHoursDefersReport(t *testing.T) {
+	now := time.Now().In(time.UTC)
+	quietEnd := now.Add(time.Hour)
+	var created []*models.NotificationLog
+	deps := pauseTestDeps(t, models.NotificationSchedule{
+		UserID: 1, WeeklyWeekday: 1, MonthlyDay: 1, DeliveryTime: "09:00", Timezone: "UTC",
+		QuietHoursStart: now.Add(-time.Hour).Format("15:04"), QuietHoursEnd: quietEnd.Format("15:04"),
+	}, &created)
+
+	report, err := RunSendNotifications(context.Background(), deps, SendNotificationsConfig{Period: "weekly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SkipCount)
+	if assert.Len(t, created, 1) && assert.NotNil(t, created[0].NextRetryAt) {
+		// 時間帯が終わったら再送バッチで送る
+		assert.Equal(t, models.NotificationStatusDeferred, created[0].Status)
+		assert.Equal(t, quietEnd.Format("15:04"), created[0].NextRetryAt.In(time.UTC).Format("15:04"))
+	}
+}
+
+func TestRunSendNotifications_DoesNotResendHeldReports(t *testing.T) {
+	for _, status := range []models.NotificationStatus{models.NotificationStatusSkipped, models.NotificationStatusDeferred} {
+		t.Run(string(status), func(t *testing.T) {
+			var created []*models.NotificationLog
+			deps := pauseTestDeps(t, *models.DefaultNotificationSchedule(1), &created)
+			deps.notificationLogRepo.FindByPeriodStartFunc = func(ctx context.Context, period string, periodStart time.Time) ([]models.NotificationLog, error) {
+				return []models.NotificationLog{{ID: 3, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: period, Status: status}}, nil
+			}
+
+			report, err := RunSendNotifications(context.Background(), deps, SendNotificationsConfig{Period: "weekly"})
+
+			assert.NoError(t, err)
+			assert.Equal(t, 1, report.SkipCount)
+			assert.Empty(t, created)
+		})
+	}
+}
@@ -0,0 +1,270 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+)
+
+const (
+	// MaxDeliveryAttempts 初回を含めた送信の最大試行回数（超えたら dead_letter にする）
+	MaxDeliveryAttempts = 5
+	// AutoDisableThreshold 恒久的な失敗がこの回数続いたら通知設定を無効化する
+	AutoDisableThreshold = 3
+	// DefaultRetryLimit 1回の再送バッチで処理する最大件数
+	DefaultRetryLimit = 500
+	// retryBaseDelay 1回目の再送までの待ち時間（以降は倍々に伸ばす）
+	retryBaseDelay = 15 * time.Minute
+)
+
+// RetryNotificationsConfig 失敗した通知の再送バッチの設定
+type RetryNotificationsConfig struct {
+	Limit        int       // 1回の実行で再送する最大件数（0の場合は DefaultRetryLimit）
+	DryRun       bool      // 送信・ログ更新を行わずメッセージを書き出す
+	DryRunOutput io.Writer // ドライラン時の出力先（nilの場合は標準出力）
+}
+
+// Args batch_runs に記録する引数
+func (c RetryNotificationsConfig) Args() map[string]string {
+	return map[string]string{
+		"limit":   strconv.Itoa(c.limit()),
+		"dry_run": strconv.FormatBool(c.DryRun),
+	}
+}
+
+// LockName 多重実行を防ぐロック名
+func (c RetryNotificationsConfig) LockName() string {
+	return "retry-failed-notifications"
+}
+
+func (c RetryNotificationsConfig) limit() int {
+	if c.Limit <= 0 {
+		return DefaultRetryLimit
+	}
+	return c.Limit
+}
+
+// RunRetryFailedNotifications 再送予定日時を過ぎた失敗通知・遅らせた通知を送る
+// レポートは初回送信時と同じ集計期間で作り直し、現在の通知設定の宛先に送る
+func RunRetryFailedNotifications(ctx context.Context, deps ISendNotificationsDeps, config RetryNotificationsConfig) (*RunReport, error) {
+	log.Println("Starting retry-failed-notifications batch...")
+	report := NewRunReport()
+
+	now := time.Now()
+	logs, err := deps.GetNotificationLogRepo().FindDueRetries(ctx, now, config.limit())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications to retry: %w", err)
+	}
+	log.Printf("Found %d notifications to retry", len(logs))
+
+	var userIDs []uint64
+	for _, l := range logs {
+		userIDs = append(userIDs, l.UserID)
+	}
+	schedules, err := loadSchedules(ctx, deps.GetNotificationScheduleRepo(), userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	registry := deps.GetNotifierRegistry()
+	destinationsByChannel := make(map[models.ChannelType]map[uint64]notifier.Destination)
+
+	for i := range logs {
+		notificationLog := &logs[i]
+
+		n, ok := registry.Get(notificationLog.ChannelType)
+		if !ok {
+			giveUpRetry(ctx, deps, config, report, notificationLog, fmt.Sprintf("channel %s is not available", notificationLog.ChannelType))
+			continue
+		}
+
+		// チャンネルごとの有効な送信先は1回だけ取得する
+		destinations, cached := destinationsByChannel[notificationLog.ChannelType]
+		if !cached {
+			found, err := n.FindEnabledDestinations(ctx)
+			if err != nil {
+				return nil, err
+			}
+			destinations = make(map[uint64]notifier.Destination, len(found))
+			for _, d := range found {
+				destinations[d.UserID] = d
+			}
+			destinationsByChannel[notificationLog.ChannelType] = destinations
+		}
+
+		destination, ok := destinations[notificationLog.UserID]
+		if !ok {
+			giveUpRetry(ctx, deps, config, report, notificationLog, "notification setting is no longer enabled")
+			continue
+		}
+
+		// 再送の時点でスヌーズ中・通知を止める時間帯の場合も送らない
+		if status, nextRetryAt, held := heldDelivery(schedules[notificationLog.UserID], now, now); held {
+			holdRetry(ctx, deps, config, report, notificationLog, status, nextRetryAt)
+			continue
+		}
+
+		sendConfig := SendNotificationsConfig{Period: notificationLog.Period, DryRun: config.DryRun, DryRunOutput: config.DryRunOutput}
+		payload, sendErr := retryDelivery(ctx, deps, n, sendConfig, notificationLog, destination)
+
+		attemptedAt := time.Now()
+		notificationLog.Attempts++
+		if payload != nil {
+			notificationLog.Payload = payload
+		}
+		applyDeliveryResult(notificationLog, sendErr, attemptedAt)
+		countDelivery(report, notificationLog, sendErr)
+
+		if config.DryRun {
+			continue
+		}
+		if err := deps.GetNotificationLogRepo().Update(ctx, notificationLog); err != nil {
+			log.Printf("Failed to update notification log %d: %v", notificationLog.ID, err)
+			continue
+		}
+		disableOnPermanentFailures(ctx, deps, n, notificationLog, attemptedAt)
+		if sendErr == nil {
+			periodStart := notifier.PeriodStart(notificationLog.Period, notifier.LogReportAnchor(notificationLog))
+			if notificationLog.PeriodStart != nil {
+				periodStart = *notificationLog.PeriodStart
+			}
+			publishToInbox(ctx, deps.GetInboxPublisher(), notifier.ReportDeliveredInboxItem(
+				notificationLog.UserID, notificationLog.Period, periodStart, []models.ChannelType{notificationLog.ChannelType},
+			))
+		}
+	}
+
+	report.Finish()
+	elapsed := report.FinishedAt.Sub(report.StartedAt)
+	log.Printf("retry-failed-notifications batch completed in %s (success: %d, failed: %d, skipped: %d)", elapsed, report.SuccessCount, report.FailureCount, report.SkipCount)
+
+	return report, nil
+}
+
+// retryDelivery 初回送信時の集計期間でレポートを作り直して再送する
+func retryDelivery(
+	ctx context.Context,
+	deps ISendNotificationsDeps,
+	n notifier.INotifier,
+	config SendNotificationsConfig,
+	notificationLog *models.NotificationLog,
+	destination notifier.Destination,
+) (models.JSONPayload, error) {
+	data, err := loadReportData(ctx, deps, notificationLog.Period, notificationLog.UserID, destination.User, notifier.LogReportAnchor(notificationLog))
+	if err != nil {
+		return nil, err
+	}
+	return deliverReport(ctx, n, config, data, destination)
+}
+
+// giveUpRetry 再送できなくなった通知を dead_letter にする
+func giveUpRetry(ctx context.Context, deps ISendNotificationsDeps, config RetryNotificationsConfig, report *RunReport, notificationLog *models.NotificationLog, reason string) {
+	log.Printf("Giving up retrying %s notification %d for user %d: %s", notificationLog.ChannelType, notificationLog.ID, notificationLog.UserID, reason)
+	report.AddSkip()
+	if config.DryRun {
+		return
+	}
+
+	notificationLog.Status = models.NotificationStatusDeadLetter
+	notificationLog.NextRetryAt = nil
+	if err := deps.GetNotificationLogRepo().Update(ctx, notificationLog); err != nil {
+		log.Printf("Failed to update notification log %d: %v", notificationLog.ID, err)
+	}
+}
+
+// holdRetry スヌーズ中の通知は skipped に、通知を止める時間帯の通知は時間帯が終わるまで遅らせる
+func holdRetry(ctx context.Context, deps ISendNotificationsDeps, config RetryNotificationsConfig, report *RunReport, notificationLog *models.NotificationLog, status models.NotificationStatus, nextRetryAt *time.Time) {
+	log.Printf("Holding %s notification %d for user %d: %s", notificationLog.ChannelType, notificationLog.ID, notificationLog.UserID, status)
+	report.AddSkip()
+	if config.DryRun {
+		return
+	}
+
+	notificationLog.Status = status
+	notificationLog.NextRetryAt = nextRetryAt
+	if err := deps.GetNotificationLogRepo().Update(ctx, notificationLog); err != nil {
+		log.Printf("Failed to update notification log %d: %v", notificationLog.ID, err)
+	}
+}
+
+// applyDeliveryResult 送信結果をログに反映する
+// リトライ可能な失敗は試行回数が上限に達するまで NextRetryAt に再送し、それ以外は dead_letter にする
+func applyDeliveryResult(notificationLog *models.NotificationLog, sendErr error, attemptedAt time.Time) {
+	if sendErr == nil {
+		notificationLog.Status = models.NotificationStatusSuccess
+		notificationLog.ErrorMessage = ""
+		notificationLog.FailureKind = ""
+		notificationLog.NextRetryAt = nil
+		return
+	}
+
+	kind := notifier.ClassifyFailure(sendErr)
+	notificationLog.ErrorMessage = sendErr.Error()
+	notificationLog.FailureKind = string(kind)
+
+	if kind == notifier.FailureKindRetryable && notificationLog.Attempts < MaxDeliveryAttempts {
+		nextRetryAt := attemptedAt.Add(retryBackoff(notificationLog.Attempts))
+		notificationLog.Status = models.NotificationStatusFailed
+		notificationLog.NextRetryAt = &nextRetryAt
+		return
+	}
+
+	notificationLog.Status = models.NotificationStatusDeadLetter
+	notificationLog.NextRetryAt = nil
+}
+
+// retryBackoff attempts 回目の失敗から次の再送までの待ち時間（15分, 30分, 1時間, 2時間...）
+func retryBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return retryBaseDelay << (attempts - 1)
+}
+
+// countDelivery 送信結果を集計する
+func countDelivery(report *RunReport, notificationLog *models.NotificationLog, sendErr error) {
+	if sendErr == nil {
+		report.AddSuccess()
+		return
+	}
+	log.Printf("Failed to send %s notification for user %d (attempt %d, %s): %v",
+		notificationLog.ChannelType, notificationLog.UserID, notificationLog.Attempts, notificationLog.FailureKind, sendErr)
+	report.AddFailure(fmt.Sprintf("user %d (%s)", notificationLog.UserID, notificationLog.ChannelType), sendErr)
+}
+
+// disableOnPermanentFailures 恒久的な失敗が AutoDisableThreshold 回続いたチャンネルを無効化し、ユーザーに知らせる
+// 404 / 410 も一時的に消えていることがあるため、1回では無効化しない
+func disableOnPermanentFailures(ctx context.Context, deps ISendNotificationsDeps, n notifier.INotifier, notificationLog *models.NotificationLog, now time.Time) {
+	if notificationLog.FailureKind != string(notifier.FailureKindPermanent) {
+		return
+	}
+
+	recent, err := deps.GetNotificationLogRepo().FindRecentByUserIDAndChannel(ctx, notificationLog.UserID, notificationLog.ChannelType, AutoDisableThreshold)
+	if err != nil {
+		log.Printf("Failed to get recent notification logs for user %d: %v", notificationLog.UserID, err)
+		return
+	}
+	if len(recent) < AutoDisableThreshold {
+		return
+	}
+	for _, l := range recent {
+		if l.FailureKind != string(notifier.FailureKindPermanent) {
+			return
+		}
+	}
+
+	if err := n.Disable(ctx, notificationLog.UserID); err != nil {
+		log.Printf("Failed to disable %s notifications for user %d: %v", notificationLog.ChannelType, notificationLog.UserID, err)
+		return
+	}
+	if err := deps.GetUserRepo().UpdateNotificationAlert(ctx, notificationLog.UserID, &now); err != nil {
+		log.Printf("Failed to flag user %d: %v", notificationLog.UserID, err)
+	}
+	log.Printf("Disabled %s notifications for user %d after %d consecutive permanent failures", notificationLog.ChannelType, notificationLog.UserID, AutoDisableThreshold)
+}
@@ -0,0 +1,485 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDeliveryResult(t *testing.T) {
+	attemptedAt := time.Date(2026, 10, 12, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name            string
+		attempts        int
+		err             error
+		wantStatus      models.NotificationStatus
+		wantFailureKind string
+		wantNextRetryAt *time.Time
+	}{
+		{"success", 1, nil, models.NotificationStatusSuccess, "", nil},
+		{"first retryable failure", 1, errors.New("connection reset"), models.NotificationStatusFailed, "retryable", ptrTime(attemptedAt.Add(15 * time.Minute))},
+		{"third retryable failure", 3, &gateway.HTTPStatusError{StatusCode: 503}, models.NotificationStatusFailed, "retryable", ptrTime(attemptedAt.Add(time.Hour))},
+		{"retries exhausted", MaxDeliveryAttempts, &gateway.HTTPStatusError{StatusCode: 503}, models.NotificationStatusDeadLetter, "retryable", nil},
+		{"permanent failure", 1, &gateway.HTTPStatusError{StatusCode: 404, Body: "no_service"}, models.NotificationStatusDeadLetter, "permanent", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notificationLog := &models.NotificationLog{Attempts: tt.attempts, ErrorMessage: "previous error", FailureKind: "retryable"}
+			applyDeliveryResult(notificationLog, tt.err, attemptedAt)
+
+			assert.Equal(t, tt.wantStatus, notificationLog.Status)
+			assert.Equal(t, tt.wantFailureKind, notificationLog.FailureKind)
+			assert.Equal(t, tt.wantNextRetryAt, notificationLog.NextRetryAt)
+			if tt.err == nil {
+				assert.Empty(t, notificationLog.ErrorMessage)
+			}
+		})
+	}
+}
+
+func ptrTime(t time.Time) *time.Time {
+	return &t
+}
+
+func TestRunSendNotifications_RetryableFailureSchedulesRetry(t *testing.T) {
+	ctx := context.Background()
+	var savedLog *models.NotificationLog
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: models.User{ID: 1, GithubUsername: "user1"}}}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				savedLog = log
+				return nil
+			},
+			FindRecentByUserIDAndChannelFunc: func(ctx context.Context, userID uint64, channelType models.ChannelType, limit int) ([]models.NotificationLog, error) {
+				t.Fatal("retryable failures must not count towards auto-disable")
+				return nil, nil
+			},
+		},
+		rivalRepo:       &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				return &gateway.HTTPStatusError{Target: "slack webhook", StatusCode: 500}
+			},
+		},
+	}
+
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.FailureCount)
+	assert.Equal(t, models.NotificationStatusFailed, savedLog.Status)
+	assert.Equal(t, string(notifier.FailureKindRetryable), savedLog.FailureKind)
+	assert.Equal(t, 1, savedLog.Attempts)
+	if assert.NotNil(t, savedLog.NextRetryAt) {
+		assert.Equal(t, savedLog.SentAt.Add(retryBaseDelay), *savedLog.NextRetryAt)
+	}
+}
+
+func TestRunSendNotifications_PermanentFailuresDisableChannel(t *testing.T) {
+	ctx := context.Background()
+	var savedLog *models.NotificationLog
+	var disabledUserID uint64
+	var alertUserID uint64
+	var alertAt *time.Time
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/gone", User: models.User{ID: 1, GithubUsername: "user1"}}}, nil
+			},
+			UpdateEnabledFunc: func(ctx context.Context, userID uint64, isEnabled bool) error {
+				assert.False(t, isEnabled)
+				disabledUserID = userID
+				return nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				savedLog = log
+				return nil
+			},
+			FindRecentByUserIDAndChannelFunc: func(ctx context.Context, userID uint64, channelType models.ChannelType, limit int) ([]models.NotificationLog, error) {
+				assert.Equal(t, models.ChannelTypeSlack, channelType)
+				assert.Equal(t, AutoDisableThreshold, limit)
+				return []models.NotificationLog{
+					{FailureKind: "permanent"},
+					{FailureKind: "permanent"},
+					{FailureKind: "permanent"},
+				}, nil
+			},
+		},
+		userRepo: &mockUserRepository{
+			UpdateNotificationAlertFunc: func(ctx context.Context, id uint64, at *time.Time) error {
+				alertUserID = id
+				alertAt = at
+				return nil
+			},
+		},
+		rivalRepo:       &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				return &gateway.HTTPStatusError{Target: "slack webhook", StatusCode: 404, Body: "no_service"}
+			},
+		},
+	}
+
+	_, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.NotificationStatusDeadLetter, savedLog.Status)
+	assert.Equal(t, string(notifier.FailureKindPermanent), savedLog.FailureKind)
+	assert.Nil(t, savedLog.NextRetryAt)
+	assert.Equal(t, uint64(1), disabledUserID)
+	assert.Equal(t, uint64(1), alertUserID)
+	assert.NotNil(t, alertAt)
+}
+
+func TestRunSendNotifications_PermanentFailureBelowThresholdKeepsChannel(t *testing.T) {
+	ctx := context.Background()
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/revoked", User: models.User{ID: 1, GithubUsername: "user1"}}}, nil
+			},
+			UpdateEnabledFunc: func(ctx context.Context, userID uint64, isEnabled bool) error {
+				t.Fatal("channel must not be disabled before reaching the threshold")
+				return nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			FindRecentByUserIDAndChannelFunc: func(ctx context.Context, userID uint64, channelType models.ChannelType, limit int) ([]models.NotificationLog, error) {
+				return []models.NotificationLog{
+					{FailureKind: "permanent"},
+					{FailureKind: "permanent"},
+					{Status: models.NotificationStatusSuccess},
+				}, nil
+			},
+		},
+		rivalRepo:       &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				return &gateway.HTTPStatusError{Target: "slack webhook", StatusCode: 403, Body: "invalid_token"}
+			},
+		},
+	}
+
+	_, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
+
+	assert.NoError(t, err)
+}
+
+func TestRunRetryFailedNotifications_GoneDestinationWaitsForThreshold(t *testing.T) {
+	ctx := context.Background()
+	var updatedLog *models.NotificationLog
+	recentChecked := false
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/gone", User: models.User{ID: 1, GithubUsername: "user1"}}}, nil
+			},
+			UpdateEnabledFunc: func(ctx context.Context, userID uint64, isEnabled bool) error {
+				t.Fatal("a single 404 must not disable the channel")
+				return nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			// 初回は一時的な失敗で、同じログを再送している
+			FindDueRetriesFunc: func(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error) {
+				return []models.NotificationLog{{
+					ID: 10, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "weekly",
+					Status: models.NotificationStatusFailed, FailureKind: "retryable", Attempts: 2, SentAt: time.Now(),
+				}}, nil
+			},
+			UpdateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				updatedLog = log
+				return nil
+			},
+			// 404 / 410 も他の恒久的な失敗と同じく AutoDisableThreshold 回続くまで待つ
+			FindRecentByUserIDAndChannelFunc: func(ctx context.Context, userID uint64, channelType models.ChannelType, limit int) ([]models.NotificationLog, error) {
+				recentChecked = true
+				assert.Equal(t, AutoDisableThreshold, limit)
+				return []models.NotificationLog{*updatedLog}, nil
+			},
+		},
+		userRepo: &mockUserRepository{
+			UpdateNotificationAlertFunc: func(ctx context.Context, id uint64, at *time.Time) error {
+				t.Fatal("user must not be alerted before reaching the threshold")
+				return nil
+			},
+		},
+		rivalRepo:       &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				return &gateway.HTTPStatusError{Target: "slack webhook", StatusCode: 404, Body: "no_service"}
+			},
+		},
+	}
+
+	_, err := RunRetryFailedNotifications(ctx, deps, RetryNotificationsConfig{})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, updatedLog) {
+		assert.Equal(t, uint64(10), updatedLog.ID)
+		assert.Equal(t, models.NotificationStatusDeadLetter, updatedLog.Status)
+		assert.Equal(t, 3, updatedLog.Attempts)
+	}
+	assert.True(t, recentChecked)
+}
+
+func TestRunRetryFailedNotifications_ResendsWithOriginalRange(t *testing.T) {
+	ctx := context.Background()
+	firstSentAt := time.Date(2026, 10, 12, 9, 0, 0, 0, time.Local)
+	var updatedLog *models.NotificationLog
+	var statsStart time.Time
+	var inbox []*models.InAppNotification
+	slackCalled := false
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}}}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			FindDueRetriesFunc: func(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error) {
+				assert.Equal(t, DefaultRetryLimit, limit)
+				nextRetryAt := firstSentAt.Add(retryBaseDelay)
+				return []models.NotificationLog{{
+					ID: 10, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "weekly",
+					Status: models.NotificationStatusFailed, FailureKind: "retryable", ErrorMessage: "slack webhook returned status 500",
+					Attempts: 1, NextRetryAt: &nextRetryAt, SentAt: firstSentAt,
+				}}, nil
+			},
+			UpdateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				updatedLog = log
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				statsStart = startDate
+				return []models.CommitStats{{CommitCount: 5}}, nil
+			},
+		},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				slackCalled = true
+				return nil
+			},
+		},
+		inAppNotificationRepo: &mockInAppNotificationRepository{
+			CreateIfNotExistsFunc: func(ctx context.Context, notification *models.InAppNotification) (bool, error) {
+				inbox = append(inbox, notification)
+				return true, nil
+			},
+		},
+	}
+
+	report, err := RunRetryFailedNotifications(ctx, deps, RetryNotificationsConfig{})
+
+	assert.NoError(t, err)
+	assert.True(t, slackCalled)
+	assert.Equal(t, 1, report.SuccessCount)
+	assert.Equal(t, time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local), statsStart)
+
+	if assert.NotNil(t, updatedLog) {
+		assert.Equal(t, uint64(10), updatedLog.ID)
+		assert.Equal(t, models.NotificationStatusSuccess, updatedLog.Status)
+		assert.Equal(t, 2, updatedLog.Attempts)
+		assert.Nil(t, updatedLog.NextRetryAt)
+		assert.Empty(t, updatedLog.ErrorMessage)
+		assert.Equal(t, firstSentAt, updatedLog.SentAt)
+		assert.Contains(t, updatedLog.Payload, "blocks")
+	}
+
+	// 受信箱には初回送信時と同じ集計期間で載せる
+	if assert.Len(t, inbox, 1) {
+		assert.Equal(t, "report_delivered:weekly:2026-10-05", *inbox[0].DedupeKey)
+	}
+}
+
+func TestRunRetryFailedNotifications_ExhaustedGoesToDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	var updatedLog *models.NotificationLog
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: models.User{ID: 1, GithubUsername: "user1"}}}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			FindDueRetriesFunc: func(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error) {
+				return []models.NotificationLog{{
+					ID: 10, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "weekly",
+					Status: models.NotificationStatusFailed, Attempts: MaxDeliveryAttempts - 1, SentAt: time.Now(),
+				}}, nil
+			},
+			UpdateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				updatedLog = log
+				return nil
+			},
+		},
+		rivalRepo:       &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				return &gateway.HTTPStatusError{Target: "slack webhook", StatusCode: 503}
+			},
+		},
+	}
+
+	report, err := RunRetryFailedNotifications(ctx, deps, RetryNotificationsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.FailureCount)
+	assert.Equal(t, models.NotificationStatusDeadLetter, updatedLog.Status)
+	assert.Equal(t, MaxDeliveryAttempts, updatedLog.Attempts)
+	assert.Nil(t, updatedLog.NextRetryAt)
+}
+
+func TestRunRetryFailedNotifications_SettingNoLongerEnabled(t *testing.T) {
+	ctx := context.Background()
+	var updatedLog *models.NotificationLog
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{},
+		notificationLogRepo: &mockNotificationLogRepository{
+			FindDueRetriesFunc: func(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error) {
+				return []models.NotificationLog{{
+					ID: 10, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "weekly",
+					Status: models.NotificationStatusFailed, Attempts: 1, SentAt: time.Now(),
+				}}, nil
+			},
+			UpdateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				updatedLog = log
+				return nil
+			},
+		},
+		rivalRepo:       &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				t.Fatal("disabled channel must not be retried")
+				return nil
+			},
+		},
+	}
+
+	report, err := RunRetryFailedNotifications(ctx, deps, RetryNotificationsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SkipCount)
+	assert.Equal(t, models.NotificationStatusDeadLetter, updatedLog.Status)
+	assert.Nil(t, updatedLog.NextRetryAt)
+}
+
+func TestRunRetryFailedNotifications_DryRunDoesNotUpdate(t *testing.T) {
+	ctx := context.Background()
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: models.User{ID: 1, GithubUsername: "user1"}}}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			FindDueRetriesFunc: func(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error) {
+				return []models.NotificationLog{{ID: 10, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "weekly", Status: models.NotificationStatusFailed, Attempts: 1, SentAt: time.Now()}}, nil
+			},
+			UpdateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				t.Fatal("notification log must not be updated in dry-run")
+				return nil
+			},
+		},
+		rivalRepo:       &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				t.Fatal("slack message must not be sent in dry-run")
+				return nil
+			},
+		},
+	}
+
+	report, err := RunRetryFailedNotifications(ctx, deps, RetryNotificationsConfig{DryRun: true, DryRunOutput: io.Discard})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+}
+
+func TestRunRetryFailedNotifications_DeferredDuringQuietHours(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().In(time.UTC)
+	var updatedLog *models.NotificationLog
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: models.User{ID: 1, GithubUsername: "user1"}}}, nil
+			},
+		},
+		scheduleRepo: &mockNotificationScheduleRepository{
+			FindByUserIDsFunc: func(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+				assert.Equal(t, []uint64{1}, userIDs)
+				return []models.NotificationSchedule{{
+					UserID: 1, DeliveryTime: "09:00", Timezone: "UTC",
+					QuietHoursStart: now.Add(-time.Hour).Format("15:04"), QuietHoursEnd: now.Add(2 * time.Hour).Format("15:04"),
+				}}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			FindDueRetriesFunc: func(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error) {
+				nextRetryAt := now.Add(-time.Minute)
+				return []models.NotificationLog{{
+					ID: 10, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "weekly",
+					Status: models.NotificationStatusFailed, Attempts: 1, NextRetryAt: &nextRetryAt, SentAt: now.Add(-time.Hour),
+				}}, nil
+			},
+			UpdateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				updatedLog = log
+				return nil
+			},
+		},
+		rivalRepo:       &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				t.Fatal("notification should not be retried during quiet hours")
+				return nil
+			},
+		},
+	}
+
+	report, err := RunRetryFailedNotifications(ctx, deps, RetryNotificationsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SkipCount)
+	if assert.NotNil(t, updatedLog) && assert.NotNil(t, updatedLog.NextRetryAt) {
+		assert.Equal(t, models.NotificationStatusDeferred, updatedLog.Status)
+		assert.Equal(t, 1, updatedLog.Attempts)
+		assert.True(t, updatedLog.NextRetryAt.After(now.Add(time.Hour)))
+	}
+}
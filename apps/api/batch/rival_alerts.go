@@ -0,0 +1,396 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+const (
+	// DefaultRivalAlertLimit ユーザーごとに RivalAlertWindow の間に送るアラートの上限
+	DefaultRivalAlertLimit = 3
+	// DefaultRivalAlertWindow アラートの送信数を数える期間
+	DefaultRivalAlertWindow = 24 * time.Hour
+	// DefaultRivalAlertMinStreak 途切れたことを知らせるライバルの連続コミット日数の下限
+	DefaultRivalAlertMinStreak = 3
+	// streakLookbackDays 連続コミット日数を数えるためにさかのぼる日数
+	streakLookbackDays = 60
+)
+
+// RivalAlertsConfig ライバルアラートバッチの設定
+type RivalAlertsConfig struct {
+	Limit        int           // ユーザーごとに Window の間に送る最大件数（0の場合は DefaultRivalAlertLimit）
+	Window       time.Duration // 送信数を数える期間（0の場合は DefaultRivalAlertWindow）
+	MinStreak    int           // 途切れたことを知らせる連続コミット日数の下限（0の場合は DefaultRivalAlertMinStreak）
+	DryRun       bool          // 送信・記録を行わずメッセージを書き出す
+	DryRunOutput io.Writer     // ドライラン時の出力先（nilの場合は標準出力）
+}
+
+// IRivalAlertsDeps ライバルアラートバッチの依存関係インターフェース
+type IRivalAlertsDeps interface {
+	GetRivalRepo() repository.IRivalRepository
+	GetCommitStatsRepo() repository.ICommitStatsRepository
+	GetRivalAlertRepo() repository.IRivalAlertRepository
+	GetNotificationScheduleRepo() repository.INotificationScheduleRepository
+	GetNotifierRegistry() *notifier.Registry
+	GetInboxPublisher() notifier.IInboxPublisher
+}
+
+// Args batch_runs に記録する引数
+func (c RivalAlertsConfig) Args() map[string]string {
+	return map[string]string{
+		"limit":      strconv.Itoa(c.limit()),
+		"window":     c.window().String(),
+		"min_streak": strconv.Itoa(c.minStreak()),
+		"dry_run":    strconv.FormatBool(c.DryRun),
+	}
+}
+
+// LockName 多重実行を防ぐロック名
+func (c RivalAlertsConfig) LockName() string {
+	return "send-rival-alerts"
+}
+
+func (c RivalAlertsConfig) limit() int {
+	if c.Limit <= 0 {
+		return DefaultRivalAlertLimit
+	}
+	return c.Limit
+}
+
+func (c RivalAlertsConfig) window() time.Duration {
+	if c.Window <= 0 {
+		return DefaultRivalAlertWindow
+	}
+	return c.Window
+}
+
+func (c RivalAlertsConfig) minStreak() int {
+	if c.MinStreak <= 0 {
+		return DefaultRivalAlertMinStreak
+	}
+	return c.MinStreak
+}
+
+// rivalAlertEvent 検出したアラート1件
+type rivalAlertEvent struct {
+	alert             notifier.Alert
+	rivalGithubUserID uint64
+	eventKey          string // 同じイベントを二度送らないためのキー
+}
+
+// RunRivalAlerts コミット同期の直後に、ライバルに抜かれた・抜き返した・ライバルの連続コミットが途切れたことを知らせる
+// 通知チャンネルが有効なユーザーだけを対象に、今週のコミット数を前回の比較結果と比べて判定する
+// 通知を止める時間帯のユーザーは判定せず、時間帯が終わった後の実行で知らせる。スヌーズ中のユーザーには送らない
+func RunRivalAlerts(ctx context.Context, deps IRivalAlertsDeps, config RivalAlertsConfig) (*RunReport, error) {
+	log.Println("Starting send-rival-alerts batch...")
+	report := NewRunReport()
+
+	if config.DryRun {
+		log.Println("Dry-run mode: alerts will be written instead of sent, and nothing will be saved")
+	}
+
+	// 全チャンネルの有効な送信先を取得
+	registry := deps.GetNotifierRegistry()
+	var destinations []notifier.Destination
+	for _, n := range registry.All() {
+		found, err := n.FindEnabledDestinations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, found...)
+	}
+
+	userIDs, destinationsByUser := groupDestinationsByUser(destinations)
+	log.Printf("Checking rival alerts for %d users", len(userIDs))
+
+	schedules, err := loadSchedules(ctx, deps.GetNotificationScheduleRepo(), userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	for _, userID := range userIDs {
+		schedule := schedules[userID]
+		// リードの状態を更新しないことで、時間帯が終わった後の実行で同じ変化を検出する
+		if until, quiet := schedule.QuietUntil(now); quiet {
+			log.Printf("Deferring rival alerts for user %d until %s: quiet hours", userID, until.Format(time.RFC3339))
+			report.AddSkip()
+			continue
+		}
+
+		userDestinations := destinationsByUser[userID]
+		events, standings, err := detectRivalAlerts(ctx, deps, config, userID, userDestinations[0].User, now)
+		if err != nil {
+			report.AddFailure(fmt.Sprintf("user %d", userID), err)
+			continue
+		}
+
+		if len(events) > 0 {
+			if schedule.IsSnoozed(now) {
+				// スヌーズが明けた後にまとめて届かないよう、リードの状態だけ更新する
+				log.Printf("Skipping %d rival alerts for user %d: snoozed", len(events), userID)
+				for range events {
+					report.AddSkip()
+				}
+			} else {
+				sendRivalAlerts(ctx, deps, config, report, userID, userDestinations, events, now)
+			}
+		}
+
+		if config.DryRun {
+			continue
+		}
+		for i := range standings {
+			if err := deps.GetRivalAlertRepo().UpsertStanding(ctx, &standings[i]); err != nil {
+				log.Printf("Failed to save rival standing for user %d: %v", userID, err)
+			}
+		}
+	}
+
+	report.Finish()
+	elapsed := report.FinishedAt.Sub(report.StartedAt)
+	log.Printf("send-rival-alerts batch completed in %s (success: %d, failed: %d, skipped: %d)", elapsed, report.SuccessCount, report.FailureCount, report.SkipCount)
+
+	return report, nil
+}
+
+// detectRivalAlerts ユーザーのライバルごとにアラートを検出し、保存し直すリードの状態と合わせて返す
+func detectRivalAlerts(
+	ctx context.Context,
+	deps IRivalAlertsDeps,
+	config RivalAlertsConfig,
+	userID uint64,
+	user models.User,
+	now time.Time,
+) ([]rivalAlertEvent, []models.RivalStanding, error) {
+	week := notifier.CurrentWeekRange(now)
+	yesterday := week.End.AddDate(0, 0, -1)
+
+	userStats, err := deps.GetCommitStatsRepo().FindByGithubUserIDAndDateRange(ctx, user.GithubUserID, week.Start, week.End)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get user commit stats: %w", err)
+	}
+	userCommits := notifier.SumCommits(userStats)
+
+	rivals, err := deps.GetRivalRepo().FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get rivals: %w", err)
+	}
+
+	standings, err := deps.GetRivalAlertRepo().FindStandingsByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get rival standings: %w", err)
+	}
+	standingByRival := make(map[uint64]models.RivalStanding, len(standings))
+	for _, s := range standings {
+		standingByRival[s.RivalGithubUserID] = s
+	}
+
+	var events []rivalAlertEvent
+	var changed []models.RivalStanding
+	for _, rival := range rivals {
+		rivalStats, err := deps.GetCommitStatsRepo().FindByGithubUserIDAndDateRange(ctx, rival.RivalGithubUserID, week.End.AddDate(0, 0, -streakLookbackDays), week.End)
+		if err != nil {
+			log.Printf("Failed to get rival %s commit stats: %v", rival.RivalGithubUsername, err)
+			continue
+		}
+
+		summary := gateway.RivalCommitSummary{
+			Username: rival.RivalGithubUsername,
+			Commits:  notifier.SumCommits(statsSince(rivalStats, week.Start)),
+		}
+		alert := notifier.Alert{
+			Username:    user.GithubUsername,
+			UserCommits: userCommits,
+			Rival:       summary,
+			Week:        week,
+		}
+
+		// 今週のコミット数のリードの変化
+		// 週が変わった直後や初めて比較するライバルは基準がないため、最初にリードした側を記録するだけにする
+		var previous *bool
+		if standing, ok := standingByRival[rival.RivalGithubUserID]; ok && sameDate(standing.WeekStart, week.Start) {
+			previous = &standing.RivalAhead
+		}
+		next, kind := notifier.LeadChange(previous, userCommits, summary)
+		if kind != "" {
+			alert.Kind = kind
+			events = append(events, rivalAlertEvent{
+				alert:             alert,
+				rivalGithubUserID: rival.RivalGithubUserID,
+				eventKey:          fmt.Sprintf("%s:%d-%d", week.Start.Format("2006-01-02"), userCommits, summary.Commits),
+			})
+		}
+		if next != nil && (previous == nil || *next != *previous) {
+			changed = append(changed, models.RivalStanding{
+				UserID:            userID,
+				RivalGithubUserID: rival.RivalGithubUserID,
+				WeekStart:         week.Start,
+				RivalAhead:        *next,
+			})
+		}
+
+		// 昨日でライバルの連続コミットが途切れたか
+		if streak := notifier.BrokenStreak(rivalStats, yesterday); streak >= config.minStreak() {
+			alert.Kind = models.RivalAlertStreakBroken
+			alert.Streak = streak
+			events = append(events, rivalAlertEvent{
+				alert:             alert,
+				rivalGithubUserID: rival.RivalGithubUserID,
+				eventKey:          yesterday.Format("2006-01-02"),
+			})
+		}
+	}
+
+	return events, changed, nil
+}
+
+// sendRivalAlerts アラートを記録してユーザーの有効な全チャンネルに送る
+// 同じイベントが記録済みの場合と、送信数の上限に達した場合は送らない
+func sendRivalAlerts(
+	ctx context.Context,
+	deps IRivalAlertsDeps,
+	config RivalAlertsConfig,
+	report *RunReport,
+	userID uint64,
+	destinations []notifier.Destination,
+	events []rivalAlertEvent,
+	now time.Time,
+) {
+	alertRepo := deps.GetRivalAlertRepo()
+	sent, err := alertRepo.CountSentSince(ctx, userID, now.Add(-config.window()))
+	if err != nil {
+		// 送信数が分からないまま送ると上限を超えるため、このユーザーは送らない
+		report.AddFailure(fmt.Sprintf("user %d", userID), fmt.Errorf("failed to count sent rival alerts: %w", err))
+		return
+	}
+
+	for _, event := range events {
+		record := &models.RivalAlert{
+			UserID:              userID,
+			RivalGithubUserID:   event.rivalGithubUserID,
+			Kind:                event.alert.Kind,
+			EventKey:            event.eventKey,
+			RivalGithubUsername: event.alert.Rival.Username,
+			Status:              models.RivalAlertStatusSent,
+			UserCommits:         event.alert.UserCommits,
+			RivalCommits:        event.alert.Rival.Commits,
+			Streak:              event.alert.Streak,
+		}
+		if int(sent) >= config.limit() {
+			record.Status = models.RivalAlertStatusSuppressed
+		}
+
+		if config.DryRun {
+			if record.Status == models.RivalAlertStatusSuppressed {
+				report.AddSkip()
+				continue
+			}
+			for _, destination := range destinations {
+				writeRivalAlertDryRun(config, registryNotifier(deps, destination), destination, &event.alert, report)
+			}
+			sent++
+			continue
+		}
+
+		created, err := alertRepo.CreateIfNotExists(ctx, record)
+		if err != nil {
+			report.AddFailure(fmt.Sprintf("user %d (%s %s)", userID, record.Kind, record.RivalGithubUsername), fmt.Errorf("failed to save rival alert: %w", err))
+			continue
+		}
+		if !created {
+			log.Printf("Skipping %s alert for user %d about %s: already handled", record.Kind, userID, record.RivalGithubUsername)
+			report.AddSkip()
+			continue
+		}
+		if record.Status == models.RivalAlertStatusSuppressed {
+			log.Printf("Suppressed %s alert for user %d about %s: %d alerts already sent in %s", record.Kind, userID, record.RivalGithubUsername, sent, config.window())
+			report.AddSkip()
+			continue
+		}
+
+		// 受信箱にはチャンネルへの配信結果に関わらず残す
+		publishToInbox(ctx, deps.GetInboxPublisher(), notifier.RivalAlertInboxItem(userID, record.ID, &event.alert))
+
+		delivered := false
+		for _, destination := range destinations {
+			if err := deliverRivalAlert(ctx, registryNotifier(deps, destination), destination, &event.alert); err != nil {
+				log.Printf("Failed to send %s alert to user %d via %s: %v", record.Kind, userID, destination.ChannelType, err)
+				report.AddFailure(fmt.Sprintf("user %d (%s)", userID, destination.ChannelType), err)
+				continue
+			}
+			report.AddSuccess()
+			delivered = true
+		}
+
+		if !delivered {
+			// アラートは鮮度が大事なため再送はしない
+			if err := alertRepo.UpdateStatus(ctx, record.ID, models.RivalAlertStatusFailed); err != nil {
+				log.Printf("Failed to update rival alert %d: %v", record.ID, err)
+			}
+			continue
+		}
+		log.Printf("Sent %s alert to user %d about %s", record.Kind, userID, record.RivalGithubUsername)
+		sent++
+	}
+}
+
+// registryNotifier 送信先のチャンネルの Notifier
+func registryNotifier(deps IRivalAlertsDeps, destination notifier.Destination) notifier.INotifier {
+	n, _ := deps.GetNotifierRegistry().Get(destination.ChannelType)
+	return n
+}
+
+// deliverRivalAlert アラートをチャンネル向けにレンダリングして配信する
+func deliverRivalAlert(ctx context.Context, n notifier.INotifier, destination notifier.Destination, alert *notifier.Alert) error {
+	message, err := n.RenderAlert(destination, alert)
+	if err != nil {
+		return fmt.Errorf("failed to render %s alert: %w", destination.ChannelType, err)
+	}
+	return n.Deliver(ctx, destination, message)
+}
+
+// writeRivalAlertDryRun 送る予定のアラートを書き出す
+func writeRivalAlertDryRun(config RivalAlertsConfig, n notifier.INotifier, destination notifier.Destination, alert *notifier.Alert, report *RunReport) {
+	message, err := n.RenderAlert(destination, alert)
+	if err == nil {
+		err = writeDryRunRecord(config.DryRunOutput, DryRunRecord{
+			UserID:         destination.UserID,
+			GithubUsername: destination.User.GithubUsername,
+			ChannelType:    destination.ChannelType,
+			Period:         "rival_alert:" + string(alert.Kind),
+			Message:        message.Body,
+		})
+	}
+	if err != nil {
+		report.AddFailure(fmt.Sprintf("user %d (%s)", destination.UserID, destination.ChannelType), err)
+		return
+	}
+	report.AddSuccess()
+}
+
+// statsSince start 以降の日の統計だけを返す
+func statsSince(stats []models.CommitStats, start time.Time) []models.CommitStats {
+	from := start.Format("2006-01-02")
+	var filtered []models.CommitStats
+	for _, s := range stats {
+		if s.Date.Format("2006-01-02") >= from {
+			filtered = append(filtered, s)
+		}
+	}
+	return filtered
+}
+
+// sameDate 同じ日付かどうか（DBの date 型はタイムゾーンが異なることがあるため日付の文字列で比べる）
+func sameDate(a, b time.Time) bool {
+	return a.Format("2006-01-02") == b.Format("2006-01-02")
+}
@@ -0,0 +1,415 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockRivalAlertRepository テスト用のモック
+type mockRivalAlertRepository struct {
+	CreateIfNotExistsFunc     func(ctx context.Context, alert *models.RivalAlert) (bool, error)
+	UpdateStatusFunc          func(ctx context.Context, id uint64, status models.RivalAlertStatus) error
+	CountSentSinceFunc        func(ctx context.Context, userID uint64, since time.Time) (int64, error)
+	FindStandingsByUserIDFunc func(ctx context.Context, userID uint64) ([]models.RivalStanding, error)
+	UpsertStandingFunc        func(ctx context.Context, standing *models.RivalStanding) error
+}
+
+func (m *mockRivalAlertRepository) CreateIfNotExists(ctx context.Context, alert *models.RivalAlert) (bool, error) {
+	if m.CreateIfNotExistsFunc != nil {
+		return m.CreateIfNotExistsFunc(ctx, alert)
+	}
+	return true, nil
+}
+
+func (m *mockRivalAlertRepository) UpdateStatus(ctx context.Context, id uint64, status models.RivalAlertStatus) error {
+	if m.UpdateStatusFunc != nil {
+		return m.UpdateStatusFunc(ctx, id, status)
+	}
+	return nil
+}
+
+func (m *mockRivalAlertRepository) CountSentSince(ctx context.Context, userID uint64, since time.Time) (int64, error) {
+	if m.CountSentSinceFunc != nil {
+		return m.CountSentSinceFunc(ctx, userID, since)
+	}
+	return 0, nil
+}
+
+func (m *mockRivalAlertRepository) FindStandingsByUserID(ctx context.Context, userID uint64) ([]models.RivalStanding, error) {
+	if m.FindStandingsByUserIDFunc != nil {
+		return m.FindStandingsByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockRivalAlertRepository) UpsertStanding(ctx context.Context, standing *models.RivalStanding) error {
+	if m.UpsertStandingFunc != nil {
+		return m.UpsertStandingFunc(ctx, standing)
+	}
+	return nil
+}
+
+// rivalAlertTestDeps ユーザー1人（Slackのみ有効）とライバル1人の依存関係を組み立てる
+func rivalAlertTestDeps(userStats, rivalStats []models.CommitStats, alertRepo *mockRivalAlertRepository, slackGateway *mockSlackGateway) *testDeps {
+	return &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{
+					{
+						UserID:     1,
+						WebhookURL: "https://hooks.slack.com/test",
+						IsEnabled:  true,
+						User:       models.User{ID: 1, GithubUserID: 12345, GithubUsername: "testuser"},
+					},
+				}, nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{
+			FindByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.Rival, error) {
+				return []models.Rival{{RivalGithubUserID: 67890, RivalGithubUsername: "rival1"}}, nil
+			},
+		},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				if githubUserID == 12345 {
+					return userStats, nil
+				}
+				return rivalStats, nil
+			},
+		},
+		rivalAlertRepo: alertRepo,
+		slackGateway:   slackGateway,
+	}
+}
+
+func TestRunRivalAlerts_Overtaken(t *testing.T) {
+	week := notifier.CurrentWeekRange(time.Now())
+	var created *models.RivalAlert
+	var saved *models.RivalStanding
+	var sentText string
+
+	alertRepo := &mockRivalAlertRepository{
+		FindStandingsByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.RivalStanding, error) {
+			return []models.RivalStanding{{UserID: 1, RivalGithubUserID: 67890, WeekStart: week.Start, RivalAhead: false}}, nil
+		},
+		CreateIfNotExistsFunc: func(ctx context.Context, alert *models.RivalAlert) (bool, error) {
+			alert.ID = 42
+			created = alert
+			return true, nil
+		},
+		UpsertStandingFunc: func(ctx context.Context, standing *models.RivalStanding) error {
+			saved = standing
+			return nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			sentText = message.Text
+			return nil
+		},
+	}
+	deps := rivalAlertTestDeps(
+		[]models.CommitStats{{Date: week.End, CommitCount: 3}},
+		[]models.CommitStats{{Date: week.End, CommitCount: 5}},
+		alertRepo, slackGateway,
+	)
+	var inbox []*models.InAppNotification
+	deps.inAppNotificationRepo = &mockInAppNotificationRepository{
+		CreateIfNotExistsFunc: func(ctx context.Context, notification *models.InAppNotification) (bool, error) {
+			inbox = append(inbox, notification)
+			return true, nil
+		},
+	}
+
+	report, err := RunRivalAlerts(context.Background(), deps, RivalAlertsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	if assert.Len(t, inbox, 1) {
+		assert.Equal(t, models.InAppNotificationRivalAlert, inbox[0].Type)
+		assert.Equal(t, "overtaken", inbox[0].Payload["kind"])
+		assert.Equal(t, "rival1", inbox[0].Payload["rival_github_username"])
+		assert.Equal(t, "rival_alert:42", *inbox[0].DedupeKey)
+	}
+	if assert.NotNil(t, created) {
+		assert.Equal(t, models.RivalAlertOvertaken, created.Kind)
+		assert.Equal(t, models.RivalAlertStatusSent, created.Status)
+		assert.Equal(t, week.Start.Format("2006-01-02")+":3-5", created.EventKey)
+	}
+	assert.Contains(t, sentText, "rival1")
+	if assert.NotNil(t, saved) {
+		assert.True(t, saved.RivalAhead)
+	}
+}
+
+func TestRunRivalAlerts_FirstComparisonOnlyRecordsStanding(t *testing.T) {
+	week := notifier.CurrentWeekRange(time.Now())
+	var saved *models.RivalStanding
+
+	alertRepo := &mockRivalAlertRepository{
+		CreateIfNotExistsFunc: func(ctx context.Context, alert *models.RivalAlert) (bool, error) {
+			t.Fatal("no alert should be created for a rival compared for the first time")
+			return false, nil
+		},
+		UpsertStandingFunc: func(ctx context.Context, standing *models.RivalStanding) error {
+			saved = standing
+			return nil
+		},
+	}
+	deps := rivalAlertTestDeps(
+		nil,
+		[]models.CommitStats{{Date: week.End, CommitCount: 5}},
+		alertRepo, &mockSlackGateway{},
+	)
+
+	report, err := RunRivalAlerts(context.Background(), deps, RivalAlertsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.SuccessCount)
+	if assert.NotNil(t, saved) {
+		assert.True(t, saved.RivalAhead)
+		assert.Equal(t, week.Start, saved.WeekStart)
+	}
+}
+
+func TestRunRivalAlerts_WeekRolloverOnlyRecordsStanding(t *testing.T) {
+	week := notifier.CurrentWeekRange(time.Now())
+	var saved *models.RivalStanding
+
+	alertRepo := &mockRivalAlertRepository{
+		// 先週はユーザーがリードしていた
+		FindStandingsByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.RivalStanding, error) {
+			return []models.RivalStanding{{UserID: 1, RivalGithubUserID: 67890, WeekStart: week.Start.AddDate(0, 0, -7), RivalAhead: false}}, nil
+		},
+		CreateIfNotExistsFunc: func(ctx context.Context, alert *models.RivalAlert) (bool, error) {
+			t.Fatal("the first lead of a new week must not be reported as overtaken")
+			return false, nil
+		},
+		UpsertStandingFunc: func(ctx context.Context, standing *models.RivalStanding) error {
+			saved = standing
+			return nil
+		},
+	}
+	// 週が変わってライバルだけが先にコミットした
+	deps := rivalAlertTestDeps(
+		nil,
+		[]models.CommitStats{{Date: week.End, CommitCount: 1}},
+		alertRepo, &mockSlackGateway{},
+	)
+
+	report, err := RunRivalAlerts(context.Background(), deps, RivalAlertsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.SuccessCount)
+	if assert.NotNil(t, saved) {
+		assert.True(t, saved.RivalAhead)
+		assert.Equal(t, week.Start, saved.WeekStart)
+	}
+}
+
+func TestRunRivalAlerts_SkipsDuplicateEvent(t *testing.T) {
+	week := notifier.CurrentWeekRange(time.Now())
+
+	alertRepo := &mockRivalAlertRepository{
+		FindStandingsByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.RivalStanding, error) {
+			return []models.RivalStanding{{UserID: 1, RivalGithubUserID: 67890, WeekStart: week.Start, RivalAhead: true}}, nil
+		},
+		CreateIfNotExistsFunc: func(ctx context.Context, alert *models.RivalAlert) (bool, error) {
+			return false, nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			t.Fatal("duplicate alert should not be sent")
+			return nil
+		},
+	}
+	deps := rivalAlertTestDeps(
+		[]models.CommitStats{{Date: week.End, CommitCount: 8}},
+		[]models.CommitStats{{Date: week.End, CommitCount: 5}},
+		alertRepo, slackGateway,
+	)
+
+	report, err := RunRivalAlerts(context.Background(), deps, RivalAlertsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SkipCount)
+}
+
+func TestRunRivalAlerts_RateLimited(t *testing.T) {
+	week := notifier.CurrentWeekRange(time.Now())
+	var created *models.RivalAlert
+
+	alertRepo := &mockRivalAlertRepository{
+		FindStandingsByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.RivalStanding, error) {
+			return []models.RivalStanding{{UserID: 1, RivalGithubUserID: 67890, WeekStart: week.Start, RivalAhead: true}}, nil
+		},
+		CountSentSinceFunc: func(ctx context.Context, userID uint64, since time.Time) (int64, error) {
+			return 2, nil
+		},
+		CreateIfNotExistsFunc: func(ctx context.Context, alert *models.RivalAlert) (bool, error) {
+			created = alert
+			return true, nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			t.Fatal("rate-limited alert should not be sent")
+			return nil
+		},
+	}
+	deps := rivalAlertTestDeps(
+		[]models.CommitStats{{Date: week.End, CommitCount: 8}},
+		[]models.CommitStats{{Date: week.End, CommitCount: 5}},
+		alertRepo, slackGateway,
+	)
+
+	deps.inAppNotificationRepo = &mockInAppNotificationRepository{
+		CreateIfNotExistsFunc: func(ctx context.Context, notification *models.InAppNotification) (bool, error) {
+			t.Fatal("suppressed alert should not be published to the inbox")
+			return false, nil
+		},
+	}
+
+	report, err := RunRivalAlerts(context.Background(), deps, RivalAlertsConfig{Limit: 2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SkipCount)
+	if assert.NotNil(t, created) {
+		// 上限を超えた分も記録し、次の実行で同じイベントを送らないようにする
+		assert.Equal(t, models.RivalAlertReclaimed, created.Kind)
+		assert.Equal(t, models.RivalAlertStatusSuppressed, created.Status)
+	}
+}
+
+func TestRunRivalAlerts_StreakBroken(t *testing.T) {
+	week := notifier.CurrentWeekRange(time.Now())
+	yesterday := week.End.AddDate(0, 0, -1)
+	var created []models.RivalAlert
+
+	alertRepo := &mockRivalAlertRepository{
+		CreateIfNotExistsFunc: func(ctx context.Context, alert *models.RivalAlert) (bool, error) {
+			created = append(created, *alert)
+			return true, nil
+		},
+	}
+	deps := rivalAlertTestDeps(
+		nil,
+		[]models.CommitStats{
+			{Date: yesterday.AddDate(0, 0, -1), CommitCount: 2},
+			{Date: yesterday.AddDate(0, 0, -2), CommitCount: 1},
+			{Date: yesterday.AddDate(0, 0, -3), CommitCount: 4},
+		},
+		alertRepo, &mockSlackGateway{},
+	)
+
+	report, err := RunRivalAlerts(context.Background(), deps, RivalAlertsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	if assert.Len(t, created, 1) {
+		assert.Equal(t, models.RivalAlertStreakBroken, created[0].Kind)
+		assert.Equal(t, 3, created[0].Streak)
+		assert.Equal(t, yesterday.Format("2006-01-02"), created[0].EventKey)
+	}
+}
+
+func TestRunRivalAlerts_MarksFailedWhenNoChannelDelivered(t *testing.T) {
+	week := notifier.CurrentWeekRange(time.Now())
+	var failedStatus models.RivalAlertStatus
+
+	alertRepo := &mockRivalAlertRepository{
+		FindStandingsByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.RivalStanding, error) {
+			return []models.RivalStanding{{UserID: 1, RivalGithubUserID: 67890, WeekStart: week.Start, RivalAhead: false}}, nil
+		},
+		UpdateStatusFunc: func(ctx context.Context, id uint64, status models.RivalAlertStatus) error {
+			failedStatus = status
+			return nil
+		},
+	}
+	slackGateway := &mockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			return errors.New("webhook error")
+		},
+	}
+	deps := rivalAlertTestDeps(
+		nil,
+		[]models.CommitStats{{Date: week.End, CommitCount: 1}},
+		alertRepo, slackGateway,
+	)
+
+	report, err := RunRivalAlerts(context.Background(), deps, RivalAlertsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.FailureCount)
+	assert.Equal(t, models.RivalAlertStatusFailed, failedStatus)
+}
+
+func TestRunRivalAlerts_QuietHoursDefersDetection(t *testing.T) {
+	now := time.Now().In(time.UTC)
+	alertRepo := &mockRivalAlertRepository{
+		UpsertStandingFunc: func(ctx context.Context, standing *models.RivalStanding) error {
+			t.Fatal("standings should be kept so the change is detected after quiet hours")
+			return nil
+		},
+	}
+	deps := rivalAlertTestDeps(nil, []models.CommitStats{{Date: notifier.CurrentWeekRange(now).End, CommitCount: 5}}, alertRepo, &mockSlackGateway{})
+	deps.scheduleRepo = &mockNotificationScheduleRepository{
+		FindByUserIDsFunc: func(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+			return []models.NotificationSchedule{{
+				UserID: 1, DeliveryTime: "09:00", Timezone: "UTC",
+				QuietHoursStart: now.Add(-time.Hour).Format("15:04"), QuietHoursEnd: now.Add(time.Hour).Format("15:04"),
+			}}, nil
+		},
+	}
+
+	report, err := RunRivalAlerts(context.Background(), deps, RivalAlertsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SkipCount)
+}
+
+func TestRunRivalAlerts_SnoozedSkipsButRecordsStanding(t *testing.T) {
+	week := notifier.CurrentWeekRange(time.Now())
+	snoozeUntil := time.Now().AddDate(0, 0, 1)
+	var saved *models.RivalStanding
+
+	alertRepo := &mockRivalAlertRepository{
+		FindStandingsByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.RivalStanding, error) {
+			return []models.RivalStanding{{UserID: 1, RivalGithubUserID: 67890, WeekStart: week.Start, RivalAhead: false}}, nil
+		},
+		CreateIfNotExistsFunc: func(ctx context.Context, alert *models.RivalAlert) (bool, error) {
+			t.Fatal("no alert should be sent while snoozed")
+			return false, nil
+		},
+		UpsertStandingFunc: func(ctx context.Context, standing *models.RivalStanding) error {
+			saved = standing
+			return nil
+		},
+	}
+	deps := rivalAlertTestDeps(
+		[]models.CommitStats{{Date: week.End, CommitCount: 3}},
+		[]models.CommitStats{{Date: week.End, CommitCount: 5}},
+		alertRepo, &mockSlackGateway{},
+	)
+	deps.scheduleRepo = &mockNotificationScheduleRepository{
+		FindByUserIDsFunc: func(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+			return []models.NotificationSchedule{{UserID: 1, DeliveryTime: "09:00", Timezone: "UTC", SnoozeUntil: &snoozeUntil}}, nil
+		},
+	}
+
+	report, err := RunRivalAlerts(context.Background(), deps, RivalAlertsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SkipCount)
+	if assert.NotNil(t, saved) {
+		assert.True(t, saved.RivalAhead)
+	}
+}
@@ -0,0 +1,137 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// バッチの終了コード
+const (
+	ExitCodeSuccess        = 0 // 全対象が成功（またはスキップ）
+	ExitCodeSetupError     = 1 // 設定・初期化の失敗
+	ExitCodePartialFailure = 2 // 一部の対象が失敗
+	ExitCodeTotalFailure   = 3 // 全対象が失敗
+)
+
+// maxErrorSamples 保存するエラーサンプルの上限
+const maxErrorSamples = 10
+
+// RunReport バッチ実行の集計結果
+type RunReport struct {
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	SuccessCount int
+	FailureCount int
+	SkipCount    int
+	ErrorSamples []string
+}
+
+// NewRunReport コンストラクタ
+func NewRunReport() *RunReport {
+	return &RunReport{
+		StartedAt: time.Now(),
+	}
+}
+
+// AddSuccess 成功した対象を記録
+func (r *RunReport) AddSuccess() {
+	r.SuccessCount++
+}
+
+// AddFailure 失敗した対象を記録
+func (r *RunReport) AddFailure(target string, err error) {
+	r.FailureCount++
+	if len(r.ErrorSamples) < maxErrorSamples {
+		r.ErrorSamples = append(r.ErrorSamples, fmt.Sprintf("%s: %v", target, err))
+	}
+}
+
+// AddSkip スキップした対象を記録
+func (r *RunReport) AddSkip() {
+	r.SkipCount++
+}
+
+// Finish 終了日時を記録
+func (r *RunReport) Finish() {
+	r.FinishedAt = time.Now()
+}
+
+// Status 集計結果からステータスを判定
+func (r *RunReport) Status() models.BatchRunStatus {
+	switch {
+	case r.FailureCount == 0:
+		return models.BatchRunStatusSuccess
+	case r.SuccessCount == 0:
+		return models.BatchRunStatusFailed
+	default:
+		return models.BatchRunStatusPartialFailure
+	}
+}
+
+// ExitCode 集計結果から終了コードを判定
+func (r *RunReport) ExitCode() int {
+	switch r.Status() {
+	case models.BatchRunStatusFailed:
+		return ExitCodeTotalFailure
+	case models.BatchRunStatusPartialFailure:
+		return ExitCodePartialFailure
+	default:
+		return ExitCodeSuccess
+	}
+}
+
+// RecordRun バッチを実行し、その結果を batch_runs テーブルに記録する
+// 記録自体の失敗はバッチの結果に影響させず、ログ出力のみ行う
+func RecordRun(
+	ctx context.Context,
+	batchRunRepo repository.IBatchRunRepository,
+	command string,
+	args map[string]string,
+	run func(ctx context.Context) (*RunReport, error),
+) (*RunReport, error) {
+	payload := models.JSONPayload{}
+	for k, v := range args {
+		payload[k] = v
+	}
+
+	batchRun := &models.BatchRun{
+		Command:   command,
+		Args:      payload,
+		Status:    models.BatchRunStatusRunning,
+		StartedAt: time.Now(),
+	}
+	if err := batchRunRepo.Create(ctx, batchRun); err != nil {
+		log.Printf("Failed to record batch run start: %v", err)
+	}
+
+	report, runErr := run(ctx)
+
+	finishedAt := time.Now()
+	batchRun.FinishedAt = &finishedAt
+
+	if runErr != nil {
+		batchRun.Status = models.BatchRunStatusFailed
+		batchRun.ErrorMessage = runErr.Error()
+	}
+	if report != nil {
+		batchRun.SuccessCount = report.SuccessCount
+		batchRun.FailureCount = report.FailureCount
+		batchRun.SkipCount = report.SkipCount
+		batchRun.ErrorSamples = report.ErrorSamples
+		if runErr == nil {
+			batchRun.Status = report.Status()
+		}
+	}
+
+	// 呼び出し元のコンテキストがキャンセルされていても終了は記録する
+	if err := batchRunRepo.Update(context.WithoutCancel(ctx), batchRun); err != nil {
+		log.Printf("Failed to record batch run result: %v", err)
+	}
+
+	return report, runErr
+}
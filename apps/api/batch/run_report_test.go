@@ -0,0 +1,173 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// mockBatchRunRepository テスト用のモック
+type mockBatchRunRepository struct {
+	CreateFunc     func(ctx context.Context, run *models.BatchRun) error
+	UpdateFunc     func(ctx context.Context, run *models.BatchRun) error
+	FindRecentFunc func(ctx context.Context, limit int) ([]models.BatchRun, error)
+	FindLatestFunc func(ctx context.Context, command string, args map[string]string) (*models.BatchRun, error)
+}
+
+func (m *mockBatchRunRepository) Create(ctx context.Context, run *models.BatchRun) error {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, run)
+	}
+	return nil
+}
+
+func (m *mockBatchRunRepository) Update(ctx context.Context, run *models.BatchRun) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, run)
+	}
+	return nil
+}
+
+func (m *mockBatchRunRepository) FindRecent(ctx context.Context, limit int) ([]models.BatchRun, error) {
+	if m.FindRecentFunc != nil {
+		return m.FindRecentFunc(ctx, limit)
+	}
+	return nil, nil
+}
+
+func (m *mockBatchRunRepository) FindLatest(ctx context.Context, command string, args map[string]string) (*models.BatchRun, error) {
+	if m.FindLatestFunc != nil {
+		return m.FindLatestFunc(ctx, command, args)
+	}
+	return nil, nil
+}
+
+func TestRunReport_StatusAndExitCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		success      int
+		failure      int
+		skip         int
+		wantStatus   models.BatchRunStatus
+		wantExitCode int
+	}{
+		{name: "全成功", success: 3, wantStatus: models.BatchRunStatusSuccess, wantExitCode: ExitCodeSuccess},
+		{name: "対象なし", wantStatus: models.BatchRunStatusSuccess, wantExitCode: ExitCodeSuccess},
+		{name: "スキップのみ", skip: 2, wantStatus: models.BatchRunStatusSuccess, wantExitCode: ExitCodeSuccess},
+		{name: "一部失敗", success: 2, failure: 1, wantStatus: models.BatchRunStatusPartialFailure, wantExitCode: ExitCodePartialFailure},
+		{name: "全失敗", failure: 2, skip: 1, wantStatus: models.BatchRunStatusFailed, wantExitCode: ExitCodeTotalFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewRunReport()
+			for i := 0; i < tt.success; i++ {
+				report.AddSuccess()
+			}
+			for i := 0; i < tt.failure; i++ {
+				report.AddFailure("target", errors.New("error"))
+			}
+			for i := 0; i < tt.skip; i++ {
+				report.AddSkip()
+			}
+
+			assert.Equal(t, tt.wantStatus, report.Status())
+			assert.Equal(t, tt.wantExitCode, report.ExitCode())
+		})
+	}
+}
+
+func TestRunReport_ErrorSamplesAreCapped(t *testing.T) {
+	report := NewRunReport()
+	for i := 0; i < maxErrorSamples+5; i++ {
+		report.AddFailure(fmt.Sprintf("user%d", i), errors.New("failed"))
+	}
+
+	assert.Equal(t, maxErrorSamples+5, report.FailureCount)
+	assert.Len(t, report.ErrorSamples, maxErrorSamples)
+	assert.Equal(t, "user0: failed", report.ErrorSamples[0])
+}
+
+func TestRecordRun_RecordsReport(t *testing.T) {
+	ctx := context.Background()
+	var created, updated *models.BatchRun
+
+	repo := &mockBatchRunRepository{
+		CreateFunc: func(ctx context.Context, run *models.BatchRun) error {
+			copied := *run
+			created = &copied
+			return nil
+		},
+		UpdateFunc: func(ctx context.Context, run *models.BatchRun) error {
+			updated = run
+			return nil
+		},
+	}
+
+	report, err := RecordRun(ctx, repo, "send-notifications", map[string]string{"period": "weekly"}, func(ctx context.Context) (*RunReport, error) {
+		report := NewRunReport()
+		report.AddSuccess()
+		report.AddFailure("user 2", errors.New("timeout"))
+		report.Finish()
+		return report, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ExitCodePartialFailure, report.ExitCode())
+
+	assert.Equal(t, "send-notifications", created.Command)
+	assert.Equal(t, models.BatchRunStatusRunning, created.Status)
+	assert.Equal(t, "weekly", created.Args["period"])
+
+	assert.Equal(t, models.BatchRunStatusPartialFailure, updated.Status)
+	assert.NotNil(t, updated.FinishedAt)
+	assert.Equal(t, 1, updated.SuccessCount)
+	assert.Equal(t, 1, updated.FailureCount)
+	assert.Equal(t, models.StringList{"user 2: timeout"}, updated.ErrorSamples)
+}
+
+func TestRecordRun_RecordsRunError(t *testing.T) {
+	ctx := context.Background()
+	var updated *models.BatchRun
+
+	repo := &mockBatchRunRepository{
+		UpdateFunc: func(ctx context.Context, run *models.BatchRun) error {
+			updated = run
+			return nil
+		},
+	}
+
+	_, err := RecordRun(ctx, repo, "send-notifications", nil, func(ctx context.Context) (*RunReport, error) {
+		return nil, errors.New("invalid period: daily")
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, models.BatchRunStatusFailed, updated.Status)
+	assert.Equal(t, "invalid period: daily", updated.ErrorMessage)
+}
+
+func TestRecordRun_RepositoryErrorDoesNotFailRun(t *testing.T) {
+	ctx := context.Background()
+
+	repo := &mockBatchRunRepository{
+		CreateFunc: func(ctx context.Context, run *models.BatchRun) error {
+			return errors.New("database error")
+		},
+		UpdateFunc: func(ctx context.Context, run *models.BatchRun) error {
+			return errors.New("database error")
+		},
+	}
+
+	report, err := RecordRun(ctx, repo, "sync-commits", nil, func(ctx context.Context) (*RunReport, error) {
+		report := NewRunReport()
+		report.AddSuccess()
+		return report, nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, ExitCodeSuccess, report.ExitCode())
+}
@@ -0,0 +1,245 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// 実行のきっかけ（batch_runs の args.trigger に記録する）
+const (
+	TriggerSchedule = "schedule"  // スケジュール通りの実行
+	TriggerCatchUp  = "catch-up"  // 停止中に逃した実行の補完
+	TriggerFollowUp = "follow-up" // 前のジョブに続けての実行
+)
+
+// errAlreadyRunByOther 予定時刻の回は他のインスタンスが実行済み
+var errAlreadyRunByOther = errors.New("this run was already handled by another instance")
+
+// defaultShutdownTimeout 停止要求後、実行中のジョブの完了を待つ時間
+const defaultShutdownTimeout = 5 * time.Minute
+
+// ScheduledJob スケジューラが実行するジョブ
+type ScheduledJob struct {
+	Name     string            // ログ出力用の名前
+	Command  string            // batch_runs に記録するコマンド名
+	Args     map[string]string // batch_runs に記録する引数（キャッチアップ判定にも使う）
+	LockName string            // 多重実行を防ぐロック名
+	Schedule *CronSchedule
+	Run      func(ctx context.Context) (*RunReport, error)
+	FollowUp *ScheduledJob // 成功した後に続けて実行するジョブ（Schedule は使わない）
+}
+
+// Scheduler cron式に従ってバッチを実行する常駐プロセス
+type Scheduler struct {
+	jobs            []ScheduledJob
+	batchRunRepo    repository.IBatchRunRepository
+	batchLockRepo   repository.IBatchLockRepository
+	ShutdownTimeout time.Duration
+	now             func() time.Time
+
+	mu      sync.Mutex
+	running map[string]bool
+	wg      sync.WaitGroup
+}
+
+// NewScheduler コンストラクタ
+func NewScheduler(batchRunRepo repository.IBatchRunRepository, batchLockRepo repository.IBatchLockRepository, jobs []ScheduledJob) *Scheduler {
+	return &Scheduler{
+		jobs:            jobs,
+		batchRunRepo:    batchRunRepo,
+		batchLockRepo:   batchLockRepo,
+		ShutdownTimeout: defaultShutdownTimeout,
+		now:             time.Now,
+		running:         map[string]bool{},
+	}
+}
+
+// Run スケジューラを起動し、ctx がキャンセルされるまでジョブを実行し続ける
+// キャンセル後は新しいジョブを開始せず、実行中のジョブの完了を ShutdownTimeout まで待つ
+func (s *Scheduler) Run(ctx context.Context) {
+	// 停止要求とジョブのキャンセルを分け、実行中のジョブは猶予期間内に最後まで走らせる
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+
+	for _, job := range s.jobs {
+		log.Printf("Scheduled %s: %s (next: %s)", job.Name, job.Schedule, job.Schedule.Next(s.now()).Format(time.RFC3339))
+	}
+
+	s.catchUp(ctx, jobCtx)
+
+	for {
+		job, next := s.nextJob()
+		if job == nil {
+			log.Println("No scheduled jobs")
+			break
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			s.shutdown(cancelJobs)
+			return
+		case <-timer.C:
+		}
+
+		// 同じ時刻に実行予定のジョブはまとめて起動する
+		for i := range s.jobs {
+			if !s.jobs[i].Schedule.Next(next.Add(-time.Minute)).After(next) {
+				s.start(jobCtx, s.jobs[i], TriggerSchedule, next)
+			}
+		}
+	}
+
+	<-ctx.Done()
+	s.shutdown(cancelJobs)
+}
+
+// catchUp 前回実行以降に実行予定時刻を過ぎたジョブを1回だけ実行する
+// 実行履歴がないジョブは初回起動とみなし、キャッチアップしない
+func (s *Scheduler) catchUp(ctx context.Context, jobCtx context.Context) {
+	now := s.now()
+	for _, job := range s.jobs {
+		last, err := s.batchRunRepo.FindLatest(ctx, job.Command, job.Args)
+		if err != nil {
+			log.Printf("Failed to load last run of %s, skipping catch-up: %v", job.Name, err)
+			continue
+		}
+		if last == nil {
+			continue
+		}
+
+		missed := job.Schedule.Next(last.StartedAt)
+		if missed.IsZero() || missed.After(now) {
+			continue
+		}
+		log.Printf("Catching up %s: missed run at %s (last run: %s)", job.Name, missed.Format(time.RFC3339), last.StartedAt.Format(time.RFC3339))
+		s.start(jobCtx, job, TriggerCatchUp, missed)
+	}
+}
+
+// nextJob 次に実行予定のジョブと実行日時を返す
+func (s *Scheduler) nextJob() (*ScheduledJob, time.Time) {
+	now := s.now()
+	var nextJob *ScheduledJob
+	var nextAt time.Time
+	for i := range s.jobs {
+		at := s.jobs[i].Schedule.Next(now)
+		if at.IsZero() {
+			continue
+		}
+		if nextJob == nil || at.Before(nextAt) {
+			nextJob = &s.jobs[i]
+			nextAt = at
+		}
+	}
+	return nextJob, nextAt
+}
+
+// start ジョブを非同期で実行する（同じジョブが実行中の場合はスキップ）
+// scheduledAt は実行予定時刻で、他のインスタンスが既にその回を実行済みならスキップする
+func (s *Scheduler) start(ctx context.Context, job ScheduledJob, trigger string, scheduledAt time.Time) {
+	s.mu.Lock()
+	if s.running[job.Name] {
+		s.mu.Unlock()
+		log.Printf("Skipping %s: previous run is still in progress", job.Name)
+		return
+	}
+	s.running[job.Name] = true
+	s.mu.Unlock()
+
+	args := map[string]string{"trigger": trigger}
+	for k, v := range job.Args {
+		args[k] = v
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, job.Name)
+			s.mu.Unlock()
+		}()
+
+		log.Printf("Starting %s (trigger: %s)", job.Name, trigger)
+		report, err := WithLock(ctx, s.batchLockRepo, job.LockName, false, func(ctx context.Context) (*RunReport, error) {
+			if s.alreadyRun(ctx, job, scheduledAt) {
+				return nil, errAlreadyRunByOther
+			}
+			return RecordRun(ctx, s.batchRunRepo, job.Command, args, job.Run)
+		})
+		if errors.Is(err, ErrAlreadyRunning) || errors.Is(err, errAlreadyRunByOther) {
+			log.Printf("Skipping %s: %v", job.Name, err)
+			return
+		}
+		if err != nil {
+			log.Printf("%s failed: %v", job.Name, err)
+			return
+		}
+		log.Printf("%s finished with status %s (success: %d, failure: %d, skip: %d)",
+			job.Name, report.Status(), report.SuccessCount, report.FailureCount, report.SkipCount)
+
+		if job.FollowUp != nil {
+			s.runFollowUp(ctx, *job.FollowUp)
+		}
+	}()
+}
+
+// runFollowUp 続けて実行するジョブを、単体で実行した場合と同じくロックを取って実行履歴に残す
+func (s *Scheduler) runFollowUp(ctx context.Context, job ScheduledJob) {
+	args := map[string]string{"trigger": TriggerFollowUp}
+	for k, v := range job.Args {
+		args[k] = v
+	}
+
+	log.Printf("Starting %s (trigger: %s)", job.Name, TriggerFollowUp)
+	report, err := WithLock(ctx, s.batchLockRepo, job.LockName, false, func(ctx context.Context) (*RunReport, error) {
+		return RecordRun(ctx, s.batchRunRepo, job.Command, args, job.Run)
+	})
+	if errors.Is(err, ErrAlreadyRunning) {
+		log.Printf("Skipping %s: %v", job.Name, err)
+		return
+	}
+	if err != nil {
+		log.Printf("%s failed: %v", job.Name, err)
+		return
+	}
+	log.Printf("%s finished with status %s (success: %d, failure: %d, skip: %d)",
+		job.Name, report.Status(), report.SuccessCount, report.FailureCount, report.SkipCount)
+}
+
+// alreadyRun 予定時刻以降に他のインスタンスが同じジョブを実行済みかどうか
+func (s *Scheduler) alreadyRun(ctx context.Context, job ScheduledJob, scheduledAt time.Time) bool {
+	last, err := s.batchRunRepo.FindLatest(ctx, job.Command, job.Args)
+	if err != nil {
+		log.Printf("Failed to load last run of %s: %v", job.Name, err)
+		return false
+	}
+	return last != nil && !last.StartedAt.Before(scheduledAt)
+}
+
+// shutdown 実行中のジョブの完了を待ち、猶予期間を過ぎたらキャンセルする
+func (s *Scheduler) shutdown(cancelJobs context.CancelFunc) {
+	log.Println("Shutting down scheduler...")
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(s.ShutdownTimeout):
+		log.Println("Shutdown timeout exceeded, cancelling running jobs")
+		cancelJobs()
+		<-done
+	}
+	log.Println("Scheduler stopped")
+}
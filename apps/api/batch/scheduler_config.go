@@ -0,0 +1,153 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keeee21/commitly/api/usecase"
+)
+
+// SchedulerConfig スケジューラの設定（未設定のスケジュールは無効）
+type SchedulerConfig struct {
+	Location             *time.Location
+	SyncCommits          *CronSchedule
+	WeeklyNotifications  *CronSchedule
+	MonthlyNotifications *CronSchedule
+	DueNotifications     *CronSchedule // 各ユーザーの配信スケジュールに従って週次・月次レポートを送る
+	RetryNotifications   *CronSchedule
+	DailyNudges          *CronSchedule // 各ユーザーが選んだ時刻を過ぎたら、今日まだコミットしていないユーザーにリマインダーを送る
+	ShutdownTimeout      time.Duration
+}
+
+// LoadSchedulerConfig 環境変数からスケジューラの設定を読み込む
+func LoadSchedulerConfig(getenv func(string) string) (*SchedulerConfig, error) {
+	location, err := time.LoadLocation(getenv("SCHEDULER_TIMEZONE"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid SCHEDULER_TIMEZONE: %w", err)
+	}
+
+	config := &SchedulerConfig{Location: location}
+
+	schedules := []struct {
+		env    string
+		target **CronSchedule
+	}{
+		{"SCHEDULE_SYNC_COMMITS", &config.SyncCommits},
+		{"SCHEDULE_WEEKLY_NOTIFICATIONS", &config.WeeklyNotifications},
+		{"SCHEDULE_MONTHLY_NOTIFICATIONS", &config.MonthlyNotifications},
+		{"SCHEDULE_DUE_NOTIFICATIONS", &config.DueNotifications},
+		{"SCHEDULE_RETRY_NOTIFICATIONS", &config.RetryNotifications},
+		{"SCHEDULE_DAILY_NUDGES", &config.DailyNudges},
+	}
+	for _, s := range schedules {
+		expr := getenv(s.env)
+		if expr == "" {
+			continue
+		}
+		schedule, err := ParseCronSchedule(expr, location)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", s.env, err)
+		}
+		*s.target = schedule
+	}
+
+	if timeout := getenv("SCHEDULER_SHUTDOWN_TIMEOUT"); timeout != "" {
+		d, err := time.ParseDuration(timeout)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SCHEDULER_SHUTDOWN_TIMEOUT: %w", err)
+		}
+		config.ShutdownTimeout = d
+	}
+
+	return config, nil
+}
+
+// BuildScheduledJobs 設定されたスケジュールからジョブを組み立てる
+// 各ジョブは cmd/batch と同じ RunSyncCommits / RunSendNotifications / RunRetryFailedNotifications / RunDailyNudges を実行する
+// コミット同期の後には続けて RunRivalAlerts を実行する（cmd/batch の send-rival-alerts と同じロック・実行履歴を使う）
+func BuildScheduledJobs(config *SchedulerConfig, syncUsecase usecase.ISyncCommitsUsecase, notificationDeps ISendNotificationsDeps) []ScheduledJob {
+	var jobs []ScheduledJob
+
+	if config.SyncCommits != nil {
+		syncConfig := SyncCommitsConfig{}
+		rivalAlertsConfig := RivalAlertsConfig{}
+		jobs = append(jobs, ScheduledJob{
+			Name:     "sync-commits",
+			Command:  "sync-commits",
+			Args:     syncConfig.Args(),
+			LockName: syncConfig.LockName(),
+			Schedule: config.SyncCommits,
+			Run: func(ctx context.Context) (*RunReport, error) {
+				return RunSyncCommits(ctx, syncUsecase, syncConfig)
+			},
+			// ライバルアラートは同期したばかりのコミット数で判定する（失敗しても同期の結果には含めない）
+			FollowUp: &ScheduledJob{
+				Name:     "send-rival-alerts",
+				Command:  "send-rival-alerts",
+				Args:     rivalAlertsConfig.Args(),
+				LockName: rivalAlertsConfig.LockName(),
+				Run: func(ctx context.Context) (*RunReport, error) {
+					return RunRivalAlerts(ctx, notificationDeps, rivalAlertsConfig)
+				},
+			},
+		})
+	}
+
+	notificationSchedules := []struct {
+		name     string
+		config   SendNotificationsConfig
+		schedule *CronSchedule
+	}{
+		{"send-notifications (weekly)", SendNotificationsConfig{Period: "weekly"}, config.WeeklyNotifications},
+		{"send-notifications (monthly)", SendNotificationsConfig{Period: "monthly"}, config.MonthlyNotifications},
+		{"send-notifications (weekly, due)", SendNotificationsConfig{Period: "weekly", DueOnly: true}, config.DueNotifications},
+		{"send-notifications (monthly, due)", SendNotificationsConfig{Period: "monthly", DueOnly: true}, config.DueNotifications},
+	}
+	for _, n := range notificationSchedules {
+		if n.schedule == nil {
+			continue
+		}
+		notificationConfig := n.config
+		jobs = append(jobs, ScheduledJob{
+			Name:     n.name,
+			Command:  "send-notifications",
+			Args:     notificationConfig.Args(),
+			LockName: notificationConfig.LockName(),
+			Schedule: n.schedule,
+			Run: func(ctx context.Context) (*RunReport, error) {
+				return RunSendNotifications(ctx, notificationDeps, notificationConfig)
+			},
+		})
+	}
+
+	if config.RetryNotifications != nil {
+		retryConfig := RetryNotificationsConfig{}
+		jobs = append(jobs, ScheduledJob{
+			Name:     "retry-failed-notifications",
+			Command:  "retry-failed-notifications",
+			Args:     retryConfig.Args(),
+			LockName: retryConfig.LockName(),
+			Schedule: config.RetryNotifications,
+			Run: func(ctx context.Context) (*RunReport, error) {
+				return RunRetryFailedNotifications(ctx, notificationDeps, retryConfig)
+			},
+		})
+	}
+
+	if config.DailyNudges != nil {
+		nudgesConfig := DailyNudgesConfig{}
+		jobs = append(jobs, ScheduledJob{
+			Name:     "send-daily-nudges",
+			Command:  "send-daily-nudges",
+			Args:     nudgesConfig.Args(),
+			LockName: nudgesConfig.LockName(),
+			Schedule: config.DailyNudges,
+			Run: func(ctx context.Context) (*RunReport, error) {
+				return RunDailyNudges(ctx, notificationDeps, syncUsecase, nudgesConfig)
+			},
+		})
+	}
+
+	return jobs
+}
@@ -0,0 +1,361 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestJob(t *testing.T, expr string, runs *int32) ScheduledJob {
+	schedule, err := ParseCronSchedule(expr, time.UTC)
+	assert.NoError(t, err)
+	return ScheduledJob{
+		Name:     "test-job",
+		Command:  "test-job",
+		LockName: "test-job",
+		Args:     map[string]string{"period": "weekly"},
+		Schedule: schedule,
+		Run: func(ctx context.Context) (*RunReport, error) {
+			atomic.AddInt32(runs, 1)
+			return NewRunReport(), nil
+		},
+	}
+}
+
+func TestScheduler_CatchUp(t *testing.T) {
+	now := time.Date(2025, 6, 4, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		lastRun  *models.BatchRun
+		findErr  error
+		wantRuns int32
+	}{
+		{"missed run is caught up", &models.BatchRun{StartedAt: now.Add(-48 * time.Hour)}, nil, 1},
+		{"up to date", &models.BatchRun{StartedAt: now.Add(-time.Hour)}, nil, 0},
+		{"no history", nil, nil, 0},
+		{"history lookup fails", nil, errors.New("db error"), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runs int32
+			var recordedArgs models.JSONPayload
+			var lookedUp map[string]string
+
+			repo := &mockBatchRunRepository{
+				FindLatestFunc: func(ctx context.Context, command string, args map[string]string) (*models.BatchRun, error) {
+					lookedUp = args
+					return tt.lastRun, tt.findErr
+				},
+				CreateFunc: func(ctx context.Context, run *models.BatchRun) error {
+					recordedArgs = run.Args
+					return nil
+				},
+			}
+
+			// 毎日 03:00 のジョブ
+			scheduler := NewScheduler(repo, &mockBatchLockRepository{}, []ScheduledJob{newTestJob(t, "0 3 * * *", &runs)})
+			scheduler.now = func() time.Time { return now }
+
+			ctx := context.Background()
+			scheduler.catchUp(ctx, ctx)
+			scheduler.wg.Wait()
+
+			assert.Equal(t, tt.wantRuns, atomic.LoadInt32(&runs))
+			assert.Equal(t, map[string]string{"period": "weekly"}, lookedUp)
+			if tt.wantRuns > 0 {
+				assert.Equal(t, TriggerCatchUp, recordedArgs["trigger"])
+				assert.Equal(t, "weekly", recordedArgs["period"])
+			}
+		})
+	}
+}
+
+func TestScheduler_SkipsOverlappingRun(t *testing.T) {
+	release := make(chan struct{})
+	var runs int32
+
+	job := newTestJob(t, "* * * * *", &runs)
+	job.Run = func(ctx context.Context) (*RunReport, error) {
+		atomic.AddInt32(&runs, 1)
+		<-release
+		return NewRunReport(), nil
+	}
+
+	scheduler := NewScheduler(&mockBatchRunRepository{}, &mockBatchLockRepository{}, []ScheduledJob{job})
+	ctx := context.Background()
+
+	scheduler.start(ctx, job, TriggerSchedule, time.Now())
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(&runs) == 1 }, time.Second, time.Millisecond)
+
+	scheduler.start(ctx, job, TriggerSchedule, time.Now())
+	close(release)
+	scheduler.wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+}
+
+func TestScheduler_ShutdownWaitsForRunningJob(t *testing.T) {
+	finished := false
+	var jobCtxErr error
+
+	job := newTestJob(t, "* * * * *", new(int32))
+	job.Run = func(ctx context.Context) (*RunReport, error) {
+		time.Sleep(50 * time.Millisecond)
+		jobCtxErr = ctx.Err()
+		finished = true
+		return NewRunReport(), nil
+	}
+
+	scheduler := NewScheduler(&mockBatchRunRepository{}, &mockBatchLockRepository{}, []ScheduledJob{job})
+	ctx, cancel := context.WithCancel(context.Background())
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+	defer cancelJobs()
+
+	scheduler.start(jobCtx, job, TriggerSchedule, time.Now())
+	cancel()
+	<-ctx.Done()
+	scheduler.shutdown(cancelJobs)
+
+	assert.True(t, finished)
+	assert.NoError(t, jobCtxErr)
+}
+
+func TestScheduler_ShutdownTimeoutCancelsJob(t *testing.T) {
+	var jobCtxErr error
+
+	job := newTestJob(t, "* * * * *", new(int32))
+	job.Run = func(ctx context.Context) (*RunReport, error) {
+		<-ctx.Done()
+		jobCtxErr = ctx.Err()
+		return nil, ctx.Err()
+	}
+
+	scheduler := NewScheduler(&mockBatchRunRepository{}, &mockBatchLockRepository{}, []ScheduledJob{job})
+	scheduler.ShutdownTimeout = 10 * time.Millisecond
+	jobCtx, cancelJobs := context.WithCancel(context.Background())
+
+	scheduler.start(jobCtx, job, TriggerSchedule, time.Now())
+	scheduler.shutdown(cancelJobs)
+
+	assert.ErrorIs(t, jobCtxErr, context.Canceled)
+}
+
+func TestScheduler_RunStopsOnCancel(t *testing.T) {
+	var runs int32
+	scheduler := NewScheduler(&mockBatchRunRepository{}, &mockBatchLockRepository{}, []ScheduledJob{newTestJob(t, "0 3 * * *", &runs)})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		scheduler.Run(ctx)
+		close(done)
+	}()
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancel")
+	}
+	assert.Equal(t, int32(0), atomic.LoadInt32(&runs))
+}
+
+func TestLoadSchedulerConfig(t *testing.T) {
+	env := map[string]string{
+		"SCHEDULER_TIMEZONE":            "Asia/Tokyo",
+		"SCHEDULE_SYNC_COMMITS":         "0 3 * * *",
+		"SCHEDULE_WEEKLY_NOTIFICATIONS": "0 9 * * 1",
+		"SCHEDULE_RETRY_NOTIFICATIONS":  "*/15 * * * *",
+		"SCHEDULER_SHUTDOWN_TIMEOUT":    "30s",
+	}
+
+	config, err := LoadSchedulerConfig(func(key string) string { return env[key] })
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Asia/Tokyo", config.Location.String())
+	assert.Equal(t, "0 3 * * *", config.SyncCommits.String())
+	assert.Equal(t, "0 9 * * 1", config.WeeklyNotifications.String())
+	assert.Nil(t, config.MonthlyNotifications)
+	assert.Equal(t, 30*time.Second, config.ShutdownTimeout)
+
+	jobs := BuildScheduledJobs(config, &mockSyncCommitsUsecase{}, &testDeps{})
+	assert.Len(t, jobs, 3)
+	assert.Equal(t, "sync-commits", jobs[0].Command)
+	if assert.NotNil(t, jobs[0].FollowUp) {
+		assert.Equal(t, "send-rival-alerts", jobs[0].FollowUp.Command)
+		assert.Equal(t, "send-rival-alerts", jobs[0].FollowUp.LockName)
+	}
+	assert.Equal(t, "send-notifications", jobs[1].Command)
+	assert.Equal(t, "weekly", jobs[1].Args["period"])
+	assert.Equal(t, "retry-failed-notifications", jobs[2].Command)
+	assert.Equal(t, "retry-failed-notifications", jobs[2].LockName)
+}
+
+func TestLoadSchedulerConfig_DueNotifications(t *testing.T) {
+	env := map[string]string{
+		"SCHEDULER_TIMEZONE":         "Asia/Tokyo",
+		"SCHEDULE_DUE_NOTIFICATIONS": "*/15 * * * *",
+	}
+
+	config, err := LoadSchedulerConfig(func(key string) string { return env[key] })
+
+	assert.NoError(t, err)
+	assert.Equal(t, "*/15 * * * *", config.DueNotifications.String())
+
+	// 週次・月次それぞれのジョブを、各ユーザーの配信スケジュールに従うモードで実行する
+	jobs := BuildScheduledJobs(config, &mockSyncCommitsUsecase{}, &testDeps{})
+	assert.Len(t, jobs, 2)
+	for i, period := range []string{"weekly", "monthly"} {
+		assert.Equal(t, "send-notifications", jobs[i].Command)
+		assert.Equal(t, period, jobs[i].Args["period"])
+		assert.Equal(t, "true", jobs[i].Args["due_only"])
+		assert.Equal(t, "send-notifications:"+period, jobs[i].LockName)
+	}
+}
+
+func TestLoadSchedulerConfig_DailyNudges(t *testing.T) {
+	env := map[string]string{
+		"SCHEDULER_TIMEZONE":    "Asia/Tokyo",
+		"SCHEDULE_DAILY_NUDGES": "*/15 * * * *",
+	}
+
+	config, err := LoadSchedulerConfig(func(key string) string { return env[key] })
+
+	assert.NoError(t, err)
+	assert.Equal(t, "*/15 * * * *", config.DailyNudges.String())
+
+	jobs := BuildScheduledJobs(config, &mockSyncCommitsUsecase{}, &testDeps{})
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, "send-daily-nudges", jobs[0].Command)
+	assert.Equal(t, "send-daily-nudges", jobs[0].LockName)
+}
+
+func TestLoadSchedulerConfig_InvalidSchedule(t *testing.T) {
+	env := map[string]string{"SCHEDULE_SYNC_COMMITS": "every day"}
+
+	_, err := LoadSchedulerConfig(func(key string) string { return env[key] })
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SCHEDULE_SYNC_COMMITS")
+}
+
+func TestScheduler_SkipsWhenLockHeld(t *testing.T) {
+	var runs int32
+	lockRepo := &mockBatchLockRepository{
+		TryLockFunc: func(ctx context.Context, name string) (func(), bool, error) {
+			assert.Equal(t, "test-job", name)
+			return nil, false, nil
+		},
+	}
+
+	job := newTestJob(t, "* * * * *", &runs)
+	scheduler := NewScheduler(&mockBatchRunRepository{}, lockRepo, []ScheduledJob{job})
+
+	scheduler.start(context.Background(), job, TriggerSchedule, time.Now())
+	scheduler.wg.Wait()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&runs))
+}
+
+func TestScheduler_SkipsWhenAlreadyRunByOtherInstance(t *testing.T) {
+	var runs int32
+	scheduledAt := time.Date(2025, 6, 4, 3, 0, 0, 0, time.UTC)
+	repo := &mockBatchRunRepository{
+		FindLatestFunc: func(ctx context.Context, command string, args map[string]string) (*models.BatchRun, error) {
+			return &models.BatchRun{StartedAt: scheduledAt.Add(2 * time.Second)}, nil
+		},
+		CreateFunc: func(ctx context.Context, run *models.BatchRun) error {
+			t.Fatal("run must not be recorded")
+			return nil
+		},
+	}
+
+	job := newTestJob(t, "0 3 * * *", &runs)
+	scheduler := NewScheduler(repo, &mockBatchLockRepository{}, []ScheduledJob{job})
+
+	scheduler.start(context.Background(), job, TriggerSchedule, scheduledAt)
+	scheduler.wg.Wait()
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&runs))
+}
+
+func TestScheduler_RunsFollowUpWithLockAndRecord(t *testing.T) {
+	var runs, followUps int32
+	var locked []string
+	var recorded []string
+	var followUpArgs models.JSONPayload
+
+	lockRepo := &mockBatchLockRepository{
+		TryLockFunc: func(ctx context.Context, name string) (func(), bool, error) {
+			locked = append(locked, name)
+			return func() {}, true, nil
+		},
+	}
+	repo := &mockBatchRunRepository{
+		CreateFunc: func(ctx context.Context, run *models.BatchRun) error {
+			recorded = append(recorded, run.Command)
+			if run.Command == "send-rival-alerts" {
+				followUpArgs = run.Args
+			}
+			return nil
+		},
+	}
+
+	job := newTestJob(t, "* * * * *", &runs)
+	job.FollowUp = &ScheduledJob{
+		Name:     "send-rival-alerts",
+		Command:  "send-rival-alerts",
+		LockName: "send-rival-alerts",
+		Args:     map[string]string{"limit": "3"},
+		Run: func(ctx context.Context) (*RunReport, error) {
+			atomic.AddInt32(&followUps, 1)
+			return NewRunReport(), nil
+		},
+	}
+	scheduler := NewScheduler(repo, lockRepo, []ScheduledJob{job})
+
+	scheduler.start(context.Background(), job, TriggerSchedule, time.Now())
+	scheduler.wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&followUps))
+	assert.Equal(t, []string{"test-job", "send-rival-alerts"}, locked)
+	assert.Equal(t, []string{"test-job", "send-rival-alerts"}, recorded)
+	assert.Equal(t, TriggerFollowUp, followUpArgs["trigger"])
+	assert.Equal(t, "3", followUpArgs["limit"])
+}
+
+func TestScheduler_SkipsFollowUpWhenLockHeld(t *testing.T) {
+	var runs, followUps int32
+	lockRepo := &mockBatchLockRepository{
+		TryLockFunc: func(ctx context.Context, name string) (func(), bool, error) {
+			// 手動の send-rival-alerts が実行中
+			return func() {}, name != "send-rival-alerts", nil
+		},
+	}
+
+	job := newTestJob(t, "* * * * *", &runs)
+	job.FollowUp = &ScheduledJob{
+		Name:     "send-rival-alerts",
+		Command:  "send-rival-alerts",
+		LockName: "send-rival-alerts",
+		Run: func(ctx context.Context) (*RunReport, error) {
+			atomic.AddInt32(&followUps, 1)
+			return NewRunReport(), nil
+		},
+	}
+	scheduler := NewScheduler(&mockBatchRunRepository{}, lockRepo, []ScheduledJob{job})
+
+	scheduler.start(context.Background(), job, TriggerSchedule, time.Now())
+	scheduler.wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&runs))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&followUps))
+}
@@ -3,45 +3,68 @@ package batch
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"strconv"
 	"time"
 
-	"github.com/keeee21/commitly/api/gateway"
 	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
 	"github.com/keeee21/commitly/api/repository"
 )
 
 // SendNotificationsConfig 通知送信バッチの設定
 type SendNotificationsConfig struct {
-	Period string // "weekly" or "monthly"
+	Period       string    // "weekly" or "monthly"
+	DueOnly      bool      // 各ユーザーの配信スケジュールで配信予定時刻を過ぎたばかりのユーザーにだけ送る
+	DryRun       bool      // 送信・ログ保存を行わずメッセージを書き出す
+	DryRunOutput io.Writer // ドライラン時の出力先（nilの場合は標準出力）
 }
 
 // ISendNotificationsDeps 通知送信バッチの依存関係インターフェース
 type ISendNotificationsDeps interface {
-	GetSlackNotificationRepo() repository.ISlackNotificationSettingRepository
 	GetNotificationLogRepo() repository.INotificationLogRepository
+	GetUserRepo() repository.IUserRepository
+	GetNotificationScheduleRepo() repository.INotificationScheduleRepository
 	GetRivalRepo() repository.IRivalRepository
 	GetCommitStatsRepo() repository.ICommitStatsRepository
-	GetSlackGateway() gateway.ISlackGateway
+	GetRivalAlertRepo() repository.IRivalAlertRepository
+	GetCircleNotificationRepo() repository.ICircleNotificationSettingRepository
+	GetCircleDigestLogRepo() repository.ICircleDigestLogRepository
+	GetDailyNudgeRepo() repository.IDailyNudgeRepository
+	GetNotifierRegistry() *notifier.Registry
+	GetCircleDigestPoster() notifier.ICircleDigestPoster
+	GetInboxPublisher() notifier.IInboxPublisher
 }
 
 // SendNotificationsDeps 通知送信バッチの依存関係
 type SendNotificationsDeps struct {
-	SlackNotificationRepo repository.ISlackNotificationSettingRepository
-	NotificationLogRepo   repository.INotificationLogRepository
-	RivalRepo             repository.IRivalRepository
-	CommitStatsRepo       repository.ICommitStatsRepository
-	SlackGateway          gateway.ISlackGateway
-}
-
-func (d *SendNotificationsDeps) GetSlackNotificationRepo() repository.ISlackNotificationSettingRepository {
-	return d.SlackNotificationRepo
+	NotificationLogRepo    repository.INotificationLogRepository
+	UserRepo               repository.IUserRepository
+	ScheduleRepo           repository.INotificationScheduleRepository
+	RivalRepo              repository.IRivalRepository
+	CommitStatsRepo        repository.ICommitStatsRepository
+	RivalAlertRepo         repository.IRivalAlertRepository
+	CircleNotificationRepo repository.ICircleNotificationSettingRepository
+	CircleDigestLogRepo    repository.ICircleDigestLogRepository
+	DailyNudgeRepo         repository.IDailyNudgeRepository
+	NotifierRegistry       *notifier.Registry
+	CircleDigestPoster     notifier.ICircleDigestPoster
+	InboxPublisher         notifier.IInboxPublisher
 }
 
 func (d *SendNotificationsDeps) GetNotificationLogRepo() repository.INotificationLogRepository {
 	return d.NotificationLogRepo
 }
 
+func (d *SendNotificationsDeps) GetUserRepo() repository.IUserRepository {
+	return d.UserRepo
+}
+
+func (d *SendNotificationsDeps) GetNotificationScheduleRepo() repository.INotificationScheduleRepository {
+	return d.ScheduleRepo
+}
+
 func (d *SendNotificationsDeps) GetRivalRepo() repository.IRivalRepository {
 	return d.RivalRepo
 }
@@ -50,260 +73,354 @@ func (d *SendNotificationsDeps) GetCommitStatsRepo() repository.ICommitStatsRepo
 	return d.CommitStatsRepo
 }
 
-func (d *SendNotificationsDeps) GetSlackGateway() gateway.ISlackGateway {
-	return d.SlackGateway
+func (d *SendNotificationsDeps) GetRivalAlertRepo() repository.IRivalAlertRepository {
+	return d.RivalAlertRepo
+}
+
+func (d *SendNotificationsDeps) GetCircleNotificationRepo() repository.ICircleNotificationSettingRepository {
+	return d.CircleNotificationRepo
+}
+
+func (d *SendNotificationsDeps) GetCircleDigestLogRepo() repository.ICircleDigestLogRepository {
+	return d.CircleDigestLogRepo
+}
+
+func (d *SendNotificationsDeps) GetDailyNudgeRepo() repository.IDailyNudgeRepository {
+	return d.DailyNudgeRepo
 }
 
-// DateRange 日付範囲
-type DateRange struct {
-	Start time.Time
-	End   time.Time
+func (d *SendNotificationsDeps) GetNotifierRegistry() *notifier.Registry {
+	return d.NotifierRegistry
+}
+
+func (d *SendNotificationsDeps) GetCircleDigestPoster() notifier.ICircleDigestPoster {
+	return d.CircleDigestPoster
+}
+
+func (d *SendNotificationsDeps) GetInboxPublisher() notifier.IInboxPublisher {
+	return d.InboxPublisher
+}
+
+// Args batch_runs に記録する引数
+func (c SendNotificationsConfig) Args() map[string]string {
+	return map[string]string{
+		"period":   c.Period,
+		"due_only": strconv.FormatBool(c.DueOnly),
+		"dry_run":  strconv.FormatBool(c.DryRun),
+	}
+}
+
+// LockName 多重実行を防ぐロック名（週次と月次は別々に実行できる）
+func (c SendNotificationsConfig) LockName() string {
+	return "send-notifications:" + c.Period
 }
 
 // RunSendNotifications 通知送信バッチを実行
-func RunSendNotifications(ctx context.Context, deps ISendNotificationsDeps, config SendNotificationsConfig) error {
+func RunSendNotifications(ctx context.Context, deps ISendNotificationsDeps, config SendNotificationsConfig) (*RunReport, error) {
 	log.Println("Starting send-notifications batch...")
-	startTime := time.Now()
+	report := NewRunReport()
 
 	// 期間のバリデーション
 	if config.Period != "weekly" && config.Period != "monthly" {
-		return fmt.Errorf("invalid period: %s (must be 'weekly' or 'monthly')", config.Period)
+		return nil, fmt.Errorf("invalid period: %s (must be 'weekly' or 'monthly')", config.Period)
 	}
 
 	log.Printf("Period: %s", config.Period)
+	if config.DryRun {
+		log.Println("Dry-run mode: messages will be written instead of sent, and no notification logs will be saved")
+	}
 
-	// 有効なSlack通知設定を取得
-	slackSettings, err := deps.GetSlackNotificationRepo().FindAllEnabled(ctx)
+	// 全チャンネルの有効な送信先を取得
+	registry := deps.GetNotifierRegistry()
+	var destinations []notifier.Destination
+	for _, n := range registry.All() {
+		found, err := n.FindEnabledDestinations(ctx)
+		if err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, found...)
+	}
+
+	userIDs, destinationsByUser := groupDestinationsByUser(destinations)
+	log.Printf("Found %d enabled notification destinations for %d users", len(destinations), len(userIDs))
+
+	schedules, err := loadSchedules(ctx, deps.GetNotificationScheduleRepo(), userIDs)
 	if err != nil {
-		return fmt.Errorf("failed to get enabled Slack notification settings: %w", err)
+		return nil, err
 	}
 
-	log.Printf("Found %d enabled Slack notification settings", len(slackSettings))
+	// 集計期間の基準日時は、スケジュール配信ではユーザーごとの配信予定時刻、それ以外は実行日時
+	now := time.Now()
+	var slots map[uint64]time.Time
+	if config.DueOnly {
+		userIDs, slots = findDueUsers(schedules, config.Period, userIDs, now)
+		log.Printf("%d users are due for the %s report", len(userIDs), config.Period)
+	}
 
-	successCount := 0
-	failCount := 0
+	// 同じ集計期間の配信ログを参照し、送信済みの送信先には送らない
+	logs := newDeliveryLogCache(deps, config.Period)
 
-	for _, setting := range slackSettings {
-		var sendErr error
-		var payload models.JSONPayload
-		sentAt := time.Now()
+	// レポートはユーザーごとに1回だけ集計し、未送信の全チャンネルに配信する
+	for _, userID := range userIDs {
+		anchor := now
+		if slot, ok := slots[userID]; ok {
+			anchor = slot
+		}
+		periodStart := notifier.PeriodStart(config.Period, anchor)
+		logsByKey, err := logs.get(ctx, periodStart)
+		if err != nil {
+			// 送信済みかどうか分からないまま送ると二重送信になるため、このユーザーは送らない
+			for _, destination := range destinationsByUser[userID] {
+				report.AddFailure(fmt.Sprintf("user %d (%s)", userID, destination.ChannelType), fmt.Errorf("failed to get notification logs: %w", err))
+			}
+			continue
+		}
 
-		if config.Period == "weekly" {
-			payload, sendErr = sendWeeklyReport(ctx, deps, setting)
-		} else {
-			payload, sendErr = sendMonthlyReport(ctx, deps, setting)
+		var pending []notifier.Destination
+		for _, destination := range destinationsByUser[userID] {
+			if existing := logsByKey[deliveryKey{userID: userID, channelType: destination.ChannelType}]; existing != nil && isDeliveryHandled(existing) {
+				log.Printf("Skipping %s report to user %d via %s: already %s", config.Period, userID, destination.ChannelType, existing.Status)
+				report.AddSkip()
+				continue
+			}
+			pending = append(pending, destination)
+		}
+		if len(pending) == 0 {
+			continue
 		}
 
-		// ログを保存
-		notificationLog := &models.NotificationLog{
-			UserID:      setting.UserID,
-			ChannelType: models.ChannelTypeSlack,
-			Period:      config.Period,
-			Payload:     payload,
-			SentAt:      sentAt,
+		// スヌーズ中は送らず、通知を止める時間帯は終わってから再送バッチで送る
+		if status, nextRetryAt, held := heldDelivery(schedules[userID], anchor, now); held {
+			for _, destination := range pending {
+				existing := logsByKey[deliveryKey{userID: userID, channelType: destination.ChannelType}]
+				recordHeldDelivery(ctx, deps, config, report, existing, userID, destination.ChannelType, periodStart, status, nextRetryAt, now)
+			}
+			continue
 		}
 
-		if sendErr != nil {
-			notificationLog.Status = models.NotificationStatusFailed
-			notificationLog.ErrorMessage = sendErr.Error()
-			log.Printf("Failed to send notification for user %d: %v", setting.UserID, sendErr)
-			failCount++
-		} else {
-			notificationLog.Status = models.NotificationStatusSuccess
-			successCount++
+		data, loadErr := loadReportData(ctx, deps, config.Period, userID, pending[0].User, anchor)
+
+		var delivered []models.ChannelType
+		for _, destination := range pending {
+			n, _ := registry.Get(destination.ChannelType)
+			sentAt := time.Now()
+			var payload models.JSONPayload
+			sendErr := loadErr
+			if sendErr == nil {
+				payload, sendErr = deliverReport(ctx, n, config, data, destination)
+			}
+			existing := logsByKey[deliveryKey{userID: userID, channelType: destination.ChannelType}]
+			recordDelivery(ctx, deps, n, config, report, existing, userID, periodStart, payload, sendErr, sentAt)
+			if sendErr == nil {
+				delivered = append(delivered, destination.ChannelType)
+			}
 		}
 
-		// ログをDBに保存
-		if err := deps.GetNotificationLogRepo().Create(ctx, notificationLog); err != nil {
-			log.Printf("Failed to save notification log for user %d: %v", setting.UserID, err)
+		// 受信箱にはチャンネルをまとめて1件だけ載せる
+		if len(delivered) > 0 && !config.DryRun {
+			publishToInbox(ctx, deps.GetInboxPublisher(), notifier.ReportDeliveredInboxItem(userID, config.Period, periodStart, delivered))
 		}
 	}
 
-	elapsed := time.Since(startTime)
-	log.Printf("send-notifications batch completed in %s (success: %d, failed: %d)", elapsed, successCount, failCount)
-
-	return nil
-}
-
-// calculateWeeklyRange 週次レポートの日付範囲を計算（過去7日間）
-func calculateWeeklyRange() DateRange {
-	now := time.Now()
-	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
-	return DateRange{
-		Start: today.AddDate(0, 0, -7),
-		End:   today.AddDate(0, 0, -1),
+	// サークルの週次ダイジェストも週次レポートと同じ実行で配信する
+	if config.Period == "weekly" {
+		if err := sendCircleDigests(ctx, deps, config, report, now); err != nil {
+			return nil, err
+		}
 	}
-}
 
-// calculateMonthlyRange 月次レポートの日付範囲を計算（先月）
-func calculateMonthlyRange() (current DateRange, previous DateRange) {
-	now := time.Now()
+	report.Finish()
+	elapsed := report.FinishedAt.Sub(report.StartedAt)
+	log.Printf("send-notifications batch completed in %s (success: %d, failed: %d, skipped: %d)", elapsed, report.SuccessCount, report.FailureCount, report.SkipCount)
 
-	// 先月の範囲
-	firstOfThisMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local)
-	lastOfLastMonth := firstOfThisMonth.AddDate(0, 0, -1)
-	firstOfLastMonth := time.Date(lastOfLastMonth.Year(), lastOfLastMonth.Month(), 1, 0, 0, 0, 0, time.Local)
+	return report, nil
+}
 
-	current = DateRange{
-		Start: firstOfLastMonth,
-		End:   lastOfLastMonth,
-	}
+// deliveryKey 配信の冪等キー（集計期間と開始日は deliveryLogCache 側で区別する）
+type deliveryKey struct {
+	userID      uint64
+	channelType models.ChannelType
+}
 
-	// 先々月の範囲
-	lastOfTwoMonthsAgo := firstOfLastMonth.AddDate(0, 0, -1)
-	firstOfTwoMonthsAgo := time.Date(lastOfTwoMonthsAgo.Year(), lastOfTwoMonthsAgo.Month(), 1, 0, 0, 0, 0, time.Local)
+// deliveryLogCache 集計期間の開始日ごとに通知ログを1回だけ取得して使い回す
+type deliveryLogCache struct {
+	repo        repository.INotificationLogRepository
+	period      string
+	byStartDate map[string]map[deliveryKey]*models.NotificationLog
+}
 
-	previous = DateRange{
-		Start: firstOfTwoMonthsAgo,
-		End:   lastOfTwoMonthsAgo,
+func newDeliveryLogCache(deps ISendNotificationsDeps, period string) *deliveryLogCache {
+	return &deliveryLogCache{
+		repo:        deps.GetNotificationLogRepo(),
+		period:      period,
+		byStartDate: make(map[string]map[deliveryKey]*models.NotificationLog),
 	}
-
-	return current, previous
 }
 
-// sendWeeklyReport 週次レポートを送信
-func sendWeeklyReport(
-	ctx context.Context,
-	deps ISendNotificationsDeps,
-	setting models.SlackNotificationSetting,
-) (models.JSONPayload, error) {
-	user := setting.User
-	dateRange := calculateWeeklyRange()
-
-	// ユーザーのコミット統計を取得
-	userStats, err := deps.GetCommitStatsRepo().FindByGithubUserIDAndDateRange(ctx, user.GithubUserID, dateRange.Start, dateRange.End)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get user commit stats: %w", err)
+func (c *deliveryLogCache) get(ctx context.Context, periodStart time.Time) (map[deliveryKey]*models.NotificationLog, error) {
+	date := periodStart.Format("2006-01-02")
+	if logsByKey, ok := c.byStartDate[date]; ok {
+		return logsByKey, nil
 	}
-	userCommits := sumCommits(userStats)
 
-	// ライバルのコミット統計を取得
-	rivalSummaries, err := getRivalSummaries(ctx, deps, setting.UserID, dateRange)
+	found, err := c.repo.FindByPeriodStart(ctx, c.period, periodStart)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get rival summaries: %w", err)
+		return nil, err
 	}
-
-	// Slackメッセージを構築して送信
-	message := gateway.BuildWeeklyReportMessage(
-		user.GithubUsername,
-		userCommits,
-		rivalSummaries,
-		dateRange.Start.Format("2006/01/02"),
-		dateRange.End.Format("2006/01/02"),
-	)
-
-	// メッセージをJSONPayloadに変換
-	payload := messageToPayload(message)
-
-	if err := deps.GetSlackGateway().SendMessage(ctx, setting.WebhookURL, message); err != nil {
-		return payload, fmt.Errorf("failed to send slack message: %w", err)
+	logsByKey := make(map[deliveryKey]*models.NotificationLog, len(found))
+	for i := range found {
+		l := &found[i]
+		logsByKey[deliveryKey{userID: l.UserID, channelType: l.ChannelType}] = l
 	}
-
-	log.Printf("Sent weekly report to user %s (commits: %d)", user.GithubUsername, userCommits)
-	return payload, nil
+	c.byStartDate[date] = logsByKey
+	return logsByKey, nil
 }
 
-// sendMonthlyReport 月次レポートを送信
-func sendMonthlyReport(
+// recordDelivery 送信結果を集計し、通知ログを保存する
+// 同じ集計期間の失敗ログがあれば、新しく作らずにそのログを更新する
+func recordDelivery(
 	ctx context.Context,
 	deps ISendNotificationsDeps,
-	setting models.SlackNotificationSetting,
-) (models.JSONPayload, error) {
-	user := setting.User
-	currentRange, previousRange := calculateMonthlyRange()
-
-	// 今月のコミット統計を取得
-	currentStats, err := deps.GetCommitStatsRepo().FindByGithubUserIDAndDateRange(ctx, user.GithubUserID, currentRange.Start, currentRange.End)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current month commit stats: %w", err)
+	n notifier.INotifier,
+	config SendNotificationsConfig,
+	report *RunReport,
+	existing *models.NotificationLog,
+	userID uint64,
+	periodStart time.Time,
+	payload models.JSONPayload,
+	sendErr error,
+	sentAt time.Time,
+) {
+	notificationLog := existing
+	if notificationLog == nil {
+		notificationLog = &models.NotificationLog{
+			UserID:      userID,
+			ChannelType: n.ChannelType(),
+			Period:      config.Period,
+			PeriodStart: &periodStart,
+			SentAt:      sentAt,
+		}
 	}
-	currentCommits := sumCommits(currentStats)
-
-	// 先月のコミット統計を取得
-	previousStats, err := deps.GetCommitStatsRepo().FindByGithubUserIDAndDateRange(ctx, user.GithubUserID, previousRange.Start, previousRange.End)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get previous month commit stats: %w", err)
+	notificationLog.Payload = payload
+	notificationLog.Attempts++
+	applyDeliveryResult(notificationLog, sendErr, sentAt)
+	countDelivery(report, notificationLog, sendErr)
+
+	// ログをDBに保存
+	if config.DryRun {
+		return
 	}
-	previousCommits := sumCommits(previousStats)
-
-	// ライバルのコミット統計を取得（今月分）
-	rivalSummaries, err := getRivalSummaries(ctx, deps, setting.UserID, currentRange)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get rival summaries: %w", err)
+	save := deps.GetNotificationLogRepo().Create
+	if existing != nil {
+		save = deps.GetNotificationLogRepo().Update
 	}
-
-	// 月のラベルを作成
-	monthLabel := fmt.Sprintf("%d年%d月", currentRange.Start.Year(), currentRange.Start.Month())
-
-	// Slackメッセージを構築して送信
-	message := gateway.BuildMonthlyReportMessage(
-		user.GithubUsername,
-		gateway.MonthlyComparison{
-			CurrentMonth:  currentCommits,
-			PreviousMonth: previousCommits,
-		},
-		rivalSummaries,
-		monthLabel,
-	)
-
-	// メッセージをJSONPayloadに変換
-	payload := messageToPayload(message)
-
-	if err := deps.GetSlackGateway().SendMessage(ctx, setting.WebhookURL, message); err != nil {
-		return payload, fmt.Errorf("failed to send slack message: %w", err)
+	if err := save(ctx, notificationLog); err != nil {
+		log.Printf("Failed to save notification log for user %d: %v", userID, err)
+		return
 	}
+	disableOnPermanentFailures(ctx, deps, n, notificationLog, sentAt)
+}
 
-	log.Printf("Sent monthly report to user %s (current: %d, previous: %d)", user.GithubUsername, currentCommits, previousCommits)
-	return payload, nil
+// isDeliveryHandled 次の配信で送り直す必要がないログか（再送を諦めたログは送り直す）
+// 再送予定のある失敗ログは再送バッチが送るため、ここで送ると同じレポートが二重に届く
+func isDeliveryHandled(notificationLog *models.NotificationLog) bool {
+	switch notificationLog.Status {
+	case models.NotificationStatusSuccess, models.NotificationStatusSkipped, models.NotificationStatusDeferred:
+		return true
+	case models.NotificationStatusFailed:
+		return notificationLog.NextRetryAt != nil
+	}
+	return false
 }
 
-// getRivalSummaries ライバルのコミットサマリーを取得
-func getRivalSummaries(
+// recordHeldDelivery スヌーズ中・通知を止める時間帯のため送らなかったレポートを記録する
+// deferred のログは NextRetryAt に再送バッチが送る
+func recordHeldDelivery(
 	ctx context.Context,
 	deps ISendNotificationsDeps,
+	config SendNotificationsConfig,
+	report *RunReport,
+	existing *models.NotificationLog,
 	userID uint64,
-	dateRange DateRange,
-) ([]gateway.RivalCommitSummary, error) {
-	rivals, err := deps.GetRivalRepo().FindByUserID(ctx, userID)
-	if err != nil {
-		return nil, err
+	channelType models.ChannelType,
+	periodStart time.Time,
+	status models.NotificationStatus,
+	nextRetryAt *time.Time,
+	now time.Time,
+) {
+	log.Printf("Holding %s report to user %d via %s: %s", config.Period, userID, channelType, status)
+	report.AddSkip()
+	if config.DryRun {
+		return
 	}
 
-	var summaries []gateway.RivalCommitSummary
-	for _, rival := range rivals {
-		rivalStats, err := deps.GetCommitStatsRepo().FindByGithubUserIDAndDateRange(ctx, rival.RivalGithubUserID, dateRange.Start, dateRange.End)
-		if err != nil {
-			log.Printf("Failed to get rival %s commit stats: %v", rival.RivalGithubUsername, err)
-			continue
+	notificationLog := existing
+	if notificationLog == nil {
+		notificationLog = &models.NotificationLog{
+			UserID:      userID,
+			ChannelType: channelType,
+			Period:      config.Period,
+			PeriodStart: &periodStart,
+			SentAt:      now,
 		}
-
-		summaries = append(summaries, gateway.RivalCommitSummary{
-			Username: rival.RivalGithubUsername,
-			Commits:  sumCommits(rivalStats),
-		})
 	}
+	notificationLog.Status = status
+	notificationLog.NextRetryAt = nextRetryAt
 
-	return summaries, nil
+	save := deps.GetNotificationLogRepo().Create
+	if existing != nil {
+		save = deps.GetNotificationLogRepo().Update
+	}
+	if err := save(ctx, notificationLog); err != nil {
+		log.Printf("Failed to save notification log for user %d: %v", userID, err)
+	}
 }
 
-func sumCommits(stats []models.CommitStats) int {
-	total := 0
-	for _, s := range stats {
-		total += s.CommitCount
+// groupDestinationsByUser 送信先をユーザーごとにまとめる（ユーザーの並びは最初に現れた順）
+func groupDestinationsByUser(destinations []notifier.Destination) ([]uint64, map[uint64][]notifier.Destination) {
+	var userIDs []uint64
+	byUser := make(map[uint64][]notifier.Destination)
+	for _, d := range destinations {
+		if _, exists := byUser[d.UserID]; !exists {
+			userIDs = append(userIDs, d.UserID)
+		}
+		byUser[d.UserID] = append(byUser[d.UserID], d)
 	}
-	return total
+	return userIDs, byUser
+}
+
+// loadReportData 期間に応じたレポートの内容を集計する（集計期間は now を基準に決める）
+func loadReportData(ctx context.Context, deps ISendNotificationsDeps, period string, userID uint64, user models.User, now time.Time) (*notifier.Report, error) {
+	return notifier.NewReportBuilder(deps.GetRivalRepo(), deps.GetCommitStatsRepo()).Build(ctx, period, userID, user, now)
 }
 
-// messageToPayload SlackMessageをJSONPayloadに変換
-func messageToPayload(message *gateway.SlackMessage) models.JSONPayload {
-	if message == nil {
-		return nil
+// deliverReport レポートをチャンネル向けにレンダリングして配信する
+func deliverReport(
+	ctx context.Context,
+	n notifier.INotifier,
+	config SendNotificationsConfig,
+	data *notifier.Report,
+	destination notifier.Destination,
+) (models.JSONPayload, error) {
+	message, err := n.Render(destination, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render %s message: %w", destination.ChannelType, err)
 	}
-	payload := models.JSONPayload{
-		"blocks": message.Blocks,
+
+	if config.DryRun {
+		return message.Payload, writeDryRunRecord(config.DryRunOutput, newDryRunRecord(config, destination.UserID, destination.User, destination.ChannelType, message.Body))
+	}
+	if err := n.Deliver(ctx, destination, message); err != nil {
+		return message.Payload, err
 	}
-	if message.Text != "" {
-		payload["text"] = message.Text
+
+	log.Printf("Sent %s report to user %s via %s (commits: %d)", config.Period, data.Username, destination.ChannelType, data.UserCommits)
+	return message.Payload, nil
+}
+
+// publishToInbox 受信箱に通知を載せる（失敗しても配信自体は成功として扱う）
+func publishToInbox(ctx context.Context, publisher notifier.IInboxPublisher, item notifier.InboxItem) {
+	if err := publisher.Publish(ctx, item); err != nil {
+		log.Printf("Failed to publish %s to inbox for user %d: %v", item.Type, item.UserID, err)
 	}
-	return payload
 }
@@ -1,13 +1,16 @@
 package batch
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"testing"
 	"time"
 
 	"github.com/keeee21/commitly/api/gateway"
 	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
 	"github.com/keeee21/commitly/api/repository"
 	"github.com/stretchr/testify/assert"
 )
@@ -122,6 +125,7 @@ type mockCommitStatsRepository struct {
 	FindByGithubUserIDsAndDateRangeFunc func(ctx context.Context, githubUserIDs []uint64, startDate, endDate time.Time) ([]models.CommitStats, error)
 	UpsertFunc                          func(ctx context.Context, stats *models.CommitStats) error
 	UpsertBatchFunc                     func(ctx context.Context, statsList []models.CommitStats) error
+	ApplyReconciliationFunc             func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error
 }
 
 func (m *mockCommitStatsRepository) FindByGithubUserIDAndDateRange(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
@@ -152,6 +156,13 @@ func (m *mockCommitStatsRepository) UpsertBatch(ctx context.Context, statsList [
 	return nil
 }
 
+func (m *mockCommitStatsRepository) ApplyReconciliation(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error {
+	if m.ApplyReconciliationFunc != nil {
+		return m.ApplyReconciliationFunc(ctx, githubUserID, startDate, endDate, statsList, selectDeletes)
+	}
+	return nil
+}
+
 // mockSlackGateway テスト用のモック
 type mockSlackGateway struct {
 	SendMessageFunc func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error
@@ -164,12 +175,284 @@ func (m *mockSlackGateway) SendMessage(ctx context.Context, webhookURL string, m
 	return nil
 }
 
+// mockDiscordNotificationSettingRepository テスト用のモック
+type mockDiscordNotificationSettingRepository struct {
+	FindAllEnabledFunc func(ctx context.Context) ([]models.DiscordNotificationSetting, error)
+}
+
+func (m *mockDiscordNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.DiscordNotificationSetting, error) {
+	return nil, nil
+}
+
+func (m *mockDiscordNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.DiscordNotificationSetting, error) {
+	if m.FindAllEnabledFunc != nil {
+		return m.FindAllEnabledFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockDiscordNotificationSettingRepository) Upsert(ctx context.Context, setting *models.DiscordNotificationSetting) error {
+	return nil
+}
+
+func (m *mockDiscordNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return nil
+}
+
+func (m *mockDiscordNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+// mockDiscordGateway テスト用のモック
+type mockDiscordGateway struct {
+	SendMessageFunc func(ctx context.Context, webhookURL string, message *gateway.DiscordMessage) error
+}
+
+func (m *mockDiscordGateway) SendMessage(ctx context.Context, webhookURL string, message *gateway.DiscordMessage) error {
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(ctx, webhookURL, message)
+	}
+	return nil
+}
+
+// mockTeamsNotificationSettingRepository テスト用のモック
+type mockTeamsNotificationSettingRepository struct {
+	FindAllEnabledFunc func(ctx context.Context) ([]models.TeamsNotificationSetting, error)
+}
+
+func (m *mockTeamsNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.TeamsNotificationSetting, error) {
+	return nil, nil
+}
+
+func (m *mockTeamsNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.TeamsNotificationSetting, error) {
+	if m.FindAllEnabledFunc != nil {
+		return m.FindAllEnabledFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockTeamsNotificationSettingRepository) Upsert(ctx context.Context, setting *models.TeamsNotificationSetting) error {
+	return nil
+}
+
+func (m *mockTeamsNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return nil
+}
+
+func (m *mockTeamsNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+// mockTeamsGateway テスト用のモック
+type mockTeamsGateway struct {
+	SendMessageFunc func(ctx context.Context, webhookURL string, message *gateway.TeamsMessage) error
+}
+
+func (m *mockTeamsGateway) SendMessage(ctx context.Context, webhookURL string, message *gateway.TeamsMessage) error {
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(ctx, webhookURL, message)
+	}
+	return nil
+}
+
+// mockMattermostNotificationSettingRepository テスト用のモック
+type mockMattermostNotificationSettingRepository struct {
+	FindAllEnabledFunc func(ctx context.Context) ([]models.MattermostNotificationSetting, error)
+}
+
+func (m *mockMattermostNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.MattermostNotificationSetting, error) {
+	return nil, nil
+}
+
+func (m *mockMattermostNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.MattermostNotificationSetting, error) {
+	if m.FindAllEnabledFunc != nil {
+		return m.FindAllEnabledFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockMattermostNotificationSettingRepository) Upsert(ctx context.Context, setting *models.MattermostNotificationSetting) error {
+	return nil
+}
+
+func (m *mockMattermostNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return nil
+}
+
+func (m *mockMattermostNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+// mockMattermostGateway テスト用のモック
+type mockMattermostGateway struct {
+	SendMessageFunc func(ctx context.Context, webhookURL string, message *gateway.MattermostMessage) error
+}
+
+func (m *mockMattermostGateway) SendMessage(ctx context.Context, webhookURL string, message *gateway.MattermostMessage) error {
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(ctx, webhookURL, message)
+	}
+	return nil
+}
+
+// mockTelegramNotificationSettingRepository テスト用のモック
+type mockTelegramNotificationSettingRepository struct {
+	FindAllEnabledFunc func(ctx context.Context) ([]models.TelegramNotificationSetting, error)
+}
+
+func (m *mockTelegramNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error) {
+	return nil, nil
+}
+
+func (m *mockTelegramNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.TelegramNotificationSetting, error) {
+	if m.FindAllEnabledFunc != nil {
+		return m.FindAllEnabledFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockTelegramNotificationSettingRepository) Upsert(ctx context.Context, setting *models.TelegramNotificationSetting) error {
+	return nil
+}
+
+func (m *mockTelegramNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return nil
+}
+
+func (m *mockTelegramNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func (m *mockTelegramNotificationSettingRepository) DisableByChatID(ctx context.Context, chatID int64) error {
+	return nil
+}
+
+// mockTelegramGateway テスト用のモック
+type mockTelegramGateway struct {
+	SendMessageFunc func(ctx context.Context, chatID string, message *gateway.TelegramMessage) error
+}
+
+func (m *mockTelegramGateway) SendMessage(ctx context.Context, chatID string, message *gateway.TelegramMessage) error {
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(ctx, chatID, message)
+	}
+	return nil
+}
+
+// mockWebPushSubscriptionRepository テスト用のモック
+type mockWebPushSubscriptionRepository struct {
+	FindByUserIDFunc func(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error)
+	FindAllFunc      func(ctx context.Context) ([]models.WebPushSubscription, error)
+	DeleteByIDFunc   func(ctx context.Context, id uint64) error
+}
+
+func (m *mockWebPushSubscriptionRepository) FindByUserID(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *mockWebPushSubscriptionRepository) FindAll(ctx context.Context) ([]models.WebPushSubscription, error) {
+	if m.FindAllFunc != nil {
+		return m.FindAllFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockWebPushSubscriptionRepository) Upsert(ctx context.Context, subscription *models.WebPushSubscription) error {
+	return nil
+}
+
+func (m *mockWebPushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, userID uint64, endpoint string) error {
+	return nil
+}
+
+func (m *mockWebPushSubscriptionRepository) DeleteByID(ctx context.Context, id uint64) error {
+	if m.DeleteByIDFunc != nil {
+		return m.DeleteByIDFunc(ctx, id)
+	}
+	return nil
+}
+
+func (m *mockWebPushSubscriptionRepository) DeleteByUserID(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+// mockWebPushGateway テスト用のモック
+type mockWebPushGateway struct {
+	SendFunc func(ctx context.Context, subscription gateway.WebPushTarget, message *gateway.WebPushMessage) error
+}
+
+func (m *mockWebPushGateway) Send(ctx context.Context, subscription gateway.WebPushTarget, message *gateway.WebPushMessage) error {
+	if m.SendFunc != nil {
+		return m.SendFunc(ctx, subscription, message)
+	}
+	return nil
+}
+
+// mockLineNotificationSettingRepository テスト用のモック
+type mockLineNotificationSettingRepository struct {
+	FindAllEnabledFunc func(ctx context.Context) ([]models.LineNotificationSetting, error)
+}
+
+func (m *mockLineNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.LineNotificationSetting, error) {
+	return nil, nil
+}
+
+func (m *mockLineNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.LineNotificationSetting, error) {
+	if m.FindAllEnabledFunc != nil {
+		return m.FindAllEnabledFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *mockLineNotificationSettingRepository) Upsert(ctx context.Context, setting *models.LineNotificationSetting) error {
+	return nil
+}
+
+func (m *mockLineNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return nil
+}
+
+func (m *mockLineNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func (m *mockLineNotificationSettingRepository) DisableByLineUserID(ctx context.Context, lineUserID string) error {
+	return nil
+}
+
+// mockLineGateway テスト用のモック
+type mockLineGateway struct {
+	PushMessageFunc func(ctx context.Context, to string, messages []gateway.LineMessage) error
+}
+
+func (m *mockLineGateway) PushMessage(ctx context.Context, to string, messages []gateway.LineMessage) error {
+	if m.PushMessageFunc != nil {
+		return m.PushMessageFunc(ctx, to, messages)
+	}
+	return nil
+}
+
+func (m *mockLineGateway) ReplyMessage(ctx context.Context, replyToken string, messages []gateway.LineMessage) error {
+	return nil
+}
+
+func (m *mockLineGateway) GetProfile(ctx context.Context, lineUserID string) (*gateway.LineProfile, error) {
+	return &gateway.LineProfile{}, nil
+}
+
 // mockNotificationLogRepository テスト用のモック
 type mockNotificationLogRepository struct {
-	CreateFunc              func(ctx context.Context, log *models.NotificationLog) error
-	FindByUserIDFunc        func(ctx context.Context, userID uint64, limit int) ([]models.NotificationLog, error)
-	FindByDateRangeFunc     func(ctx context.Context, startDate, endDate time.Time) ([]models.NotificationLog, error)
-	FindByPeriodAndDateFunc func(ctx context.Context, period string, date time.Time) ([]models.NotificationLog, error)
+	CreateFunc                       func(ctx context.Context, log *models.NotificationLog) error
+	UpdateFunc                       func(ctx context.Context, log *models.NotificationLog) error
+	FindByIDFunc                     func(ctx context.Context, id uint64) (*models.NotificationLog, error)
+	FindByUserIDFunc                 func(ctx context.Context, userID uint64, filter repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error)
+	FindByDateRangeFunc              func(ctx context.Context, startDate, endDate time.Time) ([]models.NotificationLog, error)
+	FindByPeriodStartFunc            func(ctx context.Context, period string, periodStart time.Time) ([]models.NotificationLog, error)
+	FindDueRetriesFunc               func(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error)
+	FindRecentByUserIDAndChannelFunc func(ctx context.Context, userID uint64, channelType models.ChannelType, limit int) ([]models.NotificationLog, error)
 }
 
 func (m *mockNotificationLogRepository) Create(ctx context.Context, log *models.NotificationLog) error {
@@ -179,13 +462,27 @@ func (m *mockNotificationLogRepository) Create(ctx context.Context, log *models.
 	return nil
 }
 
-func (m *mockNotificationLogRepository) FindByUserID(ctx context.Context, userID uint64, limit int) ([]models.NotificationLog, error) {
-	if m.FindByUserIDFunc != nil {
-		return m.FindByUserIDFunc(ctx, userID, limit)
+func (m *mockNotificationLogRepository) Update(ctx context.Context, log *models.NotificationLog) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, log)
+	}
+	return nil
+}
+
+func (m *mockNotificationLogRepository) FindByID(ctx context.Context, id uint64) (*models.NotificationLog, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
 	}
 	return nil, nil
 }
 
+func (m *mockNotificationLogRepository) FindByUserID(ctx context.Context, userID uint64, filter repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID, filter, limit, offset)
+	}
+	return nil, 0, nil
+}
+
 func (m *mockNotificationLogRepository) FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]models.NotificationLog, error) {
 	if m.FindByDateRangeFunc != nil {
 		return m.FindByDateRangeFunc(ctx, startDate, endDate)
@@ -193,273 +490,1233 @@ func (m *mockNotificationLogRepository) FindByDateRange(ctx context.Context, sta
 	return nil, nil
 }
 
-func (m *mockNotificationLogRepository) FindByPeriodAndDate(ctx context.Context, period string, date time.Time) ([]models.NotificationLog, error) {
-	if m.FindByPeriodAndDateFunc != nil {
-		return m.FindByPeriodAndDateFunc(ctx, period, date)
+func (m *mockNotificationLogRepository) FindByPeriodStart(ctx context.Context, period string, periodStart time.Time) ([]models.NotificationLog, error) {
+	if m.FindByPeriodStartFunc != nil {
+		return m.FindByPeriodStartFunc(ctx, period, periodStart)
+	}
+	return nil, nil
+}
+
+func (m *mockNotificationLogRepository) FindDueRetries(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error) {
+	if m.FindDueRetriesFunc != nil {
+		return m.FindDueRetriesFunc(ctx, now, limit)
+	}
+	return nil, nil
+}
+
+func (m *mockNotificationLogRepository) FindRecentByUserIDAndChannel(ctx context.Context, userID uint64, channelType models.ChannelType, limit int) ([]models.NotificationLog, error) {
+	if m.FindRecentByUserIDAndChannelFunc != nil {
+		return m.FindRecentByUserIDAndChannelFunc(ctx, userID, channelType, limit)
+	}
+	return nil, nil
+}
+
+// mockUserRepository テスト用のモック
+type mockUserRepository struct {
+	UpdateNotificationAlertFunc func(ctx context.Context, id uint64, alertAt *time.Time) error
+}
+
+func (m *mockUserRepository) FindByID(ctx context.Context, id uint64) (*models.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepository) FindByGithubUserID(ctx context.Context, githubUserID uint64) (*models.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepository) FindAll(ctx context.Context) ([]models.User, error) {
+	return nil, nil
+}
+
+func (m *mockUserRepository) Create(ctx context.Context, user *models.User) error {
+	return nil
+}
+
+func (m *mockUserRepository) Update(ctx context.Context, user *models.User) error {
+	return nil
+}
+
+func (m *mockUserRepository) UpdateNotificationAlert(ctx context.Context, id uint64, alertAt *time.Time) error {
+	if m.UpdateNotificationAlertFunc != nil {
+		return m.UpdateNotificationAlertFunc(ctx, id, alertAt)
+	}
+	return nil
+}
+
+func (m *mockUserRepository) UpdateLocale(ctx context.Context, id uint64, locale string) error {
+	return nil
+}
+
+// mockInAppNotificationRepository テスト用のモック
+type mockInAppNotificationRepository struct {
+	CreateIfNotExistsFunc func(ctx context.Context, notification *models.InAppNotification) (bool, error)
+}
+
+func (m *mockInAppNotificationRepository) CreateIfNotExists(ctx context.Context, notification *models.InAppNotification) (bool, error) {
+	if m.CreateIfNotExistsFunc != nil {
+		return m.CreateIfNotExistsFunc(ctx, notification)
+	}
+	return true, nil
+}
+
+func (m *mockInAppNotificationRepository) FindByUserID(ctx context.Context, userID uint64, beforeID uint64, unreadOnly bool, limit int) ([]models.InAppNotification, error) {
+	return nil, nil
+}
+
+func (m *mockInAppNotificationRepository) CountUnread(ctx context.Context, userID uint64) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockInAppNotificationRepository) MarkRead(ctx context.Context, userID, id uint64, readAt time.Time) (bool, error) {
+	return false, nil
+}
+
+func (m *mockInAppNotificationRepository) MarkAllRead(ctx context.Context, userID uint64, readAt time.Time) error {
+	return nil
+}
+
+// mockNotificationScheduleRepository テスト用のモック
+type mockNotificationScheduleRepository struct {
+	FindByUserIDsFunc func(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error)
+}
+
+func (m *mockNotificationScheduleRepository) FindByUserID(ctx context.Context, userID uint64) (*models.NotificationSchedule, error) {
+	return nil, nil
+}
+
+func (m *mockNotificationScheduleRepository) FindByUserIDs(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+	if m.FindByUserIDsFunc != nil {
+		return m.FindByUserIDsFunc(ctx, userIDs)
 	}
 	return nil, nil
 }
 
+func (m *mockNotificationScheduleRepository) Upsert(ctx context.Context, schedule *models.NotificationSchedule) error {
+	return nil
+}
+
 // testDeps テスト用の依存関係
 type testDeps struct {
-	slackNotificationRepo *mockSlackNotificationSettingRepository
-	notificationLogRepo   *mockNotificationLogRepository
-	rivalRepo             *mockRivalRepository
-	commitStatsRepo       *mockCommitStatsRepository
-	slackGateway          *mockSlackGateway
+	slackNotificationRepo      *mockSlackNotificationSettingRepository
+	notificationLogRepo        *mockNotificationLogRepository
+	userRepo                   *mockUserRepository
+	scheduleRepo               *mockNotificationScheduleRepository
+	rivalRepo                  *mockRivalRepository
+	commitStatsRepo            *mockCommitStatsRepository
+	rivalAlertRepo             *mockRivalAlertRepository
+	circleNotificationRepo     *mockCircleNotificationSettingRepository
+	circleDigestLogRepo        *mockCircleDigestLogRepository
+	dailyNudgeRepo             *mockDailyNudgeRepository
+	slackGateway               *mockSlackGateway
+	discordNotificationRepo    *mockDiscordNotificationSettingRepository
+	discordGateway             *mockDiscordGateway
+	teamsNotificationRepo      *mockTeamsNotificationSettingRepository
+	teamsGateway               *mockTeamsGateway
+	mattermostNotificationRepo *mockMattermostNotificationSettingRepository
+	mattermostGateway          *mockMattermostGateway
+	telegramNotificationRepo   *mockTelegramNotificationSettingRepository
+	telegramGateway            *mockTelegramGateway
+	webPushSubscriptionRepo    *mockWebPushSubscriptionRepository
+	webPushGateway             *mockWebPushGateway
+	lineNotificationRepo       *mockLineNotificationSettingRepository
+	lineGateway                *mockLineGateway
+	inAppNotificationRepo      *mockInAppNotificationRepository
 }
 
-func (d *testDeps) GetSlackNotificationRepo() repository.ISlackNotificationSettingRepository {
-	return d.slackNotificationRepo
-}
+func (d *testDeps) GetNotificationLogRepo() repository.INotificationLogRepository {
+	return d.notificationLogRepo
+}
+
+func (d *testDeps) GetUserRepo() repository.IUserRepository {
+	if d.userRepo == nil {
+		return &mockUserRepository{}
+	}
+	return d.userRepo
+}
+
+func (d *testDeps) GetNotificationScheduleRepo() repository.INotificationScheduleRepository {
+	if d.scheduleRepo == nil {
+		return &mockNotificationScheduleRepository{}
+	}
+	return d.scheduleRepo
+}
+
+func (d *testDeps) GetRivalRepo() repository.IRivalRepository {
+	return d.rivalRepo
+}
+
+func (d *testDeps) GetCommitStatsRepo() repository.ICommitStatsRepository {
+	return d.commitStatsRepo
+}
+
+func (d *testDeps) GetRivalAlertRepo() repository.IRivalAlertRepository {
+	if d.rivalAlertRepo == nil {
+		return &mockRivalAlertRepository{}
+	}
+	return d.rivalAlertRepo
+}
+
+func (d *testDeps) GetCircleNotificationRepo() repository.ICircleNotificationSettingRepository {
+	if d.circleNotificationRepo == nil {
+		return &mockCircleNotificationSettingRepository{}
+	}
+	return d.circleNotificationRepo
+}
+
+func (d *testDeps) GetCircleDigestLogRepo() repository.ICircleDigestLogRepository {
+	if d.circleDigestLogRepo == nil {
+		return &mockCircleDigestLogRepository{}
+	}
+	return d.circleDigestLogRepo
+}
+
+func (d *testDeps) GetDailyNudgeRepo() repository.IDailyNudgeRepository {
+	if d.dailyNudgeRepo == nil {
+		return &mockDailyNudgeRepository{}
+	}
+	return d.dailyNudgeRepo
+}
+
+func (d *testDeps) GetCircleDigestPoster() notifier.ICircleDigestPoster {
+	return notifier.NewCircleDigestPoster(d.slackGateway, d.discordGateway)
+}
+
+func (d *testDeps) GetInboxPublisher() notifier.IInboxPublisher {
+	if d.inAppNotificationRepo == nil {
+		return notifier.NewInboxPublisher(&mockInAppNotificationRepository{})
+	}
+	return notifier.NewInboxPublisher(d.inAppNotificationRepo)
+}
+
+// GetNotifierRegistry モックのリポジトリ・ゲートウェイから各チャンネルのNotifierを組み立てる
+func (d *testDeps) GetNotifierRegistry() *notifier.Registry {
+	slackRepo := d.slackNotificationRepo
+	if slackRepo == nil {
+		slackRepo = &mockSlackNotificationSettingRepository{}
+	}
+	discordRepo := d.discordNotificationRepo
+	if discordRepo == nil {
+		discordRepo = &mockDiscordNotificationSettingRepository{}
+	}
+	teamsRepo := d.teamsNotificationRepo
+	if teamsRepo == nil {
+		teamsRepo = &mockTeamsNotificationSettingRepository{}
+	}
+	mattermostRepo := d.mattermostNotificationRepo
+	if mattermostRepo == nil {
+		mattermostRepo = &mockMattermostNotificationSettingRepository{}
+	}
+	lineRepo := d.lineNotificationRepo
+	if lineRepo == nil {
+		lineRepo = &mockLineNotificationSettingRepository{}
+	}
+	telegramRepo := d.telegramNotificationRepo
+	if telegramRepo == nil {
+		telegramRepo = &mockTelegramNotificationSettingRepository{}
+	}
+	webPushRepo := d.webPushSubscriptionRepo
+	if webPushRepo == nil {
+		webPushRepo = &mockWebPushSubscriptionRepository{}
+	}
+	return notifier.NewRegistry(
+		notifier.NewSlackNotifier(slackRepo, d.slackGateway),
+		notifier.NewDiscordNotifier(discordRepo, d.discordGateway),
+		notifier.NewTeamsNotifier(teamsRepo, d.teamsGateway),
+		notifier.NewMattermostNotifier(mattermostRepo, d.mattermostGateway),
+		notifier.NewLineNotifier(lineRepo, d.lineGateway),
+		notifier.NewTelegramNotifier(telegramRepo, d.telegramGateway),
+		notifier.NewWebPushNotifier(webPushRepo, d.webPushGateway),
+	)
+}
+
+func TestRunSendNotifications_InvalidPeriod(t *testing.T) {
+	ctx := context.Background()
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{},
+		notificationLogRepo:   &mockNotificationLogRepository{},
+		rivalRepo:             &mockRivalRepository{},
+		commitStatsRepo:       &mockCommitStatsRepository{},
+		slackGateway:          &mockSlackGateway{},
+	}
+
+	config := SendNotificationsConfig{Period: "daily"} // invalid
+	_, err := RunSendNotifications(ctx, deps, config)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid period")
+}
+
+func TestRunSendNotifications_NoEnabledSettings(t *testing.T) {
+	ctx := context.Background()
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{},
+		rivalRepo:           &mockRivalRepository{},
+		commitStatsRepo:     &mockCommitStatsRepository{},
+		slackGateway:        &mockSlackGateway{},
+	}
+
+	config := SendNotificationsConfig{Period: "weekly"}
+	_, err := RunSendNotifications(ctx, deps, config)
+
+	assert.NoError(t, err)
+}
+
+func TestRunSendNotifications_RepositoryError(t *testing.T) {
+	ctx := context.Background()
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return nil, errors.New("database error")
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{},
+		rivalRepo:           &mockRivalRepository{},
+		commitStatsRepo:     &mockCommitStatsRepository{},
+		slackGateway:        &mockSlackGateway{},
+	}
+
+	config := SendNotificationsConfig{Period: "weekly"}
+	_, err := RunSendNotifications(ctx, deps, config)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get enabled Slack notification settings")
+}
+
+func TestRunSendNotifications_WeeklySuccess(t *testing.T) {
+	ctx := context.Background()
+	slackCalled := false
+	logSaved := false
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{
+					{
+						ID:         1,
+						UserID:     1,
+						WebhookURL: "https://hooks.slack.com/test",
+						IsEnabled:  true,
+						User: models.User{
+							ID:             1,
+							GithubUserID:   12345,
+							GithubUsername: "testuser",
+						},
+					},
+				}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				logSaved = true
+				assert.Equal(t, models.NotificationStatusSuccess, log.Status)
+				assert.Equal(t, "weekly", log.Period)
+				assert.NotNil(t, log.Payload)
+				assert.Contains(t, log.Payload, "blocks")
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{
+			FindByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.Rival, error) {
+				return []models.Rival{
+					{
+						RivalGithubUserID:   67890,
+						RivalGithubUsername: "rival1",
+					},
+				}, nil
+			},
+		},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				if githubUserID == 12345 {
+					return []models.CommitStats{{CommitCount: 10}}, nil
+				}
+				return []models.CommitStats{{CommitCount: 5}}, nil
+			},
+		},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				slackCalled = true
+				assert.Equal(t, "https://hooks.slack.com/test", webhookURL)
+				return nil
+			},
+		},
+	}
+
+	config := SendNotificationsConfig{Period: "weekly"}
+	_, err := RunSendNotifications(ctx, deps, config)
+
+	assert.NoError(t, err)
+	assert.True(t, slackCalled)
+	assert.True(t, logSaved)
+}
+
+func TestRunSendNotifications_MonthlySuccess(t *testing.T) {
+	ctx := context.Background()
+	slackCalled := false
+	logSaved := false
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{
+					{
+						ID:         1,
+						UserID:     1,
+						WebhookURL: "https://hooks.slack.com/test",
+						IsEnabled:  true,
+						User: models.User{
+							ID:             1,
+							GithubUserID:   12345,
+							GithubUsername: "testuser",
+						},
+					},
+				}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				logSaved = true
+				assert.Equal(t, models.NotificationStatusSuccess, log.Status)
+				assert.Equal(t, "monthly", log.Period)
+				assert.NotNil(t, log.Payload)
+				assert.Contains(t, log.Payload, "blocks")
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{
+			FindByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.Rival, error) {
+				return []models.Rival{
+					{
+						RivalGithubUserID:   67890,
+						RivalGithubUsername: "rival1",
+					},
+				}, nil
+			},
+		},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				if githubUserID == 12345 {
+					return []models.CommitStats{{CommitCount: 20}}, nil
+				}
+				return []models.CommitStats{{CommitCount: 15}}, nil
+			},
+		},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				slackCalled = true
+				assert.Equal(t, "https://hooks.slack.com/test", webhookURL)
+				return nil
+			},
+		},
+	}
+
+	config := SendNotificationsConfig{Period: "monthly"}
+	_, err := RunSendNotifications(ctx, deps, config)
+
+	assert.NoError(t, err)
+	assert.True(t, slackCalled)
+	assert.True(t, logSaved)
+}
+
+func TestRunSendNotifications_SendFailureIsReported(t *testing.T) {
+	ctx := context.Background()
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{
+					{UserID: 1, WebhookURL: "https://hooks.slack.com/ok", User: models.User{ID: 1, GithubUserID: 1, GithubUsername: "ok"}},
+					{UserID: 2, WebhookURL: "https://hooks.slack.com/ng", User: models.User{ID: 2, GithubUserID: 2, GithubUsername: "ng"}},
+				}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{},
+		rivalRepo:           &mockRivalRepository{},
+		commitStatsRepo:     &mockCommitStatsRepository{},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				if webhookURL == "https://hooks.slack.com/ng" {
+					return errors.New("slack webhook returned non-200 status: 500")
+				}
+				return nil
+			},
+		},
+	}
+
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	assert.Equal(t, 1, report.FailureCount)
+	assert.Len(t, report.ErrorSamples, 1)
+	assert.Contains(t, report.ErrorSamples[0], "user 2")
+	assert.Equal(t, ExitCodePartialFailure, report.ExitCode())
+}
+
+func TestRunSendNotifications_DryRun(t *testing.T) {
+	ctx := context.Background()
+	var buf bytes.Buffer
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{
+					{ID: 1, UserID: 1, WebhookURL: "https://hooks.slack.com/one", IsEnabled: true, User: models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}},
+					{ID: 2, UserID: 2, WebhookURL: "https://hooks.slack.com/two", IsEnabled: true, User: models.User{ID: 2, GithubUserID: 222, GithubUsername: "user2"}},
+				}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				t.Fatal("notification log must not be saved in dry-run")
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{
+			FindByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.Rival, error) {
+				return nil, nil
+			},
+		},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				return []models.CommitStats{{CommitCount: 3}}, nil
+			},
+		},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				t.Fatal("slack message must not be sent in dry-run")
+				return nil
+			},
+		},
+	}
+
+	config := SendNotificationsConfig{Period: "weekly", DryRun: true, DryRunOutput: &buf}
+	report, err := RunSendNotifications(ctx, deps, config)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.SuccessCount)
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal(lines[0], &record))
+	assert.Equal(t, float64(1), record["user_id"])
+	assert.Equal(t, "user1", record["github_username"])
+	assert.Equal(t, "slack", record["channel_type"])
+	assert.Equal(t, "weekly", record["period"])
+	assert.Contains(t, record["message"], "blocks")
+}
+
+func TestRunSendNotifications_Discord(t *testing.T) {
+	ctx := context.Background()
+	var sentMessage *gateway.DiscordMessage
+	var savedLogs []*models.NotificationLog
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{},
+		discordNotificationRepo: &mockDiscordNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.DiscordNotificationSetting, error) {
+				return []models.DiscordNotificationSetting{
+					{ID: 1, UserID: 1, WebhookURL: "https://discord.com/api/webhooks/1/abc", IsEnabled: true, User: models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}},
+					{ID: 2, UserID: 2, WebhookURL: "https://discord.com/api/webhooks/2/def", IsEnabled: true, User: models.User{ID: 2, GithubUserID: 222, GithubUsername: "user2"}},
+				}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				savedLogs = append(savedLogs, log)
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				return []models.CommitStats{{CommitCount: 4}}, nil
+			},
+		},
+		discordGateway: &mockDiscordGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.DiscordMessage) error {
+				if webhookURL == "https://discord.com/api/webhooks/2/def" {
+					return errors.New("discord webhook returned non-2xx status: 404")
+				}
+				sentMessage = message
+				return nil
+			},
+		},
+	}
+
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "monthly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	assert.Equal(t, 1, report.FailureCount)
+	assert.Contains(t, report.ErrorSamples[0], "user 2 (discord)")
+
+	assert.NotNil(t, sentMessage)
+	assert.Len(t, sentMessage.Embeds, 1)
+	assert.Contains(t, sentMessage.Embeds[0].Title, "月次レポート")
+
+	assert.Len(t, savedLogs, 2)
+	for _, l := range savedLogs {
+		assert.Equal(t, models.ChannelTypeDiscord, l.ChannelType)
+		assert.Equal(t, "monthly", l.Period)
+		assert.Contains(t, l.Payload, "embeds")
+	}
+	assert.Equal(t, models.NotificationStatusSuccess, savedLogs[0].Status)
+	assert.Equal(t, models.NotificationStatusFailed, savedLogs[1].Status)
+}
+
+func TestRunSendNotifications_Teams(t *testing.T) {
+	ctx := context.Background()
+	var sentMessage *gateway.TeamsMessage
+	var savedLogs []*models.NotificationLog
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{},
+		teamsNotificationRepo: &mockTeamsNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.TeamsNotificationSetting, error) {
+				return []models.TeamsNotificationSetting{
+					{ID: 1, UserID: 1, WebhookURL: "https://contoso.webhook.office.com/webhookb2/abc", IsEnabled: true, User: models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}},
+				}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				savedLogs = append(savedLogs, log)
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				return []models.CommitStats{{CommitCount: 4}}, nil
+			},
+		},
+		teamsGateway: &mockTeamsGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.TeamsMessage) error {
+				sentMessage = message
+				return nil
+			},
+		},
+	}
+
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+
+	assert.NotNil(t, sentMessage)
+	assert.Len(t, sentMessage.Attachments, 1)
+	assert.Equal(t, "application/vnd.microsoft.card.adaptive", sentMessage.Attachments[0].ContentType)
+	assert.Contains(t, sentMessage.Attachments[0].Content.Body[0].Text, "週次レポート")
+
+	assert.Len(t, savedLogs, 1)
+	assert.Equal(t, models.ChannelTypeTeams, savedLogs[0].ChannelType)
+	assert.Equal(t, models.NotificationStatusSuccess, savedLogs[0].Status)
+	assert.Contains(t, savedLogs[0].Payload, "attachments")
+}
+
+func TestRunSendNotifications_Mattermost(t *testing.T) {
+	ctx := context.Background()
+	var sentMessage *gateway.MattermostMessage
+	var savedLogs []*models.NotificationLog
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{},
+		mattermostNotificationRepo: &mockMattermostNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.MattermostNotificationSetting, error) {
+				return []models.MattermostNotificationSetting{
+					{ID: 1, UserID: 1, WebhookURL: "https://mattermost.example.com/hooks/abc", IsEnabled: true, User: models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}},
+					{ID: 2, UserID: 2, WebhookURL: "https://mattermost.example.com/hooks/def", IsEnabled: true, User: models.User{ID: 2, GithubUserID: 222, GithubUsername: "user2"}},
+				}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				savedLogs = append(savedLogs, log)
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				return []models.CommitStats{{CommitCount: 4}}, nil
+			},
+		},
+		mattermostGateway: &mockMattermostGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.MattermostMessage) error {
+				if webhookURL == "https://mattermost.example.com/hooks/def" {
+					return errors.New("mattermost webhook returned non-2xx status: 404")
+				}
+				sentMessage = message
+				return nil
+			},
+		},
+	}
+
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "monthly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	assert.Equal(t, 1, report.FailureCount)
+	assert.Contains(t, report.ErrorSamples[0], "user 2 (mattermost)")
+
+	assert.NotNil(t, sentMessage)
+	assert.Len(t, sentMessage.Attachments, 1)
+	assert.Contains(t, sentMessage.Attachments[0].Title, "月次レポート")
+	assert.NotEmpty(t, sentMessage.Attachments[0].Fallback)
+
+	assert.Len(t, savedLogs, 2)
+	for _, l := range savedLogs {
+		assert.Equal(t, models.ChannelTypeMattermost, l.ChannelType)
+		assert.Contains(t, l.Payload, "attachments")
+	}
+}
+
+func TestRunSendNotifications_Telegram(t *testing.T) {
+	ctx := context.Background()
+	var sentChatID string
+	var sentMessage *gateway.TelegramMessage
+	var savedLogs []*models.NotificationLog
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{},
+		telegramNotificationRepo: &mockTelegramNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.TelegramNotificationSetting, error) {
+				return []models.TelegramNotificationSetting{
+					{ID: 1, UserID: 1, ChatID: 12345, IsEnabled: true, User: models.User{ID: 1, GithubUserID: 111, GithubUsername: "user_1"}},
+					{ID: 2, UserID: 2, ChatID: 67890, IsEnabled: true, User: models.User{ID: 2, GithubUserID: 222, GithubUsername: "user2"}},
+				}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				savedLogs = append(savedLogs, log)
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				return []models.CommitStats{{CommitCount: 4}}, nil
+			},
+		},
+		telegramGateway: &mockTelegramGateway{
+			SendMessageFunc: func(ctx context.Context, chatID string, message *gateway.TelegramMessage) error {
+				if chatID == "67890" {
+					return &gateway.HTTPStatusError{Target: "telegram api", StatusCode: 429}
+				}
+				sentChatID = chatID
+				sentMessage = message
+				return nil
+			},
+		},
+	}
 
-func (d *testDeps) GetNotificationLogRepo() repository.INotificationLogRepository {
-	return d.notificationLogRepo
-}
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
 
-func (d *testDeps) GetRivalRepo() repository.IRivalRepository {
-	return d.rivalRepo
-}
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	assert.Equal(t, 1, report.FailureCount)
 
-func (d *testDeps) GetCommitStatsRepo() repository.ICommitStatsRepository {
-	return d.commitStatsRepo
-}
+	assert.Equal(t, "12345", sentChatID)
+	if assert.NotNil(t, sentMessage) {
+		assert.Equal(t, gateway.TelegramParseModeMarkdownV2, sentMessage.ParseMode)
+		assert.Contains(t, sentMessage.Text, `user\_1`)
+	}
 
-func (d *testDeps) GetSlackGateway() gateway.ISlackGateway {
-	return d.slackGateway
+	// Slackと同じく通知ログに記録され、429はリトライ対象になる
+	assert.Len(t, savedLogs, 2)
+	for _, l := range savedLogs {
+		assert.Equal(t, models.ChannelTypeTelegram, l.ChannelType)
+		assert.Equal(t, "weekly", l.Period)
+	}
+	assert.Equal(t, models.NotificationStatusSuccess, savedLogs[0].Status)
+	assert.Equal(t, "MarkdownV2", savedLogs[0].Payload["parse_mode"])
+	assert.Equal(t, models.NotificationStatusFailed, savedLogs[1].Status)
+	assert.Equal(t, string(notifier.FailureKindRetryable), savedLogs[1].FailureKind)
+	assert.NotNil(t, savedLogs[1].NextRetryAt)
 }
 
-func TestCalculateWeeklyRange(t *testing.T) {
-	dateRange := calculateWeeklyRange()
-
-	assert.True(t, dateRange.Start.Before(dateRange.End))
-	assert.Equal(t, 6, int(dateRange.End.Sub(dateRange.Start).Hours()/24)) // 7日間の差
-}
+func TestRunSendNotifications_WebPush(t *testing.T) {
+	ctx := context.Background()
+	var sentEndpoints []string
+	var sentMessage *gateway.WebPushMessage
+	var deletedIDs []uint64
+	var savedLogs []*models.NotificationLog
+	var inbox []*models.InAppNotification
+
+	user1 := models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}
+	user2 := models.User{ID: 2, GithubUserID: 222, GithubUsername: "user2"}
+	subscriptions := map[uint64][]models.WebPushSubscription{
+		1: {
+			{ID: 1, UserID: 1, Endpoint: "https://push.example.com/laptop", User: user1},
+			{ID: 2, UserID: 1, Endpoint: "https://push.example.com/expired", User: user1},
+		},
+		2: {
+			{ID: 3, UserID: 2, Endpoint: "https://push.example.com/gone", User: user2},
+		},
+	}
 
-func TestCalculateMonthlyRange(t *testing.T) {
-	current, previous := calculateMonthlyRange()
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{},
+		webPushSubscriptionRepo: &mockWebPushSubscriptionRepository{
+			FindAllFunc: func(ctx context.Context) ([]models.WebPushSubscription, error) {
+				return append(append([]models.WebPushSubscription{}, subscriptions[1]...), subscriptions[2]...), nil
+			},
+			FindByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error) {
+				return subscriptions[userID], nil
+			},
+			DeleteByIDFunc: func(ctx context.Context, id uint64) error {
+				deletedIDs = append(deletedIDs, id)
+				return nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				savedLogs = append(savedLogs, log)
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				return []models.CommitStats{{CommitCount: 4}}, nil
+			},
+		},
+		webPushGateway: &mockWebPushGateway{
+			SendFunc: func(ctx context.Context, subscription gateway.WebPushTarget, message *gateway.WebPushMessage) error {
+				if subscription.Endpoint != "https://push.example.com/laptop" {
+					return &gateway.HTTPStatusError{Target: "web push", StatusCode: 410}
+				}
+				sentEndpoints = append(sentEndpoints, subscription.Endpoint)
+				sentMessage = message
+				return nil
+			},
+		},
+		inAppNotificationRepo: &mockInAppNotificationRepository{
+			CreateIfNotExistsFunc: func(ctx context.Context, notification *models.InAppNotification) (bool, error) {
+				inbox = append(inbox, notification)
+				return true, nil
+			},
+		},
+	}
 
-	// 今月の範囲は先月を指す
-	assert.True(t, current.Start.Before(current.End) || current.Start.Equal(current.End))
-	// 先月の範囲は先々月を指す
-	assert.True(t, previous.Start.Before(previous.End) || previous.Start.Equal(previous.End))
-	// 先月は先々月より後
-	assert.True(t, current.Start.After(previous.End))
-}
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
 
-func TestSumCommits(t *testing.T) {
-	stats := []models.CommitStats{
-		{CommitCount: 5},
-		{CommitCount: 3},
-		{CommitCount: 2},
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	assert.Equal(t, 1, report.FailureCount)
+
+	// ユーザーごとに1回だけ送り、410を返した購読は削除する
+	assert.Equal(t, []string{"https://push.example.com/laptop"}, sentEndpoints)
+	assert.ElementsMatch(t, []uint64{2, 3}, deletedIDs)
+	if assert.NotNil(t, sentMessage) {
+		assert.Equal(t, "/dashboard", sentMessage.URL)
+		assert.Contains(t, sentMessage.Body, "user1")
 	}
 
-	total := sumCommits(stats)
-
-	assert.Equal(t, 10, total)
+	// すべての購読が失効したユーザーは恒久的な失敗になる
+	assert.Len(t, savedLogs, 2)
+	for _, l := range savedLogs {
+		assert.Equal(t, models.ChannelTypeWebPush, l.ChannelType)
+	}
+	assert.Equal(t, models.NotificationStatusSuccess, savedLogs[0].Status)
+	assert.Equal(t, sentMessage.Title, savedLogs[0].Payload["title"])
+	assert.Equal(t, models.NotificationStatusDeadLetter, savedLogs[1].Status)
+	assert.Equal(t, string(notifier.FailureKindPermanent), savedLogs[1].FailureKind)
+	assert.Nil(t, savedLogs[1].NextRetryAt)
+
+	// 受信箱には届いたユーザーの分だけ載せる
+	if assert.Len(t, inbox, 1) {
+		assert.Equal(t, uint64(1), inbox[0].UserID)
+		assert.Equal(t, models.InAppNotificationReportDelivered, inbox[0].Type)
+		assert.Equal(t, "weekly", inbox[0].Payload["period"])
+		assert.Equal(t, []models.ChannelType{models.ChannelTypeWebPush}, inbox[0].Payload["channels"])
+		if assert.NotNil(t, inbox[0].DedupeKey) {
+			assert.Equal(t, "report_delivered:weekly:"+inbox[0].Payload["period_start"].(string), *inbox[0].DedupeKey)
+		}
+	}
 }
 
-func TestSumCommits_Empty(t *testing.T) {
-	stats := []models.CommitStats{}
+func TestRunSendNotifications_DiscordRepositoryError(t *testing.T) {
+	ctx := context.Background()
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{},
+		discordNotificationRepo: &mockDiscordNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.DiscordNotificationSetting, error) {
+				return nil, errors.New("database error")
+			},
+		},
+	}
 
-	total := sumCommits(stats)
+	_, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
 
-	assert.Equal(t, 0, total)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Discord")
 }
 
-func TestRunSendNotifications_InvalidPeriod(t *testing.T) {
+func TestRunSendNotifications_Line(t *testing.T) {
 	ctx := context.Background()
+	var pushedTo string
+	var pushedMessages []gateway.LineMessage
+	var savedLogs []*models.NotificationLog
 
 	deps := &testDeps{
 		slackNotificationRepo: &mockSlackNotificationSettingRepository{},
-		notificationLogRepo:   &mockNotificationLogRepository{},
-		rivalRepo:             &mockRivalRepository{},
-		commitStatsRepo:       &mockCommitStatsRepository{},
-		slackGateway:          &mockSlackGateway{},
+		lineNotificationRepo: &mockLineNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.LineNotificationSetting, error) {
+				return []models.LineNotificationSetting{
+					{ID: 1, UserID: 1, LineUserID: "U1234567890", IsEnabled: true, User: models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}},
+				}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				savedLogs = append(savedLogs, log)
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				return []models.CommitStats{{CommitCount: 3}}, nil
+			},
+		},
+		lineGateway: &mockLineGateway{
+			PushMessageFunc: func(ctx context.Context, to string, messages []gateway.LineMessage) error {
+				pushedTo = to
+				pushedMessages = messages
+				return nil
+			},
+		},
 	}
 
-	config := SendNotificationsConfig{Period: "daily"} // invalid
-	err := RunSendNotifications(ctx, deps, config)
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "invalid period")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	assert.Equal(t, "U1234567890", pushedTo)
+	assert.Len(t, pushedMessages, 1)
+	assert.Equal(t, "flex", pushedMessages[0].Type)
+	assert.Contains(t, pushedMessages[0].AltText, "週次レポート")
+
+	assert.Len(t, savedLogs, 1)
+	assert.Equal(t, models.ChannelTypeLINE, savedLogs[0].ChannelType)
+	assert.Equal(t, models.NotificationStatusSuccess, savedLogs[0].Status)
+	assert.Contains(t, savedLogs[0].Payload, "altText")
 }
 
-func TestRunSendNotifications_NoEnabledSettings(t *testing.T) {
+func TestRunSendNotifications_AllChannelsOfUser(t *testing.T) {
 	ctx := context.Background()
+	user := models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}
+	statsCalls := 0
+	var delivered []models.ChannelType
 
 	deps := &testDeps{
 		slackNotificationRepo: &mockSlackNotificationSettingRepository{
 			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
-				return []models.SlackNotificationSetting{}, nil
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: user}}, nil
+			},
+		},
+		discordNotificationRepo: &mockDiscordNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.DiscordNotificationSetting, error) {
+				return []models.DiscordNotificationSetting{{UserID: 1, WebhookURL: "https://discord.com/api/webhooks/1/abc", User: user}}, nil
+			},
+		},
+		lineNotificationRepo: &mockLineNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.LineNotificationSetting, error) {
+				return []models.LineNotificationSetting{{UserID: 1, LineUserID: "U111", User: user}}, nil
 			},
 		},
 		notificationLogRepo: &mockNotificationLogRepository{},
 		rivalRepo:           &mockRivalRepository{},
-		commitStatsRepo:     &mockCommitStatsRepository{},
-		slackGateway:        &mockSlackGateway{},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+				statsCalls++
+				return []models.CommitStats{{CommitCount: 2}}, nil
+			},
+		},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				delivered = append(delivered, models.ChannelTypeSlack)
+				return nil
+			},
+		},
+		discordGateway: &mockDiscordGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.DiscordMessage) error {
+				delivered = append(delivered, models.ChannelTypeDiscord)
+				return nil
+			},
+		},
+		lineGateway: &mockLineGateway{
+			PushMessageFunc: func(ctx context.Context, to string, messages []gateway.LineMessage) error {
+				delivered = append(delivered, models.ChannelTypeLINE)
+				return nil
+			},
+		},
 	}
 
-	config := SendNotificationsConfig{Period: "weekly"}
-	err := RunSendNotifications(ctx, deps, config)
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
 
 	assert.NoError(t, err)
+	assert.Equal(t, 3, report.SuccessCount)
+	assert.Equal(t, []models.ChannelType{models.ChannelTypeSlack, models.ChannelTypeDiscord, models.ChannelTypeLINE}, delivered)
+	// レポートはユーザーごとに1回だけ集計される
+	assert.Equal(t, 1, statsCalls)
 }
 
-func TestRunSendNotifications_RepositoryError(t *testing.T) {
+func TestRunSendNotifications_ReportLoadFailureFailsAllChannels(t *testing.T) {
 	ctx := context.Background()
+	user := models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}
+	var savedLogs []*models.NotificationLog
 
 	deps := &testDeps{
 		slackNotificationRepo: &mockSlackNotificationSettingRepository{
 			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: user}}, nil
+			},
+		},
+		discordNotificationRepo: &mockDiscordNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.DiscordNotificationSetting, error) {
+				return []models.DiscordNotificationSetting{{UserID: 1, WebhookURL: "https://discord.com/api/webhooks/1/abc", User: user}}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				savedLogs = append(savedLogs, log)
+				return nil
+			},
+		},
+		rivalRepo: &mockRivalRepository{},
+		commitStatsRepo: &mockCommitStatsRepository{
+			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
 				return nil, errors.New("database error")
 			},
 		},
-		notificationLogRepo: &mockNotificationLogRepository{},
-		rivalRepo:           &mockRivalRepository{},
-		commitStatsRepo:     &mockCommitStatsRepository{},
-		slackGateway:        &mockSlackGateway{},
 	}
 
-	config := SendNotificationsConfig{Period: "weekly"}
-	err := RunSendNotifications(ctx, deps, config)
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
 
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "failed to get enabled Slack notification settings")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.SuccessCount)
+	assert.Equal(t, 2, report.FailureCount)
+	assert.Len(t, savedLogs, 2)
+	for _, l := range savedLogs {
+		assert.Equal(t, models.NotificationStatusFailed, l.Status)
+		assert.Contains(t, l.ErrorMessage, "failed to get user commit stats")
+	}
 }
 
-func TestRunSendNotifications_WeeklySuccess(t *testing.T) {
+func TestRunSendNotifications_RerunSendsOnlyUndelivered(t *testing.T) {
 	ctx := context.Background()
-	slackCalled := false
-	logSaved := false
+	user := models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}
+	var delivered []models.ChannelType
+	var created, updated []*models.NotificationLog
 
 	deps := &testDeps{
 		slackNotificationRepo: &mockSlackNotificationSettingRepository{
 			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
-				return []models.SlackNotificationSetting{
-					{
-						ID:         1,
-						UserID:     1,
-						WebhookURL: "https://hooks.slack.com/test",
-						IsEnabled:  true,
-						User: models.User{
-							ID:             1,
-							GithubUserID:   12345,
-							GithubUsername: "testuser",
-						},
-					},
-				}, nil
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: user}}, nil
+			},
+		},
+		discordNotificationRepo: &mockDiscordNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.DiscordNotificationSetting, error) {
+				return []models.DiscordNotificationSetting{{UserID: 1, WebhookURL: "https://discord.com/api/webhooks/1/abc", User: user}}, nil
+			},
+		},
+		lineNotificationRepo: &mockLineNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.LineNotificationSetting, error) {
+				return []models.LineNotificationSetting{{UserID: 1, LineUserID: "U111", User: user}}, nil
 			},
 		},
 		notificationLogRepo: &mockNotificationLogRepository{
+			FindByPeriodStartFunc: func(ctx context.Context, period string, periodStart time.Time) ([]models.NotificationLog, error) {
+				assert.Equal(t, "weekly", period)
+				assert.Equal(t, notifier.PeriodStart("weekly", time.Now()), periodStart)
+				// Slackは送信済み、Discordは前回失敗、LINEは未送信
+				return []models.NotificationLog{
+					{ID: 10, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "weekly", Status: models.NotificationStatusSuccess, Attempts: 1},
+					{ID: 11, UserID: 1, ChannelType: models.ChannelTypeDiscord, Period: "weekly", Status: models.NotificationStatusFailed, Attempts: 1, ErrorMessage: "timeout"},
+				}, nil
+			},
 			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
-				logSaved = true
-				assert.Equal(t, models.NotificationStatusSuccess, log.Status)
-				assert.Equal(t, "weekly", log.Period)
-				assert.NotNil(t, log.Payload)
-				assert.Contains(t, log.Payload, "blocks")
+				created = append(created, log)
 				return nil
 			},
-		},
-		rivalRepo: &mockRivalRepository{
-			FindByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.Rival, error) {
-				return []models.Rival{
-					{
-						RivalGithubUserID:   67890,
-						RivalGithubUsername: "rival1",
-					},
-				}, nil
+			UpdateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				updated = append(updated, log)
+				return nil
 			},
 		},
+		rivalRepo: &mockRivalRepository{},
 		commitStatsRepo: &mockCommitStatsRepository{
 			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
-				if githubUserID == 12345 {
-					return []models.CommitStats{{CommitCount: 10}}, nil
-				}
-				return []models.CommitStats{{CommitCount: 5}}, nil
+				return []models.CommitStats{{CommitCount: 2}}, nil
 			},
 		},
 		slackGateway: &mockSlackGateway{
 			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
-				slackCalled = true
-				assert.Equal(t, "https://hooks.slack.com/test", webhookURL)
+				delivered = append(delivered, models.ChannelTypeSlack)
+				return nil
+			},
+		},
+		discordGateway: &mockDiscordGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.DiscordMessage) error {
+				delivered = append(delivered, models.ChannelTypeDiscord)
+				return nil
+			},
+		},
+		lineGateway: &mockLineGateway{
+			PushMessageFunc: func(ctx context.Context, to string, messages []gateway.LineMessage) error {
+				delivered = append(delivered, models.ChannelTypeLINE)
 				return nil
 			},
 		},
 	}
 
-	config := SendNotificationsConfig{Period: "weekly"}
-	err := RunSendNotifications(ctx, deps, config)
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
 
 	assert.NoError(t, err)
-	assert.True(t, slackCalled)
-	assert.True(t, logSaved)
+	assert.Equal(t, []models.ChannelType{models.ChannelTypeDiscord, models.ChannelTypeLINE}, delivered)
+	assert.Equal(t, 2, report.SuccessCount)
+	assert.Equal(t, 1, report.SkipCount)
+
+	// 失敗していたログは作り直さずに更新する
+	assert.Len(t, updated, 1)
+	assert.Equal(t, uint64(11), updated[0].ID)
+	assert.Equal(t, models.NotificationStatusSuccess, updated[0].Status)
+	assert.Equal(t, 2, updated[0].Attempts)
+	assert.Empty(t, updated[0].ErrorMessage)
+
+	// 未送信のチャンネルは冪等キー付きでログを作る
+	assert.Len(t, created, 1)
+	assert.Equal(t, models.ChannelTypeLINE, created[0].ChannelType)
+	assert.Equal(t, 1, created[0].Attempts)
+	if assert.NotNil(t, created[0].PeriodStart) {
+		assert.Equal(t, notifier.PeriodStart("weekly", time.Now()), *created[0].PeriodStart)
+	}
 }
 
-func TestRunSendNotifications_MonthlySuccess(t *testing.T) {
+func TestRunSendNotifications_LeavesScheduledRetriesToRetryBatch(t *testing.T) {
 	ctx := context.Background()
-	slackCalled := false
-	logSaved := false
+	user := models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}
+	nextRetryAt := time.Now().Add(15 * time.Minute)
+	sent := false
+	saved := false
 
 	deps := &testDeps{
 		slackNotificationRepo: &mockSlackNotificationSettingRepository{
 			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
-				return []models.SlackNotificationSetting{
-					{
-						ID:         1,
-						UserID:     1,
-						WebhookURL: "https://hooks.slack.com/test",
-						IsEnabled:  true,
-						User: models.User{
-							ID:             1,
-							GithubUserID:   12345,
-							GithubUsername: "testuser",
-						},
-					},
-				}, nil
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: user}}, nil
 			},
 		},
 		notificationLogRepo: &mockNotificationLogRepository{
+			FindByPeriodStartFunc: func(ctx context.Context, period string, periodStart time.Time) ([]models.NotificationLog, error) {
+				// 前回失敗し、再送バッチが送る予定のログ
+				return []models.NotificationLog{
+					{ID: 10, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "weekly", Status: models.NotificationStatusFailed, Attempts: 1, NextRetryAt: &nextRetryAt},
+				}, nil
+			},
 			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
-				logSaved = true
-				assert.Equal(t, models.NotificationStatusSuccess, log.Status)
-				assert.Equal(t, "monthly", log.Period)
-				assert.NotNil(t, log.Payload)
-				assert.Contains(t, log.Payload, "blocks")
+				saved = true
+				return nil
+			},
+			UpdateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				saved = true
 				return nil
 			},
 		},
-		rivalRepo: &mockRivalRepository{
-			FindByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.Rival, error) {
-				return []models.Rival{
-					{
-						RivalGithubUserID:   67890,
-						RivalGithubUsername: "rival1",
-					},
+		slackGateway: &mockSlackGateway{
+			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+				sent = true
+				return nil
+			},
+		},
+	}
+
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
+
+	// 再送バッチと同時に動いても、同じレポートを二重に送らない
+	assert.NoError(t, err)
+	assert.False(t, sent)
+	assert.False(t, saved)
+	assert.Equal(t, 1, report.SkipCount)
+}
+
+func TestRunSendNotifications_AllDeliveredSkipsReportLoad(t *testing.T) {
+	ctx := context.Background()
+	user := models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}
+
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: user}}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			FindByPeriodStartFunc: func(ctx context.Context, period string, periodStart time.Time) ([]models.NotificationLog, error) {
+				return []models.NotificationLog{
+					{UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "monthly", Status: models.NotificationStatusSuccess},
 				}, nil
 			},
+			CreateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+				t.Fatal("notification log should not be created")
+				return nil
+			},
 		},
+		rivalRepo: &mockRivalRepository{},
 		commitStatsRepo: &mockCommitStatsRepository{
 			FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
-				if githubUserID == 12345 {
-					return []models.CommitStats{{CommitCount: 20}}, nil
-				}
-				return []models.CommitStats{{CommitCount: 15}}, nil
+				t.Fatal("report should not be loaded")
+				return nil, nil
+			},
+		},
+	}
+
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "monthly"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, report.SuccessCount)
+	assert.Equal(t, 1, report.SkipCount)
+	assert.Equal(t, models.BatchRunStatusSuccess, report.Status())
+}
+
+func TestRunSendNotifications_LogLookupErrorDoesNotSend(t *testing.T) {
+	ctx := context.Background()
+	user := models.User{ID: 1, GithubUserID: 111, GithubUsername: "user1"}
+	deps := &testDeps{
+		slackNotificationRepo: &mockSlackNotificationSettingRepository{
+			FindAllEnabledFunc: func(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+				return []models.SlackNotificationSetting{{UserID: 1, WebhookURL: "https://hooks.slack.com/one", User: user}}, nil
+			},
+		},
+		notificationLogRepo: &mockNotificationLogRepository{
+			FindByPeriodStartFunc: func(ctx context.Context, period string, periodStart time.Time) ([]models.NotificationLog, error) {
+				return nil, errors.New("database error")
 			},
 		},
 		slackGateway: &mockSlackGateway{
 			SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
-				slackCalled = true
-				assert.Equal(t, "https://hooks.slack.com/test", webhookURL)
+				t.Fatal("report should not be sent")
 				return nil
 			},
 		},
 	}
 
-	config := SendNotificationsConfig{Period: "monthly"}
-	err := RunSendNotifications(ctx, deps, config)
+	report, err := RunSendNotifications(ctx, deps, SendNotificationsConfig{Period: "weekly"})
 
 	assert.NoError(t, err)
-	assert.True(t, slackCalled)
-	assert.True(t, logSaved)
+	assert.Equal(t, 1, report.FailureCount)
+	assert.Contains(t, report.ErrorSamples[0], "failed to get notification logs")
 }
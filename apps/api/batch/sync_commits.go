@@ -3,7 +3,9 @@ package batch
 import (
 	"context"
 	"fmt"
+	"io"
 	"log"
+	"strconv"
 	"time"
 
 	"github.com/keeee21/commitly/api/usecase"
@@ -11,8 +13,16 @@ import (
 
 // SyncCommitsConfig sync-commitsバッチの設定
 type SyncCommitsConfig struct {
-	FromDate string
-	ToDate   string
+	FromDate     string
+	ToDate       string
+	Reconcile    bool      // 期間内の統計を最新データで置き換える
+	DryRun       bool      // DBに書き込まず、書き込む予定の内容を出力する
+	DryRunOutput io.Writer // ドライラン時の出力先（nilの場合は標準出力）
+}
+
+// LockName 多重実行を防ぐロック名
+func (c SyncCommitsConfig) LockName() string {
+	return "sync-commits"
 }
 
 // ParseDateRange 日付範囲をパースする
@@ -48,20 +58,37 @@ func ValidateDateRange(fromDate, toDate *time.Time) error {
 	return nil
 }
 
+// Args batch_runs に記録する引数
+func (c SyncCommitsConfig) Args() map[string]string {
+	return map[string]string{
+		"from":      c.FromDate,
+		"to":        c.ToDate,
+		"reconcile": strconv.FormatBool(c.Reconcile),
+		"dry_run":   strconv.FormatBool(c.DryRun),
+	}
+}
+
 // RunSyncCommits sync-commitsバッチを実行する
-func RunSyncCommits(ctx context.Context, syncUsecase usecase.ISyncCommitsUsecase, config SyncCommitsConfig) error {
+func RunSyncCommits(ctx context.Context, syncUsecase usecase.ISyncCommitsUsecase, config SyncCommitsConfig) (*RunReport, error) {
 	log.Println("Starting sync-commits batch...")
-	startTime := time.Now()
+	report := NewRunReport()
 
 	// Parse date options
 	fromDate, toDate, err := ParseDateRange(config.FromDate, config.ToDate)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Validate date range
 	if err := ValidateDateRange(fromDate, toDate); err != nil {
-		return err
+		return nil, err
+	}
+
+	if config.Reconcile {
+		log.Printf("Reconcile mode enabled")
+	}
+	if config.DryRun {
+		log.Println("Dry-run mode: commit stats will be printed instead of saved")
 	}
 
 	if fromDate != nil {
@@ -72,12 +99,27 @@ func RunSyncCommits(ctx context.Context, syncUsecase usecase.ISyncCommitsUsecase
 	}
 
 	// Run sync
-	if err := syncUsecase.SyncAllUsersWithDateRange(ctx, fromDate, toDate); err != nil {
-		return fmt.Errorf("failed to sync commits: %w", err)
+	result, err := syncUsecase.SyncAllUsersWithOptions(ctx, usecase.SyncOptions{
+		FromDate:  fromDate,
+		ToDate:    toDate,
+		Reconcile: config.Reconcile,
+		DryRun:    config.DryRun,
+		Output:    config.DryRunOutput,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sync commits: %w", err)
 	}
 
-	elapsed := time.Since(startTime)
-	log.Printf("sync-commits batch completed in %s", elapsed)
+	report.SuccessCount = result.Succeeded
+	report.SkipCount = result.Skipped
+	for _, failure := range result.Failures {
+		report.AddFailure(failure.GithubUsername, failure.Err)
+	}
+	report.Finish()
 
-	return nil
+	elapsed := report.FinishedAt.Sub(report.StartedAt)
+	log.Printf("sync-commits batch completed in %s (success: %d, failed: %d, skipped: %d)",
+		elapsed, report.SuccessCount, report.FailureCount, report.SkipCount)
+
+	return report, nil
 }
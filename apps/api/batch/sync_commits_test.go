@@ -1,11 +1,13 @@
 package batch
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"testing"
 	"time"
 
+	"github.com/keeee21/commitly/api/usecase"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -128,7 +130,8 @@ func TestValidateDateRange_NilToDate(t *testing.T) {
 // mockSyncCommitsUsecase テスト用のモック
 type mockSyncCommitsUsecase struct {
 	SyncAllUsersFunc              func(ctx context.Context) error
-	SyncAllUsersWithDateRangeFunc func(ctx context.Context, fromDate, toDate *time.Time) error
+	SyncAllUsersWithDateRangeFunc func(ctx context.Context, fromDate, toDate *time.Time) (*usecase.SyncResult, error)
+	SyncAllUsersWithOptionsFunc   func(ctx context.Context, opts usecase.SyncOptions) (*usecase.SyncResult, error)
 	SyncUserFunc                  func(ctx context.Context, githubUserID uint64, githubUsername string, fromDate, toDate *time.Time) error
 }
 
@@ -139,11 +142,18 @@ func (m *mockSyncCommitsUsecase) SyncAllUsers(ctx context.Context) error {
 	return nil
 }
 
-func (m *mockSyncCommitsUsecase) SyncAllUsersWithDateRange(ctx context.Context, fromDate, toDate *time.Time) error {
+func (m *mockSyncCommitsUsecase) SyncAllUsersWithDateRange(ctx context.Context, fromDate, toDate *time.Time) (*usecase.SyncResult, error) {
 	if m.SyncAllUsersWithDateRangeFunc != nil {
 		return m.SyncAllUsersWithDateRangeFunc(ctx, fromDate, toDate)
 	}
-	return nil
+	return &usecase.SyncResult{}, nil
+}
+
+func (m *mockSyncCommitsUsecase) SyncAllUsersWithOptions(ctx context.Context, opts usecase.SyncOptions) (*usecase.SyncResult, error) {
+	if m.SyncAllUsersWithOptionsFunc != nil {
+		return m.SyncAllUsersWithOptionsFunc(ctx, opts)
+	}
+	return &usecase.SyncResult{}, nil
 }
 
 func (m *mockSyncCommitsUsecase) SyncUser(ctx context.Context, githubUserID uint64, githubUsername string, fromDate, toDate *time.Time) error {
@@ -158,9 +168,9 @@ func TestRunSyncCommits_Success(t *testing.T) {
 	called := false
 
 	mockUsecase := &mockSyncCommitsUsecase{
-		SyncAllUsersWithDateRangeFunc: func(ctx context.Context, fromDate, toDate *time.Time) error {
+		SyncAllUsersWithOptionsFunc: func(ctx context.Context, opts usecase.SyncOptions) (*usecase.SyncResult, error) {
 			called = true
-			return nil
+			return &usecase.SyncResult{}, nil
 		},
 	}
 
@@ -169,7 +179,7 @@ func TestRunSyncCommits_Success(t *testing.T) {
 		ToDate:   "2025-01-31",
 	}
 
-	err := RunSyncCommits(ctx, mockUsecase, config)
+	_, err := RunSyncCommits(ctx, mockUsecase, config)
 
 	assert.NoError(t, err)
 	assert.True(t, called)
@@ -180,16 +190,16 @@ func TestRunSyncCommits_WithoutDates(t *testing.T) {
 	var capturedFrom, capturedTo *time.Time
 
 	mockUsecase := &mockSyncCommitsUsecase{
-		SyncAllUsersWithDateRangeFunc: func(ctx context.Context, fromDate, toDate *time.Time) error {
-			capturedFrom = fromDate
-			capturedTo = toDate
-			return nil
+		SyncAllUsersWithOptionsFunc: func(ctx context.Context, opts usecase.SyncOptions) (*usecase.SyncResult, error) {
+			capturedFrom = opts.FromDate
+			capturedTo = opts.ToDate
+			return &usecase.SyncResult{}, nil
 		},
 	}
 
 	config := SyncCommitsConfig{}
 
-	err := RunSyncCommits(ctx, mockUsecase, config)
+	_, err := RunSyncCommits(ctx, mockUsecase, config)
 
 	assert.NoError(t, err)
 	assert.Nil(t, capturedFrom)
@@ -205,7 +215,7 @@ func TestRunSyncCommits_InvalidFromDate(t *testing.T) {
 		FromDate: "invalid",
 	}
 
-	err := RunSyncCommits(ctx, mockUsecase, config)
+	_, err := RunSyncCommits(ctx, mockUsecase, config)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid from date format")
@@ -220,7 +230,7 @@ func TestRunSyncCommits_InvalidToDate(t *testing.T) {
 		ToDate: "invalid",
 	}
 
-	err := RunSyncCommits(ctx, mockUsecase, config)
+	_, err := RunSyncCommits(ctx, mockUsecase, config)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid to date format")
@@ -236,7 +246,7 @@ func TestRunSyncCommits_FromAfterTo(t *testing.T) {
 		ToDate:   "2025-01-01",
 	}
 
-	err := RunSyncCommits(ctx, mockUsecase, config)
+	_, err := RunSyncCommits(ctx, mockUsecase, config)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "from date must be before to date")
@@ -246,14 +256,14 @@ func TestRunSyncCommits_UsecaseError(t *testing.T) {
 	ctx := context.Background()
 
 	mockUsecase := &mockSyncCommitsUsecase{
-		SyncAllUsersWithDateRangeFunc: func(ctx context.Context, fromDate, toDate *time.Time) error {
-			return errors.New("sync failed")
+		SyncAllUsersWithOptionsFunc: func(ctx context.Context, opts usecase.SyncOptions) (*usecase.SyncResult, error) {
+			return nil, errors.New("sync failed")
 		},
 	}
 
 	config := SyncCommitsConfig{}
 
-	err := RunSyncCommits(ctx, mockUsecase, config)
+	_, err := RunSyncCommits(ctx, mockUsecase, config)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to sync commits")
@@ -264,10 +274,10 @@ func TestRunSyncCommits_PassesDatesToUsecase(t *testing.T) {
 	var capturedFrom, capturedTo *time.Time
 
 	mockUsecase := &mockSyncCommitsUsecase{
-		SyncAllUsersWithDateRangeFunc: func(ctx context.Context, fromDate, toDate *time.Time) error {
-			capturedFrom = fromDate
-			capturedTo = toDate
-			return nil
+		SyncAllUsersWithOptionsFunc: func(ctx context.Context, opts usecase.SyncOptions) (*usecase.SyncResult, error) {
+			capturedFrom = opts.FromDate
+			capturedTo = opts.ToDate
+			return &usecase.SyncResult{}, nil
 		},
 	}
 
@@ -276,7 +286,7 @@ func TestRunSyncCommits_PassesDatesToUsecase(t *testing.T) {
 		ToDate:   "2025-06-30",
 	}
 
-	err := RunSyncCommits(ctx, mockUsecase, config)
+	_, err := RunSyncCommits(ctx, mockUsecase, config)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, capturedFrom)
@@ -288,3 +298,76 @@ func TestRunSyncCommits_PassesDatesToUsecase(t *testing.T) {
 	assert.Equal(t, time.June, capturedTo.Month())
 	assert.Equal(t, 30, capturedTo.Day())
 }
+
+func TestRunSyncCommits_ReportsPerTargetResults(t *testing.T) {
+	ctx := context.Background()
+
+	mockUsecase := &mockSyncCommitsUsecase{
+		SyncAllUsersWithOptionsFunc: func(ctx context.Context, opts usecase.SyncOptions) (*usecase.SyncResult, error) {
+			return &usecase.SyncResult{
+				Succeeded: 3,
+				Skipped:   1,
+				Failures: []usecase.SyncFailure{
+					{GithubUserID: 100, GithubUsername: "user1", Err: errors.New("rate limited")},
+				},
+			}, nil
+		},
+	}
+
+	report, err := RunSyncCommits(ctx, mockUsecase, SyncCommitsConfig{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.SuccessCount)
+	assert.Equal(t, 1, report.FailureCount)
+	assert.Equal(t, 1, report.SkipCount)
+	assert.Equal(t, []string{"user1: rate limited"}, report.ErrorSamples)
+	assert.Equal(t, ExitCodePartialFailure, report.ExitCode())
+}
+
+func TestRunSyncCommits_PassesReconcileOptions(t *testing.T) {
+	ctx := context.Background()
+	var captured usecase.SyncOptions
+
+	mockUsecase := &mockSyncCommitsUsecase{
+		SyncAllUsersWithOptionsFunc: func(ctx context.Context, opts usecase.SyncOptions) (*usecase.SyncResult, error) {
+			captured = opts
+			return &usecase.SyncResult{}, nil
+		},
+	}
+
+	config := SyncCommitsConfig{
+		FromDate:  "2025-06-01",
+		ToDate:    "2025-06-30",
+		Reconcile: true,
+		DryRun:    true,
+	}
+
+	_, err := RunSyncCommits(ctx, mockUsecase, config)
+
+	assert.NoError(t, err)
+	assert.True(t, captured.Reconcile)
+	assert.True(t, captured.DryRun)
+}
+
+func TestRunSyncCommits_DryRunWithoutReconcile(t *testing.T) {
+	ctx := context.Background()
+	var captured usecase.SyncOptions
+	var buf bytes.Buffer
+
+	mockUsecase := &mockSyncCommitsUsecase{
+		SyncAllUsersWithOptionsFunc: func(ctx context.Context, opts usecase.SyncOptions) (*usecase.SyncResult, error) {
+			captured = opts
+			return &usecase.SyncResult{Succeeded: 1}, nil
+		},
+	}
+
+	config := SyncCommitsConfig{DryRun: true, DryRunOutput: &buf}
+
+	report, err := RunSyncCommits(ctx, mockUsecase, config)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.SuccessCount)
+	assert.False(t, captured.Reconcile)
+	assert.True(t, captured.DryRun)
+	assert.Equal(t, &buf, captured.Output)
+}
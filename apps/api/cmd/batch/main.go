@@ -2,7 +2,9 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
+	"io"
 	"log"
 	"os"
 
@@ -10,20 +12,29 @@ import (
 	"github.com/keeee21/commitly/api/batch"
 	"github.com/keeee21/commitly/api/db"
 	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/notifier"
 	"github.com/keeee21/commitly/api/repository"
 	"github.com/keeee21/commitly/api/usecase"
 )
 
 func main() {
 	// Parse command line flags
-	command := flag.String("command", "", "batch command to run (sync-commits, send-notifications)")
+	command := flag.String("command", "", "batch command to run (sync-commits, send-notifications, retry-failed-notifications, send-rival-alerts, send-daily-nudges)")
 	fromDate := flag.String("from", "", "start date for sync (YYYY-MM-DD)")
 	toDate := flag.String("to", "", "end date for sync (YYYY-MM-DD)")
 	period := flag.String("period", "weekly", "notification period (weekly, monthly)")
+	dueOnly := flag.Bool("due-only", false, "send only to users whose scheduled delivery time has just passed in their timezone (send-notifications)")
+	reconcile := flag.Bool("reconcile", false, "replace commit stats in the date range with fresh data and prune stale rows (sync-commits)")
+	dryRun := flag.Bool("dry-run", false, "print what would be written or sent without touching the database or external services")
+	retryLimit := flag.Int("limit", batch.DefaultRetryLimit, "maximum number of notifications to retry in one run (retry-failed-notifications)")
+	alertLimit := flag.Int("alert-limit", batch.DefaultRivalAlertLimit, "maximum number of rival alerts per user within -alert-window (send-rival-alerts)")
+	alertWindow := flag.Duration("alert-window", batch.DefaultRivalAlertWindow, "window for counting rival alerts per user (send-rival-alerts)")
+	wait := flag.Bool("wait", false, "wait for another running instance of the same command to finish instead of exiting")
+	dryRunOutput := flag.String("dry-run-output", "", "file to write dry-run output to (default: stdout, JSONL for send-notifications)")
 	flag.Parse()
 
 	if *command == "" {
-		log.Fatal("command flag is required. Available commands: sync-commits, send-notifications")
+		log.Fatal("command flag is required. Available commands: sync-commits, send-notifications, retry-failed-notifications, send-rival-alerts, send-daily-nudges")
 	}
 
 	// Load .env file
@@ -37,7 +48,24 @@ func main() {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
 
+	// ドライランの出力先を準備
+	var dryRunWriter io.Writer = os.Stdout
+	if *dryRun && *dryRunOutput != "" {
+		file, err := os.Create(*dryRunOutput)
+		if err != nil {
+			log.Fatalf("Failed to create dry-run output file: %v", err)
+		}
+		defer file.Close()
+		dryRunWriter = file
+	}
+
 	ctx := context.Background()
+	batchRunRepo := repository.NewBatchRunRepository(database)
+	batchLockRepo := repository.NewBatchLockRepository(database)
+
+	var run func(ctx context.Context) (*batch.RunReport, error)
+	var args map[string]string
+	var lockName string
 
 	// Run command
 	switch *command {
@@ -46,51 +74,134 @@ func main() {
 		userRepo := repository.NewUserRepository(database)
 		rivalRepo := repository.NewRivalRepository(database)
 		commitStatsRepo := repository.NewCommitStatsRepository(database)
+		inAppNotificationRepo := repository.NewInAppNotificationRepository(database)
 
 		// Initialize gateway with GitHub token
 		githubToken := os.Getenv("GITHUB_TOKEN")
 		githubGateway := gateway.NewGithubGateway(githubToken)
 
 		// Initialize usecase
-		syncUsecase := usecase.NewSyncCommitsUsecase(userRepo, rivalRepo, commitStatsRepo, githubGateway)
+		syncUsecase := usecase.NewSyncCommitsUsecase(userRepo, rivalRepo, commitStatsRepo, githubGateway, notifier.NewInboxPublisher(inAppNotificationRepo))
 
 		// Run sync
 		config := batch.SyncCommitsConfig{
-			FromDate: *fromDate,
-			ToDate:   *toDate,
+			FromDate:     *fromDate,
+			ToDate:       *toDate,
+			Reconcile:    *reconcile,
+			DryRun:       *dryRun,
+			DryRunOutput: dryRunWriter,
 		}
-		if err := batch.RunSyncCommits(ctx, syncUsecase, config); err != nil {
-			log.Fatalf("Failed to run sync-commits: %v", err)
+		args = config.Args()
+		lockName = config.LockName()
+		run = func(ctx context.Context) (*batch.RunReport, error) {
+			return batch.RunSyncCommits(ctx, syncUsecase, config)
 		}
 
 	case "send-notifications":
-		// Initialize repositories
-		slackNotificationRepo := repository.NewSlackNotificationSettingRepository(database)
-		notificationLogRepo := repository.NewNotificationLogRepository(database)
-		rivalRepo := repository.NewRivalRepository(database)
-		commitStatsRepo := repository.NewCommitStatsRepository(database)
-
-		// Initialize gateway
-		slackGateway := gateway.NewSlackGateway()
-
-		// Initialize dependencies
-		deps := &batch.SendNotificationsDeps{
-			SlackNotificationRepo: slackNotificationRepo,
-			NotificationLogRepo:   notificationLogRepo,
-			RivalRepo:             rivalRepo,
-			CommitStatsRepo:       commitStatsRepo,
-			SlackGateway:          slackGateway,
+		deps, err := batch.NewSendNotificationsDeps(database, os.Getenv)
+		if err != nil {
+			log.Fatalf("Failed to initialize notification dependencies: %v", err)
 		}
 
 		// Run send notifications
 		config := batch.SendNotificationsConfig{
-			Period: *period,
+			Period:       *period,
+			DueOnly:      *dueOnly,
+			DryRun:       *dryRun,
+			DryRunOutput: dryRunWriter,
 		}
-		if err := batch.RunSendNotifications(ctx, deps, config); err != nil {
-			log.Fatalf("Failed to run send-notifications: %v", err)
+		args = config.Args()
+		lockName = config.LockName()
+		run = func(ctx context.Context) (*batch.RunReport, error) {
+			return batch.RunSendNotifications(ctx, deps, config)
+		}
+
+	case "retry-failed-notifications":
+		deps, err := batch.NewSendNotificationsDeps(database, os.Getenv)
+		if err != nil {
+			log.Fatalf("Failed to initialize notification dependencies: %v", err)
+		}
+
+		// Run retry
+		config := batch.RetryNotificationsConfig{
+			Limit:        *retryLimit,
+			DryRun:       *dryRun,
+			DryRunOutput: dryRunWriter,
+		}
+		args = config.Args()
+		lockName = config.LockName()
+		run = func(ctx context.Context) (*batch.RunReport, error) {
+			return batch.RunRetryFailedNotifications(ctx, deps, config)
+		}
+
+	case "send-rival-alerts":
+		deps, err := batch.NewSendNotificationsDeps(database, os.Getenv)
+		if err != nil {
+			log.Fatalf("Failed to initialize notification dependencies: %v", err)
+		}
+
+		// Run rival alerts (run right after sync-commits)
+		config := batch.RivalAlertsConfig{
+			Limit:        *alertLimit,
+			Window:       *alertWindow,
+			DryRun:       *dryRun,
+			DryRunOutput: dryRunWriter,
+		}
+		args = config.Args()
+		lockName = config.LockName()
+		run = func(ctx context.Context) (*batch.RunReport, error) {
+			return batch.RunRivalAlerts(ctx, deps, config)
+		}
+
+	case "send-daily-nudges":
+		deps, err := batch.NewSendNotificationsDeps(database, os.Getenv)
+		if err != nil {
+			log.Fatalf("Failed to initialize notification dependencies: %v", err)
+		}
+
+		// Sync today's commits for each due user before checking
+		userRepo := repository.NewUserRepository(database)
+		rivalRepo := repository.NewRivalRepository(database)
+		commitStatsRepo := repository.NewCommitStatsRepository(database)
+		githubGateway := gateway.NewGithubGateway(os.Getenv("GITHUB_TOKEN"))
+		syncUsecase := usecase.NewSyncCommitsUsecase(userRepo, rivalRepo, commitStatsRepo, githubGateway, deps.InboxPublisher)
+
+		// Run daily nudges
+		config := batch.DailyNudgesConfig{
+			DryRun:       *dryRun,
+			DryRunOutput: dryRunWriter,
+		}
+		args = config.Args()
+		lockName = config.LockName()
+		run = func(ctx context.Context) (*batch.RunReport, error) {
+			return batch.RunDailyNudges(ctx, deps, syncUsecase, config)
 		}
 
 	default:
 		log.Fatalf("Unknown command: %s", *command)
 	}
+
+	var report *batch.RunReport
+	if *dryRun {
+		// ドライランは実行履歴も含めてDBに書き込まず、ロックも取らない
+		report, err = run(ctx)
+	} else {
+		// 同じコマンドが複数ホストで同時に走らないようロックを取ってから実行する
+		report, err = batch.WithLock(ctx, batchLockRepo, lockName, *wait, func(ctx context.Context) (*batch.RunReport, error) {
+			return batch.RecordRun(ctx, batchRunRepo, *command, args, run)
+		})
+	}
+	if errors.Is(err, batch.ErrAlreadyRunning) {
+		log.Printf("Skipped: %v (pass -wait to wait for it to finish)", err)
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to run %s: %v", *command, err)
+	}
+
+	// 一部失敗・全失敗をスケジューラから検知できるよう終了コードで返す
+	if exitCode := report.ExitCode(); exitCode != batch.ExitCodeSuccess {
+		log.Printf("%s finished with status %s (exit code %d)", *command, report.Status(), exitCode)
+		os.Exit(exitCode)
+	}
 }
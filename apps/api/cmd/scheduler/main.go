@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/joho/godotenv"
+	"github.com/keeee21/commitly/api/batch"
+	"github.com/keeee21/commitly/api/db"
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/repository"
+	"github.com/keeee21/commitly/api/usecase"
+)
+
+func main() {
+	// Load .env file
+	if err := godotenv.Load(); err != nil {
+		log.Println("No .env file found")
+	}
+
+	config, err := batch.LoadSchedulerConfig(os.Getenv)
+	if err != nil {
+		log.Fatalf("Failed to load scheduler config: %v", err)
+	}
+
+	// Connect to database
+	database, err := db.NewDatabase(os.Getenv("DATABASE_URL"))
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+
+	// Initialize repositories
+	batchRunRepo := repository.NewBatchRunRepository(database)
+	batchLockRepo := repository.NewBatchLockRepository(database)
+	userRepo := repository.NewUserRepository(database)
+	rivalRepo := repository.NewRivalRepository(database)
+	commitStatsRepo := repository.NewCommitStatsRepository(database)
+
+	// Initialize gateways
+	githubGateway := gateway.NewGithubGateway(os.Getenv("GITHUB_TOKEN"))
+
+	// Initialize usecase and dependencies
+	notificationDeps, err := batch.NewSendNotificationsDeps(database, os.Getenv)
+	if err != nil {
+		log.Fatalf("Failed to initialize notification dependencies: %v", err)
+	}
+	syncUsecase := usecase.NewSyncCommitsUsecase(userRepo, rivalRepo, commitStatsRepo, githubGateway, notificationDeps.InboxPublisher)
+
+	jobs := batch.BuildScheduledJobs(config, syncUsecase, notificationDeps)
+	if len(jobs) == 0 {
+		log.Fatal("No schedules configured. Set at least one of SCHEDULE_SYNC_COMMITS, SCHEDULE_WEEKLY_NOTIFICATIONS, SCHEDULE_MONTHLY_NOTIFICATIONS, SCHEDULE_DUE_NOTIFICATIONS, SCHEDULE_RETRY_NOTIFICATIONS, SCHEDULE_DAILY_NUDGES")
+	}
+
+	scheduler := batch.NewScheduler(batchRunRepo, batchLockRepo, jobs)
+	if config.ShutdownTimeout > 0 {
+		scheduler.ShutdownTimeout = config.ShutdownTimeout
+	}
+
+	// SIGINT/SIGTERM で新規実行を止め、実行中のジョブの完了を待って終了する
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Printf("Scheduler started (timezone: %s)", config.Location)
+	scheduler.Run(ctx)
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/keeee21/commitly/api/gateway"
+)
+
+// Web Push 用の VAPID 鍵ペアを生成して .env に貼り付けられる形で出力する
+func main() {
+	privateKey, publicKey, err := gateway.GenerateVAPIDKeys()
+	if err != nil {
+		log.Fatalf("Failed to generate VAPID keys: %v", err)
+	}
+
+	fmt.Printf("WEB_PUSH_VAPID_PRIVATE_KEY=%s\n", privateKey)
+	fmt.Printf("# 公開鍵（GET /api/notifications/webpush が返すため設定は不要）: %s\n", publicKey)
+}
@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/i18n"
 	"github.com/keeee21/commitly/api/usecase"
 	"github.com/labstack/echo/v4"
 )
@@ -27,7 +28,7 @@ func NewAuthController(userUsecase usecase.IUserUsecase) IAuthController {
 
 // Callback Github OAuth コールバック処理
 // @Summary      Github OAuth コールバック
-// @Description  フロントエンド（NextAuth.js）からユーザー情報を受け取りDBに保存
+// @Description  フロントエンド（NextAuth.js）からユーザー情報を受け取りDBに保存。新規ユーザーの言語は locale、なければ Accept-Language から決める
 // @Tags         auth
 // @Accept       json
 // @Produce      json
@@ -56,6 +57,7 @@ func (ctrl *authController) Callback(c echo.Context) error {
 		req.GithubUsername,
 		req.Email,
 		req.AvatarURL,
+		callbackLocale(c, req.Locale),
 	)
 	if err != nil {
 		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
@@ -68,9 +70,22 @@ func (ctrl *authController) Callback(c echo.Context) error {
 		GithubUserID:   user.GithubUserID,
 		GithubUsername: user.GithubUsername,
 		AvatarURL:      user.AvatarURL,
+		Locale:         user.Locale,
 	})
 }
 
+// callbackLocale ブラウザの言語を決める（リクエストの locale、Accept-Language の順。どちらもなければ空）
+// 新規ユーザーと、言語を明示的に設定していないユーザーの言語になる
+func callbackLocale(c echo.Context, locale string) string {
+	if locale != "" {
+		return locale
+	}
+	if parsed, ok := i18n.FromAcceptLanguage(c.Request().Header.Get("Accept-Language")); ok {
+		return string(parsed)
+	}
+	return ""
+}
+
 // Logout ログアウト処理
 // @Summary      ログアウト
 // @Description  セッションはフロントエンド（NextAuth.js）で管理されるため、バックエンドでは特に処理不要
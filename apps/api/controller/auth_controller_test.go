@@ -23,7 +23,7 @@ func TestCallback_Success(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	mockUserUsecase := &mocks.MockUserUsecase{
-		GetOrCreateUserFunc: func(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL string) (*models.User, error) {
+		GetOrCreateUserFunc: func(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL, locale string) (*models.User, error) {
 			return &models.User{
 				ID:             1,
 				GithubUserID:   githubUserID,
@@ -45,6 +45,32 @@ func TestCallback_Success(t *testing.T) {
 	assert.NotContains(t, rec.Body.String(), `"email"`)
 }
 
+func TestCallback_LocaleFromAcceptLanguage(t *testing.T) {
+	e := echo.New()
+	body := `{"github_user_id": 12345, "github_username": "testuser"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/auth/callback", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Accept-Language", "fr-FR,en-US;q=0.9,ja;q=0.8")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var receivedLocale string
+	mockUserUsecase := &mocks.MockUserUsecase{
+		GetOrCreateUserFunc: func(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL, locale string) (*models.User, error) {
+			receivedLocale = locale
+			return &models.User{ID: 1, GithubUserID: githubUserID, GithubUsername: githubUsername, Locale: "en"}, nil
+		},
+	}
+
+	ctrl := NewAuthController(mockUserUsecase)
+	err := ctrl.Callback(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "en", receivedLocale)
+	assert.Contains(t, rec.Body.String(), `"locale":"en"`)
+}
+
 func TestCallback_InvalidRequest(t *testing.T) {
 	e := echo.New()
 	body := `invalid json`
@@ -90,7 +116,7 @@ func TestCallback_UsecaseError(t *testing.T) {
 	c := e.NewContext(req, rec)
 
 	mockUserUsecase := &mocks.MockUserUsecase{
-		GetOrCreateUserFunc: func(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL string) (*models.User, error) {
+		GetOrCreateUserFunc: func(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL, locale string) (*models.User, error) {
 			return nil, errors.New("database error")
 		},
 	}
@@ -0,0 +1,94 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// IBatchRunController バッチ実行履歴コントローラーのインターフェース
+type IBatchRunController interface {
+	GetRecentRuns(c echo.Context) error
+}
+
+type batchRunController struct {
+	batchRunUsecase usecase.IBatchRunUsecase
+}
+
+// NewBatchRunController コンストラクタ
+func NewBatchRunController(batchRunUsecase usecase.IBatchRunUsecase) IBatchRunController {
+	return &batchRunController{
+		batchRunUsecase: batchRunUsecase,
+	}
+}
+
+// GetRecentRuns 最近のバッチ実行履歴を取得
+// @Summary      最近のバッチ実行履歴を取得
+// @Description  sync-commits / send-notifications の実行結果を新しい順に返す（管理者用）
+// @Tags         admin
+// @Produce      json
+// @Param        limit query int false "取得件数（既定20、最大100）"
+// @Success      200 {object} dto.BatchRunsListResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Router       /api/admin/batch-runs [get]
+func (ctrl *batchRunController) GetRecentRuns(c echo.Context) error {
+	limit := 0
+	if limitStr := c.QueryParam("limit"); limitStr != "" {
+		parsed, err := strconv.Atoi(limitStr)
+		if err != nil || parsed < 0 {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "limitが不正です",
+			})
+		}
+		limit = parsed
+	}
+
+	runs, err := ctrl.batchRunUsecase.GetRecentRuns(c.Request().Context(), limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "バッチ実行履歴の取得に失敗しました",
+		})
+	}
+
+	response := dto.BatchRunsListResponse{
+		Runs: make([]dto.BatchRunResponse, 0, len(runs)),
+	}
+	for _, run := range runs {
+		response.Runs = append(response.Runs, toBatchRunResponse(run))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+func toBatchRunResponse(run models.BatchRun) dto.BatchRunResponse {
+	args := make(map[string]string, len(run.Args))
+	for k, v := range run.Args {
+		args[k] = fmt.Sprint(v)
+	}
+
+	errorSamples := []string(run.ErrorSamples)
+	if errorSamples == nil {
+		errorSamples = []string{}
+	}
+
+	return dto.BatchRunResponse{
+		ID:           run.ID,
+		Command:      run.Command,
+		Args:         args,
+		Status:       string(run.Status),
+		StartedAt:    run.StartedAt,
+		FinishedAt:   run.FinishedAt,
+		SuccessCount: run.SuccessCount,
+		FailureCount: run.FailureCount,
+		SkipCount:    run.SkipCount,
+		ErrorSamples: errorSamples,
+		ErrorMessage: run.ErrorMessage,
+	}
+}
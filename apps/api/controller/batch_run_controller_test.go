@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRecentRuns_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/batch-runs?limit=5", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	finishedAt := time.Now()
+	var capturedLimit int
+	mockUsecase := &mocks.MockBatchRunUsecase{
+		GetRecentRunsFunc: func(ctx context.Context, limit int) ([]models.BatchRun, error) {
+			capturedLimit = limit
+			return []models.BatchRun{
+				{
+					ID:           1,
+					Command:      "send-notifications",
+					Args:         models.JSONPayload{"period": "weekly"},
+					Status:       models.BatchRunStatusPartialFailure,
+					StartedAt:    finishedAt.Add(-time.Minute),
+					FinishedAt:   &finishedAt,
+					SuccessCount: 3,
+					FailureCount: 1,
+					ErrorSamples: models.StringList{"user 2 (slack): timeout"},
+				},
+			}, nil
+		},
+	}
+
+	ctrl := NewBatchRunController(mockUsecase)
+	err := ctrl.GetRecentRuns(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, 5, capturedLimit)
+	assert.Contains(t, rec.Body.String(), `"command":"send-notifications"`)
+	assert.Contains(t, rec.Body.String(), `"period":"weekly"`)
+	assert.Contains(t, rec.Body.String(), `"status":"partial_failure"`)
+	assert.Contains(t, rec.Body.String(), `"failure_count":1`)
+	assert.Contains(t, rec.Body.String(), `"error_samples":["user 2 (slack): timeout"]`)
+}
+
+func TestGetRecentRuns_InvalidLimit(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/batch-runs?limit=abc", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	ctrl := NewBatchRunController(&mocks.MockBatchRunUsecase{})
+	err := ctrl.GetRecentRuns(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestGetRecentRuns_Error(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/batch-runs", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUsecase := &mocks.MockBatchRunUsecase{
+		GetRecentRunsFunc: func(ctx context.Context, limit int) ([]models.BatchRun, error) {
+			return nil, errors.New("database error")
+		},
+	}
+
+	ctrl := NewBatchRunController(mockUsecase)
+	err := ctrl.GetRecentRuns(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// ICircleNotificationController サークルの週次ダイジェスト配信設定コントローラーのインターフェース
+type ICircleNotificationController interface {
+	GetSetting(c echo.Context) error
+	UpdateSetting(c echo.Context) error
+	DeleteSetting(c echo.Context) error
+}
+
+type circleNotificationController struct {
+	circleNotificationUsecase usecase.ICircleNotificationUsecase
+}
+
+// NewCircleNotificationController コンストラクタ
+func NewCircleNotificationController(circleNotificationUsecase usecase.ICircleNotificationUsecase) ICircleNotificationController {
+	return &circleNotificationController{
+		circleNotificationUsecase: circleNotificationUsecase,
+	}
+}
+
+// GetSetting サークルの週次ダイジェスト配信設定を取得
+// @Summary      サークルの週次ダイジェスト配信設定を取得
+// @Description  サークルのダイジェスト配信設定を返す（オーナーのみ、Webhook URLはマスク済み）
+// @Tags         circles
+// @Produce      json
+// @Param        id path int true "サークルID"
+// @Success      200 {object} dto.CircleNotificationSettingResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/circles/{id}/notification [get]
+func (ctrl *circleNotificationController) GetSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	circleID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "サークルIDが不正です",
+		})
+	}
+
+	setting, err := ctrl.circleNotificationUsecase.GetSetting(c.Request().Context(), user.ID, circleID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+	if setting == nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "サークルの通知設定が見つかりません",
+		})
+	}
+
+	return c.JSON(http.StatusOK, buildCircleNotificationSettingResponse(setting))
+}
+
+// UpdateSetting サークルの週次ダイジェスト配信設定を登録・更新
+// @Summary      サークルの週次ダイジェスト配信設定を登録・更新
+// @Description  SlackまたはDiscordのWebhook URLと配信する曜日・時刻を設定する（オーナーのみ）
+// @Tags         circles
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "サークルID"
+// @Param        request body dto.UpdateCircleNotificationRequest true "配信設定更新リクエスト"
+// @Success      200 {object} dto.CircleNotificationSettingResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/circles/{id}/notification [put]
+func (ctrl *circleNotificationController) UpdateSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	circleID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "サークルIDが不正です",
+		})
+	}
+
+	var req dto.UpdateCircleNotificationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if req.WebhookURL == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Webhook URLを入力してください",
+		})
+	}
+
+	channelType := models.ChannelType(req.ChannelType)
+	switch channelType {
+	case models.ChannelTypeSlack:
+		if !strings.HasPrefix(req.WebhookURL, slackWebhookURLPrefix) {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "無効なSlack Webhook URLです。https://hooks.slack.com/services/ で始まるURLを入力してください",
+			})
+		}
+	case models.ChannelTypeDiscord:
+		if !isDiscordWebhookURL(req.WebhookURL) {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "無効なDiscord Webhook URLです。https://discord.com/api/webhooks/ で始まるURLを入力してください",
+			})
+		}
+	default:
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "通知先はslackまたはdiscordを指定してください",
+		})
+	}
+
+	isEnabled := req.IsEnabled == nil || *req.IsEnabled
+
+	setting, err := ctrl.circleNotificationUsecase.UpdateSetting(
+		c.Request().Context(), user.ID, circleID,
+		channelType, req.WebhookURL, req.Weekday, req.DeliveryTime, req.Timezone, isEnabled,
+	)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, buildCircleNotificationSettingResponse(setting))
+}
+
+// DeleteSetting サークルの週次ダイジェスト配信設定を削除
+// @Summary      サークルの週次ダイジェスト配信設定を削除
+// @Description  サークルのダイジェスト配信設定を削除する（オーナーのみ）
+// @Tags         circles
+// @Param        id path int true "サークルID"
+// @Success      204
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/circles/{id}/notification [delete]
+func (ctrl *circleNotificationController) DeleteSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	circleID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "サークルIDが不正です",
+		})
+	}
+
+	if err := ctrl.circleNotificationUsecase.DeleteSetting(c.Request().Context(), user.ID, circleID); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+func buildCircleNotificationSettingResponse(setting *models.CircleNotificationSetting) dto.CircleNotificationSettingResponse {
+	return dto.CircleNotificationSettingResponse{
+		CircleID:     setting.CircleID,
+		ChannelType:  string(setting.ChannelType),
+		WebhookURL:   maskWebhookURL(setting.WebhookURL),
+		Weekday:      setting.Weekday,
+		DeliveryTime: setting.DeliveryTime,
+		Timezone:     setting.Timezone,
+		IsEnabled:    setting.IsEnabled,
+		CreatedAt:    setting.CreatedAt,
+		UpdatedAt:    setting.UpdatedAt,
+	}
+}
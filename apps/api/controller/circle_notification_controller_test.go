@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCircleNotificationContext(method, body string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(method, "/api/circles/7/notification", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	c.Set("user", &models.User{ID: 1})
+	return c, rec
+}
+
+func TestGetCircleNotificationSetting_Success(t *testing.T) {
+	c, rec := newCircleNotificationContext(http.MethodGet, "")
+
+	mockUsecase := &mocks.MockCircleNotificationUsecase{
+		GetSettingFunc: func(ctx context.Context, userID uint64, circleID uint64) (*models.CircleNotificationSetting, error) {
+			assert.Equal(t, uint64(7), circleID)
+			return &models.CircleNotificationSetting{
+				CircleID:     circleID,
+				ChannelType:  models.ChannelTypeSlack,
+				WebhookURL:   "https://hooks.slack.com/services/T000/B000/XXXXXXXXXXXXXXXX",
+				Weekday:      1,
+				DeliveryTime: "09:00",
+				Timezone:     "Asia/Tokyo",
+				IsEnabled:    true,
+			}, nil
+		},
+	}
+
+	ctrl := NewCircleNotificationController(mockUsecase)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"webhook_url":"https://hooks.slack.com/services/T000/B0..."`)
+	assert.Contains(t, rec.Body.String(), `"channel_type":"slack"`)
+}
+
+func TestGetCircleNotificationSetting_NotFound(t *testing.T) {
+	c, rec := newCircleNotificationContext(http.MethodGet, "")
+
+	ctrl := NewCircleNotificationController(&mocks.MockCircleNotificationUsecase{})
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestUpdateCircleNotificationSetting_Success(t *testing.T) {
+	body := `{"channel_type":"discord","webhook_url":"https://discord.com/api/webhooks/1/abc","weekday":5,"delivery_time":"18:00","timezone":"Asia/Tokyo"}`
+	c, rec := newCircleNotificationContext(http.MethodPut, body)
+
+	mockUsecase := &mocks.MockCircleNotificationUsecase{
+		UpdateSettingFunc: func(ctx context.Context, userID uint64, circleID uint64, channelType models.ChannelType, webhookURL string, weekday int, deliveryTime, timezone string, isEnabled bool) (*models.CircleNotificationSetting, error) {
+			assert.Equal(t, models.ChannelTypeDiscord, channelType)
+			// is_enabled を省略した場合は有効にする
+			assert.True(t, isEnabled)
+			return &models.CircleNotificationSetting{CircleID: circleID, ChannelType: channelType, WebhookURL: webhookURL, Weekday: weekday, DeliveryTime: deliveryTime, Timezone: timezone, IsEnabled: isEnabled}, nil
+		},
+	}
+
+	ctrl := NewCircleNotificationController(mockUsecase)
+	err := ctrl.UpdateSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"weekday":5`)
+}
+
+func TestUpdateCircleNotificationSetting_InvalidWebhookURL(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "slack channel with discord url", body: `{"channel_type":"slack","webhook_url":"https://discord.com/api/webhooks/1/abc"}`},
+		{name: "discord channel with slack url", body: `{"channel_type":"discord","webhook_url":"https://hooks.slack.com/services/x"}`},
+		{name: "unsupported channel", body: `{"channel_type":"line","webhook_url":"https://hooks.slack.com/services/x"}`},
+		{name: "empty url", body: `{"channel_type":"slack"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, rec := newCircleNotificationContext(http.MethodPut, tt.body)
+
+			mockUsecase := &mocks.MockCircleNotificationUsecase{
+				UpdateSettingFunc: func(ctx context.Context, userID uint64, circleID uint64, channelType models.ChannelType, webhookURL string, weekday int, deliveryTime, timezone string, isEnabled bool) (*models.CircleNotificationSetting, error) {
+					t.Fatal("usecase should not be called")
+					return nil, nil
+				},
+			}
+
+			ctrl := NewCircleNotificationController(mockUsecase)
+			err := ctrl.UpdateSetting(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestUpdateCircleNotificationSetting_NotOwner(t *testing.T) {
+	body := `{"channel_type":"slack","webhook_url":"https://hooks.slack.com/services/x","weekday":1,"delivery_time":"09:00","timezone":"UTC"}`
+	c, rec := newCircleNotificationContext(http.MethodPut, body)
+
+	mockUsecase := &mocks.MockCircleNotificationUsecase{
+		UpdateSettingFunc: func(ctx context.Context, userID uint64, circleID uint64, channelType models.ChannelType, webhookURL string, weekday int, deliveryTime, timezone string, isEnabled bool) (*models.CircleNotificationSetting, error) {
+			return nil, errors.New("サークルの通知設定を変更する権限がありません")
+		},
+	}
+
+	ctrl := NewCircleNotificationController(mockUsecase)
+	err := ctrl.UpdateSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "権限がありません")
+}
+
+func TestDeleteCircleNotificationSetting_Success(t *testing.T) {
+	c, rec := newCircleNotificationContext(http.MethodDelete, "")
+
+	ctrl := NewCircleNotificationController(&mocks.MockCircleNotificationUsecase{})
+	err := ctrl.DeleteSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+}
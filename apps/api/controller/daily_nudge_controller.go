@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// IDailyNudgeController リマインダー設定コントローラーのインターフェース
+type IDailyNudgeController interface {
+	GetSetting(c echo.Context) error
+	UpdateSetting(c echo.Context) error
+}
+
+type dailyNudgeController struct {
+	dailyNudgeUsecase usecase.IDailyNudgeUsecase
+}
+
+// NewDailyNudgeController コンストラクタ
+func NewDailyNudgeController(dailyNudgeUsecase usecase.IDailyNudgeUsecase) IDailyNudgeController {
+	return &dailyNudgeController{
+		dailyNudgeUsecase: dailyNudgeUsecase,
+	}
+}
+
+// GetSetting リマインダーの設定を取得
+// @Summary      リマインダーの設定を取得
+// @Description  今日まだコミットしていないときに送るリマインダーの時刻と、受け取るチャンネルを返す。未設定の場合は 20:00 でどのチャンネルにも送らない
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.DailyNudgeResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/nudge [get]
+func (ctrl *dailyNudgeController) GetSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	setting, err := ctrl.dailyNudgeUsecase.GetSetting(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "リマインダーの設定の取得に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusOK, toDailyNudgeResponse(setting))
+}
+
+// UpdateSetting リマインダーの設定を更新
+// @Summary      リマインダーの設定を更新
+// @Description  リマインダーを送る時刻（配信スケジュールのタイムゾーン）と、受け取るチャンネルを設定する。チャンネルの通知設定が無効の場合は送らない
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateDailyNudgeRequest true "リマインダーの設定更新リクエスト"
+// @Success      200 {object} dto.DailyNudgeResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/nudge [put]
+func (ctrl *dailyNudgeController) UpdateSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateDailyNudgeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	channels := make([]models.ChannelType, 0, len(req.Channels))
+	for _, channel := range req.Channels {
+		channels = append(channels, models.ChannelType(channel))
+	}
+
+	setting, err := ctrl.dailyNudgeUsecase.UpdateSetting(c.Request().Context(), user.ID, req.NudgeTime, channels)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, toDailyNudgeResponse(setting))
+}
+
+// toDailyNudgeResponse レスポンスに変換
+func toDailyNudgeResponse(setting *usecase.DailyNudgeSetting) dto.DailyNudgeResponse {
+	channels := make([]string, 0, len(setting.Channels))
+	for _, channel := range setting.Channels {
+		channels = append(channels, string(channel))
+	}
+	return dto.DailyNudgeResponse{
+		NudgeTime: setting.NudgeTime,
+		Timezone:  setting.Timezone,
+		Channels:  channels,
+	}
+}
@@ -0,0 +1,103 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetDailyNudge_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/nudge", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockDailyNudgeUsecase{
+		GetSettingFunc: func(ctx context.Context, userID uint64) (*usecase.DailyNudgeSetting, error) {
+			return &usecase.DailyNudgeSetting{NudgeTime: "20:00", Timezone: "Asia/Tokyo"}, nil
+		},
+	}
+
+	ctrl := NewDailyNudgeController(mockUsecase)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"nudge_time":"20:00","timezone":"Asia/Tokyo","channels":[]}`, rec.Body.String())
+}
+
+func TestGetDailyNudge_Error(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/nudge", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockDailyNudgeUsecase{
+		GetSettingFunc: func(ctx context.Context, userID uint64) (*usecase.DailyNudgeSetting, error) {
+			return nil, errors.New("db error")
+		},
+	}
+
+	ctrl := NewDailyNudgeController(mockUsecase)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestUpdateDailyNudge_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/notifications/nudge", strings.NewReader(`{"nudge_time":"21:00","channels":["slack","line"]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockDailyNudgeUsecase{
+		UpdateSettingFunc: func(ctx context.Context, userID uint64, nudgeTime string, channels []models.ChannelType) (*usecase.DailyNudgeSetting, error) {
+			assert.Equal(t, uint64(1), userID)
+			assert.Equal(t, []models.ChannelType{models.ChannelTypeSlack, models.ChannelTypeLINE}, channels)
+			return &usecase.DailyNudgeSetting{NudgeTime: nudgeTime, Timezone: "Asia/Tokyo", Channels: channels}, nil
+		},
+	}
+
+	ctrl := NewDailyNudgeController(mockUsecase)
+	err := ctrl.UpdateSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"nudge_time":"21:00","timezone":"Asia/Tokyo","channels":["slack","line"]}`, rec.Body.String())
+}
+
+func TestUpdateDailyNudge_ValidationError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/notifications/nudge", strings.NewReader(`{"nudge_time":"8pm","channels":[]}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockDailyNudgeUsecase{
+		UpdateSettingFunc: func(ctx context.Context, userID uint64, nudgeTime string, channels []models.ChannelType) (*usecase.DailyNudgeSetting, error) {
+			return nil, errors.New("リマインダーの時刻はHH:MM形式で指定してください")
+		},
+	}
+
+	ctrl := NewDailyNudgeController(mockUsecase)
+	err := ctrl.UpdateSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "HH:MM")
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// discordWebhookURLPrefixes Discord Webhook URLとして受け付ける接頭辞（旧ドメインを含む）
+var discordWebhookURLPrefixes = []string{
+	"https://discord.com/api/webhooks/",
+	"https://discordapp.com/api/webhooks/",
+}
+
+// IDiscordNotificationController Discord通知コントローラーのインターフェース
+type IDiscordNotificationController interface {
+	GetSetting(c echo.Context) error
+	Create(c echo.Context) error
+	UpdateEnabled(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type discordNotificationController struct {
+	discordNotificationUsecase usecase.IDiscordNotificationUsecase
+}
+
+// NewDiscordNotificationController コンストラクタ
+func NewDiscordNotificationController(discordNotificationUsecase usecase.IDiscordNotificationUsecase) IDiscordNotificationController {
+	return &discordNotificationController{
+		discordNotificationUsecase: discordNotificationUsecase,
+	}
+}
+
+// GetSetting Discord通知設定を取得
+// @Summary      Discord通知設定を取得
+// @Description  現在のDiscord通知設定を返す（Webhook URLはマスク済み）
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.DiscordNotificationSettingResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/discord [get]
+func (ctrl *discordNotificationController) GetSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	setting, err := ctrl.discordNotificationUsecase.GetSetting(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Discord通知設定の取得に失敗しました",
+		})
+	}
+
+	if setting == nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Discord通知設定が見つかりません",
+		})
+	}
+
+	maskedURL := maskWebhookURL(setting.WebhookURL)
+
+	return c.JSON(http.StatusOK, dto.DiscordNotificationSettingResponse{
+		ID:         setting.ID,
+		WebhookURL: maskedURL,
+		IsEnabled:  setting.IsEnabled,
+		CreatedAt:  setting.CreatedAt,
+		UpdatedAt:  setting.UpdatedAt,
+	})
+}
+
+// isDiscordWebhookURL Discord Webhook URLかどうかを判定
+func isDiscordWebhookURL(url string) bool {
+	for _, prefix := range discordWebhookURLPrefixes {
+		if strings.HasPrefix(url, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Create Discord通知設定を作成
+// @Summary      Discord通知設定を作成
+// @Description  Discord Webhook URLを登録して通知設定を作成する
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateDiscordNotificationRequest true "Discord通知設定作成リクエスト"
+// @Success      201 {object} dto.DiscordNotificationSettingResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/discord [post]
+func (ctrl *discordNotificationController) Create(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.CreateDiscordNotificationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if req.WebhookURL == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Webhook URLを入力してください",
+		})
+	}
+
+	if !isDiscordWebhookURL(req.WebhookURL) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "無効なDiscord Webhook URLです。https://discord.com/api/webhooks/ で始まるURLを入力してください",
+		})
+	}
+
+	setting, err := ctrl.discordNotificationUsecase.Create(c.Request().Context(), user.ID, req.WebhookURL)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Discord通知設定の作成に失敗しました",
+		})
+	}
+
+	maskedURL := maskWebhookURL(setting.WebhookURL)
+
+	return c.JSON(http.StatusCreated, dto.DiscordNotificationSettingResponse{
+		ID:         setting.ID,
+		WebhookURL: maskedURL,
+		IsEnabled:  setting.IsEnabled,
+		CreatedAt:  setting.CreatedAt,
+		UpdatedAt:  setting.UpdatedAt,
+	})
+}
+
+// UpdateEnabled Discord通知の有効/無効を更新
+// @Summary      Discord通知の有効/無効を更新
+// @Description  Discord通知設定の有効/無効を切り替える
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateEnabledRequest true "有効/無効更新リクエスト"
+// @Success      200 {object} dto.UpdateEnabledResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/discord [put]
+func (ctrl *discordNotificationController) UpdateEnabled(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateEnabledRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if err := ctrl.discordNotificationUsecase.UpdateEnabled(c.Request().Context(), user.ID, req.IsEnabled); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Discord通知設定の更新に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.UpdateEnabledResponse{
+		IsEnabled: req.IsEnabled,
+	})
+}
+
+// Delete Discord通知設定を削除
+// @Summary      Discord通知設定を削除
+// @Description  Discord通知設定を削除する
+// @Tags         notifications
+// @Success      204
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/discord [delete]
+func (ctrl *discordNotificationController) Delete(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.discordNotificationUsecase.Delete(c.Request().Context(), user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Discord通知設定の削除に失敗しました",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,186 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupDiscordNotificationControllerTest() (*echo.Echo, *models.User) {
+	e := echo.New()
+	user := &models.User{
+		ID:             1,
+		GithubUserID:   12345,
+		GithubUsername: "testuser",
+	}
+	return e, user
+}
+
+func TestDiscordGetSetting_Success(t *testing.T) {
+	e, user := setupDiscordNotificationControllerTest()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/discord", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", user)
+
+	mockUsecase := &mocks.MockDiscordNotificationUsecase{
+		GetSettingFunc: func(ctx context.Context, userID uint64) (*models.DiscordNotificationSetting, error) {
+			return &models.DiscordNotificationSetting{
+				ID:         1,
+				UserID:     userID,
+				WebhookURL: "https://discord.com/api/webhooks/123456789012345678/secret-token-value",
+				IsEnabled:  true,
+				CreatedAt:  time.Now(),
+				UpdatedAt:  time.Now(),
+			}, nil
+		},
+	}
+
+	ctrl := NewDiscordNotificationController(mockUsecase)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"is_enabled":true`)
+	assert.NotContains(t, rec.Body.String(), "secret-token-value")
+}
+
+func TestDiscordGetSetting_NotFound(t *testing.T) {
+	e, user := setupDiscordNotificationControllerTest()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/discord", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", user)
+
+	ctrl := NewDiscordNotificationController(&mocks.MockDiscordNotificationUsecase{})
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestDiscordCreate_Success(t *testing.T) {
+	tests := []struct {
+		name       string
+		webhookURL string
+	}{
+		{"discord.com", "https://discord.com/api/webhooks/123456789012345678/token"},
+		{"discordapp.com", "https://discordapp.com/api/webhooks/123456789012345678/token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, user := setupDiscordNotificationControllerTest()
+			body := `{"webhook_url":"` + tt.webhookURL + `"}`
+			req := httptest.NewRequest(http.MethodPost, "/api/notifications/discord", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", user)
+
+			var captured string
+			mockUsecase := &mocks.MockDiscordNotificationUsecase{
+				CreateFunc: func(ctx context.Context, userID uint64, webhookURL string) (*models.DiscordNotificationSetting, error) {
+					captured = webhookURL
+					return &models.DiscordNotificationSetting{ID: 1, UserID: userID, WebhookURL: webhookURL, IsEnabled: true}, nil
+				},
+			}
+
+			ctrl := NewDiscordNotificationController(mockUsecase)
+			err := ctrl.Create(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Equal(t, tt.webhookURL, captured)
+		})
+	}
+}
+
+func TestDiscordCreate_InvalidURL(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"empty", `{"webhook_url":""}`},
+		{"slack url", `{"webhook_url":"https://hooks.slack.com/services/T000/B000/XXXX"}`},
+		{"http", `{"webhook_url":"http://discord.com/api/webhooks/1/token"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, user := setupDiscordNotificationControllerTest()
+			req := httptest.NewRequest(http.MethodPost, "/api/notifications/discord", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", user)
+
+			mockUsecase := &mocks.MockDiscordNotificationUsecase{
+				CreateFunc: func(ctx context.Context, userID uint64, webhookURL string) (*models.DiscordNotificationSetting, error) {
+					t.Fatal("usecase must not be called")
+					return nil, nil
+				},
+			}
+
+			ctrl := NewDiscordNotificationController(mockUsecase)
+			err := ctrl.Create(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestDiscordUpdateEnabled_Success(t *testing.T) {
+	e, user := setupDiscordNotificationControllerTest()
+	req := httptest.NewRequest(http.MethodPut, "/api/notifications/discord", strings.NewReader(`{"is_enabled":false}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", user)
+
+	var captured *bool
+	mockUsecase := &mocks.MockDiscordNotificationUsecase{
+		UpdateEnabledFunc: func(ctx context.Context, userID uint64, isEnabled bool) error {
+			captured = &isEnabled
+			return nil
+		},
+	}
+
+	ctrl := NewDiscordNotificationController(mockUsecase)
+	err := ctrl.UpdateEnabled(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotNil(t, captured)
+	assert.False(t, *captured)
+}
+
+func TestDiscordDelete_Error(t *testing.T) {
+	e, user := setupDiscordNotificationControllerTest()
+	req := httptest.NewRequest(http.MethodDelete, "/api/notifications/discord", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", user)
+
+	mockUsecase := &mocks.MockDiscordNotificationUsecase{
+		DeleteFunc: func(ctx context.Context, userID uint64) error {
+			return errors.New("database error")
+		},
+	}
+
+	ctrl := NewDiscordNotificationController(mockUsecase)
+	err := ctrl.Delete(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
@@ -0,0 +1,214 @@
+package controller
+
+import (
+	"fmt"
+	"html"
+	"net/http"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// IEmailNotificationController メール通知コントローラーのインターフェース
+type IEmailNotificationController interface {
+	GetSetting(c echo.Context) error
+	Create(c echo.Context) error
+	ResendVerification(c echo.Context) error
+	UpdateEnabled(c echo.Context) error
+	Delete(c echo.Context) error
+	Verify(c echo.Context) error
+	Unsubscribe(c echo.Context) error
+}
+
+type emailNotificationController struct {
+	emailNotificationUsecase usecase.IEmailNotificationUsecase
+}
+
+// NewEmailNotificationController コンストラクタ
+func NewEmailNotificationController(emailNotificationUsecase usecase.IEmailNotificationUsecase) IEmailNotificationController {
+	return &emailNotificationController{
+		emailNotificationUsecase: emailNotificationUsecase,
+	}
+}
+
+// GetSetting メール通知設定を取得
+// @Summary      メール通知設定を取得
+// @Description  現在のメール通知設定と、アドレスの確認状態を返す
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.EmailNotificationSettingResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/email [get]
+func (ctrl *emailNotificationController) GetSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	setting, err := ctrl.emailNotificationUsecase.GetSetting(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "メール通知設定の取得に失敗しました",
+		})
+	}
+
+	if setting == nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "メール通知設定が見つかりません",
+		})
+	}
+
+	return c.JSON(http.StatusOK, toEmailNotificationSettingResponse(setting))
+}
+
+// Create メール通知設定を作成
+// @Summary      メール通知設定を作成
+// @Description  GitHubアカウントのメールアドレス宛てに通知設定を作成し、確認メールを送る。確認が済むまでレポートは送信されない
+// @Tags         notifications
+// @Produce      json
+// @Success      201 {object} dto.EmailNotificationSettingResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/email [post]
+func (ctrl *emailNotificationController) Create(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	setting, err := ctrl.emailNotificationUsecase.Create(c.Request().Context(), user)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, toEmailNotificationSettingResponse(setting))
+}
+
+// ResendVerification 確認メールを再送
+// @Summary      確認メールを再送
+// @Description  メールアドレスの確認メールを再送する
+// @Tags         notifications
+// @Success      204
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/email/verification [post]
+func (ctrl *emailNotificationController) ResendVerification(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.emailNotificationUsecase.ResendVerification(c.Request().Context(), user); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// UpdateEnabled メール通知の有効/無効を更新
+// @Summary      メール通知の有効/無効を更新
+// @Description  メール通知設定の有効/無効を切り替える
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateEnabledRequest true "有効/無効更新リクエスト"
+// @Success      200 {object} dto.UpdateEnabledResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/email [put]
+func (ctrl *emailNotificationController) UpdateEnabled(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateEnabledRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if err := ctrl.emailNotificationUsecase.UpdateEnabled(c.Request().Context(), user.ID, req.IsEnabled); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "メール通知設定の更新に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.UpdateEnabledResponse{
+		IsEnabled: req.IsEnabled,
+	})
+}
+
+// Delete メール通知設定を削除
+// @Summary      メール通知設定を削除
+// @Description  メール通知設定を削除する
+// @Tags         notifications
+// @Success      204
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/email [delete]
+func (ctrl *emailNotificationController) Delete(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.emailNotificationUsecase.Delete(c.Request().Context(), user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "メール通知設定の削除に失敗しました",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Verify メールアドレスを確認
+// @Summary      メールアドレスを確認
+// @Description  確認メールのリンクから開かれ、署名付きトークンを検証してアドレスを確認済みにする
+// @Tags         notifications
+// @Produce      html
+// @Param        token query string true "確認トークン"
+// @Success      200
+// @Failure      400
+// @Router       /api/email/verify [get]
+func (ctrl *emailNotificationController) Verify(c echo.Context) error {
+	if err := ctrl.emailNotificationUsecase.Verify(c.Request().Context(), c.QueryParam("token")); err != nil {
+		return c.HTML(http.StatusBadRequest, emailResultPage("メールアドレスを確認できませんでした", err.Error()))
+	}
+
+	return c.HTML(http.StatusOK, emailResultPage("メールアドレスを確認しました", "今後のレポートはこのアドレスに届きます。"))
+}
+
+// Unsubscribe メール通知の配信を停止
+// @Summary      メール通知の配信を停止
+// @Description  レポートメールの配信停止リンクから開かれ、署名付きトークンを検証してメール通知を無効にする（RFC 8058 のワンクリック配信停止のためPOSTも受け付ける）
+// @Tags         notifications
+// @Produce      html
+// @Param        token query string true "配信停止トークン"
+// @Success      200
+// @Failure      400
+// @Router       /api/email/unsubscribe [get]
+// @Router       /api/email/unsubscribe [post]
+func (ctrl *emailNotificationController) Unsubscribe(c echo.Context) error {
+	if err := ctrl.emailNotificationUsecase.Unsubscribe(c.Request().Context(), c.QueryParam("token")); err != nil {
+		return c.HTML(http.StatusBadRequest, emailResultPage("配信を停止できませんでした", err.Error()))
+	}
+
+	return c.HTML(http.StatusOK, emailResultPage("配信を停止しました", "Commitly からのレポートメールは今後届きません。設定画面からいつでも再開できます。"))
+}
+
+// emailResultPage メールのリンクを開いたときに表示する簡易ページ
+func emailResultPage(title, message string) string {
+	return fmt.Sprintf(
+		`<!DOCTYPE html><html lang="ja"><head><meta charset="UTF-8"><title>%[1]s</title></head>`+
+			`<body style="font-family:sans-serif;padding:40px;text-align:center;"><h1 style="font-size:20px;">%[1]s</h1><p>%[2]s</p></body></html>`,
+		html.EscapeString(title), html.EscapeString(message),
+	)
+}
+
+// toEmailNotificationSettingResponse レスポンスに変換
+func toEmailNotificationSettingResponse(setting *models.EmailNotificationSetting) dto.EmailNotificationSettingResponse {
+	return dto.EmailNotificationSettingResponse{
+		ID:         setting.ID,
+		Email:      setting.Email,
+		IsVerified: setting.VerifiedAt != nil,
+		IsEnabled:  setting.IsEnabled,
+		CreatedAt:  setting.CreatedAt,
+		UpdatedAt:  setting.UpdatedAt,
+	}
+}
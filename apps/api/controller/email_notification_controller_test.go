@@ -0,0 +1,159 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEmailGetSetting_ReportsVerification(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/email", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	verifiedAt := time.Now()
+	mockUsecase := &mocks.MockEmailNotificationUsecase{
+		GetSettingFunc: func(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error) {
+			return &models.EmailNotificationSetting{ID: 1, UserID: userID, Email: "tanaka@example.com", VerifiedAt: &verifiedAt, IsEnabled: true}, nil
+		},
+	}
+
+	ctrl := NewEmailNotificationController(mockUsecase)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"email":"tanaka@example.com"`)
+	assert.Contains(t, rec.Body.String(), `"is_verified":true`)
+}
+
+func TestEmailGetSetting_NotFound(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/email", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	ctrl := NewEmailNotificationController(&mocks.MockEmailNotificationUsecase{})
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestEmailCreate_PassesUser(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/email", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	user := &models.User{ID: 1, Email: "tanaka@example.com"}
+	c.Set("user", user)
+
+	var captured *models.User
+	mockUsecase := &mocks.MockEmailNotificationUsecase{
+		CreateFunc: func(ctx context.Context, u *models.User) (*models.EmailNotificationSetting, error) {
+			captured = u
+			return &models.EmailNotificationSetting{ID: 1, UserID: u.ID, Email: u.Email, IsEnabled: true}, nil
+		},
+	}
+
+	ctrl := NewEmailNotificationController(mockUsecase)
+	err := ctrl.Create(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, user, captured)
+	assert.Contains(t, rec.Body.String(), `"is_verified":false`)
+}
+
+func TestEmailCreate_UsecaseError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/email", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockEmailNotificationUsecase{
+		CreateFunc: func(ctx context.Context, u *models.User) (*models.EmailNotificationSetting, error) {
+			return nil, errors.New("GitHubアカウントのメールアドレスが取得できていません")
+		},
+	}
+
+	ctrl := NewEmailNotificationController(mockUsecase)
+	err := ctrl.Create(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "メールアドレスが取得できていません")
+}
+
+func TestEmailVerify(t *testing.T) {
+	tests := []struct {
+		name       string
+		verifyErr  error
+		wantStatus int
+		wantBody   string
+	}{
+		{"success", nil, http.StatusOK, "メールアドレスを確認しました"},
+		{"invalid token", errors.New("確認リンクが無効か、有効期限が切れています"), http.StatusBadRequest, "有効期限が切れています"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/email/verify?token=abc.def", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			var captured string
+			mockUsecase := &mocks.MockEmailNotificationUsecase{
+				VerifyFunc: func(ctx context.Context, token string) error {
+					captured = token
+					return tt.verifyErr
+				},
+			}
+
+			ctrl := NewEmailNotificationController(mockUsecase)
+			err := ctrl.Verify(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "abc.def", captured)
+			assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/html")
+			assert.Contains(t, rec.Body.String(), tt.wantBody)
+		})
+	}
+}
+
+func TestEmailUnsubscribe_OneClickPost(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/email/unsubscribe?token=abc.def", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var captured string
+	mockUsecase := &mocks.MockEmailNotificationUsecase{
+		UnsubscribeFunc: func(ctx context.Context, token string) error {
+			captured = token
+			return nil
+		},
+	}
+
+	ctrl := NewEmailNotificationController(mockUsecase)
+	err := ctrl.Unsubscribe(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "abc.def", captured)
+	assert.Contains(t, rec.Body.String(), "配信を停止しました")
+}
@@ -0,0 +1,152 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// IInboxController 受信箱コントローラーのインターフェース
+type IInboxController interface {
+	GetInbox(c echo.Context) error
+	MarkRead(c echo.Context) error
+	MarkAllRead(c echo.Context) error
+}
+
+type inboxController struct {
+	inboxUsecase usecase.IInboxUsecase
+}
+
+// NewInboxController コンストラクタ
+func NewInboxController(inboxUsecase usecase.IInboxUsecase) IInboxController {
+	return &inboxController{
+		inboxUsecase: inboxUsecase,
+	}
+}
+
+// GetInbox 受信箱を取得
+// @Summary      受信箱を取得
+// @Description  ライバルアラート・サークルへの参加・同期の失敗・レポートの配信などのアプリ内通知を新しい順に返す
+// @Tags         inbox
+// @Produce      json
+// @Param        cursor query string false "前のページの next_cursor"
+// @Param        limit query int false "取得件数（既定20、最大100）"
+// @Param        unread_only query bool false "未読の通知だけを返す"
+// @Success      200 {object} dto.InboxResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/inbox [get]
+func (ctrl *inboxController) GetInbox(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var cursor uint64
+	if value := c.QueryParam("cursor"); value != "" {
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "cursorが不正です",
+			})
+		}
+		cursor = parsed
+	}
+	limit, ok := parseNonNegativeQuery(c, "limit")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "limitが不正です",
+		})
+	}
+	unreadOnly := false
+	if value := c.QueryParam("unread_only"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "unread_onlyが不正です",
+			})
+		}
+		unreadOnly = parsed
+	}
+
+	page, err := ctrl.inboxUsecase.GetInbox(c.Request().Context(), user.ID, cursor, limit, unreadOnly)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "受信箱の取得に失敗しました",
+		})
+	}
+
+	response := dto.InboxResponse{
+		Items:       make([]dto.InboxItemResponse, 0, len(page.Items)),
+		UnreadCount: page.UnreadCount,
+	}
+	if page.NextCursor != 0 {
+		response.NextCursor = strconv.FormatUint(page.NextCursor, 10)
+	}
+	for i := range page.Items {
+		response.Items = append(response.Items, toInboxItemResponse(&page.Items[i]))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// MarkRead 通知を既読にする
+// @Summary      通知を既読にする
+// @Tags         inbox
+// @Param        id path int true "通知ID"
+// @Success      204
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/inbox/{id}/read [put]
+func (ctrl *inboxController) MarkRead(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "通知IDが不正です",
+		})
+	}
+
+	if err := ctrl.inboxUsecase.MarkRead(c.Request().Context(), user.ID, id); err != nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// MarkAllRead すべての通知を既読にする
+// @Summary      すべての通知を既読にする
+// @Tags         inbox
+// @Success      204
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/inbox/read [put]
+func (ctrl *inboxController) MarkAllRead(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.inboxUsecase.MarkAllRead(c.Request().Context(), user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "通知を既読にできませんでした",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// toInboxItemResponse レスポンスに変換
+func toInboxItemResponse(notification *models.InAppNotification) dto.InboxItemResponse {
+	return dto.InboxItemResponse{
+		ID:        notification.ID,
+		Type:      string(notification.Type),
+		Payload:   notification.Payload,
+		IsRead:    notification.ReadAt != nil,
+		ReadAt:    notification.ReadAt,
+		CreatedAt: notification.CreatedAt,
+	}
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetInbox(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/inbox?cursor=50&limit=2&unread_only=true", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	readAt := time.Now()
+	var gotCursor uint64
+	var gotLimit int
+	var gotUnreadOnly bool
+	mockUsecase := &mocks.MockInboxUsecase{
+		GetInboxFunc: func(ctx context.Context, userID uint64, cursor uint64, limit int, unreadOnly bool) (*usecase.InboxPage, error) {
+			gotCursor, gotLimit, gotUnreadOnly = cursor, limit, unreadOnly
+			return &usecase.InboxPage{
+				Items: []models.InAppNotification{
+					{ID: 49, UserID: userID, Type: models.InAppNotificationRivalAlert, Payload: models.JSONPayload{"kind": "overtaken"}, CreatedAt: time.Now()},
+					{ID: 47, UserID: userID, Type: models.InAppNotificationSyncFailed, Payload: models.JSONPayload{"date": "2026-10-19"}, ReadAt: &readAt, CreatedAt: time.Now()},
+				},
+				NextCursor:  47,
+				UnreadCount: 5,
+			}, nil
+		},
+	}
+
+	ctrl := NewInboxController(mockUsecase)
+	err := ctrl.GetInbox(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, uint64(50), gotCursor)
+	assert.Equal(t, 2, gotLimit)
+	assert.True(t, gotUnreadOnly)
+	assert.Contains(t, rec.Body.String(), `"next_cursor":"47"`)
+	assert.Contains(t, rec.Body.String(), `"unread_count":5`)
+	assert.Contains(t, rec.Body.String(), `"type":"rival_alert"`)
+	assert.Contains(t, rec.Body.String(), `"is_read":true`)
+}
+
+func TestGetInbox_LastPage(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/inbox", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	ctrl := NewInboxController(&mocks.MockInboxUsecase{})
+	err := ctrl.GetInbox(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"items":[]`)
+	assert.NotContains(t, rec.Body.String(), "next_cursor")
+}
+
+func TestGetInbox_InvalidQuery(t *testing.T) {
+	for _, query := range []string{"cursor=abc", "limit=-1", "unread_only=maybe"} {
+		e := echo.New()
+		req := httptest.NewRequest(http.MethodGet, "/api/inbox?"+query, nil)
+		rec := httptest.NewRecorder()
+		c := e.NewContext(req, rec)
+		c.Set("user", &models.User{ID: 1})
+
+		ctrl := NewInboxController(&mocks.MockInboxUsecase{})
+		err := ctrl.GetInbox(c)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, query)
+	}
+}
+
+func TestMarkInboxRead(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/inbox/10/read", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("10")
+	c.Set("user", &models.User{ID: 1})
+
+	var gotID uint64
+	mockUsecase := &mocks.MockInboxUsecase{
+		MarkReadFunc: func(ctx context.Context, userID, id uint64) error {
+			gotID = id
+			return nil
+		},
+	}
+
+	ctrl := NewInboxController(mockUsecase)
+	err := ctrl.MarkRead(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, uint64(10), gotID)
+}
+
+func TestMarkInboxRead_NotFound(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/inbox/10/read", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("10")
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockInboxUsecase{
+		MarkReadFunc: func(ctx context.Context, userID, id uint64) error {
+			return errors.New("通知が見つかりません")
+		},
+	}
+
+	ctrl := NewInboxController(mockUsecase)
+	err := ctrl.MarkRead(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "通知が見つかりません")
+}
+
+func TestMarkInboxRead_InvalidID(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/inbox/abc/read", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	c.Set("user", &models.User{ID: 1})
+
+	ctrl := NewInboxController(&mocks.MockInboxUsecase{})
+	err := ctrl.MarkRead(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestMarkAllInboxRead(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/inbox/read", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	var gotUserID uint64
+	mockUsecase := &mocks.MockInboxUsecase{
+		MarkAllReadFunc: func(ctx context.Context, userID uint64) error {
+			gotUserID = userID
+			return nil
+		},
+	}
+
+	ctrl := NewInboxController(mockUsecase)
+	err := ctrl.MarkAllRead(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, uint64(1), gotUserID)
+}
@@ -0,0 +1,221 @@
+package controller
+
+import (
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// ILineNotificationController LINE通知コントローラーのインターフェース
+type ILineNotificationController interface {
+	GetSetting(c echo.Context) error
+	Link(c echo.Context) error
+	UpdateEnabled(c echo.Context) error
+	Delete(c echo.Context) error
+	Webhook(c echo.Context) error
+}
+
+type lineNotificationController struct {
+	lineNotificationUsecase usecase.ILineNotificationUsecase
+	channelSecret           string
+}
+
+// NewLineNotificationController コンストラクタ
+func NewLineNotificationController(lineNotificationUsecase usecase.ILineNotificationUsecase, channelSecret string) ILineNotificationController {
+	return &lineNotificationController{
+		lineNotificationUsecase: lineNotificationUsecase,
+		channelSecret:           channelSecret,
+	}
+}
+
+// GetSetting LINE通知設定を取得
+// @Summary      LINE通知設定を取得
+// @Description  現在のLINE通知設定を返す（LINEユーザーIDはマスク済み）
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.LineNotificationSettingResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/line [get]
+func (ctrl *lineNotificationController) GetSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	setting, err := ctrl.lineNotificationUsecase.GetSetting(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "LINE通知設定の取得に失敗しました",
+		})
+	}
+
+	if setting == nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "LINE通知設定が見つかりません",
+		})
+	}
+
+	return c.JSON(http.StatusOK, toLineNotificationSettingResponse(setting))
+}
+
+// Link LINEアカウントを連携
+// @Summary      LINEアカウントを連携
+// @Description  LINE公式アカウントを友だち追加した際に届く連携コードで、LINE通知設定を作成する
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.LinkLineNotificationRequest true "LINE連携リクエスト"
+// @Success      201 {object} dto.LineNotificationSettingResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/line [post]
+func (ctrl *lineNotificationController) Link(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.LinkLineNotificationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if req.LinkCode == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "連携コードを入力してください",
+		})
+	}
+
+	setting, err := ctrl.lineNotificationUsecase.Link(c.Request().Context(), user.ID, req.LinkCode)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, toLineNotificationSettingResponse(setting))
+}
+
+// UpdateEnabled LINE通知の有効/無効を更新
+// @Summary      LINE通知の有効/無効を更新
+// @Description  LINE通知設定の有効/無効を切り替える
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateEnabledRequest true "有効/無効更新リクエスト"
+// @Success      200 {object} dto.UpdateEnabledResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/line [put]
+func (ctrl *lineNotificationController) UpdateEnabled(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateEnabledRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if err := ctrl.lineNotificationUsecase.UpdateEnabled(c.Request().Context(), user.ID, req.IsEnabled); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "LINE通知設定の更新に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.UpdateEnabledResponse{
+		IsEnabled: req.IsEnabled,
+	})
+}
+
+// Delete LINE通知設定を削除
+// @Summary      LINE通知設定を削除
+// @Description  LINE通知設定を削除する
+// @Tags         notifications
+// @Success      204
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/line [delete]
+func (ctrl *lineNotificationController) Delete(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.lineNotificationUsecase.Delete(c.Request().Context(), user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "LINE通知設定の削除に失敗しました",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Webhook LINE Messaging APIのWebhookを受け取る
+// @Summary      LINE Webhook
+// @Description  友だち追加・ブロックなどのイベントを受け取る（X-Line-Signatureで検証）
+// @Tags         notifications
+// @Accept       json
+// @Success      200
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Router       /api/line/webhook [post]
+func (ctrl *lineNotificationController) Webhook(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if !gateway.VerifyLineSignature(ctrl.channelSecret, body, c.Request().Header.Get("X-Line-Signature")) {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "署名が不正です",
+		})
+	}
+
+	var req dto.LineWebhookRequest
+	if err := json.Unmarshal(body, &req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	// LINEは非200応答を再送するため、個別イベントの失敗はログに残して200を返す
+	ctx := c.Request().Context()
+	for _, event := range req.Events {
+		if event.Source.UserID == "" {
+			continue
+		}
+		switch event.Type {
+		case "follow":
+			if err := ctrl.lineNotificationUsecase.HandleFollow(ctx, event.Source.UserID, event.ReplyToken); err != nil {
+				log.Printf("Failed to handle LINE follow event: %v", err)
+			}
+		case "unfollow":
+			if err := ctrl.lineNotificationUsecase.HandleUnfollow(ctx, event.Source.UserID); err != nil {
+				log.Printf("Failed to handle LINE unfollow event: %v", err)
+			}
+		}
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// toLineNotificationSettingResponse レスポンスに変換（LINEユーザーIDはマスク）
+func toLineNotificationSettingResponse(setting *models.LineNotificationSetting) dto.LineNotificationSettingResponse {
+	lineUserID := setting.LineUserID
+	if len(lineUserID) > 5 {
+		lineUserID = lineUserID[:5] + "..."
+	}
+	return dto.LineNotificationSettingResponse{
+		ID:         setting.ID,
+		LineUserID: lineUserID,
+		IsEnabled:  setting.IsEnabled,
+		CreatedAt:  setting.CreatedAt,
+		UpdatedAt:  setting.UpdatedAt,
+	}
+}
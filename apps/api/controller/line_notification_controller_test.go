@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const testLineChannelSecret = "test-channel-secret"
+
+func signLineBody(body string) string {
+	mac := hmac.New(sha256.New, []byte(testLineChannelSecret))
+	mac.Write([]byte(body))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestLineGetSetting_MasksLineUserID(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/line", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockLineNotificationUsecase{
+		GetSettingFunc: func(ctx context.Context, userID uint64) (*models.LineNotificationSetting, error) {
+			return &models.LineNotificationSetting{
+				ID:         1,
+				UserID:     userID,
+				LineUserID: "U4af4980629abcdef",
+				IsEnabled:  true,
+				CreatedAt:  time.Now(),
+				UpdatedAt:  time.Now(),
+			}, nil
+		},
+	}
+
+	ctrl := NewLineNotificationController(mockUsecase, testLineChannelSecret)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"line_user_id":"U4af4..."`)
+	assert.NotContains(t, rec.Body.String(), "abcdef")
+}
+
+func TestLineLink_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/line", strings.NewReader(`{"link_code":"ABCD1234"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	var receivedCode string
+	mockUsecase := &mocks.MockLineNotificationUsecase{
+		LinkFunc: func(ctx context.Context, userID uint64, linkCode string) (*models.LineNotificationSetting, error) {
+			receivedCode = linkCode
+			return &models.LineNotificationSetting{ID: 1, UserID: userID, LineUserID: "U4af4980629", IsEnabled: true}, nil
+		},
+	}
+
+	ctrl := NewLineNotificationController(mockUsecase, testLineChannelSecret)
+	err := ctrl.Link(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "ABCD1234", receivedCode)
+}
+
+func TestLineLink_EmptyCode(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/line", strings.NewReader(`{"link_code":""}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	ctrl := NewLineNotificationController(&mocks.MockLineNotificationUsecase{}, testLineChannelSecret)
+	err := ctrl.Link(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestLineLink_UsecaseError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/line", strings.NewReader(`{"link_code":"EXPIRED1"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockLineNotificationUsecase{
+		LinkFunc: func(ctx context.Context, userID uint64, linkCode string) (*models.LineNotificationSetting, error) {
+			return nil, errors.New("連携コードが無効か、有効期限が切れています")
+		},
+	}
+
+	ctrl := NewLineNotificationController(mockUsecase, testLineChannelSecret)
+	err := ctrl.Link(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "有効期限")
+}
+
+func TestLineWebhook_FollowAndUnfollow(t *testing.T) {
+	body := `{"destination":"Uxxx","events":[` +
+		`{"type":"follow","replyToken":"reply-token","source":{"type":"user","userId":"U111"}},` +
+		`{"type":"unfollow","source":{"type":"user","userId":"U222"}},` +
+		`{"type":"message","replyToken":"other","source":{"type":"user","userId":"U333"}}]}`
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/line/webhook", strings.NewReader(body))
+	req.Header.Set("X-Line-Signature", signLineBody(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	var followed, replyToken, unfollowed string
+	mockUsecase := &mocks.MockLineNotificationUsecase{
+		HandleFollowFunc: func(ctx context.Context, lineUserID, token string) error {
+			followed = lineUserID
+			replyToken = token
+			return nil
+		},
+		HandleUnfollowFunc: func(ctx context.Context, lineUserID string) error {
+			unfollowed = lineUserID
+			return nil
+		},
+	}
+
+	ctrl := NewLineNotificationController(mockUsecase, testLineChannelSecret)
+	err := ctrl.Webhook(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "U111", followed)
+	assert.Equal(t, "reply-token", replyToken)
+	assert.Equal(t, "U222", unfollowed)
+}
+
+func TestLineWebhook_InvalidSignature(t *testing.T) {
+	body := `{"events":[{"type":"follow","replyToken":"t","source":{"type":"user","userId":"U111"}}]}`
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/line/webhook", strings.NewReader(body))
+	req.Header.Set("X-Line-Signature", base64.StdEncoding.EncodeToString([]byte("invalid")))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	called := false
+	mockUsecase := &mocks.MockLineNotificationUsecase{
+		HandleFollowFunc: func(ctx context.Context, lineUserID, token string) error {
+			called = true
+			return nil
+		},
+	}
+
+	ctrl := NewLineNotificationController(mockUsecase, testLineChannelSecret)
+	err := ctrl.Webhook(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, called)
+}
+
+func TestLineWebhook_HandlerErrorStillReturns200(t *testing.T) {
+	body := `{"events":[{"type":"follow","replyToken":"t","source":{"type":"user","userId":"U111"}}]}`
+
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/line/webhook", strings.NewReader(body))
+	req.Header.Set("X-Line-Signature", signLineBody(body))
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUsecase := &mocks.MockLineNotificationUsecase{
+		HandleFollowFunc: func(ctx context.Context, lineUserID, token string) error {
+			return errors.New("line api error")
+		},
+	}
+
+	ctrl := NewLineNotificationController(mockUsecase, testLineChannelSecret)
+	err := ctrl.Webhook(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// IMattermostNotificationController Mattermost通知コントローラーのインターフェース
+type IMattermostNotificationController interface {
+	GetSetting(c echo.Context) error
+	Create(c echo.Context) error
+	UpdateEnabled(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type mattermostNotificationController struct {
+	mattermostNotificationUsecase usecase.IMattermostNotificationUsecase
+}
+
+// NewMattermostNotificationController コンストラクタ
+func NewMattermostNotificationController(mattermostNotificationUsecase usecase.IMattermostNotificationUsecase) IMattermostNotificationController {
+	return &mattermostNotificationController{
+		mattermostNotificationUsecase: mattermostNotificationUsecase,
+	}
+}
+
+// GetSetting Mattermost通知設定を取得
+// @Summary      Mattermost通知設定を取得
+// @Description  現在のMattermost通知設定を返す（Webhook URLはマスク済み）
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.MattermostNotificationSettingResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/mattermost [get]
+func (ctrl *mattermostNotificationController) GetSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	setting, err := ctrl.mattermostNotificationUsecase.GetSetting(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Mattermost通知設定の取得に失敗しました",
+		})
+	}
+
+	if setting == nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Mattermost通知設定が見つかりません",
+		})
+	}
+
+	maskedURL := maskOutboundWebhookURL(setting.WebhookURL)
+
+	return c.JSON(http.StatusOK, dto.MattermostNotificationSettingResponse{
+		ID:         setting.ID,
+		WebhookURL: maskedURL,
+		IsEnabled:  setting.IsEnabled,
+		CreatedAt:  setting.CreatedAt,
+		UpdatedAt:  setting.UpdatedAt,
+	})
+}
+
+// isMattermostWebhookURL Mattermost の Incoming Webhook URL（https://<サーバー>/hooks/<ID>）かどうかを判定
+// セルフホストのためホスト名は問わないが、内部向けのアドレスは受け付けない
+func isMattermostWebhookURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || u.Host == "" || !isPublicURLHost(u) {
+		return false
+	}
+	i := strings.LastIndex(u.Path, "/hooks/")
+	if i < 0 {
+		return false
+	}
+	id := u.Path[i+len("/hooks/"):]
+	return id != "" && !strings.Contains(id, "/")
+}
+
+// Create Mattermost通知設定を作成
+// @Summary      Mattermost通知設定を作成
+// @Description  Mattermost Webhook URLを登録して通知設定を作成する
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateMattermostNotificationRequest true "Mattermost通知設定作成リクエスト"
+// @Success      201 {object} dto.MattermostNotificationSettingResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/mattermost [post]
+func (ctrl *mattermostNotificationController) Create(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.CreateMattermostNotificationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if req.WebhookURL == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Webhook URLを入力してください",
+		})
+	}
+
+	if !isMattermostWebhookURL(req.WebhookURL) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "無効なMattermost Webhook URLです。https://<サーバー>/hooks/ で始まるURLを入力してください",
+		})
+	}
+
+	setting, err := ctrl.mattermostNotificationUsecase.Create(c.Request().Context(), user.ID, req.WebhookURL)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Mattermost通知設定の作成に失敗しました",
+		})
+	}
+
+	maskedURL := maskOutboundWebhookURL(setting.WebhookURL)
+
+	return c.JSON(http.StatusCreated, dto.MattermostNotificationSettingResponse{
+		ID:         setting.ID,
+		WebhookURL: maskedURL,
+		IsEnabled:  setting.IsEnabled,
+		CreatedAt:  setting.CreatedAt,
+		UpdatedAt:  setting.UpdatedAt,
+	})
+}
+
+// UpdateEnabled Mattermost通知の有効/無効を更新
+// @Summary      Mattermost通知の有効/無効を更新
+// @Description  Mattermost通知設定の有効/無効を切り替える
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateEnabledRequest true "有効/無効更新リクエスト"
+// @Success      200 {object} dto.UpdateEnabledResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/mattermost [put]
+func (ctrl *mattermostNotificationController) UpdateEnabled(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateEnabledRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if err := ctrl.mattermostNotificationUsecase.UpdateEnabled(c.Request().Context(), user.ID, req.IsEnabled); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Mattermost通知設定の更新に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.UpdateEnabledResponse{
+		IsEnabled: req.IsEnabled,
+	})
+}
+
+// Delete Mattermost通知設定を削除
+// @Summary      Mattermost通知設定を削除
+// @Description  Mattermost通知設定を削除する
+// @Tags         notifications
+// @Success      204
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/mattermost [delete]
+func (ctrl *mattermostNotificationController) Delete(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.mattermostNotificationUsecase.Delete(c.Request().Context(), user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Mattermost通知設定の削除に失敗しました",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,144 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupMattermostNotificationControllerTest() (*echo.Echo, *models.User) {
+	e := echo.New()
+	user := &models.User{
+		ID:             1,
+		GithubUserID:   12345,
+		GithubUsername: "testuser",
+	}
+	return e, user
+}
+
+func TestMattermostGetSetting_Success(t *testing.T) {
+	e, user := setupMattermostNotificationControllerTest()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/mattermost", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", user)
+
+	mockUsecase := &mocks.MockMattermostNotificationUsecase{
+		GetSettingFunc: func(ctx context.Context, userID uint64) (*models.MattermostNotificationSetting, error) {
+			return &models.MattermostNotificationSetting{
+				ID:         1,
+				UserID:     userID,
+				WebhookURL: "https://mattermost.example.com/hooks/secret-token-value",
+				IsEnabled:  true,
+				CreatedAt:  time.Now(),
+				UpdatedAt:  time.Now(),
+			}, nil
+		},
+	}
+
+	ctrl := NewMattermostNotificationController(mockUsecase)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"is_enabled":true`)
+	assert.NotContains(t, rec.Body.String(), "secret-token-value")
+}
+
+func TestMattermostGetSetting_NotFound(t *testing.T) {
+	e, user := setupMattermostNotificationControllerTest()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/mattermost", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", user)
+
+	ctrl := NewMattermostNotificationController(&mocks.MockMattermostNotificationUsecase{})
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestMattermostCreate_Success(t *testing.T) {
+	tests := []struct {
+		name       string
+		webhookURL string
+	}{
+		{"root", "https://mattermost.example.com/hooks/xxxxxxxxxxxxxxxxxxxxxxxxxx"},
+		{"subpath", "https://example.com/mattermost/hooks/xxxxxxxxxxxxxxxxxxxxxxxxxx"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, user := setupMattermostNotificationControllerTest()
+			body := `{"webhook_url":"` + tt.webhookURL + `"}`
+			req := httptest.NewRequest(http.MethodPost, "/api/notifications/mattermost", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", user)
+
+			var captured string
+			mockUsecase := &mocks.MockMattermostNotificationUsecase{
+				CreateFunc: func(ctx context.Context, userID uint64, webhookURL string) (*models.MattermostNotificationSetting, error) {
+					captured = webhookURL
+					return &models.MattermostNotificationSetting{ID: 1, UserID: userID, WebhookURL: webhookURL, IsEnabled: true}, nil
+				},
+			}
+
+			ctrl := NewMattermostNotificationController(mockUsecase)
+			err := ctrl.Create(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Equal(t, tt.webhookURL, captured)
+		})
+	}
+}
+
+func TestMattermostCreate_InvalidURL(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"empty", `{"webhook_url":""}`},
+		{"http", `{"webhook_url":"http://mattermost.example.com/hooks/abc"}`},
+		{"missing hook id", `{"webhook_url":"https://mattermost.example.com/hooks/"}`},
+		{"not a hook", `{"webhook_url":"https://mattermost.example.com/api/v4/posts"}`},
+		{"loopback", `{"webhook_url":"https://127.0.0.1/hooks/abc"}`},
+		{"private address", `{"webhook_url":"https://10.0.0.5:8065/hooks/abc"}`},
+		{"localhost", `{"webhook_url":"https://localhost/hooks/abc"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, user := setupMattermostNotificationControllerTest()
+			req := httptest.NewRequest(http.MethodPost, "/api/notifications/mattermost", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", user)
+
+			mockUsecase := &mocks.MockMattermostNotificationUsecase{
+				CreateFunc: func(ctx context.Context, userID uint64, webhookURL string) (*models.MattermostNotificationSetting, error) {
+					t.Fatal("usecase must not be called")
+					return nil, nil
+				},
+			}
+
+			ctrl := NewMattermostNotificationController(mockUsecase)
+			err := ctrl.Create(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
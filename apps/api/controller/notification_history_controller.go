@@ -0,0 +1,193 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// INotificationHistoryController 通知履歴コントローラーのインターフェース
+type INotificationHistoryController interface {
+	GetHistory(c echo.Context) error
+	Resend(c echo.Context) error
+}
+
+type notificationHistoryController struct {
+	notificationHistoryUsecase usecase.INotificationHistoryUsecase
+}
+
+// NewNotificationHistoryController コンストラクタ
+func NewNotificationHistoryController(notificationHistoryUsecase usecase.INotificationHistoryUsecase) INotificationHistoryController {
+	return &notificationHistoryController{
+		notificationHistoryUsecase: notificationHistoryUsecase,
+	}
+}
+
+// GetHistory 通知履歴を取得
+// @Summary      通知履歴を取得
+// @Description  送信したレポートの履歴を新しい順に返す。送信した内容と失敗時のエラーメッセージを含む
+// @Tags         notifications
+// @Produce      json
+// @Param        channel query string false "チャンネルで絞り込み（slack / discord / line / webhook / email）"
+// @Param        period query string false "期間で絞り込み（weekly / monthly）"
+// @Param        status query string false "ステータスで絞り込み（success / failed / dead_letter / skipped / deferred）"
+// @Param        limit query int false "取得件数（既定20、最大100）"
+// @Param        offset query int false "読み飛ばす件数"
+// @Success      200 {object} dto.NotificationHistoryResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/history [get]
+func (ctrl *notificationHistoryController) GetHistory(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	limit, ok := parseNonNegativeQuery(c, "limit")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "limitが不正です",
+		})
+	}
+	offset, ok := parseNonNegativeQuery(c, "offset")
+	if !ok {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "offsetが不正です",
+		})
+	}
+
+	filter := repository.NotificationLogFilter{
+		ChannelType: models.ChannelType(c.QueryParam("channel")),
+		Period:      c.QueryParam("period"),
+		Status:      models.NotificationStatus(c.QueryParam("status")),
+	}
+	if filter.ChannelType != "" && !filter.ChannelType.IsValid() {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "チャンネルが不正です",
+		})
+	}
+	if filter.Period != "" && filter.Period != "weekly" && filter.Period != "monthly" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "期間はweeklyまたはmonthlyで指定してください",
+		})
+	}
+	switch filter.Status {
+	case "", models.NotificationStatusSuccess, models.NotificationStatusFailed, models.NotificationStatusDeadLetter,
+		models.NotificationStatusSkipped, models.NotificationStatusDeferred:
+	default:
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "ステータスが不正です",
+		})
+	}
+
+	logs, total, err := ctrl.notificationHistoryUsecase.GetHistory(c.Request().Context(), user.ID, filter, limit, offset)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "通知履歴の取得に失敗しました",
+		})
+	}
+
+	response := dto.NotificationHistoryResponse{
+		Items: make([]dto.NotificationHistoryItem, 0, len(logs)),
+		Total: total,
+	}
+	for i := range logs {
+		response.Items = append(response.Items, toNotificationHistoryItem(&logs[i]))
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Resend 過去のレポートを再送
+// @Summary      過去のレポートを再送
+// @Description  履歴のレポートを同じ集計期間で作り直し、現在の送信先に送り直す
+// @Tags         notifications
+// @Produce      json
+// @Param        id path int true "通知履歴ID"
+// @Success      200 {object} dto.NotificationHistoryItem
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/history/{id}/resend [post]
+func (ctrl *notificationHistoryController) Resend(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	logID, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "通知履歴IDが不正です",
+		})
+	}
+
+	notificationLog, err := ctrl.notificationHistoryUsecase.Resend(c.Request().Context(), user.ID, logID)
+	if err != nil {
+		if errors.Is(err, usecase.ErrNotificationDeliveryFailed) {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "再送に失敗しました。通知先の設定を確認してください",
+			})
+		}
+		return notificationSendError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, toNotificationHistoryItem(notificationLog))
+}
+
+// notificationSendError 再送・テスト送信のエラーをレスポンスにする
+// 送信先やDBのエラーの詳細は内部のアドレスなどを含みうるため返さない
+func notificationSendError(c echo.Context, err error) error {
+	switch {
+	case errors.Is(err, usecase.ErrNotificationLogNotFound):
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "通知履歴が見つかりません",
+		})
+	case errors.Is(err, usecase.ErrNotificationChannelUnsupported):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "このチャンネルには送信できません",
+		})
+	case errors.Is(err, usecase.ErrNotificationDestinationNotFound):
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "通知先が設定されていないか、無効になっています",
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+		Error: "通知の送信に失敗しました",
+	})
+}
+
+// parseNonNegativeQuery 0以上の整数のクエリパラメータを読む（未指定は0）
+func parseNonNegativeQuery(c echo.Context, name string) (int, bool) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return 0, true
+	}
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 0 {
+		return 0, false
+	}
+	return parsed, true
+}
+
+// toNotificationHistoryItem レスポンスに変換
+func toNotificationHistoryItem(notificationLog *models.NotificationLog) dto.NotificationHistoryItem {
+	item := dto.NotificationHistoryItem{
+		ID:           notificationLog.ID,
+		ChannelType:  string(notificationLog.ChannelType),
+		Period:       notificationLog.Period,
+		Status:       string(notificationLog.Status),
+		Payload:      notificationLog.Payload,
+		ErrorMessage: notificationLog.ErrorMessage,
+		Attempts:     notificationLog.Attempts,
+		NextRetryAt:  notificationLog.NextRetryAt,
+		SentAt:       notificationLog.SentAt,
+		UpdatedAt:    notificationLog.UpdatedAt,
+	}
+	if notificationLog.PeriodStart != nil {
+		item.PeriodStart = notificationLog.PeriodStart.Format("2006-01-02")
+	}
+	return item
+}
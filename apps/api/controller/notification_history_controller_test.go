@@ -0,0 +1,195 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNotificationHistory_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/history?channel=slack&period=weekly&status=failed&limit=10&offset=20", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	periodStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	sentAt := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	mockUsecase := &mocks.MockNotificationHistoryUsecase{
+		GetHistoryFunc: func(ctx context.Context, userID uint64, filter repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error) {
+			assert.Equal(t, uint64(1), userID)
+			assert.Equal(t, repository.NotificationLogFilter{ChannelType: models.ChannelTypeSlack, Period: "weekly", Status: models.NotificationStatusFailed}, filter)
+			assert.Equal(t, 10, limit)
+			assert.Equal(t, 20, offset)
+			return []models.NotificationLog{{
+				ID: 7, ChannelType: models.ChannelTypeSlack, Period: "weekly", PeriodStart: &periodStart,
+				Status: models.NotificationStatusFailed, Payload: models.JSONPayload{"text": "hi"}, ErrorMessage: "timeout",
+				Attempts: 2, SentAt: sentAt, UpdatedAt: sentAt,
+			}}, 21, nil
+		},
+	}
+
+	ctrl := NewNotificationHistoryController(mockUsecase)
+	err := ctrl.GetHistory(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"items": [{
+			"id": 7, "channel_type": "slack", "period": "weekly", "period_start": "2026-10-05",
+			"status": "failed", "payload": {"text": "hi"}, "error_message": "timeout", "attempts": 2,
+			"sent_at": "2026-10-12T09:00:00Z", "updated_at": "2026-10-12T09:00:00Z"
+		}],
+		"total": 21
+	}`, rec.Body.String())
+}
+
+func TestGetNotificationHistory_InvalidQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "limit", query: "limit=abc"},
+		{name: "offset", query: "offset=-1"},
+		{name: "channel", query: "channel=fax"},
+		{name: "period", query: "period=daily"},
+		{name: "status", query: "status=pending"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodGet, "/api/notifications/history?"+tt.query, nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", &models.User{ID: 1})
+
+			mockUsecase := &mocks.MockNotificationHistoryUsecase{
+				GetHistoryFunc: func(ctx context.Context, userID uint64, filter repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error) {
+					t.Fatal("usecase should not be called")
+					return nil, 0, nil
+				},
+			}
+
+			ctrl := NewNotificationHistoryController(mockUsecase)
+			err := ctrl.GetHistory(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestResendNotification_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/notifications/history/:id/resend")
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationHistoryUsecase{
+		ResendFunc: func(ctx context.Context, userID, logID uint64) (*models.NotificationLog, error) {
+			assert.Equal(t, uint64(1), userID)
+			assert.Equal(t, uint64(7), logID)
+			return &models.NotificationLog{ID: logID, ChannelType: models.ChannelTypeSlack, Period: "monthly", Status: models.NotificationStatusSuccess, Attempts: 2}, nil
+		},
+	}
+
+	ctrl := NewNotificationHistoryController(mockUsecase)
+	err := ctrl.Resend(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"status":"success"`)
+}
+
+func TestResendNotification_Error(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/notifications/history/:id/resend")
+	c.SetParamNames("id")
+	c.SetParamValues("7")
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationHistoryUsecase{
+		ResendFunc: func(ctx context.Context, userID, logID uint64) (*models.NotificationLog, error) {
+			return nil, usecase.ErrNotificationLogNotFound
+		},
+	}
+
+	ctrl := NewNotificationHistoryController(mockUsecase)
+	err := ctrl.Resend(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Contains(t, rec.Body.String(), "通知履歴が見つかりません")
+}
+
+func TestResendNotification_HidesInternalErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"delivery failure", fmt.Errorf("%w: dial tcp 10.0.0.5:443: connection refused", usecase.ErrNotificationDeliveryFailed), http.StatusBadRequest},
+		{"database failure", errors.New(`pq: relation "notification_logs" does not exist`), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.SetParamNames("id")
+			c.SetParamValues("7")
+			c.Set("user", &models.User{ID: 1})
+
+			mockUsecase := &mocks.MockNotificationHistoryUsecase{
+				ResendFunc: func(ctx context.Context, userID, logID uint64) (*models.NotificationLog, error) {
+					return nil, tt.err
+				},
+			}
+
+			ctrl := NewNotificationHistoryController(mockUsecase)
+			err := ctrl.Resend(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.NotContains(t, rec.Body.String(), "10.0.0.5")
+			assert.NotContains(t, rec.Body.String(), "pq:")
+		})
+	}
+}
+
+func TestResendNotification_InvalidID(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("id")
+	c.SetParamValues("abc")
+	c.Set("user", &models.User{ID: 1})
+
+	ctrl := NewNotificationHistoryController(&mocks.MockNotificationHistoryUsecase{})
+	err := ctrl.Resend(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
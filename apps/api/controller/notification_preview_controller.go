@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// INotificationPreviewController 通知のテスト送信・プレビューコントローラーのインターフェース
+type INotificationPreviewController interface {
+	SendTest(c echo.Context) error
+	Preview(c echo.Context) error
+}
+
+type notificationPreviewController struct {
+	notificationPreviewUsecase usecase.INotificationPreviewUsecase
+}
+
+// NewNotificationPreviewController コンストラクタ
+func NewNotificationPreviewController(notificationPreviewUsecase usecase.INotificationPreviewUsecase) INotificationPreviewController {
+	return &notificationPreviewController{
+		notificationPreviewUsecase: notificationPreviewUsecase,
+	}
+}
+
+// SendTest テスト通知を送信
+// @Summary      テスト通知を送信
+// @Description  設定した通知先に短いテスト通知を送り、届くかどうかを確認する
+// @Tags         notifications
+// @Produce      json
+// @Param        channel path string true "チャンネル（slack / discord / line / webhook / email）"
+// @Success      200 {object} dto.MessageResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/{channel}/test [post]
+func (ctrl *notificationPreviewController) SendTest(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	channelType := models.ChannelType(c.Param("channel"))
+	if !channelType.IsValid() {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "チャンネルが不正です",
+		})
+	}
+
+	if err := ctrl.notificationPreviewUsecase.SendTest(c.Request().Context(), user.ID, channelType); err != nil {
+		if errors.Is(err, usecase.ErrNotificationDeliveryFailed) {
+			return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+				Error: "テスト通知の送信に失敗しました。通知先の設定を確認してください",
+			})
+		}
+		return notificationSendError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "テスト通知を送信しました",
+	})
+}
+
+// Preview レポートのプレビューを取得
+// @Summary      レポートのプレビューを取得
+// @Description  今送信した場合のレポートを、有効なチャンネルごとにレンダリングして返す
+// @Tags         notifications
+// @Produce      json
+// @Param        period query string false "期間（weekly / monthly、既定はweekly）"
+// @Success      200 {object} dto.NotificationPreviewResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/preview [get]
+func (ctrl *notificationPreviewController) Preview(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	period := c.QueryParam("period")
+	if period == "" {
+		period = "weekly"
+	}
+	if period != "weekly" && period != "monthly" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "期間はweeklyまたはmonthlyで指定してください",
+		})
+	}
+
+	preview, err := ctrl.notificationPreviewUsecase.Preview(c.Request().Context(), user, period)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "レポートのプレビューに失敗しました",
+		})
+	}
+
+	response := dto.NotificationPreviewResponse{
+		Period:     preview.Report.Period,
+		RangeStart: preview.Report.RangeStart.Format("2006-01-02"),
+		RangeEnd:   preview.Report.RangeEnd.Format("2006-01-02"),
+		Channels:   make([]dto.NotificationChannelPreviewResponse, 0, len(preview.Channels)),
+	}
+	for _, channel := range preview.Channels {
+		response.Channels = append(response.Channels, dto.NotificationChannelPreviewResponse{
+			ChannelType: string(channel.ChannelType),
+			Payload:     channel.Payload,
+		})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,157 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendTestNotification_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetPath("/api/notifications/:channel/test")
+	c.SetParamNames("channel")
+	c.SetParamValues("slack")
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationPreviewUsecase{
+		SendTestFunc: func(ctx context.Context, userID uint64, channelType models.ChannelType) error {
+			assert.Equal(t, uint64(1), userID)
+			assert.Equal(t, models.ChannelTypeSlack, channelType)
+			return nil
+		},
+	}
+
+	ctrl := NewNotificationPreviewController(mockUsecase)
+	err := ctrl.SendTest(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "テスト通知を送信しました")
+}
+
+func TestSendTestNotification_InvalidChannel(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("channel")
+	c.SetParamValues("history")
+	c.Set("user", &models.User{ID: 1})
+
+	ctrl := NewNotificationPreviewController(&mocks.MockNotificationPreviewUsecase{})
+	err := ctrl.SendTest(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+func TestSendTestNotification_DeliveryError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("channel")
+	c.SetParamValues("discord")
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationPreviewUsecase{
+		SendTestFunc: func(ctx context.Context, userID uint64, channelType models.ChannelType) error {
+			return fmt.Errorf("%w: discord webhook returned status 404: Unknown Webhook", usecase.ErrNotificationDeliveryFailed)
+		},
+	}
+
+	ctrl := NewNotificationPreviewController(mockUsecase)
+	err := ctrl.SendTest(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "テスト通知の送信に失敗しました")
+	assert.NotContains(t, rec.Body.String(), "Unknown Webhook")
+}
+
+func TestSendTestNotification_UnexpectedError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.SetParamNames("channel")
+	c.SetParamValues("discord")
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationPreviewUsecase{
+		SendTestFunc: func(ctx context.Context, userID uint64, channelType models.ChannelType) error {
+			return errors.New("dial tcp: lookup db.internal: no such host")
+		},
+	}
+
+	ctrl := NewNotificationPreviewController(mockUsecase)
+	err := ctrl.SendTest(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "db.internal")
+}
+
+func TestPreviewNotification_DefaultsToWeekly(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/preview", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationPreviewUsecase{
+		PreviewFunc: func(ctx context.Context, user *models.User, period string) (*usecase.NotificationPreview, error) {
+			assert.Equal(t, "weekly", period)
+			return &usecase.NotificationPreview{
+				Report: &notifier.Report{
+					Period:     period,
+					RangeStart: time.Date(2026, 10, 11, 0, 0, 0, 0, time.Local),
+					RangeEnd:   time.Date(2026, 10, 17, 0, 0, 0, 0, time.Local),
+				},
+				Channels: []usecase.NotificationChannelPreview{
+					{ChannelType: models.ChannelTypeDiscord, Payload: models.JSONPayload{"content": "hi"}},
+				},
+			}, nil
+		},
+	}
+
+	ctrl := NewNotificationPreviewController(mockUsecase)
+	err := ctrl.Preview(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{
+		"period": "weekly",
+		"range_start": "2026-10-11",
+		"range_end": "2026-10-17",
+		"channels": [{"channel_type": "discord", "payload": {"content": "hi"}}]
+	}`, rec.Body.String())
+}
+
+func TestPreviewNotification_InvalidPeriod(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/preview?period=daily", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	ctrl := NewNotificationPreviewController(&mocks.MockNotificationPreviewUsecase{})
+	err := ctrl.Preview(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,130 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// INotificationScheduleController 通知スケジュールコントローラーのインターフェース
+type INotificationScheduleController interface {
+	GetSchedule(c echo.Context) error
+	UpdateSchedule(c echo.Context) error
+	UpdatePause(c echo.Context) error
+}
+
+type notificationScheduleController struct {
+	notificationScheduleUsecase usecase.INotificationScheduleUsecase
+}
+
+// NewNotificationScheduleController コンストラクタ
+func NewNotificationScheduleController(notificationScheduleUsecase usecase.INotificationScheduleUsecase) INotificationScheduleController {
+	return &notificationScheduleController{
+		notificationScheduleUsecase: notificationScheduleUsecase,
+	}
+}
+
+// GetSchedule 通知スケジュールを取得
+// @Summary      通知スケジュールを取得
+// @Description  週次・月次レポートを配信する曜日・日・時刻・タイムゾーンを返す。未設定の場合はデフォルト（月曜・1日 9:00 Asia/Tokyo）
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.NotificationScheduleResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/schedule [get]
+func (ctrl *notificationScheduleController) GetSchedule(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	schedule, err := ctrl.notificationScheduleUsecase.GetSchedule(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "通知スケジュールの取得に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusOK, toNotificationScheduleResponse(schedule))
+}
+
+// UpdateSchedule 通知スケジュールを更新
+// @Summary      通知スケジュールを更新
+// @Description  週次レポートの曜日、月次レポートの日、配信時刻とタイムゾーンを設定する
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateNotificationScheduleRequest true "通知スケジュール更新リクエスト"
+// @Success      200 {object} dto.NotificationScheduleResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/schedule [put]
+func (ctrl *notificationScheduleController) UpdateSchedule(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateNotificationScheduleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	schedule, err := ctrl.notificationScheduleUsecase.UpdateSchedule(c.Request().Context(), user.ID, req.WeeklyWeekday, req.MonthlyDay, req.DeliveryTime, req.Timezone)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, toNotificationScheduleResponse(schedule))
+}
+
+// UpdatePause 通知を止める時間帯・スヌーズを更新
+// @Summary      通知を止める時間帯・スヌーズを更新
+// @Description  夜間など通知を止める時間帯（配信スケジュールのタイムゾーン）と、休暇中などに通知を止める期限を設定する。時間帯のレポートは終わってから送り、スヌーズ中のレポートは送らない（skipped）。空の場合は解除
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateNotificationPauseRequest true "通知を止める時間帯・スヌーズの更新リクエスト"
+// @Success      200 {object} dto.NotificationScheduleResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/schedule/pause [put]
+func (ctrl *notificationScheduleController) UpdatePause(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateNotificationPauseRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	schedule, err := ctrl.notificationScheduleUsecase.UpdatePause(c.Request().Context(), user.ID, req.QuietHoursStart, req.QuietHoursEnd, req.SnoozeUntil)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, toNotificationScheduleResponse(schedule))
+}
+
+// toNotificationScheduleResponse レスポンスに変換
+func toNotificationScheduleResponse(schedule *models.NotificationSchedule) dto.NotificationScheduleResponse {
+	res := dto.NotificationScheduleResponse{
+		WeeklyWeekday:   schedule.WeeklyWeekday,
+		MonthlyDay:      schedule.MonthlyDay,
+		DeliveryTime:    schedule.DeliveryTime,
+		Timezone:        schedule.Timezone,
+		IsDefault:       schedule.ID == 0,
+		QuietHoursStart: schedule.QuietHoursStart,
+		QuietHoursEnd:   schedule.QuietHoursEnd,
+	}
+	if schedule.SnoozeUntil != nil {
+		snoozeUntil := schedule.SnoozeUntil.Format("2006-01-02")
+		res.SnoozeUntil = &snoozeUntil
+	}
+	return res
+}
@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNotificationSchedule_Default(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/schedule", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationScheduleUsecase{
+		GetScheduleFunc: func(ctx context.Context, userID uint64) (*models.NotificationSchedule, error) {
+			return models.DefaultNotificationSchedule(userID), nil
+		},
+	}
+
+	ctrl := NewNotificationScheduleController(mockUsecase)
+	err := ctrl.GetSchedule(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"weekly_weekday":1,"monthly_day":1,"delivery_time":"09:00","timezone":"Asia/Tokyo","is_default":true,"quiet_hours_start":"","quiet_hours_end":"","snooze_until":null}`, rec.Body.String())
+}
+
+func TestUpdateNotificationSchedule_Success(t *testing.T) {
+	e := echo.New()
+	body := `{"weekly_weekday":5,"monthly_day":15,"delivery_time":"18:30","timezone":"America/New_York"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/notifications/schedule", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationScheduleUsecase{
+		UpdateScheduleFunc: func(ctx context.Context, userID uint64, weeklyWeekday, monthlyDay int, deliveryTime, timezone string) (*models.NotificationSchedule, error) {
+			assert.Equal(t, uint64(1), userID)
+			return &models.NotificationSchedule{ID: 3, UserID: userID, WeeklyWeekday: weeklyWeekday, MonthlyDay: monthlyDay, DeliveryTime: deliveryTime, Timezone: timezone}, nil
+		},
+	}
+
+	ctrl := NewNotificationScheduleController(mockUsecase)
+	err := ctrl.UpdateSchedule(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"weekly_weekday":5,"monthly_day":15,"delivery_time":"18:30","timezone":"America/New_York","is_default":false,"quiet_hours_start":"","quiet_hours_end":"","snooze_until":null}`, rec.Body.String())
+}
+
+func TestUpdateNotificationSchedule_ValidationError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/notifications/schedule", strings.NewReader(`{"weekly_weekday":1,"monthly_day":1,"delivery_time":"09:00","timezone":"Mars/Olympus"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationScheduleUsecase{
+		UpdateScheduleFunc: func(ctx context.Context, userID uint64, weeklyWeekday, monthlyDay int, deliveryTime, timezone string) (*models.NotificationSchedule, error) {
+			return nil, errors.New("タイムゾーンが不正です")
+		},
+	}
+
+	ctrl := NewNotificationScheduleController(mockUsecase)
+	err := ctrl.UpdateSchedule(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "タイムゾーンが不正です")
+}
+
+func TestUpdateNotificationPause_Success(t *testing.T) {
+	e := echo.New()
+	body := `{"quiet_hours_start":"22:00","quiet_hours_end":"07:00","snooze_until":"2026-10-25"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/notifications/schedule/pause", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationScheduleUsecase{
+		UpdatePauseFunc: func(ctx context.Context, userID uint64, quietHoursStart, quietHoursEnd, snoozeUntil string) (*models.NotificationSchedule, error) {
+			schedule := models.DefaultNotificationSchedule(userID)
+			schedule.ID = 1
+			schedule.QuietHoursStart = quietHoursStart
+			schedule.QuietHoursEnd = quietHoursEnd
+			date, _ := time.Parse("2006-01-02", snoozeUntil)
+			schedule.SnoozeUntil = &date
+			return schedule, nil
+		},
+	}
+
+	ctrl := NewNotificationScheduleController(mockUsecase)
+	err := ctrl.UpdatePause(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"quiet_hours_start":"22:00","quiet_hours_end":"07:00","snooze_until":"2026-10-25"`)
+}
+
+func TestUpdateNotificationPause_ValidationError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/notifications/schedule/pause", strings.NewReader(`{"quiet_hours_start":"22:00"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockNotificationScheduleUsecase{
+		UpdatePauseFunc: func(ctx context.Context, userID uint64, quietHoursStart, quietHoursEnd, snoozeUntil string) (*models.NotificationSchedule, error) {
+			return nil, errors.New("通知を止める時間帯は開始と終了の両方を指定してください")
+		},
+	}
+
+	ctrl := NewNotificationScheduleController(mockUsecase)
+	err := ctrl.UpdatePause(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "開始と終了の両方")
+}
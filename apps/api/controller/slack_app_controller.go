@@ -0,0 +1,250 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// slackResponseTimeout response_url への応答にかける時間の上限
+const slackResponseTimeout = 30 * time.Second
+
+// ISlackAppController Slackアプリ（スラッシュコマンド・Interactivity・アカウント連携）コントローラーのインターフェース
+type ISlackAppController interface {
+	Command(c echo.Context) error
+	Interaction(c echo.Context) error
+	GetLink(c echo.Context) error
+	Link(c echo.Context) error
+	Unlink(c echo.Context) error
+}
+
+type slackAppController struct {
+	slackCommandUsecase usecase.ISlackCommandUsecase
+	signingSecret       string
+	// async Slackには3秒以内に応答する必要があるため、集計は応答後に行う
+	async func(task func())
+}
+
+// NewSlackAppController コンストラクタ
+func NewSlackAppController(slackCommandUsecase usecase.ISlackCommandUsecase, signingSecret string) ISlackAppController {
+	return &slackAppController{
+		slackCommandUsecase: slackCommandUsecase,
+		signingSecret:       signingSecret,
+		async:               func(task func()) { go task() },
+	}
+}
+
+// Command スラッシュコマンドを受け取る
+// @Summary      Slackスラッシュコマンド
+// @Description  `/commitly week` などを受け取り、すぐに200を返してから結果を response_url に送る（X-Slack-Signatureで検証）
+// @Tags         slack
+// @Accept       x-www-form-urlencoded
+// @Success      200
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Router       /api/slack/commands [post]
+func (ctrl *slackAppController) Command(c echo.Context) error {
+	form, ok := ctrl.verifiedForm(c)
+	if !ok {
+		return nil
+	}
+
+	command := usecase.SlackCommand{
+		TeamID:      form.Get("team_id"),
+		UserID:      form.Get("user_id"),
+		Text:        form.Get("text"),
+		ResponseURL: form.Get("response_url"),
+	}
+	if command.TeamID == "" || command.UserID == "" || command.ResponseURL == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	ctrl.async(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), slackResponseTimeout)
+		defer cancel()
+		if err := ctrl.slackCommandUsecase.HandleCommand(ctx, command); err != nil {
+			log.Printf("Failed to handle Slack command from %s/%s: %v", command.TeamID, command.UserID, err)
+		}
+	})
+
+	return c.NoContent(http.StatusOK)
+}
+
+// Interaction メッセージ上のボタン操作を受け取る
+// @Summary      Slack Interactivity
+// @Description  スラッシュコマンドの応答に付けたボタンの操作を受け取り、結果を response_url に送る（X-Slack-Signatureで検証）
+// @Tags         slack
+// @Accept       x-www-form-urlencoded
+// @Success      200
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Router       /api/slack/interactions [post]
+func (ctrl *slackAppController) Interaction(c echo.Context) error {
+	form, ok := ctrl.verifiedForm(c)
+	if !ok {
+		return nil
+	}
+
+	var payload dto.SlackInteractionPayload
+	if err := json.Unmarshal([]byte(form.Get("payload")), &payload); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+	if payload.Type != "block_actions" {
+		return c.NoContent(http.StatusOK)
+	}
+
+	for _, a := range payload.Actions {
+		action := usecase.SlackAction{
+			TeamID:      payload.Team.ID,
+			UserID:      payload.User.ID,
+			ActionID:    a.ActionID,
+			ResponseURL: payload.ResponseURL,
+		}
+		ctrl.async(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), slackResponseTimeout)
+			defer cancel()
+			if err := ctrl.slackCommandUsecase.HandleAction(ctx, action); err != nil {
+				log.Printf("Failed to handle Slack action %s from %s/%s: %v", action.ActionID, action.TeamID, action.UserID, err)
+			}
+		})
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// verifiedForm 署名を検証してフォームを返す
+// 検証に失敗した場合はエラーレスポンスを書き込んで false を返すため、呼び出し元はそのまま処理を終える
+func (ctrl *slackAppController) verifiedForm(c echo.Context) (url.Values, bool) {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+		return nil, false
+	}
+
+	header := c.Request().Header
+	if !gateway.VerifySlackSignature(ctrl.signingSecret, header.Get("X-Slack-Request-Timestamp"), body, header.Get("X-Slack-Signature"), time.Now()) {
+		c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "署名が不正です",
+		})
+		return nil, false
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+		return nil, false
+	}
+	return form, true
+}
+
+// GetLink Slack連携を取得
+// @Summary      Slack連携を取得
+// @Description  スラッシュコマンドに使うSlackアカウントの連携状況を返す
+// @Tags         slack
+// @Produce      json
+// @Success      200 {object} dto.SlackUserLinkResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/slack/link [get]
+func (ctrl *slackAppController) GetLink(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	link, err := ctrl.slackCommandUsecase.GetLink(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Slack連携の取得に失敗しました",
+		})
+	}
+	if link == nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Slackと連携されていません",
+		})
+	}
+
+	return c.JSON(http.StatusOK, toSlackUserLinkResponse(link))
+}
+
+// Link Slackアカウントを連携
+// @Summary      Slackアカウントを連携
+// @Description  スラッシュコマンドの応答で届いた連携コードで、SlackアカウントをCommitlyに連携する
+// @Tags         slack
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.LinkSlackRequest true "Slack連携リクエスト"
+// @Success      201 {object} dto.SlackUserLinkResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/slack/link [post]
+func (ctrl *slackAppController) Link(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.LinkSlackRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if req.LinkCode == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "連携コードを入力してください",
+		})
+	}
+
+	link, err := ctrl.slackCommandUsecase.Link(c.Request().Context(), user.ID, req.LinkCode)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, toSlackUserLinkResponse(link))
+}
+
+// Unlink Slack連携を解除
+// @Summary      Slack連携を解除
+// @Description  Slackアカウントとの連携を解除する
+// @Tags         slack
+// @Success      204
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/slack/link [delete]
+func (ctrl *slackAppController) Unlink(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.slackCommandUsecase.Unlink(c.Request().Context(), user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Slack連携の解除に失敗しました",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// toSlackUserLinkResponse レスポンスに変換
+func toSlackUserLinkResponse(link *models.SlackUserLink) dto.SlackUserLinkResponse {
+	return dto.SlackUserLinkResponse{
+		SlackTeamID: link.SlackTeamID,
+		SlackUserID: link.SlackUserID,
+		CreatedAt:   link.CreatedAt,
+	}
+}
@@ -0,0 +1,215 @@
+package controller
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSlackSigningSecret = "test-signing-secret"
+
+// newSlackAppRequest 署名付きのSlackアプリへのリクエストを組み立てる
+func newSlackAppRequest(path, body string) *http.Request {
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(testSlackSigningSecret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+// newSyncSlackAppController 応答後の処理をその場で実行するコントローラーを返す
+func newSyncSlackAppController(mockUsecase *mocks.MockSlackCommandUsecase) ISlackAppController {
+	ctrl := NewSlackAppController(mockUsecase, testSlackSigningSecret)
+	ctrl.(*slackAppController).async = func(task func()) { task() }
+	return ctrl
+}
+
+func TestSlackCommand_Success(t *testing.T) {
+	body := url.Values{
+		"team_id":      {"T1"},
+		"user_id":      {"U1"},
+		"command":      {"/commitly"},
+		"text":         {"vs alice"},
+		"response_url": {"https://hooks.slack.com/commands/1"},
+	}.Encode()
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newSlackAppRequest("/api/slack/commands", body), rec)
+
+	var received usecase.SlackCommand
+	mockUsecase := &mocks.MockSlackCommandUsecase{
+		HandleCommandFunc: func(ctx context.Context, command usecase.SlackCommand) error {
+			received = command
+			return nil
+		},
+	}
+
+	err := newSyncSlackAppController(mockUsecase).Command(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Body.String())
+	assert.Equal(t, usecase.SlackCommand{TeamID: "T1", UserID: "U1", Text: "vs alice", ResponseURL: "https://hooks.slack.com/commands/1"}, received)
+}
+
+func TestSlackCommand_InvalidSignature(t *testing.T) {
+	e := echo.New()
+	req := newSlackAppRequest("/api/slack/commands", "team_id=T1&user_id=U1&text=week&response_url=x")
+	req.Header.Set("X-Slack-Signature", "v0=invalid")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUsecase := &mocks.MockSlackCommandUsecase{
+		HandleCommandFunc: func(ctx context.Context, command usecase.SlackCommand) error {
+			t.Fatal("usecase should not be called for an invalid signature")
+			return nil
+		},
+	}
+
+	err := newSyncSlackAppController(mockUsecase).Command(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	// 署名の検証に失敗したら処理を続けず、レスポンスは1つだけ書き込む
+	assert.JSONEq(t, `{"error":"署名が不正です"}`, rec.Body.String())
+}
+
+func TestSlackInteraction_InvalidSignature(t *testing.T) {
+	payload := `{"type":"block_actions","team":{"id":"T1"},"user":{"id":"U1"},` +
+		`"response_url":"https://hooks.slack.com/actions/1","actions":[{"action_id":"` + gateway.SlackActionMonth + `"}]}`
+	e := echo.New()
+	req := newSlackAppRequest("/api/slack/interactions", url.Values{"payload": {payload}}.Encode())
+	req.Header.Set("X-Slack-Signature", "v0=invalid")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	mockUsecase := &mocks.MockSlackCommandUsecase{
+		HandleActionFunc: func(ctx context.Context, action usecase.SlackAction) error {
+			t.Fatal("usecase should not be called for an invalid signature")
+			return nil
+		},
+	}
+
+	err := newSyncSlackAppController(mockUsecase).Interaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.JSONEq(t, `{"error":"署名が不正です"}`, rec.Body.String())
+}
+
+func TestSlackCommand_UsecaseErrorStillReturns200(t *testing.T) {
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newSlackAppRequest("/api/slack/commands", "team_id=T1&user_id=U1&text=week&response_url=https%3A%2F%2Fhooks.slack.com%2Fcommands%2F1"), rec)
+
+	mockUsecase := &mocks.MockSlackCommandUsecase{
+		HandleCommandFunc: func(ctx context.Context, command usecase.SlackCommand) error {
+			return errors.New("database error")
+		},
+	}
+
+	err := newSyncSlackAppController(mockUsecase).Command(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestSlackInteraction_BlockActions(t *testing.T) {
+	payload := `{"type":"block_actions","team":{"id":"T1"},"user":{"id":"U1"},` +
+		`"response_url":"https://hooks.slack.com/actions/1","actions":[{"action_id":"` + gateway.SlackActionMonth + `"}]}`
+	body := url.Values{"payload": {payload}}.Encode()
+
+	e := echo.New()
+	rec := httptest.NewRecorder()
+	c := e.NewContext(newSlackAppRequest("/api/slack/interactions", body), rec)
+
+	var received usecase.SlackAction
+	mockUsecase := &mocks.MockSlackCommandUsecase{
+		HandleActionFunc: func(ctx context.Context, action usecase.SlackAction) error {
+			received = action
+			return nil
+		},
+	}
+
+	err := newSyncSlackAppController(mockUsecase).Interaction(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, usecase.SlackAction{TeamID: "T1", UserID: "U1", ActionID: gateway.SlackActionMonth, ResponseURL: "https://hooks.slack.com/actions/1"}, received)
+}
+
+func TestSlackLink_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/slack/link", strings.NewReader(`{"link_code":"ABCD1234"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockSlackCommandUsecase{
+		LinkFunc: func(ctx context.Context, userID uint64, linkCode string) (*models.SlackUserLink, error) {
+			return &models.SlackUserLink{UserID: userID, SlackTeamID: "T1", SlackUserID: "U1"}, nil
+		},
+	}
+
+	err := NewSlackAppController(mockUsecase, testSlackSigningSecret).Link(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"slack_user_id":"U1"`)
+}
+
+func TestSlackLink_UsecaseError(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/slack/link", strings.NewReader(`{"link_code":"EXPIRED1"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockSlackCommandUsecase{
+		LinkFunc: func(ctx context.Context, userID uint64, linkCode string) (*models.SlackUserLink, error) {
+			return nil, errors.New("連携コードが無効か、有効期限が切れています")
+		},
+	}
+
+	err := NewSlackAppController(mockUsecase, testSlackSigningSecret).Link(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "有効期限")
+}
+
+func TestSlackGetLink_NotLinked(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/slack/link", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	err := NewSlackAppController(&mocks.MockSlackCommandUsecase{}, testSlackSigningSecret).GetLink(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,196 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// teamsWebhookURLPatterns Teams の Webhook URLとして受け付けるホスト（テナントごとのサブドメイン）とパスの接頭辞
+var teamsWebhookURLPatterns = []struct {
+	hostSuffix string
+	pathPrefix string
+}{
+	{".webhook.office.com", "/webhookb2/"}, // Incoming Webhook コネクタ
+	{".logic.azure.com", "/workflows/"},    // Workflows（Power Automate）の Webhook
+}
+
+// ITeamsNotificationController Teams通知コントローラーのインターフェース
+type ITeamsNotificationController interface {
+	GetSetting(c echo.Context) error
+	Create(c echo.Context) error
+	UpdateEnabled(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type teamsNotificationController struct {
+	teamsNotificationUsecase usecase.ITeamsNotificationUsecase
+}
+
+// NewTeamsNotificationController コンストラクタ
+func NewTeamsNotificationController(teamsNotificationUsecase usecase.ITeamsNotificationUsecase) ITeamsNotificationController {
+	return &teamsNotificationController{
+		teamsNotificationUsecase: teamsNotificationUsecase,
+	}
+}
+
+// GetSetting Teams通知設定を取得
+// @Summary      Teams通知設定を取得
+// @Description  現在のTeams通知設定を返す（Webhook URLはマスク済み）
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.TeamsNotificationSettingResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/teams [get]
+func (ctrl *teamsNotificationController) GetSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	setting, err := ctrl.teamsNotificationUsecase.GetSetting(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Teams通知設定の取得に失敗しました",
+		})
+	}
+
+	if setting == nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Teams通知設定が見つかりません",
+		})
+	}
+
+	maskedURL := maskOutboundWebhookURL(setting.WebhookURL)
+
+	return c.JSON(http.StatusOK, dto.TeamsNotificationSettingResponse{
+		ID:         setting.ID,
+		WebhookURL: maskedURL,
+		IsEnabled:  setting.IsEnabled,
+		CreatedAt:  setting.CreatedAt,
+		UpdatedAt:  setting.UpdatedAt,
+	})
+}
+
+// isTeamsWebhookURL Teams の Webhook URLかどうかを判定
+func isTeamsWebhookURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" {
+		return false
+	}
+	for _, p := range teamsWebhookURLPatterns {
+		if strings.HasSuffix(u.Hostname(), p.hostSuffix) && strings.HasPrefix(u.Path, p.pathPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Create Teams通知設定を作成
+// @Summary      Teams通知設定を作成
+// @Description  Teams Webhook URLを登録して通知設定を作成する
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateTeamsNotificationRequest true "Teams通知設定作成リクエスト"
+// @Success      201 {object} dto.TeamsNotificationSettingResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/teams [post]
+func (ctrl *teamsNotificationController) Create(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.CreateTeamsNotificationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if req.WebhookURL == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Webhook URLを入力してください",
+		})
+	}
+
+	if !isTeamsWebhookURL(req.WebhookURL) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "無効なTeams Webhook URLです。https://<テナント>.webhook.office.com/webhookb2/ で始まるURLか、Workflows の Webhook URLを入力してください",
+		})
+	}
+
+	setting, err := ctrl.teamsNotificationUsecase.Create(c.Request().Context(), user.ID, req.WebhookURL)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Teams通知設定の作成に失敗しました",
+		})
+	}
+
+	maskedURL := maskOutboundWebhookURL(setting.WebhookURL)
+
+	return c.JSON(http.StatusCreated, dto.TeamsNotificationSettingResponse{
+		ID:         setting.ID,
+		WebhookURL: maskedURL,
+		IsEnabled:  setting.IsEnabled,
+		CreatedAt:  setting.CreatedAt,
+		UpdatedAt:  setting.UpdatedAt,
+	})
+}
+
+// UpdateEnabled Teams通知の有効/無効を更新
+// @Summary      Teams通知の有効/無効を更新
+// @Description  Teams通知設定の有効/無効を切り替える
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateEnabledRequest true "有効/無効更新リクエスト"
+// @Success      200 {object} dto.UpdateEnabledResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/teams [put]
+func (ctrl *teamsNotificationController) UpdateEnabled(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateEnabledRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if err := ctrl.teamsNotificationUsecase.UpdateEnabled(c.Request().Context(), user.ID, req.IsEnabled); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Teams通知設定の更新に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.UpdateEnabledResponse{
+		IsEnabled: req.IsEnabled,
+	})
+}
+
+// Delete Teams通知設定を削除
+// @Summary      Teams通知設定を削除
+// @Description  Teams通知設定を削除する
+// @Tags         notifications
+// @Success      204
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/teams [delete]
+func (ctrl *teamsNotificationController) Delete(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.teamsNotificationUsecase.Delete(c.Request().Context(), user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Teams通知設定の削除に失敗しました",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTeamsNotificationControllerTest() (*echo.Echo, *models.User) {
+	e := echo.New()
+	user := &models.User{
+		ID:             1,
+		GithubUserID:   12345,
+		GithubUsername: "testuser",
+	}
+	return e, user
+}
+
+func TestTeamsGetSetting_Success(t *testing.T) {
+	e, user := setupTeamsNotificationControllerTest()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/teams", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", user)
+
+	mockUsecase := &mocks.MockTeamsNotificationUsecase{
+		GetSettingFunc: func(ctx context.Context, userID uint64) (*models.TeamsNotificationSetting, error) {
+			return &models.TeamsNotificationSetting{
+				ID:         1,
+				UserID:     userID,
+				WebhookURL: "https://contoso.webhook.office.com/webhookb2/1111@2222/IncomingWebhook/secret-token-value/3333",
+				IsEnabled:  true,
+				CreatedAt:  time.Now(),
+				UpdatedAt:  time.Now(),
+			}, nil
+		},
+	}
+
+	ctrl := NewTeamsNotificationController(mockUsecase)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"is_enabled":true`)
+	assert.NotContains(t, rec.Body.String(), "secret-token-value")
+}
+
+func TestTeamsGetSetting_NotFound(t *testing.T) {
+	e, user := setupTeamsNotificationControllerTest()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/teams", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", user)
+
+	ctrl := NewTeamsNotificationController(&mocks.MockTeamsNotificationUsecase{})
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestTeamsCreate_Success(t *testing.T) {
+	tests := []struct {
+		name       string
+		webhookURL string
+	}{
+		{"incoming webhook", "https://contoso.webhook.office.com/webhookb2/1111@2222/IncomingWebhook/abc/3333"},
+		{"workflows", "https://prod-00.japaneast.logic.azure.com:443/workflows/abc/triggers/manual/paths/invoke?sig=xyz"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, user := setupTeamsNotificationControllerTest()
+			body := `{"webhook_url":"` + tt.webhookURL + `"}`
+			req := httptest.NewRequest(http.MethodPost, "/api/notifications/teams", strings.NewReader(body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", user)
+
+			var captured string
+			mockUsecase := &mocks.MockTeamsNotificationUsecase{
+				CreateFunc: func(ctx context.Context, userID uint64, webhookURL string) (*models.TeamsNotificationSetting, error) {
+					captured = webhookURL
+					return &models.TeamsNotificationSetting{ID: 1, UserID: userID, WebhookURL: webhookURL, IsEnabled: true}, nil
+				},
+			}
+
+			ctrl := NewTeamsNotificationController(mockUsecase)
+			err := ctrl.Create(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, rec.Code)
+			assert.Equal(t, tt.webhookURL, captured)
+		})
+	}
+}
+
+func TestTeamsCreate_InvalidURL(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"empty", `{"webhook_url":""}`},
+		{"slack url", `{"webhook_url":"https://hooks.slack.com/services/T000/B000/XXXX"}`},
+		{"http", `{"webhook_url":"http://contoso.webhook.office.com/webhookb2/abc"}`},
+		{"lookalike host", `{"webhook_url":"https://webhook.office.com.example.com/webhookb2/abc"}`},
+		{"wrong path", `{"webhook_url":"https://contoso.webhook.office.com/other/abc"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, user := setupTeamsNotificationControllerTest()
+			req := httptest.NewRequest(http.MethodPost, "/api/notifications/teams", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", user)
+
+			mockUsecase := &mocks.MockTeamsNotificationUsecase{
+				CreateFunc: func(ctx context.Context, userID uint64, webhookURL string) (*models.TeamsNotificationSetting, error) {
+					t.Fatal("usecase must not be called")
+					return nil, nil
+				},
+			}
+
+			ctrl := NewTeamsNotificationController(mockUsecase)
+			err := ctrl.Create(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
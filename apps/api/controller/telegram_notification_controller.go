@@ -0,0 +1,222 @@
+package controller
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// ITelegramNotificationController Telegram通知コントローラーのインターフェース
+type ITelegramNotificationController interface {
+	GetSetting(c echo.Context) error
+	IssueLink(c echo.Context) error
+	UpdateEnabled(c echo.Context) error
+	Delete(c echo.Context) error
+	Webhook(c echo.Context) error
+}
+
+type telegramNotificationController struct {
+	telegramNotificationUsecase usecase.ITelegramNotificationUsecase
+	webhookSecret               string
+}
+
+// NewTelegramNotificationController コンストラクタ
+func NewTelegramNotificationController(telegramNotificationUsecase usecase.ITelegramNotificationUsecase, webhookSecret string) ITelegramNotificationController {
+	return &telegramNotificationController{
+		telegramNotificationUsecase: telegramNotificationUsecase,
+		webhookSecret:               webhookSecret,
+	}
+}
+
+// GetSetting Telegram通知設定を取得
+// @Summary      Telegram通知設定を取得
+// @Description  現在のTelegram通知設定を返す（チャットIDはマスク済み）
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.TelegramNotificationSettingResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/telegram [get]
+func (ctrl *telegramNotificationController) GetSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	setting, err := ctrl.telegramNotificationUsecase.GetSetting(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Telegram通知設定の取得に失敗しました",
+		})
+	}
+
+	if setting == nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Telegram通知設定が見つかりません",
+		})
+	}
+
+	return c.JSON(http.StatusOK, toTelegramNotificationSettingResponse(setting))
+}
+
+// IssueLink Telegram連携リンクを発行
+// @Summary      Telegram連携リンクを発行
+// @Description  ボットを開いて /start を送ると、そのチャットにレポートが届くようになるリンクを発行する
+// @Tags         notifications
+// @Produce      json
+// @Success      201 {object} dto.TelegramLinkResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/telegram/link [post]
+func (ctrl *telegramNotificationController) IssueLink(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	link, err := ctrl.telegramNotificationUsecase.IssueLinkToken(c.Request().Context(), user.ID)
+	if err != nil {
+		log.Printf("Failed to issue Telegram link token: %v", err)
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Telegram連携リンクの発行に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, dto.TelegramLinkResponse{
+		DeepLink:  link.DeepLink,
+		ExpiresAt: link.ExpiresAt,
+	})
+}
+
+// UpdateEnabled Telegram通知の有効/無効を更新
+// @Summary      Telegram通知の有効/無効を更新
+// @Description  Telegram通知設定の有効/無効を切り替える
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateEnabledRequest true "有効/無効更新リクエスト"
+// @Success      200 {object} dto.UpdateEnabledResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/telegram [put]
+func (ctrl *telegramNotificationController) UpdateEnabled(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateEnabledRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if err := ctrl.telegramNotificationUsecase.UpdateEnabled(c.Request().Context(), user.ID, req.IsEnabled); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Telegram通知設定の更新に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.UpdateEnabledResponse{
+		IsEnabled: req.IsEnabled,
+	})
+}
+
+// Delete Telegram通知設定を削除
+// @Summary      Telegram通知設定を削除
+// @Description  Telegram通知設定を削除する
+// @Tags         notifications
+// @Success      204
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/telegram [delete]
+func (ctrl *telegramNotificationController) Delete(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.telegramNotificationUsecase.Delete(c.Request().Context(), user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Telegram通知設定の削除に失敗しました",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Webhook Telegram Bot APIのWebhookを受け取る
+// @Summary      Telegram Webhook
+// @Description  /start コマンドやボットのブロックを受け取る（X-Telegram-Bot-Api-Secret-Tokenで検証）
+// @Tags         notifications
+// @Accept       json
+// @Success      200
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      401 {object} dto.ErrorResponse
+// @Router       /api/telegram/webhook [post]
+func (ctrl *telegramNotificationController) Webhook(c echo.Context) error {
+	if !gateway.VerifyTelegramSecretToken(ctrl.webhookSecret, c.Request().Header.Get("X-Telegram-Bot-Api-Secret-Token")) {
+		return c.JSON(http.StatusUnauthorized, dto.ErrorResponse{
+			Error: "シークレットトークンが不正です",
+		})
+	}
+
+	var req dto.TelegramWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	// Telegramは非2xx応答を再送するため、処理の失敗はログに残して200を返す
+	ctx := c.Request().Context()
+	if req.Message != nil {
+		if token, ok := parseTelegramStartCommand(req.Message.Text); ok {
+			languageCode := ""
+			if req.Message.From != nil {
+				languageCode = req.Message.From.LanguageCode
+			}
+			if err := ctrl.telegramNotificationUsecase.HandleStart(ctx, req.Message.Chat.ID, token, languageCode); err != nil {
+				log.Printf("Failed to handle Telegram /start command: %v", err)
+			}
+		}
+	}
+	if req.MyChatMember != nil {
+		switch req.MyChatMember.NewChatMember.Status {
+		case "kicked", "left":
+			if err := ctrl.telegramNotificationUsecase.HandleBlocked(ctx, req.MyChatMember.Chat.ID); err != nil {
+				log.Printf("Failed to handle Telegram bot removal: %v", err)
+			}
+		}
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// parseTelegramStartCommand "/start <トークン>"（グループでは "/start@ボット名 <トークン>"）からトークンを取り出す
+func parseTelegramStartCommand(text string) (token string, ok bool) {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return "", false
+	}
+	if fields[0] != "/start" && !strings.HasPrefix(fields[0], "/start@") {
+		return "", false
+	}
+	if len(fields) > 1 {
+		token = fields[1]
+	}
+	return token, true
+}
+
+// toTelegramNotificationSettingResponse レスポンスに変換（チャットIDはマスク）
+func toTelegramNotificationSettingResponse(setting *models.TelegramNotificationSetting) dto.TelegramNotificationSettingResponse {
+	chatID := strconv.FormatInt(setting.ChatID, 10)
+	if len(chatID) > 4 {
+		chatID = chatID[:4] + "..."
+	}
+	return dto.TelegramNotificationSettingResponse{
+		ID:        setting.ID,
+		ChatID:    chatID,
+		IsEnabled: setting.IsEnabled,
+		CreatedAt: setting.CreatedAt,
+		UpdatedAt: setting.UpdatedAt,
+	}
+}
@@ -0,0 +1,227 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+const testTelegramWebhookSecret = "test-webhook-secret"
+
+func newTelegramWebhookContext(body, secret string) (echo.Context, *httptest.ResponseRecorder) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/telegram/webhook", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+func TestTelegramGetSetting_MasksChatID(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/telegram", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockTelegramNotificationUsecase{
+		GetSettingFunc: func(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error) {
+			return &models.TelegramNotificationSetting{
+				ID:        1,
+				UserID:    userID,
+				ChatID:    123456789,
+				IsEnabled: true,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}, nil
+		},
+	}
+
+	ctrl := NewTelegramNotificationController(mockUsecase, testTelegramWebhookSecret)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"chat_id":"1234..."`)
+	assert.NotContains(t, rec.Body.String(), "123456789")
+}
+
+func TestTelegramGetSetting_NotFound(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/telegram", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	ctrl := NewTelegramNotificationController(&mocks.MockTelegramNotificationUsecase{}, testTelegramWebhookSecret)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestTelegramIssueLink_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/telegram/link", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockTelegramNotificationUsecase{
+		IssueLinkTokenFunc: func(ctx context.Context, userID uint64) (*usecase.TelegramLink, error) {
+			return &usecase.TelegramLink{Token: "0123abcd", DeepLink: "https://t.me/commitly_bot?start=0123abcd", ExpiresAt: time.Now().Add(30 * time.Minute)}, nil
+		},
+	}
+
+	ctrl := NewTelegramNotificationController(mockUsecase, testTelegramWebhookSecret)
+	err := ctrl.IssueLink(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"deep_link":"https://t.me/commitly_bot?start=0123abcd"`)
+}
+
+func TestTelegramIssueLink_Error(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/telegram/link", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockTelegramNotificationUsecase{
+		IssueLinkTokenFunc: func(ctx context.Context, userID uint64) (*usecase.TelegramLink, error) {
+			return nil, errors.New("Telegram bot username is not configured")
+		},
+	}
+
+	ctrl := NewTelegramNotificationController(mockUsecase, testTelegramWebhookSecret)
+	err := ctrl.IssueLink(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+}
+
+func TestTelegramWebhook_Start(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantToken string
+	}{
+		{"private chat", "/start 0123abcd", "0123abcd"},
+		{"group chat", "/start@commitly_bot 0123abcd", "0123abcd"},
+		{"without token", "/start", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := `{"update_id":1,"message":{"chat":{"id":222,"type":"private"},"from":{"language_code":"en"},"text":"` + tt.text + `"}}`
+			c, rec := newTelegramWebhookContext(body, testTelegramWebhookSecret)
+
+			called := false
+			var gotChatID int64
+			var gotToken, gotLanguage string
+			mockUsecase := &mocks.MockTelegramNotificationUsecase{
+				HandleStartFunc: func(ctx context.Context, chatID int64, token, languageCode string) error {
+					called = true
+					gotChatID, gotToken, gotLanguage = chatID, token, languageCode
+					return nil
+				},
+			}
+
+			ctrl := NewTelegramNotificationController(mockUsecase, testTelegramWebhookSecret)
+			err := ctrl.Webhook(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.True(t, called)
+			assert.Equal(t, int64(222), gotChatID)
+			assert.Equal(t, tt.wantToken, gotToken)
+			assert.Equal(t, "en", gotLanguage)
+		})
+	}
+}
+
+func TestTelegramWebhook_IgnoresOtherMessages(t *testing.T) {
+	body := `{"update_id":1,"message":{"chat":{"id":222,"type":"private"},"text":"hello"}}`
+	c, rec := newTelegramWebhookContext(body, testTelegramWebhookSecret)
+
+	mockUsecase := &mocks.MockTelegramNotificationUsecase{
+		HandleStartFunc: func(ctx context.Context, chatID int64, token, languageCode string) error {
+			t.Fatal("usecase must not be called")
+			return nil
+		},
+	}
+
+	ctrl := NewTelegramNotificationController(mockUsecase, testTelegramWebhookSecret)
+	err := ctrl.Webhook(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestTelegramWebhook_Blocked(t *testing.T) {
+	body := `{"update_id":1,"my_chat_member":{"chat":{"id":222,"type":"private"},"new_chat_member":{"status":"kicked"}}}`
+	c, rec := newTelegramWebhookContext(body, testTelegramWebhookSecret)
+
+	var blocked int64
+	mockUsecase := &mocks.MockTelegramNotificationUsecase{
+		HandleBlockedFunc: func(ctx context.Context, chatID int64) error {
+			blocked = chatID
+			return nil
+		},
+	}
+
+	ctrl := NewTelegramNotificationController(mockUsecase, testTelegramWebhookSecret)
+	err := ctrl.Webhook(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, int64(222), blocked)
+}
+
+func TestTelegramWebhook_InvalidSecret(t *testing.T) {
+	body := `{"update_id":1,"message":{"chat":{"id":222,"type":"private"},"text":"/start 0123abcd"}}`
+	c, rec := newTelegramWebhookContext(body, "wrong-secret")
+
+	called := false
+	mockUsecase := &mocks.MockTelegramNotificationUsecase{
+		HandleStartFunc: func(ctx context.Context, chatID int64, token, languageCode string) error {
+			called = true
+			return nil
+		},
+	}
+
+	ctrl := NewTelegramNotificationController(mockUsecase, testTelegramWebhookSecret)
+	err := ctrl.Webhook(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, called)
+}
+
+func TestTelegramWebhook_HandlerErrorStillReturns200(t *testing.T) {
+	body := `{"update_id":1,"message":{"chat":{"id":222,"type":"private"},"text":"/start 0123abcd"}}`
+	c, rec := newTelegramWebhookContext(body, testTelegramWebhookSecret)
+
+	mockUsecase := &mocks.MockTelegramNotificationUsecase{
+		HandleStartFunc: func(ctx context.Context, chatID int64, token, languageCode string) error {
+			return errors.New("telegram api error")
+		},
+	}
+
+	ctrl := NewTelegramNotificationController(mockUsecase, testTelegramWebhookSecret)
+	err := ctrl.Webhook(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
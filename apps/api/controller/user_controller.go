@@ -12,6 +12,8 @@ import (
 // IUserController ユーザーコントローラーのインターフェース
 type IUserController interface {
 	GetMe(c echo.Context) error
+	DismissNotificationAlert(c echo.Context) error
+	UpdateLocale(c echo.Context) error
 }
 
 type userController struct {
@@ -37,10 +39,64 @@ func (ctrl *userController) GetMe(c echo.Context) error {
 	user := c.Get("user").(*models.User)
 
 	return c.JSON(http.StatusOK, dto.UserResponse{
-		ID:             user.ID,
-		GithubUserID:   user.GithubUserID,
-		GithubUsername: user.GithubUsername,
-		AvatarURL:      user.AvatarURL,
-		CreatedAt:      user.CreatedAt,
+		ID:                  user.ID,
+		GithubUserID:        user.GithubUserID,
+		GithubUsername:      user.GithubUsername,
+		AvatarURL:           user.AvatarURL,
+		Locale:              user.Locale,
+		CreatedAt:           user.CreatedAt,
+		NotificationAlertAt: user.NotificationAlertAt,
+	})
+}
+
+// DismissNotificationAlert 通知チャンネルの自動停止のお知らせを確認済みにする
+// @Summary      通知の自動停止のお知らせを確認済みにする
+// @Description  送信先エラーが続いて通知チャンネルが自動停止されたことを確認済みにし、/api/me の notification_alert_at を消す
+// @Tags         user
+// @Success      204
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/me/notification-alert [delete]
+func (ctrl *userController) DismissNotificationAlert(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.userUsecase.DismissNotificationAlert(c.Request().Context(), user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "お知らせの更新に失敗しました",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// UpdateLocale 通知メッセージの言語を変更する
+// @Summary      通知メッセージの言語を変更
+// @Description  週次・月次レポートやテスト通知の言語を変更する（ja / en）
+// @Tags         user
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateLocaleRequest true "言語変更リクエスト"
+// @Success      200 {object} dto.MessageResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/me/locale [put]
+func (ctrl *userController) UpdateLocale(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateLocaleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if err := ctrl.userUsecase.UpdateLocale(c.Request().Context(), user.ID, req.Locale); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.MessageResponse{
+		Message: "言語を変更しました",
 	})
 }
@@ -1,8 +1,11 @@
 package controller
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -42,3 +45,88 @@ func TestGetMe_Success(t *testing.T) {
 	// email should NOT be in response
 	assert.NotContains(t, rec.Body.String(), `"email"`)
 }
+
+func TestGetMe_NotificationAlert(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/me", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	alertAt := time.Date(2026, 10, 12, 9, 0, 0, 0, time.UTC)
+	c.Set("user", &models.User{ID: 1, GithubUsername: "testuser", NotificationAlertAt: &alertAt})
+
+	ctrl := NewUserController(&mocks.MockUserUsecase{})
+	err := ctrl.GetMe(c)
+
+	assert.NoError(t, err)
+	assert.Contains(t, rec.Body.String(), `"notification_alert_at":"2026-10-12T09:00:00Z"`)
+}
+
+func TestDismissNotificationAlert(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/api/me/notification-alert", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	var dismissedUserID uint64
+	mockUserUsecase := &mocks.MockUserUsecase{
+		DismissNotificationAlertFunc: func(ctx context.Context, userID uint64) error {
+			dismissedUserID = userID
+			return nil
+		},
+	}
+
+	ctrl := NewUserController(mockUserUsecase)
+	err := ctrl.DismissNotificationAlert(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, uint64(1), dismissedUserID)
+}
+
+func TestUpdateLocale_Success(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/me/locale", strings.NewReader(`{"locale": "en"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	var updatedLocale string
+	mockUserUsecase := &mocks.MockUserUsecase{
+		UpdateLocaleFunc: func(ctx context.Context, userID uint64, locale string) error {
+			updatedLocale = locale
+			return nil
+		},
+	}
+
+	ctrl := NewUserController(mockUserUsecase)
+	err := ctrl.UpdateLocale(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "en", updatedLocale)
+}
+
+func TestUpdateLocale_Unsupported(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPut, "/api/me/locale", strings.NewReader(`{"locale": "fr"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUserUsecase := &mocks.MockUserUsecase{
+		UpdateLocaleFunc: func(ctx context.Context, userID uint64, locale string) error {
+			return errors.New("対応していない言語です: fr")
+		},
+	}
+
+	ctrl := NewUserController(mockUserUsecase)
+	err := ctrl.UpdateLocale(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "対応していない言語です")
+}
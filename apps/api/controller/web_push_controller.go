@@ -0,0 +1,135 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// IWebPushController Web Push通知コントローラーのインターフェース
+type IWebPushController interface {
+	GetSetting(c echo.Context) error
+	Subscribe(c echo.Context) error
+	Unsubscribe(c echo.Context) error
+}
+
+type webPushController struct {
+	webPushUsecase usecase.IWebPushUsecase
+}
+
+// NewWebPushController コンストラクタ
+func NewWebPushController(webPushUsecase usecase.IWebPushUsecase) IWebPushController {
+	return &webPushController{
+		webPushUsecase: webPushUsecase,
+	}
+}
+
+// GetSetting Web Push設定を取得
+// @Summary      Web Push設定を取得
+// @Description  購読に使うVAPID公開鍵と、登録済みの端末の一覧を返す（エンドポイントはマスク済み）
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.WebPushSettingResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/webpush [get]
+func (ctrl *webPushController) GetSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	subscriptions, err := ctrl.webPushUsecase.GetSubscriptions(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Web Push設定の取得に失敗しました",
+		})
+	}
+
+	res := dto.WebPushSettingResponse{
+		VAPIDPublicKey: ctrl.webPushUsecase.VAPIDPublicKey(),
+		Subscriptions:  make([]dto.WebPushSubscriptionResponse, 0, len(subscriptions)),
+	}
+	for _, s := range subscriptions {
+		res.Subscriptions = append(res.Subscriptions, toWebPushSubscriptionResponse(&s))
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+// Subscribe Web Push購読を登録
+// @Summary      Web Push購読を登録
+// @Description  ブラウザの PushSubscription を登録する（同じエンドポイントは上書き）
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.SubscribeWebPushRequest true "Web Push購読リクエスト"
+// @Success      201 {object} dto.WebPushSubscriptionResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/webpush/subscriptions [post]
+func (ctrl *webPushController) Subscribe(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.SubscribeWebPushRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	subscription, err := ctrl.webPushUsecase.Subscribe(
+		c.Request().Context(),
+		user.ID,
+		req.Endpoint,
+		req.Keys.P256dh,
+		req.Keys.Auth,
+		c.Request().UserAgent(),
+	)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusCreated, toWebPushSubscriptionResponse(subscription))
+}
+
+// Unsubscribe Web Push購読を解除
+// @Summary      Web Push購読を解除
+// @Description  指定したエンドポイントの購読を削除する
+// @Tags         notifications
+// @Accept       json
+// @Param        request body dto.UnsubscribeWebPushRequest true "Web Push購読解除リクエスト"
+// @Success      204
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/webpush/subscriptions [delete]
+func (ctrl *webPushController) Unsubscribe(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UnsubscribeWebPushRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if err := ctrl.webPushUsecase.Unsubscribe(c.Request().Context(), user.ID, req.Endpoint); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// toWebPushSubscriptionResponse レスポンスに変換（エンドポイントはマスク）
+func toWebPushSubscriptionResponse(subscription *models.WebPushSubscription) dto.WebPushSubscriptionResponse {
+	return dto.WebPushSubscriptionResponse{
+		ID:        subscription.ID,
+		Endpoint:  maskOutboundWebhookURL(subscription.Endpoint),
+		UserAgent: subscription.UserAgent,
+		CreatedAt: subscription.CreatedAt,
+	}
+}
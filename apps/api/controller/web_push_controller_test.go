@@ -0,0 +1,133 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebPushGetSetting_MasksEndpoints(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/webpush", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockWebPushUsecase{
+		VAPIDPublicKeyFunc: func() string { return "BPublicKey" },
+		GetSubscriptionsFunc: func(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error) {
+			return []models.WebPushSubscription{
+				{ID: 1, UserID: userID, Endpoint: "https://fcm.googleapis.com/fcm/send/secret-token", UserAgent: "Firefox", CreatedAt: time.Now()},
+			}, nil
+		},
+	}
+
+	ctrl := NewWebPushController(mockUsecase)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"vapid_public_key":"BPublicKey"`)
+	assert.Contains(t, rec.Body.String(), `"endpoint":"https://fcm.googleapis.com/..."`)
+	assert.NotContains(t, rec.Body.String(), "secret-token")
+}
+
+func TestWebPushGetSetting_Empty(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/webpush", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	ctrl := NewWebPushController(&mocks.MockWebPushUsecase{})
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"subscriptions":[]`)
+}
+
+func TestWebPushSubscribe(t *testing.T) {
+	e := echo.New()
+	body := `{"endpoint":"https://push.example.com/abc","expirationTime":null,"keys":{"p256dh":"BKey","auth":"secret"}}`
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/webpush/subscriptions", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("User-Agent", "Mozilla/5.0 Firefox")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	var gotEndpoint, gotP256dh, gotAuth, gotUserAgent string
+	mockUsecase := &mocks.MockWebPushUsecase{
+		SubscribeFunc: func(ctx context.Context, userID uint64, endpoint, p256dh, auth, userAgent string) (*models.WebPushSubscription, error) {
+			gotEndpoint, gotP256dh, gotAuth, gotUserAgent = endpoint, p256dh, auth, userAgent
+			return &models.WebPushSubscription{ID: 1, UserID: userID, Endpoint: endpoint, UserAgent: userAgent}, nil
+		},
+	}
+
+	ctrl := NewWebPushController(mockUsecase)
+	err := ctrl.Subscribe(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "https://push.example.com/abc", gotEndpoint)
+	assert.Equal(t, "BKey", gotP256dh)
+	assert.Equal(t, "secret", gotAuth)
+	assert.Equal(t, "Mozilla/5.0 Firefox", gotUserAgent)
+	assert.Contains(t, rec.Body.String(), `"endpoint":"https://push.example.com/..."`)
+}
+
+func TestWebPushSubscribe_Invalid(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/webpush/subscriptions", strings.NewReader(`{"endpoint":"http://push.example.com"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockWebPushUsecase{
+		SubscribeFunc: func(ctx context.Context, userID uint64, endpoint, p256dh, auth, userAgent string) (*models.WebPushSubscription, error) {
+			return nil, errors.New("購読のエンドポイントが不正です")
+		},
+	}
+
+	ctrl := NewWebPushController(mockUsecase)
+	err := ctrl.Subscribe(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "購読のエンドポイントが不正です")
+}
+
+func TestWebPushUnsubscribe(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodDelete, "/api/notifications/webpush/subscriptions", strings.NewReader(`{"endpoint":"https://push.example.com/abc"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	var gotEndpoint string
+	mockUsecase := &mocks.MockWebPushUsecase{
+		UnsubscribeFunc: func(ctx context.Context, userID uint64, endpoint string) error {
+			gotEndpoint = endpoint
+			return nil
+		},
+	}
+
+	ctrl := NewWebPushController(mockUsecase)
+	err := ctrl.Unsubscribe(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://push.example.com/abc", gotEndpoint)
+}
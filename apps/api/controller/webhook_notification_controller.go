@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/keeee21/commitly/api/dto"
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+	"github.com/labstack/echo/v4"
+)
+
+// IWebhookNotificationController 汎用Webhook通知コントローラーのインターフェース
+type IWebhookNotificationController interface {
+	GetSetting(c echo.Context) error
+	Create(c echo.Context) error
+	RotateSecret(c echo.Context) error
+	UpdateEnabled(c echo.Context) error
+	Delete(c echo.Context) error
+}
+
+type webhookNotificationController struct {
+	webhookNotificationUsecase usecase.IWebhookNotificationUsecase
+}
+
+// NewWebhookNotificationController コンストラクタ
+func NewWebhookNotificationController(webhookNotificationUsecase usecase.IWebhookNotificationUsecase) IWebhookNotificationController {
+	return &webhookNotificationController{
+		webhookNotificationUsecase: webhookNotificationUsecase,
+	}
+}
+
+// GetSetting 汎用Webhook通知設定を取得
+// @Summary      汎用Webhook通知設定を取得
+// @Description  現在の汎用Webhook通知設定を返す（URLはマスク済み、シークレットは含まない）
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.WebhookNotificationSettingResponse
+// @Failure      404 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/webhook [get]
+func (ctrl *webhookNotificationController) GetSetting(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	setting, err := ctrl.webhookNotificationUsecase.GetSetting(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Webhook通知設定の取得に失敗しました",
+		})
+	}
+
+	if setting == nil {
+		return c.JSON(http.StatusNotFound, dto.ErrorResponse{
+			Error: "Webhook通知設定が見つかりません",
+		})
+	}
+
+	return c.JSON(http.StatusOK, toWebhookNotificationSettingResponse(setting, false))
+}
+
+// Create 汎用Webhook通知設定を作成
+// @Summary      汎用Webhook通知設定を作成
+// @Description  レポートの送信先URLを登録する。初回作成時に署名用のシークレットを発行し、レスポンスに含める
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.CreateWebhookNotificationRequest true "汎用Webhook通知設定作成リクエスト"
+// @Success      201 {object} dto.WebhookNotificationSettingResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/webhook [post]
+func (ctrl *webhookNotificationController) Create(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.CreateWebhookNotificationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if req.URL == "" {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "Webhook URLを入力してください",
+		})
+	}
+
+	if !isOutboundWebhookURL(req.URL) {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "無効なWebhook URLです。https:// で始まる外部のURLを入力してください",
+		})
+	}
+
+	setting, err := ctrl.webhookNotificationUsecase.Create(c.Request().Context(), user.ID, req.URL)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Webhook通知設定の作成に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusCreated, toWebhookNotificationSettingResponse(setting, true))
+}
+
+// RotateSecret 署名用シークレットを再発行
+// @Summary      汎用Webhookの署名用シークレットを再発行
+// @Description  X-Commitly-Signature の署名に使うシークレットを再発行し、新しいシークレットを返す
+// @Tags         notifications
+// @Produce      json
+// @Success      200 {object} dto.WebhookNotificationSettingResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/webhook/secret [post]
+func (ctrl *webhookNotificationController) RotateSecret(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	setting, err := ctrl.webhookNotificationUsecase.RotateSecret(c.Request().Context(), user.ID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, toWebhookNotificationSettingResponse(setting, true))
+}
+
+// UpdateEnabled 汎用Webhook通知の有効/無効を更新
+// @Summary      汎用Webhook通知の有効/無効を更新
+// @Description  汎用Webhook通知設定の有効/無効を切り替える
+// @Tags         notifications
+// @Accept       json
+// @Produce      json
+// @Param        request body dto.UpdateEnabledRequest true "有効/無効更新リクエスト"
+// @Success      200 {object} dto.UpdateEnabledResponse
+// @Failure      400 {object} dto.ErrorResponse
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/webhook [put]
+func (ctrl *webhookNotificationController) UpdateEnabled(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	var req dto.UpdateEnabledRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dto.ErrorResponse{
+			Error: "リクエストが不正です",
+		})
+	}
+
+	if err := ctrl.webhookNotificationUsecase.UpdateEnabled(c.Request().Context(), user.ID, req.IsEnabled); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Webhook通知設定の更新に失敗しました",
+		})
+	}
+
+	return c.JSON(http.StatusOK, dto.UpdateEnabledResponse{
+		IsEnabled: req.IsEnabled,
+	})
+}
+
+// Delete 汎用Webhook通知設定を削除
+// @Summary      汎用Webhook通知設定を削除
+// @Description  汎用Webhook通知設定を削除する
+// @Tags         notifications
+// @Success      204
+// @Failure      500 {object} dto.ErrorResponse
+// @Security     GitHubUserID
+// @Router       /api/notifications/webhook [delete]
+func (ctrl *webhookNotificationController) Delete(c echo.Context) error {
+	user := c.Get("user").(*models.User)
+
+	if err := ctrl.webhookNotificationUsecase.Delete(c.Request().Context(), user.ID); err != nil {
+		return c.JSON(http.StatusInternalServerError, dto.ErrorResponse{
+			Error: "Webhook通知設定の削除に失敗しました",
+		})
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// isOutboundWebhookURL 送信先として受け付けるURLかどうかを判定（httpsのみ）
+func isOutboundWebhookURL(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "https" && u.Host != "" && isPublicURLHost(u)
+}
+
+// isPublicURLHost 内部向けと分かるホスト（localhost・内部向けのIPアドレス）でないか
+func isPublicURLHost(u *url.URL) bool {
+	return gateway.IsPublicHost(u.Hostname())
+}
+
+// maskOutboundWebhookURL パスやクエリにトークンが含まれる場合があるため、ホストまでを表示する
+func maskOutboundWebhookURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "..."
+	}
+	return u.Scheme + "://" + u.Host + "/..."
+}
+
+// toWebhookNotificationSettingResponse レスポンスに変換
+func toWebhookNotificationSettingResponse(setting *models.WebhookNotificationSetting, includeSecret bool) dto.WebhookNotificationSettingResponse {
+	res := dto.WebhookNotificationSettingResponse{
+		ID:        setting.ID,
+		URL:       maskOutboundWebhookURL(setting.URL),
+		IsEnabled: setting.IsEnabled,
+		CreatedAt: setting.CreatedAt,
+		UpdatedAt: setting.UpdatedAt,
+	}
+	if includeSecret {
+		res.Secret = setting.Secret
+	}
+	return res
+}
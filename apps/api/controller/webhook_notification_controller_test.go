@@ -0,0 +1,134 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/tests/mocks"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookGetSetting_HidesSecretAndPath(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/api/notifications/webhook", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockWebhookNotificationUsecase{
+		GetSettingFunc: func(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error) {
+			return &models.WebhookNotificationSetting{
+				ID:        1,
+				UserID:    userID,
+				URL:       "https://n8n.example.com/webhook/secret-path-token",
+				Secret:    "whsec_abcdef",
+				IsEnabled: true,
+				CreatedAt: time.Now(),
+				UpdatedAt: time.Now(),
+			}, nil
+		},
+	}
+
+	ctrl := NewWebhookNotificationController(mockUsecase)
+	err := ctrl.GetSetting(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"url":"https://n8n.example.com/..."`)
+	assert.NotContains(t, rec.Body.String(), "secret-path-token")
+	assert.NotContains(t, rec.Body.String(), "whsec_abcdef")
+}
+
+func TestWebhookCreate_ReturnsSecret(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/webhook", strings.NewReader(`{"url":"https://n8n.example.com/webhook/abc"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	var captured string
+	mockUsecase := &mocks.MockWebhookNotificationUsecase{
+		CreateFunc: func(ctx context.Context, userID uint64, url string) (*models.WebhookNotificationSetting, error) {
+			captured = url
+			return &models.WebhookNotificationSetting{ID: 1, UserID: userID, URL: url, Secret: "whsec_abcdef", IsEnabled: true}, nil
+		},
+	}
+
+	ctrl := NewWebhookNotificationController(mockUsecase)
+	err := ctrl.Create(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+	assert.Equal(t, "https://n8n.example.com/webhook/abc", captured)
+	assert.Contains(t, rec.Body.String(), `"secret":"whsec_abcdef"`)
+}
+
+func TestWebhookCreate_InvalidURL(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{"empty", `{"url":""}`},
+		{"http", `{"url":"http://n8n.example.com/webhook/abc"}`},
+		{"no host", `{"url":"https:///webhook"}`},
+		{"not a url", `{"url":"n8n.example.com"}`},
+		{"loopback", `{"url":"https://127.0.0.1/webhook"}`},
+		{"metadata server", `{"url":"https://169.254.169.254/latest/meta-data"}`},
+		{"private", `{"url":"https://10.0.0.8:8443/hook"}`},
+		{"ipv6 loopback", `{"url":"https://[::1]/hook"}`},
+		{"localhost", `{"url":"https://localhost/hook"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := echo.New()
+			req := httptest.NewRequest(http.MethodPost, "/api/notifications/webhook", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+			c.Set("user", &models.User{ID: 1})
+
+			mockUsecase := &mocks.MockWebhookNotificationUsecase{
+				CreateFunc: func(ctx context.Context, userID uint64, url string) (*models.WebhookNotificationSetting, error) {
+					t.Fatal("usecase must not be called")
+					return nil, nil
+				},
+			}
+
+			ctrl := NewWebhookNotificationController(mockUsecase)
+			err := ctrl.Create(c)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestWebhookRotateSecret_NotFound(t *testing.T) {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodPost, "/api/notifications/webhook/secret", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	c.Set("user", &models.User{ID: 1})
+
+	mockUsecase := &mocks.MockWebhookNotificationUsecase{
+		RotateSecretFunc: func(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error) {
+			return nil, errors.New("Webhook通知設定が見つかりません")
+		},
+	}
+
+	ctrl := NewWebhookNotificationController(mockUsecase)
+	err := ctrl.RotateSecret(c)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "Webhook通知設定が見つかりません")
+}
@@ -25,9 +25,28 @@ func AutoMigrate(db *gorm.DB) error {
 		&models.CommitStats{},
 		&models.SlackNotificationSetting{},
 		&models.LineNotificationSetting{},
+		&models.LineLinkCode{},
+		&models.SlackUserLink{},
+		&models.SlackLinkCode{},
 		&models.DiscordNotificationSetting{},
+		&models.TeamsNotificationSetting{},
+		&models.MattermostNotificationSetting{},
+		&models.TelegramNotificationSetting{},
+		&models.TelegramLinkToken{},
+		&models.WebPushSubscription{},
+		&models.InAppNotification{},
+		&models.WebhookNotificationSetting{},
+		&models.EmailNotificationSetting{},
 		&models.NotificationLog{},
+		&models.NotificationSchedule{},
 		&models.Circle{},
 		&models.CircleMember{},
+		&models.CircleNotificationSetting{},
+		&models.CircleDigestLog{},
+		&models.BatchRun{},
+		&models.RivalAlert{},
+		&models.RivalStanding{},
+		&models.DailyNudgeChannel{},
+		&models.DailyNudgeLog{},
 	)
 }
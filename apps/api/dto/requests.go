@@ -6,6 +6,12 @@ type CallbackRequest struct {
 	GithubUsername string `json:"github_username" validate:"required"`
 	Email          string `json:"email"`
 	AvatarURL      string `json:"avatar_url"`
+	Locale         string `json:"locale"` // ブラウザの言語（省略時は Accept-Language から判定）
+}
+
+// UpdateLocaleRequest 通知メッセージの言語変更リクエスト
+type UpdateLocaleRequest struct {
+	Locale string `json:"locale" validate:"required" example:"en"`
 }
 
 // AddRivalRequest ライバル追加リクエスト
@@ -18,11 +24,164 @@ type CreateSlackNotificationRequest struct {
 	WebhookURL string `json:"webhook_url"`
 }
 
+// CreateDiscordNotificationRequest Discord通知設定作成リクエスト
+type CreateDiscordNotificationRequest struct {
+	WebhookURL string `json:"webhook_url"`
+}
+
+// CreateTeamsNotificationRequest Microsoft Teams通知設定作成リクエスト
+type CreateTeamsNotificationRequest struct {
+	WebhookURL string `json:"webhook_url"`
+}
+
+// CreateMattermostNotificationRequest Mattermost通知設定作成リクエスト
+type CreateMattermostNotificationRequest struct {
+	WebhookURL string `json:"webhook_url"`
+}
+
+// CreateWebhookNotificationRequest 汎用Webhook通知設定作成リクエスト
+type CreateWebhookNotificationRequest struct {
+	URL string `json:"url"`
+}
+
+// LinkLineNotificationRequest LINE連携リクエスト
+type LinkLineNotificationRequest struct {
+	LinkCode string `json:"link_code"`
+}
+
+// LineWebhookRequest LINE Messaging APIのWebhookリクエスト
+type LineWebhookRequest struct {
+	Destination string             `json:"destination"`
+	Events      []LineWebhookEvent `json:"events"`
+}
+
+// LineWebhookEvent LINE Webhookイベント
+type LineWebhookEvent struct {
+	Type       string                 `json:"type"` // follow / unfollow / message など
+	ReplyToken string                 `json:"replyToken"`
+	Source     LineWebhookEventSource `json:"source"`
+	Timestamp  int64                  `json:"timestamp"`
+}
+
+// LineWebhookEventSource LINE Webhookイベントの送信元
+type LineWebhookEventSource struct {
+	Type   string `json:"type"` // user / group / room
+	UserID string `json:"userId"`
+}
+
+// TelegramWebhookRequest Telegram Bot APIのWebhookで届くUpdate（使うフィールドのみ）
+type TelegramWebhookRequest struct {
+	UpdateID     int64                      `json:"update_id"`
+	Message      *TelegramWebhookMessage    `json:"message"`
+	MyChatMember *TelegramWebhookChatMember `json:"my_chat_member"` // ボットのブロック・グループからの削除など
+}
+
+// TelegramWebhookMessage Telegramのメッセージ
+type TelegramWebhookMessage struct {
+	Chat TelegramWebhookChat  `json:"chat"`
+	From *TelegramWebhookUser `json:"from"`
+	Text string               `json:"text"`
+}
+
+// TelegramWebhookChatMember チャットでのボットの状態の変化
+type TelegramWebhookChatMember struct {
+	Chat          TelegramWebhookChat `json:"chat"`
+	NewChatMember struct {
+		Status string `json:"status"` // member / kicked / left など
+	} `json:"new_chat_member"`
+}
+
+// TelegramWebhookChat Telegramのチャット
+type TelegramWebhookChat struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"` // private / group / supergroup / channel
+}
+
+// TelegramWebhookUser Telegramのユーザー
+type TelegramWebhookUser struct {
+	LanguageCode string `json:"language_code"`
+}
+
+// SubscribeWebPushRequest Web Push購読リクエスト（ブラウザの PushSubscription.toJSON() の形）
+type SubscribeWebPushRequest struct {
+	Endpoint string `json:"endpoint" example:"https://fcm.googleapis.com/fcm/send/..."`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
+
+// UnsubscribeWebPushRequest Web Push購読解除リクエスト
+type UnsubscribeWebPushRequest struct {
+	Endpoint string `json:"endpoint" example:"https://fcm.googleapis.com/fcm/send/..."`
+}
+
+// LinkSlackRequest Slack連携リクエスト
+type LinkSlackRequest struct {
+	LinkCode string `json:"link_code"`
+}
+
+// SlackInteractionPayload Slackアプリの Interactivity リクエスト（payload パラメータのJSON）
+type SlackInteractionPayload struct {
+	Type        string                   `json:"type"` // block_actions など
+	Team        SlackInteractionTeam     `json:"team"`
+	User        SlackInteractionUser     `json:"user"`
+	ResponseURL string                   `json:"response_url"`
+	Actions     []SlackInteractionAction `json:"actions"`
+}
+
+// SlackInteractionTeam Interactivity リクエストのワークスペース
+type SlackInteractionTeam struct {
+	ID string `json:"id"`
+}
+
+// SlackInteractionUser Interactivity リクエストの操作したユーザー
+type SlackInteractionUser struct {
+	ID string `json:"id"`
+}
+
+// SlackInteractionAction 押されたボタン
+type SlackInteractionAction struct {
+	ActionID string `json:"action_id"`
+	Value    string `json:"value"`
+}
+
 // UpdateEnabledRequest 有効/無効更新リクエスト
 type UpdateEnabledRequest struct {
 	IsEnabled bool `json:"is_enabled"`
 }
 
+// UpdateNotificationScheduleRequest 通知スケジュール更新リクエスト
+type UpdateNotificationScheduleRequest struct {
+	WeeklyWeekday int    `json:"weekly_weekday"` // 0=日曜〜6=土曜
+	MonthlyDay    int    `json:"monthly_day"`    // 1〜31
+	DeliveryTime  string `json:"delivery_time"`  // HH:MM
+	Timezone      string `json:"timezone"`       // IANAタイムゾーン名
+}
+
+// UpdateNotificationPauseRequest 通知を止める時間帯・スヌーズの更新リクエスト（空の場合は解除）
+type UpdateNotificationPauseRequest struct {
+	QuietHoursStart string `json:"quiet_hours_start"` // HH:MM（配信スケジュールのタイムゾーン）
+	QuietHoursEnd   string `json:"quiet_hours_end"`   // HH:MM（開始より早い場合は翌日）
+	SnoozeUntil     string `json:"snooze_until"`      // YYYY-MM-DD（この日まで通知を止める）
+}
+
+// UpdateDailyNudgeRequest 「今日はまだコミットしていない」リマインダーの設定更新リクエスト
+type UpdateDailyNudgeRequest struct {
+	NudgeTime string   `json:"nudge_time"` // HH:MM（配信スケジュールのタイムゾーン）
+	Channels  []string `json:"channels"`   // リマインダーを受け取るチャンネル（空の場合は送らない）
+}
+
+// UpdateCircleNotificationRequest サークルの週次ダイジェスト配信設定の更新リクエスト
+type UpdateCircleNotificationRequest struct {
+	ChannelType  string `json:"channel_type"`  // slack / discord
+	WebhookURL   string `json:"webhook_url"`   // Incoming Webhook URL
+	Weekday      int    `json:"weekday"`       // 0=日曜〜6=土曜
+	DeliveryTime string `json:"delivery_time"` // HH:MM
+	Timezone     string `json:"timezone"`      // IANAタイムゾーン名
+	IsEnabled    *bool  `json:"is_enabled"`    // 省略時は有効
+}
+
 // CreateCircleRequest サークル作成リクエスト
 type CreateCircleRequest struct {
 	Name string `json:"name" validate:"required"`
@@ -19,11 +19,13 @@ type HealthResponse struct {
 
 // UserResponse ユーザーレスポンス
 type UserResponse struct {
-	ID             uint64    `json:"id" validate:"required" example:"1"`
-	GithubUserID   uint64    `json:"github_user_id" validate:"required" example:"12345"`
-	GithubUsername string    `json:"github_username" validate:"required" example:"octocat"`
-	AvatarURL      string    `json:"avatar_url" validate:"required" example:"https://avatars.githubusercontent.com/u/1"`
-	CreatedAt      time.Time `json:"created_at,omitempty"`
+	ID                  uint64     `json:"id" validate:"required" example:"1"`
+	GithubUserID        uint64     `json:"github_user_id" validate:"required" example:"12345"`
+	GithubUsername      string     `json:"github_username" validate:"required" example:"octocat"`
+	AvatarURL           string     `json:"avatar_url" validate:"required" example:"https://avatars.githubusercontent.com/u/1"`
+	Locale              string     `json:"locale" validate:"required" example:"ja"` // 通知メッセージの言語（ja / en）
+	CreatedAt           time.Time  `json:"created_at,omitempty"`
+	NotificationAlertAt *time.Time `json:"notification_alert_at,omitempty"` // 通知チャンネルが自動停止された日時（確認済みの場合は省略）
 }
 
 // RivalResponse ライバルレスポンス
@@ -51,6 +53,154 @@ type SlackNotificationSettingResponse struct {
 	UpdatedAt  time.Time `json:"updated_at" validate:"required"`
 }
 
+// DiscordNotificationSettingResponse Discord通知設定レスポンス
+type DiscordNotificationSettingResponse struct {
+	ID         uint64    `json:"id" validate:"required" example:"1"`
+	WebhookURL string    `json:"webhook_url" validate:"required" example:"https://discord.com/api/webhooks/123..."`
+	IsEnabled  bool      `json:"is_enabled" validate:"required" example:"true"`
+	CreatedAt  time.Time `json:"created_at" validate:"required"`
+	UpdatedAt  time.Time `json:"updated_at" validate:"required"`
+}
+
+// TeamsNotificationSettingResponse Microsoft Teams通知設定レスポンス
+type TeamsNotificationSettingResponse struct {
+	ID         uint64    `json:"id" validate:"required" example:"1"`
+	WebhookURL string    `json:"webhook_url" validate:"required" example:"https://contoso.webhook.office.com/..."`
+	IsEnabled  bool      `json:"is_enabled" validate:"required" example:"true"`
+	CreatedAt  time.Time `json:"created_at" validate:"required"`
+	UpdatedAt  time.Time `json:"updated_at" validate:"required"`
+}
+
+// MattermostNotificationSettingResponse Mattermost通知設定レスポンス
+type MattermostNotificationSettingResponse struct {
+	ID         uint64    `json:"id" validate:"required" example:"1"`
+	WebhookURL string    `json:"webhook_url" validate:"required" example:"https://mattermost.example.com/..."`
+	IsEnabled  bool      `json:"is_enabled" validate:"required" example:"true"`
+	CreatedAt  time.Time `json:"created_at" validate:"required"`
+	UpdatedAt  time.Time `json:"updated_at" validate:"required"`
+}
+
+// LineNotificationSettingResponse LINE通知設定レスポンス
+type LineNotificationSettingResponse struct {
+	ID         uint64    `json:"id" validate:"required" example:"1"`
+	LineUserID string    `json:"line_user_id" validate:"required" example:"U4af4..."`
+	IsEnabled  bool      `json:"is_enabled" validate:"required" example:"true"`
+	CreatedAt  time.Time `json:"created_at" validate:"required"`
+	UpdatedAt  time.Time `json:"updated_at" validate:"required"`
+}
+
+// TelegramNotificationSettingResponse Telegram通知設定レスポンス
+type TelegramNotificationSettingResponse struct {
+	ID        uint64    `json:"id" validate:"required" example:"1"`
+	ChatID    string    `json:"chat_id" validate:"required" example:"1234..."`
+	IsEnabled bool      `json:"is_enabled" validate:"required" example:"true"`
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+	UpdatedAt time.Time `json:"updated_at" validate:"required"`
+}
+
+// TelegramLinkResponse Telegram連携リンクレスポンス
+type TelegramLinkResponse struct {
+	DeepLink  string    `json:"deep_link" validate:"required" example:"https://t.me/commitly_bot?start=0123abcd..."`
+	ExpiresAt time.Time `json:"expires_at" validate:"required"`
+}
+
+// WebPushSettingResponse Web Push設定レスポンス
+type WebPushSettingResponse struct {
+	VAPIDPublicKey string                        `json:"vapid_public_key" validate:"required" example:"BEl62iUYgUivxIkv69yViEuiBIa-Ib9-SkvMeAtA3LFgDzkrxZJjSgSnfckjBJuBkr3qBUYIHBQFLXYp5Nksh8U"` // 未設定の場合は空
+	Subscriptions  []WebPushSubscriptionResponse `json:"subscriptions" validate:"required"`
+}
+
+// WebPushSubscriptionResponse Web Push購読レスポンス
+type WebPushSubscriptionResponse struct {
+	ID        uint64    `json:"id" validate:"required" example:"1"`
+	Endpoint  string    `json:"endpoint" validate:"required" example:"https://fcm.googleapis.com/..."`
+	UserAgent string    `json:"user_agent" validate:"required" example:"Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) ..."`
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+}
+
+// SlackUserLinkResponse Slack連携レスポンス
+type SlackUserLinkResponse struct {
+	SlackTeamID string    `json:"slack_team_id" validate:"required" example:"T0123ABCD"`
+	SlackUserID string    `json:"slack_user_id" validate:"required" example:"U0123ABCD"`
+	CreatedAt   time.Time `json:"created_at" validate:"required"`
+}
+
+// WebhookNotificationSettingResponse 汎用Webhook通知設定レスポンス
+// シークレットは作成・再発行のレスポンスにのみ含める
+type WebhookNotificationSettingResponse struct {
+	ID        uint64    `json:"id" validate:"required" example:"1"`
+	URL       string    `json:"url" validate:"required" example:"https://n8n.example.com/..."`
+	Secret    string    `json:"secret,omitempty" example:"whsec_3f1a..."`
+	IsEnabled bool      `json:"is_enabled" validate:"required" example:"true"`
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+	UpdatedAt time.Time `json:"updated_at" validate:"required"`
+}
+
+// EmailNotificationSettingResponse メール通知設定レスポンス
+type EmailNotificationSettingResponse struct {
+	ID         uint64    `json:"id" validate:"required" example:"1"`
+	Email      string    `json:"email" validate:"required" example:"tanaka@example.com"`
+	IsVerified bool      `json:"is_verified" validate:"required" example:"false"`
+	IsEnabled  bool      `json:"is_enabled" validate:"required" example:"true"`
+	CreatedAt  time.Time `json:"created_at" validate:"required"`
+	UpdatedAt  time.Time `json:"updated_at" validate:"required"`
+}
+
+// NotificationScheduleResponse 通知スケジュールレスポンス
+type NotificationScheduleResponse struct {
+	WeeklyWeekday int    `json:"weekly_weekday" validate:"required" example:"1"`
+	MonthlyDay    int    `json:"monthly_day" validate:"required" example:"1"`
+	DeliveryTime  string `json:"delivery_time" validate:"required" example:"09:00"`
+	Timezone      string `json:"timezone" validate:"required" example:"Asia/Tokyo"`
+	IsDefault     bool   `json:"is_default" validate:"required" example:"false"` // 未設定でデフォルトのスケジュールを使っている
+	// 通知を止める時間帯（未設定の場合は空文字）
+	QuietHoursStart string  `json:"quiet_hours_start" validate:"required" example:"22:00"`
+	QuietHoursEnd   string  `json:"quiet_hours_end" validate:"required" example:"07:00"`
+	SnoozeUntil     *string `json:"snooze_until" example:"2026-10-25"` // この日まで通知を止める（未設定の場合はnull）
+}
+
+// DailyNudgeResponse 「今日はまだコミットしていない」リマインダーの設定レスポンス
+type DailyNudgeResponse struct {
+	NudgeTime string   `json:"nudge_time" validate:"required" example:"20:00"`
+	Timezone  string   `json:"timezone" validate:"required" example:"Asia/Tokyo"` // 配信スケジュールのタイムゾーン
+	Channels  []string `json:"channels" validate:"required" example:"slack,line"` // リマインダーを受け取るチャンネル
+}
+
+// NotificationHistoryItem 通知履歴の1件
+type NotificationHistoryItem struct {
+	ID           uint64                 `json:"id" validate:"required" example:"1"`
+	ChannelType  string                 `json:"channel_type" validate:"required" example:"slack"`
+	Period       string                 `json:"period" validate:"required" example:"weekly"`
+	PeriodStart  string                 `json:"period_start,omitempty" example:"2026-10-05"`
+	Status       string                 `json:"status" validate:"required" example:"failed"`
+	Payload      map[string]interface{} `json:"payload"` // 送信したメッセージ内容（チャンネルごとの形式）
+	ErrorMessage string                 `json:"error_message,omitempty" example:"Slack webhook returned status 404, body: no_service"`
+	Attempts     int                    `json:"attempts" validate:"required" example:"1"`
+	NextRetryAt  *time.Time             `json:"next_retry_at,omitempty"`
+	SentAt       time.Time              `json:"sent_at" validate:"required"`
+	UpdatedAt    time.Time              `json:"updated_at" validate:"required"`
+}
+
+// NotificationHistoryResponse 通知履歴一覧レスポンス
+type NotificationHistoryResponse struct {
+	Items []NotificationHistoryItem `json:"items" validate:"required"`
+	Total int64                     `json:"total" validate:"required" example:"42"` // 絞り込み条件に合う総件数
+}
+
+// NotificationChannelPreviewResponse チャンネル向けにレンダリングしたレポート
+type NotificationChannelPreviewResponse struct {
+	ChannelType string                 `json:"channel_type" validate:"required" example:"slack"`
+	Payload     map[string]interface{} `json:"payload" validate:"required"` // 送信するメッセージ内容（チャンネルごとの形式）
+}
+
+// NotificationPreviewResponse レポートのプレビューレスポンス
+type NotificationPreviewResponse struct {
+	Period     string                               `json:"period" validate:"required" example:"weekly"`
+	RangeStart string                               `json:"range_start" validate:"required" example:"2026-10-11"`
+	RangeEnd   string                               `json:"range_end" validate:"required" example:"2026-10-17"`
+	Channels   []NotificationChannelPreviewResponse `json:"channels" validate:"required"` // 有効なチャンネルのみ
+}
+
 // UpdateEnabledResponse 有効/無効更新レスポンス
 type UpdateEnabledResponse struct {
 	IsEnabled bool `json:"is_enabled" validate:"required" example:"true"`
@@ -112,6 +262,19 @@ type CircleResponse struct {
 	CreatedAt  time.Time              `json:"created_at" validate:"required"`
 }
 
+// CircleNotificationSettingResponse サークルの週次ダイジェスト配信設定レスポンス
+type CircleNotificationSettingResponse struct {
+	CircleID     uint64    `json:"circle_id" validate:"required" example:"1"`
+	ChannelType  string    `json:"channel_type" validate:"required" example:"slack"`
+	WebhookURL   string    `json:"webhook_url" validate:"required" example:"https://hooks.slack.com/services/T00..."`
+	Weekday      int       `json:"weekday" validate:"required" example:"1"`
+	DeliveryTime string    `json:"delivery_time" validate:"required" example:"09:00"`
+	Timezone     string    `json:"timezone" validate:"required" example:"Asia/Tokyo"`
+	IsEnabled    bool      `json:"is_enabled" validate:"required" example:"true"`
+	CreatedAt    time.Time `json:"created_at" validate:"required"`
+	UpdatedAt    time.Time `json:"updated_at" validate:"required"`
+}
+
 // CirclesListResponse サークル一覧レスポンス
 type CirclesListResponse struct {
 	Circles    []CircleResponse `json:"circles" validate:"required"`
@@ -139,3 +302,40 @@ type SignalResponse struct {
 type SignalsListResponse struct {
 	Signals []SignalResponse `json:"signals" validate:"required"`
 }
+
+// BatchRunResponse バッチ実行履歴レスポンス
+type BatchRunResponse struct {
+	ID           uint64            `json:"id" validate:"required" example:"1"`
+	Command      string            `json:"command" validate:"required" example:"send-notifications"`
+	Args         map[string]string `json:"args" validate:"required"`
+	Status       string            `json:"status" validate:"required" example:"partial_failure"`
+	StartedAt    time.Time         `json:"started_at" validate:"required"`
+	FinishedAt   *time.Time        `json:"finished_at,omitempty"`
+	SuccessCount int               `json:"success_count" validate:"required" example:"10"`
+	FailureCount int               `json:"failure_count" validate:"required" example:"2"`
+	SkipCount    int               `json:"skip_count" validate:"required" example:"0"`
+	ErrorSamples []string          `json:"error_samples" validate:"required"`
+	ErrorMessage string            `json:"error_message,omitempty" example:"invalid period: daily"`
+}
+
+// BatchRunsListResponse バッチ実行履歴一覧レスポンス
+type BatchRunsListResponse struct {
+	Runs []BatchRunResponse `json:"runs" validate:"required"`
+}
+
+// InboxItemResponse 受信箱の通知1件
+type InboxItemResponse struct {
+	ID        uint64                 `json:"id" validate:"required" example:"1"`
+	Type      string                 `json:"type" validate:"required" example:"rival_alert"`
+	Payload   map[string]interface{} `json:"payload" validate:"required"` // 通知の種類ごとの内容
+	IsRead    bool                   `json:"is_read" validate:"required" example:"false"`
+	ReadAt    *time.Time             `json:"read_at,omitempty"`
+	CreatedAt time.Time              `json:"created_at" validate:"required"`
+}
+
+// InboxResponse 受信箱レスポンス
+type InboxResponse struct {
+	Items       []InboxItemResponse `json:"items" validate:"required"`
+	NextCursor  string              `json:"next_cursor,omitempty" example:"42"` // 次のページを取得するときに cursor に指定する
+	UnreadCount int64               `json:"unread_count" validate:"required" example:"3"`
+}
@@ -0,0 +1,115 @@
+package gateway
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+)
+
+// discordColorCircleDigest サークルのダイジェストの埋め込みの色
+const discordColorCircleDigest = 0xFEE75C
+
+// CircleDigest サークルの週次ダイジェストの内容
+type CircleDigest struct {
+	CircleName   string
+	RangeStart   time.Time
+	RangeEnd     time.Time
+	Members      []RivalCommitSummary // メンバーのコミット数（多い順）
+	Signals      []CircleDigestSignal
+	Repositories []RepositoryCommitSummary // よくコミットされたリポジトリ（多い順）
+}
+
+// CircleDigestSignal ダイジェストに載せるシグナル
+type CircleDigestSignal struct {
+	Type      string // same_day / same_hour / same_language
+	Date      string // YYYY-MM-DD
+	Usernames []string
+	Detail    string // same_language の場合は言語名
+}
+
+// RepositoryCommitSummary リポジトリのコミット数
+type RepositoryCommitSummary struct {
+	Name    string // owner/repo
+	Commits int
+}
+
+// BuildCircleDigestSlackMessage サークルの週次ダイジェストのSlackメッセージを構築
+func BuildCircleDigestSlackMessage(l *i18n.Localizer, digest *CircleDigest) *SlackMessage {
+	title := l.T("digest.title", i18n.Vars{"Circle": digest.CircleName})
+
+	blocks := []SlackBlock{
+		{Type: "header", Text: &SlackText{Type: "plain_text", Text: title}},
+		{Type: "context", Elements: []SlackText{{Type: "mrkdwn", Text: l.FormatRange(digest.RangeStart, digest.RangeEnd)}}},
+	}
+	for _, section := range circleDigestSections(l, digest) {
+		blocks = append(blocks,
+			SlackBlock{Type: "divider"},
+			SlackBlock{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: fmt.Sprintf("%s *%s*\n%s", section.emoji, section.title, section.body)}},
+		)
+	}
+	blocks = append(blocks, buildSlackFooter(l))
+
+	return &SlackMessage{
+		Text:   l.T("digest.summary", i18n.Vars{"Circle": digest.CircleName, "Range": l.FormatRange(digest.RangeStart, digest.RangeEnd)}),
+		Blocks: blocks,
+	}
+}
+
+// BuildCircleDigestDiscordMessage サークルの週次ダイジェストのDiscordメッセージを構築
+func BuildCircleDigestDiscordMessage(l *i18n.Localizer, digest *CircleDigest) *DiscordMessage {
+	embed := DiscordEmbed{
+		Title:       l.T("digest.title", i18n.Vars{"Circle": digest.CircleName}),
+		Description: l.FormatRange(digest.RangeStart, digest.RangeEnd),
+		Color:       discordColorCircleDigest,
+	}
+	for _, section := range circleDigestSections(l, digest) {
+		embed.Fields = append(embed.Fields, DiscordEmbedField{
+			Name:  section.emoji + " " + section.title,
+			Value: section.body,
+		})
+	}
+
+	return &DiscordMessage{Embeds: []DiscordEmbed{withDiscordFooter(l, embed)}}
+}
+
+// circleDigestSection ダイジェストの1項目
+type circleDigestSection struct {
+	emoji string
+	title string
+	body  string
+}
+
+// circleDigestSections リーダーボード・シグナル・リポジトリの各項目を組み立てる（Slack / Discord 共通）
+func circleDigestSections(l *i18n.Localizer, digest *CircleDigest) []circleDigestSection {
+	var leaderboard []string
+	for i, member := range digest.Members {
+		leaderboard = append(leaderboard, fmt.Sprintf("%d. %s: %s", i+1, member.Username, l.Commits(member.Commits)))
+	}
+
+	var signals []string
+	for _, signal := range digest.Signals {
+		label := l.T("digest.signal."+signal.Type, i18n.Vars{"Language": signal.Detail})
+		signals = append(signals, fmt.Sprintf("• %s %s: %s", signal.Date, label, strings.Join(signal.Usernames, ", ")))
+	}
+
+	var repositories []string
+	for _, repository := range digest.Repositories {
+		repositories = append(repositories, fmt.Sprintf("• %s: %s", repository.Name, l.Commits(repository.Commits)))
+	}
+
+	return []circleDigestSection{
+		{emoji: l.Emoji("leaderboard"), title: l.T("digest.leaderboard", nil), body: joinOr(leaderboard, l.T("digest.no_commits", nil))},
+		{emoji: l.Emoji("signals"), title: l.T("digest.signals", nil), body: joinOr(signals, l.T("digest.no_signals", nil))},
+		{emoji: l.Emoji("repositories"), title: l.T("digest.repositories", nil), body: joinOr(repositories, l.T("digest.no_commits", nil))},
+	}
+}
+
+// joinOr 行を改行でつなぐ（空の場合は empty を返す）
+func joinOr(lines []string, empty string) string {
+	if len(lines) == 0 {
+		return empty
+	}
+	return strings.Join(lines, "\n")
+}
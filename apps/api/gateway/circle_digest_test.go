@@ -0,0 +1,40 @@
+package gateway
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCircleDigestMessages(t *testing.T) {
+	digest := &CircleDigest{
+		CircleName: "勉強会仲間",
+		RangeStart: time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local),
+		RangeEnd:   time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local),
+		Members: []RivalCommitSummary{
+			{Username: "tanaka", Commits: 7},
+			{Username: "me", Commits: 1},
+		},
+		Signals: []CircleDigestSignal{
+			{Type: "same_language", Date: "2026-10-15", Usernames: []string{"me", "tanaka"}, Detail: "Go"},
+		},
+	}
+
+	slack := BuildCircleDigestSlackMessage(i18n.For("en"), digest)
+	body, _ := json.Marshal(slack.Blocks)
+	assert.Contains(t, slack.Text, "勉強会仲間 Weekly Digest")
+	assert.Contains(t, string(body), `1. tanaka: 7 commits\n2. me: 1 commit`)
+	assert.Contains(t, string(body), "2026-10-15 Same language (Go): me, tanaka")
+	// リポジトリがない場合は空の旨を表示する
+	assert.Contains(t, string(body), "No commits in this period")
+
+	discord := BuildCircleDigestDiscordMessage(i18n.For("ja"), digest)
+	if assert.Len(t, discord.Embeds, 1) {
+		assert.Equal(t, "勉強会仲間 週次ダイジェスト", discord.Embeds[0].Title)
+		assert.Len(t, discord.Embeds[0].Fields, 3)
+		assert.Contains(t, discord.Embeds[0].Fields[1].Value, "同じ言語（Go）")
+	}
+}
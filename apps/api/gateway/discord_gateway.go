@@ -0,0 +1,174 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+)
+
+// Discord埋め込みの色
+const (
+	discordColorWeekly  = 0x5865F2
+	discordColorMonthly = 0x57F287
+)
+
+// IDiscordGateway Discordゲートウェイのインターフェース
+type IDiscordGateway interface {
+	SendMessage(ctx context.Context, webhookURL string, message *DiscordMessage) error
+}
+
+// DiscordMessage Discord Webhookメッセージ構造体
+type DiscordMessage struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []DiscordEmbed `json:"embeds,omitempty"`
+}
+
+// DiscordEmbed Discord埋め込み構造体
+type DiscordEmbed struct {
+	Title       string              `json:"title,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Color       int                 `json:"color,omitempty"`
+	Fields      []DiscordEmbedField `json:"fields,omitempty"`
+	Footer      *DiscordEmbedFooter `json:"footer,omitempty"`
+	Timestamp   string              `json:"timestamp,omitempty"`
+}
+
+// DiscordEmbedField Discord埋め込みフィールド構造体
+type DiscordEmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+// DiscordEmbedFooter Discord埋め込みフッター構造体
+type DiscordEmbedFooter struct {
+	Text string `json:"text"`
+}
+
+type discordGateway struct {
+	httpClient *http.Client
+}
+
+// NewDiscordGateway コンストラクタ
+func NewDiscordGateway() IDiscordGateway {
+	return &discordGateway{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (g *discordGateway) SendMessage(ctx context.Context, webhookURL string, message *DiscordMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal discord message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send discord message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Discordは成功時に 204 No Content を返す（?wait=true の場合は 200）
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newHTTPStatusError("discord webhook", resp)
+	}
+
+	return nil
+}
+
+// BuildWeeklyReportDiscordMessage 週次レポートのDiscordメッセージを構築
+func BuildWeeklyReportDiscordMessage(
+	l *i18n.Localizer,
+	username string,
+	userCommits int,
+	rivals []RivalCommitSummary,
+	rangeStart, rangeEnd time.Time,
+) *DiscordMessage {
+	embed := DiscordEmbed{
+		Title: l.Emoji("report_weekly") + " " + l.T("report.weekly.title", nil),
+		Description: fmt.Sprintf(
+			"%s %s: **%d**\n*%s*",
+			l.Emoji("user"),
+			l.T("report.weekly.user_commits", i18n.Vars{"Username": "**" + username + "**"}),
+			userCommits,
+			l.FormatRange(rangeStart, rangeEnd),
+		),
+		Color: discordColorWeekly,
+	}
+
+	if len(rivals) > 0 {
+		embed.Fields = append(embed.Fields, DiscordEmbedField{
+			Name:  l.Emoji("rivals") + " " + l.T("report.weekly.rivals_title", nil),
+			Value: buildDiscordRivalList(l, userCommits, rivals),
+		})
+	}
+
+	return &DiscordMessage{
+		Embeds: []DiscordEmbed{withDiscordFooter(l, embed)},
+	}
+}
+
+// BuildMonthlyReportDiscordMessage 月次レポートのDiscordメッセージを構築
+func BuildMonthlyReportDiscordMessage(
+	l *i18n.Localizer,
+	username string,
+	comparison MonthlyComparison,
+	rivals []RivalCommitSummary,
+	month time.Time,
+) *DiscordMessage {
+	monthLabel := l.FormatMonth(month)
+	diffText, growthRate := formatMonthlyDiff(l, comparison)
+
+	embed := DiscordEmbed{
+		Title:       l.Emoji("report_monthly") + " " + l.T("report.monthly.title", i18n.Vars{"Month": monthLabel}),
+		Description: l.Emoji("user") + " " + l.T("report.monthly.user_commits", i18n.Vars{"Username": "**" + username + "**", "Month": monthLabel}),
+		Color:       discordColorMonthly,
+		Fields: []DiscordEmbedField{
+			{Name: l.T("report.monthly.this_month", nil), Value: fmt.Sprintf("**%s**", l.Commits(comparison.CurrentMonth)), Inline: true},
+			{Name: l.T("report.monthly.last_month", nil), Value: fmt.Sprintf("**%s**", l.Commits(comparison.PreviousMonth)), Inline: true},
+			{Name: l.T("report.monthly.diff", nil), Value: fmt.Sprintf("%s **%s** %s", trendEmoji(l, comparison), diffText, growthRate), Inline: true},
+		},
+	}
+
+	if len(rivals) > 0 {
+		embed.Fields = append(embed.Fields, DiscordEmbedField{
+			Name:  l.Emoji("rivals") + " " + l.T("report.monthly.rivals_title", nil),
+			Value: buildDiscordRivalList(l, comparison.CurrentMonth, rivals),
+		})
+	}
+
+	return &DiscordMessage{
+		Embeds: []DiscordEmbed{withDiscordFooter(l, embed)},
+	}
+}
+
+// buildDiscordRivalList ライバルのコミット数一覧を構築
+func buildDiscordRivalList(l *i18n.Localizer, userCommits int, rivals []RivalCommitSummary) string {
+	var text string
+	for i, rival := range rivals {
+		emoji := comparisonEmoji(l, userCommits, rival.Commits)
+		text += fmt.Sprintf("%d. %s %s: **%s**\n", i+1, emoji, rival.Username, l.Commits(rival.Commits))
+	}
+	return text
+}
+
+// withDiscordFooter フッターと送信日時を付与する
+func withDiscordFooter(l *i18n.Localizer, embed DiscordEmbed) DiscordEmbed {
+	embed.Footer = &DiscordEmbedFooter{Text: l.T("report.footer_short", nil)}
+	embed.Timestamp = time.Now().Format(time.RFC3339)
+	return embed
+}
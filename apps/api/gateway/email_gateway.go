@@ -0,0 +1,394 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+)
+
+// DefaultSMTPPort SMTP_PORT 未設定時のポート（STARTTLSのsubmission）
+const DefaultSMTPPort = 587
+
+//go:embed templates/*.tmpl
+var emailTemplateFS embed.FS
+
+// emailTemplateFuncs テンプレートの関数（t は実行時にユーザーの言語のものに差し替える）
+var emailTemplateFuncs = map[string]interface{}{
+	"inc": func(i int) int { return i + 1 },
+	"t":   i18n.For(string(i18n.DefaultLocale)).TemplateFunc(),
+}
+
+var (
+	reportHTMLTemplate       = htmltemplate.Must(htmltemplate.New("email_report.html.tmpl").Funcs(emailTemplateFuncs).ParseFS(emailTemplateFS, "templates/email_report.html.tmpl"))
+	reportTextTemplate       = texttemplate.Must(texttemplate.New("email_report.txt.tmpl").Funcs(emailTemplateFuncs).ParseFS(emailTemplateFS, "templates/email_report.txt.tmpl"))
+	verificationHTMLTemplate = htmltemplate.Must(htmltemplate.New("email_verification.html.tmpl").Funcs(emailTemplateFuncs).ParseFS(emailTemplateFS, "templates/email_verification.html.tmpl"))
+	verificationTextTemplate = texttemplate.Must(texttemplate.New("email_verification.txt.tmpl").Funcs(emailTemplateFuncs).ParseFS(emailTemplateFS, "templates/email_verification.txt.tmpl"))
+)
+
+// SMTPConfig SMTPサーバーの設定
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string // 空の場合は認証しない
+	Password string
+	From     string // "Commitly <noreply@example.com>" 形式も可
+}
+
+// LoadSMTPConfig 環境変数からSMTPの設定を読み込む
+func LoadSMTPConfig(getenv func(string) string) (SMTPConfig, error) {
+	config := SMTPConfig{
+		Host:     getenv("SMTP_HOST"),
+		Port:     DefaultSMTPPort,
+		Username: getenv("SMTP_USERNAME"),
+		Password: getenv("SMTP_PASSWORD"),
+		From:     getenv("SMTP_FROM"),
+	}
+	if port := getenv("SMTP_PORT"); port != "" {
+		p, err := strconv.Atoi(port)
+		if err != nil || p <= 0 || p > 65535 {
+			return SMTPConfig{}, fmt.Errorf("invalid SMTP_PORT: %q", port)
+		}
+		config.Port = p
+	}
+	return config, nil
+}
+
+// IEmailGateway メールゲートウェイのインターフェース
+type IEmailGateway interface {
+	Send(ctx context.Context, message *EmailMessage) error
+}
+
+// EmailMessage メール構造体（text/plain と text/html の multipart/alternative で送信する）
+type EmailMessage struct {
+	To       string            `json:"to"`
+	Subject  string            `json:"subject"`
+	TextBody string            `json:"text_body"`
+	HTMLBody string            `json:"html_body"`
+	Headers  map[string]string `json:"headers,omitempty"` // List-Unsubscribe など追加のヘッダー
+}
+
+// EmailReportData レポートメールのテンプレートに渡す内容
+type EmailReportData struct {
+	Period          string // "weekly" or "monthly"
+	Username        string
+	UserCommits     int
+	PreviousCommits int
+	Rivals          []RivalCommitSummary
+	RangeStart      time.Time // 集計期間の初日
+	RangeEnd        time.Time // 集計期間の最終日
+	UnsubscribeURL  string
+}
+
+type emailGateway struct {
+	config SMTPConfig
+}
+
+// NewEmailGateway コンストラクタ
+func NewEmailGateway(config SMTPConfig) IEmailGateway {
+	if config.Port == 0 {
+		config.Port = DefaultSMTPPort
+	}
+	return &emailGateway{config: config}
+}
+
+func (g *emailGateway) Send(ctx context.Context, message *EmailMessage) error {
+	if g.config.Host == "" {
+		return fmt.Errorf("SMTP host is not configured")
+	}
+	from, err := mail.ParseAddress(g.config.From)
+	if err != nil {
+		return fmt.Errorf("invalid SMTP sender %q: %w", g.config.From, err)
+	}
+	to, err := mail.ParseAddress(message.To)
+	if err != nil {
+		return fmt.Errorf("invalid recipient %q: %w", message.To, err)
+	}
+
+	raw, err := buildMIMEMessage(from, to, message)
+	if err != nil {
+		return fmt.Errorf("failed to build email: %w", err)
+	}
+
+	dialer := &net.Dialer{Timeout: 10 * time.Second}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(g.config.Host, strconv.Itoa(g.config.Port)))
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	deadline := time.Now().Add(30 * time.Second)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, g.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: g.config.Host}); err != nil {
+			return fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+	if g.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", g.config.Username, g.config.Password, g.config.Host)); err != nil {
+			return fmt.Errorf("failed to authenticate with SMTP server: %w", err)
+		}
+	}
+
+	if err := client.Mail(from.Address); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM failed: %w", err)
+	}
+	if err := client.Rcpt(to.Address); err != nil {
+		return fmt.Errorf("SMTP RCPT TO failed: %w", err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := w.Write(raw); err != nil {
+		return fmt.Errorf("failed to write email body: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("SMTP server rejected the email: %w", err)
+	}
+
+	return client.Quit()
+}
+
+// buildMIMEMessage ヘッダーと multipart/alternative の本文を組み立てる
+func buildMIMEMessage(from, to *mail.Address, message *EmailMessage) ([]byte, error) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=UTF-8", message.TextBody},
+		{"text/html; charset=UTF-8", message.HTMLBody},
+	}
+	for _, p := range parts {
+		pw, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {p.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		qw := quotedprintable.NewWriter(pw)
+		if _, err := qw.Write([]byte(p.content)); err != nil {
+			return nil, err
+		}
+		if err := qw.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+
+	messageID, err := newMessageID(from.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	writeHeader := func(key, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", key, value)
+	}
+	writeHeader("From", from.String())
+	writeHeader("To", to.String())
+	writeHeader("Subject", mime.BEncoding.Encode("UTF-8", message.Subject))
+	writeHeader("Date", time.Now().Format(time.RFC1123Z))
+	writeHeader("Message-ID", messageID)
+	writeHeader("MIME-Version", "1.0")
+
+	keys := make([]string, 0, len(message.Headers))
+	for k := range message.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		writeHeader(k, message.Headers[k])
+	}
+
+	writeHeader("Content-Type", "multipart/alternative; boundary="+mw.Boundary())
+	buf.WriteString("\r\n")
+	buf.Write(body.Bytes())
+	return buf.Bytes(), nil
+}
+
+// newMessageID 送信元ドメインを使ったMessage-IDを生成する
+func newMessageID(fromAddress string) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	domain := "commitly"
+	if at := strings.LastIndex(fromAddress, "@"); at >= 0 {
+		domain = fromAddress[at+1:]
+	}
+	return fmt.Sprintf("<%s@%s>", hex.EncodeToString(b), domain), nil
+}
+
+// emailRivalView テンプレート用のライバル行
+type emailRivalView struct {
+	Username string
+	Commits  int
+	Emoji    string
+}
+
+// BuildReportEmail 週次・月次レポートのメールを構築
+func BuildReportEmail(l *i18n.Localizer, to string, data EmailReportData) (*EmailMessage, error) {
+	weekly := data.Period == "weekly"
+
+	view := struct {
+		EmailReportData
+		Lang        string
+		Weekly      bool
+		Title       string
+		HeaderColor string
+		RivalsTitle string
+		Range       string
+		MonthLabel  string
+		DiffText    string
+		GrowthRate  string
+		Rivals      []emailRivalView
+		SentAt      string
+	}{
+		EmailReportData: data,
+		Lang:            string(l.Locale()),
+		Weekly:          weekly,
+		SentAt:          l.FormatDateTime(time.Now()),
+	}
+
+	var subject string
+	if weekly {
+		view.Title = l.Emoji("report_weekly") + " " + l.T("report.weekly.title", nil)
+		view.HeaderColor = lineColorHeaderWeekly
+		view.RivalsTitle = l.Emoji("rivals") + " " + l.T("report.weekly.rivals_title", nil)
+		view.Range = l.FormatRange(data.RangeStart, data.RangeEnd)
+		subject = l.T("report.weekly.email_subject", i18n.Vars{"Count": data.UserCommits})
+	} else {
+		view.MonthLabel = l.FormatMonth(data.RangeStart)
+		view.Title = l.Emoji("report_monthly") + " " + l.T("report.monthly.title", i18n.Vars{"Month": view.MonthLabel})
+		view.HeaderColor = lineColorHeaderMonthly
+		view.RivalsTitle = l.Emoji("rivals") + " " + l.T("report.monthly.rivals_title", nil)
+		view.DiffText, view.GrowthRate = formatMonthlyDiff(l, MonthlyComparison{CurrentMonth: data.UserCommits, PreviousMonth: data.PreviousCommits})
+		subject = l.T("report.monthly.email_subject", i18n.Vars{"Month": view.MonthLabel, "Count": data.UserCommits})
+	}
+	for _, rival := range data.Rivals {
+		view.Rivals = append(view.Rivals, emailRivalView{
+			Username: rival.Username,
+			Commits:  rival.Commits,
+			Emoji:    comparisonEmoji(l, data.UserCommits, rival.Commits),
+		})
+	}
+
+	funcs := map[string]interface{}{"t": l.TemplateFunc()}
+	htmlTemplate, err := reportHTMLTemplate.Clone()
+	if err != nil {
+		return nil, err
+	}
+	textTemplate, err := reportTextTemplate.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	var html, text bytes.Buffer
+	if err := htmlTemplate.Funcs(funcs).Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("failed to render html template: %w", err)
+	}
+	if err := textTemplate.Funcs(funcs).Execute(&text, view); err != nil {
+		return nil, fmt.Errorf("failed to render text template: %w", err)
+	}
+
+	message := &EmailMessage{
+		To:       to,
+		Subject:  subject,
+		TextBody: text.String(),
+		HTMLBody: html.String(),
+	}
+	if data.UnsubscribeURL != "" {
+		// RFC 8058 のワンクリック配信停止に対応する
+		message.Headers = map[string]string{
+			"List-Unsubscribe":      "<" + data.UnsubscribeURL + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}
+	}
+	return message, nil
+}
+
+// BuildVerificationEmail メールアドレス確認メールを構築
+func BuildVerificationEmail(l *i18n.Localizer, to, verifyURL string, validFor time.Duration) (*EmailMessage, error) {
+	view := struct {
+		Lang       string
+		VerifyURL  string
+		ValidHours int
+	}{
+		Lang:       string(l.Locale()),
+		VerifyURL:  verifyURL,
+		ValidHours: int(validFor.Hours()),
+	}
+
+	funcs := map[string]interface{}{"t": l.TemplateFunc()}
+	htmlTemplate, err := verificationHTMLTemplate.Clone()
+	if err != nil {
+		return nil, err
+	}
+	textTemplate, err := verificationTextTemplate.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	var html, text bytes.Buffer
+	if err := htmlTemplate.Funcs(funcs).Execute(&html, view); err != nil {
+		return nil, fmt.Errorf("failed to render html template: %w", err)
+	}
+	if err := textTemplate.Funcs(funcs).Execute(&text, view); err != nil {
+		return nil, fmt.Errorf("failed to render text template: %w", err)
+	}
+
+	return &EmailMessage{
+		To:       to,
+		Subject:  l.T("email.verification.subject", nil),
+		TextBody: text.String(),
+		HTMLBody: html.String(),
+	}, nil
+}
+
+// BuildTestEmail 送信先の確認用のテストメールを構築
+func BuildTestEmail(l *i18n.Localizer, to, text string) *EmailMessage {
+	return BuildTextEmail(to, l.T("test.email_subject", nil), text)
+}
+
+// BuildTextEmail 1段落だけの短いメールを構築
+func BuildTextEmail(to, subject, text string) *EmailMessage {
+	return &EmailMessage{
+		To:       to,
+		Subject:  subject,
+		TextBody: text + "\n",
+		HTMLBody: "<p>" + htmltemplate.HTMLEscapeString(text) + "</p>\n",
+	}
+}
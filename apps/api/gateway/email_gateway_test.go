@@ -0,0 +1,208 @@
+package gateway
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSMTPSession ローカルSMTPサーバーが受け取った内容
+type fakeSMTPSession struct {
+	mailFrom string
+	rcptTo   string
+	data     string
+}
+
+// startFakeSMTPServer 1セッションだけ受け付ける最小限のSMTPサーバーを起動する
+func startFakeSMTPServer(t *testing.T) (string, int, <-chan fakeSMTPSession) {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	sessions := make(chan fakeSMTPSession, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		r := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+		var session fakeSMTPSession
+
+		reply("220 localhost ESMTP")
+		for {
+			line, err := r.ReadString('\n')
+			if err != nil {
+				return
+			}
+			cmd := strings.TrimSpace(line)
+			switch {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "MAIL FROM:"):
+				session.mailFrom = strings.TrimPrefix(cmd, "MAIL FROM:")
+				reply("250 OK")
+			case strings.HasPrefix(cmd, "RCPT TO:"):
+				session.rcptTo = strings.TrimPrefix(cmd, "RCPT TO:")
+				reply("250 OK")
+			case cmd == "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					l, err := r.ReadString('\n')
+					if err != nil {
+						return
+					}
+					if l == ".\r\n" {
+						break
+					}
+					data.WriteString(strings.TrimPrefix(l, "."))
+				}
+				session.data = data.String()
+				reply("250 OK")
+			case cmd == "QUIT":
+				reply("221 Bye")
+				sessions <- session
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+
+	host, port, _ := net.SplitHostPort(ln.Addr().String())
+	p, _ := strconv.Atoi(port)
+	return host, p, sessions
+}
+
+func TestEmailGateway_SendDeliversMultipartMessage(t *testing.T) {
+	host, port, sessions := startFakeSMTPServer(t)
+
+	g := NewEmailGateway(SMTPConfig{Host: host, Port: port, From: "Commitly <noreply@commitly.example>"})
+	message, err := BuildReportEmail(i18n.For("ja"), "user1@example.com", EmailReportData{
+		Period:         "weekly",
+		Username:       "user1",
+		UserCommits:    12,
+		Rivals:         []RivalCommitSummary{{Username: "rival1", Commits: 8}},
+		RangeStart:     time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local),
+		RangeEnd:       time.Date(2026, 10, 11, 0, 0, 0, 0, time.Local),
+		UnsubscribeURL: "https://api.example.com/api/email/unsubscribe?token=abc",
+	})
+	assert.NoError(t, err)
+
+	err = g.Send(context.Background(), message)
+	assert.NoError(t, err)
+
+	session := <-sessions
+	assert.Equal(t, "<noreply@commitly.example>", session.mailFrom)
+	assert.Equal(t, "<user1@example.com>", session.rcptTo)
+
+	parsed, err := mail.ReadMessage(strings.NewReader(session.data))
+	if !assert.NoError(t, err) {
+		return
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, message.Subject, subject)
+	assert.Equal(t, "<https://api.example.com/api/email/unsubscribe?token=abc>", parsed.Header.Get("List-Unsubscribe"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", parsed.Header.Get("List-Unsubscribe-Post"))
+	assert.True(t, strings.HasSuffix(parsed.Header.Get("Message-ID"), "@commitly.example>"))
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	mr := multipart.NewReader(parsed.Body, params["boundary"])
+	var contentTypes []string
+	var bodies []string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if !assert.NoError(t, err) {
+			return
+		}
+		body, _ := io.ReadAll(part)
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+		// quoted-printable の改行はCRLFになる
+		bodies = append(bodies, strings.ReplaceAll(string(body), "\r\n", "\n"))
+	}
+	assert.Equal(t, []string{"text/plain; charset=UTF-8", "text/html; charset=UTF-8"}, contentTypes)
+	assert.Equal(t, message.TextBody, bodies[0])
+	assert.Equal(t, message.HTMLBody, bodies[1])
+	assert.Contains(t, bodies[0], "rival1")
+}
+
+func TestEmailGateway_SendWithoutHost(t *testing.T) {
+	g := NewEmailGateway(SMTPConfig{From: "noreply@commitly.example"})
+
+	err := g.Send(context.Background(), &EmailMessage{To: "user1@example.com"})
+
+	assert.Error(t, err)
+}
+
+func TestLoadSMTPConfig(t *testing.T) {
+	env := map[string]string{
+		"SMTP_HOST": "localhost",
+		"SMTP_FROM": "noreply@commitly.example",
+	}
+	config, err := LoadSMTPConfig(func(key string) string { return env[key] })
+	assert.NoError(t, err)
+	assert.Equal(t, "localhost", config.Host)
+	assert.Equal(t, DefaultSMTPPort, config.Port)
+
+	env["SMTP_PORT"] = "1025"
+	config, err = LoadSMTPConfig(func(key string) string { return env[key] })
+	assert.NoError(t, err)
+	assert.Equal(t, 1025, config.Port)
+
+	env["SMTP_PORT"] = "smtp"
+	_, err = LoadSMTPConfig(func(key string) string { return env[key] })
+	assert.Error(t, err)
+}
+
+func TestBuildReportEmail_English(t *testing.T) {
+	message, err := BuildReportEmail(i18n.For("en"), "user1@example.com", EmailReportData{
+		Period:         "monthly",
+		Username:       "user1",
+		UserCommits:    1,
+		RangeStart:     time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local),
+		RangeEnd:       time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local),
+		UnsubscribeURL: "https://api.example.com/api/email/unsubscribe?token=abc",
+	})
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Commitly Monthly Report (September 2026): 1 commit", message.Subject)
+	assert.Contains(t, message.HTMLBody, `lang="en"`)
+	assert.Contains(t, message.TextBody, "Unsubscribe: https://api.example.com/api/email/unsubscribe?token=abc")
+	assert.NotContains(t, message.TextBody, "コミット")
+}
+
+func TestBuildVerificationEmail_English(t *testing.T) {
+	message, err := BuildVerificationEmail(i18n.For("en"), "user1@example.com", "https://api.example.com/api/email/verify?token=abc", 24*time.Hour)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "Commitly: Confirm your email address", message.Subject)
+	assert.Contains(t, message.HTMLBody, `lang="en"`)
+	assert.Contains(t, message.HTMLBody, `href="https://api.example.com/api/email/verify?token=abc"`)
+	assert.Contains(t, message.TextBody, "This link expires in 24 hours.")
+	assert.NotContains(t, message.TextBody, "メールアドレス")
+}
@@ -0,0 +1,28 @@
+package gateway
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// HTTPStatusError 送信先が成功以外のステータスを返したエラー（リトライ可否の判定に使う）
+type HTTPStatusError struct {
+	Target     string // "slack webhook" など、ログ用の送信先名
+	StatusCode int
+	Body       string
+}
+
+func (e *HTTPStatusError) Error() string {
+	return fmt.Sprintf("%s returned status %d, body: %s", e.Target, e.StatusCode, e.Body)
+}
+
+// newHTTPStatusError レスポンスからエラーを作成（本文は先頭1KBのみ）
+func newHTTPStatusError(target string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &HTTPStatusError{
+		Target:     target,
+		StatusCode: resp.StatusCode,
+		Body:       string(body),
+	}
+}
@@ -0,0 +1,293 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+)
+
+// DefaultLineAPIBaseURL LINE Messaging APIのベースURL
+const DefaultLineAPIBaseURL = "https://api.line.me"
+
+// LINE Flex Messageの配色
+const (
+	lineColorHeaderWeekly  = "#5865F2"
+	lineColorHeaderMonthly = "#2DA44E"
+	lineColorSubText       = "#888888"
+)
+
+// ILineGateway LINEゲートウェイのインターフェース
+type ILineGateway interface {
+	PushMessage(ctx context.Context, to string, messages []LineMessage) error
+	ReplyMessage(ctx context.Context, replyToken string, messages []LineMessage) error
+	GetProfile(ctx context.Context, lineUserID string) (*LineProfile, error)
+}
+
+// LineProfile LINEユーザーのプロフィール（language はLINEアプリの言語設定。非公開の場合は空）
+type LineProfile struct {
+	DisplayName string `json:"displayName"`
+	Language    string `json:"language"`
+}
+
+// LineMessage LINEメッセージ構造体（text / flex）
+type LineMessage struct {
+	Type     string             `json:"type"`
+	Text     string             `json:"text,omitempty"`
+	AltText  string             `json:"altText,omitempty"`
+	Contents *LineFlexComponent `json:"contents,omitempty"`
+}
+
+// LineFlexComponent Flex Messageのコンポーネント（bubble / box / text / separator）
+type LineFlexComponent struct {
+	Type            string              `json:"type"`
+	Layout          string              `json:"layout,omitempty"`
+	Contents        []LineFlexComponent `json:"contents,omitempty"`
+	Header          *LineFlexComponent  `json:"header,omitempty"`
+	Body            *LineFlexComponent  `json:"body,omitempty"`
+	Footer          *LineFlexComponent  `json:"footer,omitempty"`
+	Text            string              `json:"text,omitempty"`
+	Size            string              `json:"size,omitempty"`
+	Weight          string              `json:"weight,omitempty"`
+	Color           string              `json:"color,omitempty"`
+	Align           string              `json:"align,omitempty"`
+	Wrap            bool                `json:"wrap,omitempty"`
+	Flex            int                 `json:"flex,omitempty"`
+	Margin          string              `json:"margin,omitempty"`
+	Spacing         string              `json:"spacing,omitempty"`
+	BackgroundColor string              `json:"backgroundColor,omitempty"`
+}
+
+type lineGateway struct {
+	httpClient         *http.Client
+	baseURL            string
+	channelAccessToken string
+}
+
+// NewLineGateway コンストラクタ（baseURLが空の場合は本番APIを使う）
+func NewLineGateway(baseURL, channelAccessToken string) ILineGateway {
+	if baseURL == "" {
+		baseURL = DefaultLineAPIBaseURL
+	}
+	return &lineGateway{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		baseURL:            strings.TrimRight(baseURL, "/"),
+		channelAccessToken: channelAccessToken,
+	}
+}
+
+func (g *lineGateway) PushMessage(ctx context.Context, to string, messages []LineMessage) error {
+	return g.post(ctx, "/v2/bot/message/push", map[string]interface{}{
+		"to":       to,
+		"messages": messages,
+	})
+}
+
+func (g *lineGateway) ReplyMessage(ctx context.Context, replyToken string, messages []LineMessage) error {
+	return g.post(ctx, "/v2/bot/message/reply", map[string]interface{}{
+		"replyToken": replyToken,
+		"messages":   messages,
+	})
+}
+
+// GetProfile 友だち追加したLINEユーザーのプロフィールを取得する
+func (g *lineGateway) GetProfile(ctx context.Context, lineUserID string) (*LineProfile, error) {
+	if g.channelAccessToken == "" {
+		return nil, fmt.Errorf("LINE channel access token is not configured")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, g.baseURL+"/v2/bot/profile/"+url.PathEscape(lineUserID), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+g.channelAccessToken)
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get line profile: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newHTTPStatusError("line api", resp)
+	}
+
+	var profile LineProfile
+	if err := json.NewDecoder(resp.Body).Decode(&profile); err != nil {
+		return nil, fmt.Errorf("failed to decode line profile: %w", err)
+	}
+	return &profile, nil
+}
+
+func (g *lineGateway) post(ctx context.Context, path string, body interface{}) error {
+	if g.channelAccessToken == "" {
+		return fmt.Errorf("LINE channel access token is not configured")
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal line message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+path, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+g.channelAccessToken)
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send line message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newHTTPStatusError("line api", resp)
+	}
+
+	return nil
+}
+
+// VerifyLineSignature Webhookリクエストの X-Line-Signature を検証する
+func VerifyLineSignature(channelSecret string, body []byte, signature string) bool {
+	if channelSecret == "" || signature == "" {
+		return false
+	}
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(channelSecret))
+	mac.Write(body)
+	return hmac.Equal(decoded, mac.Sum(nil))
+}
+
+// BuildLineTextMessage テキストメッセージを構築
+func BuildLineTextMessage(text string) LineMessage {
+	return LineMessage{Type: "text", Text: text}
+}
+
+// BuildWeeklyReportLineMessage 週次レポートのFlex Messageを構築
+func BuildWeeklyReportLineMessage(
+	l *i18n.Localizer,
+	username string,
+	userCommits int,
+	rivals []RivalCommitSummary,
+	rangeStart, rangeEnd time.Time,
+) LineMessage {
+	body := []LineFlexComponent{
+		{Type: "text", Text: l.T("report.weekly.user_commits", i18n.Vars{"Username": username}), Size: "sm", Wrap: true},
+		{Type: "text", Text: l.Commits(userCommits), Size: "xxl", Weight: "bold"},
+		{Type: "text", Text: l.FormatRange(rangeStart, rangeEnd), Size: "xs", Color: lineColorSubText},
+	}
+	body = append(body, buildLineRivalSection(l, l.T("report.weekly.rivals_title", nil), userCommits, rivals)...)
+
+	return LineMessage{
+		Type:     "flex",
+		AltText:  l.T("report.weekly.summary", i18n.Vars{"Username": username, "Count": userCommits}),
+		Contents: buildLineReportBubble(l, l.Emoji("report_weekly")+" "+l.T("report.weekly.title", nil), lineColorHeaderWeekly, body),
+	}
+}
+
+// BuildMonthlyReportLineMessage 月次レポートのFlex Messageを構築
+func BuildMonthlyReportLineMessage(
+	l *i18n.Localizer,
+	username string,
+	comparison MonthlyComparison,
+	rivals []RivalCommitSummary,
+	month time.Time,
+) LineMessage {
+	monthLabel := l.FormatMonth(month)
+	diffText, growthRate := formatMonthlyDiff(l, comparison)
+
+	body := []LineFlexComponent{
+		{Type: "text", Text: l.T("report.monthly.user_commits", i18n.Vars{"Username": username, "Month": monthLabel}), Size: "sm", Wrap: true},
+		buildLineRow(l.T("report.monthly.this_month", nil), l.Commits(comparison.CurrentMonth)),
+		buildLineRow(l.T("report.monthly.last_month", nil), l.Commits(comparison.PreviousMonth)),
+		buildLineRow(l.T("report.monthly.diff", nil), diffText),
+	}
+	if growthRate != "" {
+		body = append(body, LineFlexComponent{Type: "text", Text: growthRate, Size: "xs", Color: lineColorSubText, Align: "end"})
+	}
+	body = append(body, buildLineRivalSection(l, l.T("report.monthly.rivals_title", nil), comparison.CurrentMonth, rivals)...)
+
+	title := l.T("report.monthly.title", i18n.Vars{"Month": monthLabel})
+	return LineMessage{
+		Type:     "flex",
+		AltText:  l.T("report.monthly.summary", i18n.Vars{"Month": monthLabel, "Username": username, "Count": comparison.CurrentMonth}),
+		Contents: buildLineReportBubble(l, l.Emoji("report_monthly")+" "+title, lineColorHeaderMonthly, body),
+	}
+}
+
+// buildLineReportBubble ヘッダー・本文・フッターからなるbubbleを構築
+func buildLineReportBubble(l *i18n.Localizer, title, headerColor string, body []LineFlexComponent) *LineFlexComponent {
+	return &LineFlexComponent{
+		Type: "bubble",
+		Header: &LineFlexComponent{
+			Type:            "box",
+			Layout:          "vertical",
+			BackgroundColor: headerColor,
+			Contents: []LineFlexComponent{
+				{Type: "text", Text: title, Weight: "bold", Color: "#FFFFFF", Wrap: true},
+			},
+		},
+		Body: &LineFlexComponent{
+			Type:     "box",
+			Layout:   "vertical",
+			Spacing:  "sm",
+			Contents: body,
+		},
+		Footer: &LineFlexComponent{
+			Type:   "box",
+			Layout: "vertical",
+			Contents: []LineFlexComponent{
+				{Type: "text", Text: l.T("report.footer", i18n.Vars{"SentAt": l.FormatDateTime(time.Now())}), Size: "xxs", Color: lineColorSubText},
+			},
+		},
+	}
+}
+
+// buildLineRivalSection ライバルのコミット数一覧を構築
+func buildLineRivalSection(l *i18n.Localizer, title string, userCommits int, rivals []RivalCommitSummary) []LineFlexComponent {
+	if len(rivals) == 0 {
+		return nil
+	}
+
+	section := []LineFlexComponent{
+		{Type: "separator", Margin: "md"},
+		{Type: "text", Text: l.Emoji("rivals") + " " + title, Weight: "bold", Size: "sm", Margin: "md"},
+	}
+	for i, rival := range rivals {
+		emoji := comparisonEmoji(l, userCommits, rival.Commits)
+		section = append(section, buildLineRow(
+			fmt.Sprintf("%d. %s %s", i+1, emoji, rival.Username),
+			fmt.Sprintf("%d", rival.Commits),
+		))
+	}
+	return section
+}
+
+// buildLineRow ラベルと値を左右に並べた行を構築
+func buildLineRow(label, value string) LineFlexComponent {
+	return LineFlexComponent{
+		Type:   "box",
+		Layout: "horizontal",
+		Contents: []LineFlexComponent{
+			{Type: "text", Text: label, Size: "sm", Flex: 3, Wrap: true},
+			{Type: "text", Text: value, Size: "sm", Weight: "bold", Flex: 2, Align: "end"},
+		},
+	}
+}
@@ -0,0 +1,169 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+)
+
+// Mattermost添付の色（Discordの埋め込みと揃える）
+const (
+	mattermostColorWeekly  = "#5865F2"
+	mattermostColorMonthly = "#57F287"
+)
+
+// IMattermostGateway Mattermostゲートウェイのインターフェース
+type IMattermostGateway interface {
+	SendMessage(ctx context.Context, webhookURL string, message *MattermostMessage) error
+}
+
+// MattermostMessage Mattermost Incoming Webhookメッセージ構造体（Slack互換）
+type MattermostMessage struct {
+	Text        string                 `json:"text,omitempty"`
+	Attachments []MattermostAttachment `json:"attachments,omitempty"`
+}
+
+// MattermostAttachment Slack互換のメッセージ添付構造体
+type MattermostAttachment struct {
+	Fallback string                      `json:"fallback"` // 通知やプレビューに表示される本文
+	Color    string                      `json:"color,omitempty"`
+	Title    string                      `json:"title,omitempty"`
+	Text     string                      `json:"text,omitempty"` // Markdown
+	Fields   []MattermostAttachmentField `json:"fields,omitempty"`
+	Footer   string                      `json:"footer,omitempty"`
+}
+
+// MattermostAttachmentField 添付のフィールド
+type MattermostAttachmentField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+type mattermostGateway struct {
+	httpClient *http.Client
+}
+
+// NewMattermostGateway コンストラクタ（送信先はユーザーが指定するため、内部向けのアドレスには接続しない）
+func NewMattermostGateway() IMattermostGateway {
+	return &mattermostGateway{
+		httpClient: newOutboundHTTPClient(10 * time.Second),
+	}
+}
+
+func (g *mattermostGateway) SendMessage(ctx context.Context, webhookURL string, message *MattermostMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal mattermost message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send mattermost message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newHTTPStatusError("mattermost webhook", resp)
+	}
+
+	return nil
+}
+
+// BuildWeeklyReportMattermostMessage 週次レポートのMattermostメッセージを構築
+func BuildWeeklyReportMattermostMessage(
+	l *i18n.Localizer,
+	username string,
+	userCommits int,
+	rivals []RivalCommitSummary,
+	rangeStart, rangeEnd time.Time,
+) *MattermostMessage {
+	title := l.Emoji("report_weekly") + " " + l.T("report.weekly.title", nil)
+	attachment := MattermostAttachment{
+		Fallback: l.T("report.weekly.summary", i18n.Vars{"Username": username, "Count": userCommits}),
+		Color:    mattermostColorWeekly,
+		Title:    title,
+		Text: fmt.Sprintf(
+			"%s %s: **%d**\n_%s_",
+			l.Emoji("user"),
+			l.T("report.weekly.user_commits", i18n.Vars{"Username": "**" + username + "**"}),
+			userCommits,
+			l.FormatRange(rangeStart, rangeEnd),
+		),
+	}
+
+	if len(rivals) > 0 {
+		attachment.Fields = append(attachment.Fields, MattermostAttachmentField{
+			Title: l.Emoji("rivals") + " " + l.T("report.weekly.rivals_title", nil),
+			Value: buildMattermostRivalList(l, userCommits, rivals),
+		})
+	}
+
+	return &MattermostMessage{
+		Attachments: []MattermostAttachment{withMattermostFooter(l, attachment)},
+	}
+}
+
+// BuildMonthlyReportMattermostMessage 月次レポートのMattermostメッセージを構築
+func BuildMonthlyReportMattermostMessage(
+	l *i18n.Localizer,
+	username string,
+	comparison MonthlyComparison,
+	rivals []RivalCommitSummary,
+	month time.Time,
+) *MattermostMessage {
+	monthLabel := l.FormatMonth(month)
+	title := l.Emoji("report_monthly") + " " + l.T("report.monthly.title", i18n.Vars{"Month": monthLabel})
+	diffText, growthRate := formatMonthlyDiff(l, comparison)
+
+	attachment := MattermostAttachment{
+		Fallback: l.T("report.monthly.summary", i18n.Vars{"Month": monthLabel, "Username": username, "Count": comparison.CurrentMonth}),
+		Color:    mattermostColorMonthly,
+		Title:    title,
+		Text:     l.Emoji("user") + " " + l.T("report.monthly.user_commits", i18n.Vars{"Username": "**" + username + "**", "Month": monthLabel}),
+		Fields: []MattermostAttachmentField{
+			{Title: l.T("report.monthly.this_month", nil), Value: fmt.Sprintf("**%s**", l.Commits(comparison.CurrentMonth)), Short: true},
+			{Title: l.T("report.monthly.last_month", nil), Value: fmt.Sprintf("**%s**", l.Commits(comparison.PreviousMonth)), Short: true},
+			{Title: l.T("report.monthly.diff", nil), Value: fmt.Sprintf("%s **%s** %s", trendEmoji(l, comparison), diffText, growthRate), Short: true},
+		},
+	}
+
+	if len(rivals) > 0 {
+		attachment.Fields = append(attachment.Fields, MattermostAttachmentField{
+			Title: l.Emoji("rivals") + " " + l.T("report.monthly.rivals_title", nil),
+			Value: buildMattermostRivalList(l, comparison.CurrentMonth, rivals),
+		})
+	}
+
+	return &MattermostMessage{
+		Attachments: []MattermostAttachment{withMattermostFooter(l, attachment)},
+	}
+}
+
+// buildMattermostRivalList ライバルのコミット数一覧を構築
+func buildMattermostRivalList(l *i18n.Localizer, userCommits int, rivals []RivalCommitSummary) string {
+	var text string
+	for i, rival := range rivals {
+		emoji := comparisonEmoji(l, userCommits, rival.Commits)
+		text += fmt.Sprintf("%d. %s %s: **%s**\n", i+1, emoji, rival.Username, l.Commits(rival.Commits))
+	}
+	return text
+}
+
+// withMattermostFooter 送信日時のフッターを付与する
+func withMattermostFooter(l *i18n.Localizer, attachment MattermostAttachment) MattermostAttachment {
+	attachment.Footer = l.T("report.footer", i18n.Vars{"SentAt": l.FormatDateTime(time.Now())})
+	return attachment
+}
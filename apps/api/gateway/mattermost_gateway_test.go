@@ -0,0 +1,40 @@
+package gateway
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWeeklyReportMattermostMessage(t *testing.T) {
+	l := i18n.For("ja")
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	rivals := []RivalCommitSummary{{Username: "rival1", Commits: 3}}
+
+	msg := BuildWeeklyReportMattermostMessage(l, "user1", 5, rivals, start, start.AddDate(0, 0, 6))
+
+	assert.Len(t, msg.Attachments, 1)
+	attachment := msg.Attachments[0]
+	assert.Equal(t, mattermostColorWeekly, attachment.Color)
+	assert.Contains(t, attachment.Title, "週次レポート")
+	assert.NotEmpty(t, attachment.Fallback)
+	assert.NotEmpty(t, attachment.Footer)
+	assert.Len(t, attachment.Fields, 1)
+	assert.Contains(t, attachment.Fields[0].Value, "rival1")
+}
+
+func TestBuildMonthlyReportMattermostMessage(t *testing.T) {
+	l := i18n.For("en")
+	comparison := MonthlyComparison{CurrentMonth: 10, PreviousMonth: 5}
+
+	msg := BuildMonthlyReportMattermostMessage(l, "user1", comparison, nil, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	attachment := msg.Attachments[0]
+	assert.Equal(t, mattermostColorMonthly, attachment.Color)
+	assert.Len(t, attachment.Fields, 3)
+	for _, f := range attachment.Fields {
+		assert.True(t, f.Short)
+	}
+}
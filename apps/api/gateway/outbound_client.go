@@ -0,0 +1,71 @@
+package gateway
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrDisallowedAddress ユーザーが指定した送信先が内部向けのアドレスに解決された
+var ErrDisallowedAddress = errors.New("destination address is not allowed")
+
+// carrierGradeNAT 共有アドレス空間（RFC 6598）。クラウド内部のネットワークで使われる
+var carrierGradeNAT = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// IsPublicAddress ユーザーが指定したURLの送信先として接続してよいアドレスか
+// ループバック・プライベート・リンクローカル（メタデータサーバーを含む）・未指定のアドレスは拒否する
+func IsPublicAddress(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsMulticast() || ip.IsUnspecified() {
+		return false
+	}
+	return !carrierGradeNAT.Contains(ip)
+}
+
+// IsPublicHost ユーザーが指定したURLのホストが、内部向けと分かるもの（localhost・内部向けのIPアドレス）でないか
+// 名前解決が必要なホストは newOutboundHTTPClient が接続時に検査する
+func IsPublicHost(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" || host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return IsPublicAddress(ip)
+	}
+	return true
+}
+
+// newOutboundHTTPClient ユーザーが指定したURLに送るためのHTTPクライアント
+// 名前解決後の接続先アドレスを接続時に検査するため、DNSリバインディングやリダイレクトでも内部に届かない
+func newOutboundHTTPClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   denyInternalAddress,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// プロキシを経由すると接続先がプロキシのアドレスになり検査できないため使わない
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// denyInternalAddress net.Dialer.Control 用。接続直前のアドレスが内部向けの場合は接続しない
+func denyInternalAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicAddress(ip) {
+		return fmt.Errorf("%w: %s", ErrDisallowedAddress, host)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package gateway
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsPublicAddress(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "::1", "10.0.0.5", "172.16.3.4", "192.168.1.1", "169.254.169.254", "fe80::1", "0.0.0.0", "::", "100.64.0.1", "::ffff:127.0.0.1", "fd00::1"} {
+		assert.False(t, IsPublicAddress(net.ParseIP(addr)), addr)
+	}
+	for _, addr := range []string{"8.8.8.8", "140.82.112.3", "2606:4700::1111"} {
+		assert.True(t, IsPublicAddress(net.ParseIP(addr)), addr)
+	}
+}
+
+func TestIsPublicHost(t *testing.T) {
+	for _, host := range []string{"", "localhost", "LOCALHOST.", "api.localhost", "127.0.0.1", "10.0.0.5", "169.254.169.254", "::1"} {
+		assert.False(t, IsPublicHost(host), host)
+	}
+	for _, host := range []string{"hooks.example.com", "fcm.googleapis.com", "8.8.8.8"} {
+		assert.True(t, IsPublicHost(host), host)
+	}
+}
+
+func TestOutboundHTTPClient_RejectsInternalAddress(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, server.URL, nil)
+	if err != nil {
+		t.Fatalf("failed to create request: %v", err)
+	}
+	_, err = newOutboundHTTPClient(time.Second).Do(req)
+
+	// ループバックのサーバーには接続しない
+	assert.Error(t, err)
+	assert.True(t, errors.Is(err, ErrDisallowedAddress))
+	assert.False(t, called)
+}
@@ -0,0 +1,134 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+)
+
+// SlackSignatureMaxAge リクエストのタイムスタンプとして受け付ける時刻のずれ（リプレイ攻撃対策）
+const SlackSignatureMaxAge = 5 * time.Minute
+
+// スラッシュコマンドの応答に付けるボタンの action_id
+const (
+	SlackActionWeek  = "commitly_week"
+	SlackActionMonth = "commitly_month"
+)
+
+// VerifySlackSignature Slackアプリへのリクエストの X-Slack-Signature を検証する
+func VerifySlackSignature(signingSecret, timestamp string, body []byte, signature string, now time.Time) bool {
+	if signingSecret == "" || timestamp == "" || signature == "" {
+		return false
+	}
+	sec, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return false
+	}
+	age := now.Sub(time.Unix(sec, 0))
+	if age > SlackSignatureMaxAge || age < -SlackSignatureMaxAge {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// BuildSlackCommandTextMessage 本人にだけ見えるテキストの応答を構築
+func BuildSlackCommandTextMessage(text string) *SlackMessage {
+	return &SlackMessage{ResponseType: "ephemeral", Text: text}
+}
+
+// BuildSlackCommandStatsMessage `/commitly week` `/commitly month` の応答を構築
+// period が "weekly" の場合は今月、"monthly" の場合は直近7日間に切り替えるボタンを付ける
+func BuildSlackCommandStatsMessage(
+	l *i18n.Localizer,
+	period string,
+	username string,
+	userCommits int,
+	rivals []RivalCommitSummary,
+	rangeStart, rangeEnd time.Time,
+) *SlackMessage {
+	title := l.T("slack_command.weekly_title", nil)
+	button := slackButton(l.T("slack_command.show_monthly", nil), SlackActionMonth)
+	if period == "monthly" {
+		title = l.T("slack_command.monthly_title", nil)
+		button = slackButton(l.T("slack_command.show_weekly", nil), SlackActionWeek)
+	}
+
+	userText := fmt.Sprintf("%s %s: *%s*\n_%s_",
+		l.Emoji("user"),
+		l.T("slack_command.you", i18n.Vars{"Username": username}),
+		l.Commits(userCommits),
+		l.FormatRange(rangeStart, rangeEnd),
+	)
+
+	blocks := []SlackBlock{
+		{Type: "header", Text: &SlackText{Type: "plain_text", Text: title}},
+		{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: userText}, Accessory: button},
+	}
+	if len(rivals) == 0 {
+		blocks = append(blocks, SlackBlock{Type: "context", Elements: []SlackText{{Type: "mrkdwn", Text: l.T("slack_command.no_rivals", nil)}}})
+	} else {
+		blocks = append(blocks, buildSlackRivalBlocks(l, l.T("slack_command.rivals", nil), userCommits, rivals)...)
+	}
+
+	return &SlackMessage{
+		ResponseType: "ephemeral",
+		Text:         fmt.Sprintf("%s: %s", title, l.Commits(userCommits)),
+		Blocks:       blocks,
+	}
+}
+
+// BuildSlackCommandVersusMessage `/commitly vs <ライバル>` の応答を構築
+func BuildSlackCommandVersusMessage(
+	l *i18n.Localizer,
+	username string,
+	userCommits int,
+	rival RivalCommitSummary,
+	rangeStart, rangeEnd time.Time,
+) *SlackMessage {
+	title := l.T("slack_command.versus_title", i18n.Vars{"Username": username, "Rival": rival.Username})
+
+	var result string
+	switch diff := userCommits - rival.Commits; {
+	case diff > 0:
+		result = l.T("slack_command.versus_ahead", i18n.Vars{"Rival": rival.Username, "Diff": l.Commits(diff)})
+	case diff < 0:
+		result = l.T("slack_command.versus_behind", i18n.Vars{"Rival": rival.Username, "Diff": l.Commits(-diff)})
+	default:
+		result = l.T("slack_command.versus_tie", i18n.Vars{"Rival": rival.Username})
+	}
+
+	scoreText := fmt.Sprintf("%s %s: *%s*\n%s %s: *%s*\n_%s_",
+		l.Emoji("user"), l.T("slack_command.you", i18n.Vars{"Username": username}), l.Commits(userCommits),
+		comparisonEmoji(l, userCommits, rival.Commits), rival.Username, l.Commits(rival.Commits),
+		l.FormatRange(rangeStart, rangeEnd),
+	)
+
+	return &SlackMessage{
+		ResponseType: "ephemeral",
+		Text:         title + ": " + result,
+		Blocks: []SlackBlock{
+			{Type: "header", Text: &SlackText{Type: "plain_text", Text: title}},
+			{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: scoreText}},
+			{Type: "section", Text: &SlackText{Type: "mrkdwn", Text: result}},
+		},
+	}
+}
+
+// slackButton ボタンを構築
+func slackButton(text, actionID string) *SlackButton {
+	return &SlackButton{
+		Type:     "button",
+		Text:     SlackText{Type: "plain_text", Text: text},
+		ActionID: actionID,
+	}
+}
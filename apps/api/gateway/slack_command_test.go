@@ -0,0 +1,61 @@
+package gateway
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifySlackSignature(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 0, 0, 0, time.UTC)
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	body := []byte("team_id=T1&user_id=U1&text=week")
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	signature := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, VerifySlackSignature("secret", timestamp, body, signature, now))
+	assert.False(t, VerifySlackSignature("secret", timestamp, []byte("team_id=T1&user_id=U2&text=week"), signature, now))
+	assert.False(t, VerifySlackSignature("other", timestamp, body, signature, now))
+	// 古いリクエストは再送とみなして拒否する
+	assert.False(t, VerifySlackSignature("secret", timestamp, body, signature, now.Add(SlackSignatureMaxAge+time.Second)))
+	// シークレット未設定の場合は常に拒否する
+	assert.False(t, VerifySlackSignature("", timestamp, body, signature, now))
+}
+
+func TestBuildSlackCommandStatsMessage(t *testing.T) {
+	start := time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local)
+	end := time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local)
+
+	weekly := BuildSlackCommandStatsMessage(i18n.For("en"), "weekly", "me", 3, []RivalCommitSummary{{Username: "rival1", Commits: 5}}, start, end)
+	body, _ := json.Marshal(weekly)
+	assert.Equal(t, "ephemeral", weekly.ResponseType)
+	assert.Contains(t, weekly.Text, "Commits in the last 7 days: 3 commits")
+	assert.Contains(t, string(body), "rival1")
+	assert.Contains(t, string(body), `"action_id":"`+SlackActionMonth+`"`)
+
+	// 月次の応答には直近7日間に切り替えるボタンを付ける
+	monthly := BuildSlackCommandStatsMessage(i18n.For("ja"), "monthly", "me", 0, nil, start, end)
+	body, _ = json.Marshal(monthly)
+	assert.Contains(t, string(body), `"action_id":"`+SlackActionWeek+`"`)
+}
+
+func TestBuildSlackCommandVersusMessage(t *testing.T) {
+	start := time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local)
+	end := time.Date(2026, 10, 18, 0, 0, 0, 0, time.Local)
+
+	message := BuildSlackCommandVersusMessage(i18n.For("en"), "me", 2, RivalCommitSummary{Username: "alice", Commits: 5}, start, end)
+	assert.Equal(t, "me vs alice (last 7 days): You're 3 commits behind alice", message.Text)
+
+	message = BuildSlackCommandVersusMessage(i18n.For("en"), "me", 4, RivalCommitSummary{Username: "alice", Commits: 4}, start, end)
+	assert.Contains(t, message.Text, "You and alice are tied")
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"net/http"
 	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
 )
 
 // ISlackGateway Slackゲートウェイのインターフェース
@@ -19,14 +21,18 @@ type SlackMessage struct {
 	Text        string            `json:"text,omitempty"`
 	Blocks      []SlackBlock      `json:"blocks,omitempty"`
 	Attachments []SlackAttachment `json:"attachments,omitempty"`
+	// スラッシュコマンドの response_url に返す場合のみ使う
+	ResponseType    string `json:"response_type,omitempty"` // ephemeral / in_channel
+	ReplaceOriginal bool   `json:"replace_original,omitempty"`
 }
 
 // SlackBlock Slackブロック構造体
 type SlackBlock struct {
-	Type     string      `json:"type"`
-	Text     *SlackText  `json:"text,omitempty"`
-	Fields   []SlackText `json:"fields,omitempty"`
-	Elements []SlackText `json:"elements,omitempty"` // context block用
+	Type      string       `json:"type"`
+	Text      *SlackText   `json:"text,omitempty"`
+	Fields    []SlackText  `json:"fields,omitempty"`
+	Elements  []SlackText  `json:"elements,omitempty"`  // context block用
+	Accessory *SlackButton `json:"accessory,omitempty"` // section block用
 }
 
 // SlackText Slackテキスト構造体
@@ -35,6 +41,14 @@ type SlackText struct {
 	Text string `json:"text"`
 }
 
+// SlackButton Slackボタン構造体（押されると Interactivity のエンドポイントに action_id と value が届く）
+type SlackButton struct {
+	Type     string    `json:"type"`
+	Text     SlackText `json:"text"`
+	ActionID string    `json:"action_id"`
+	Value    string    `json:"value,omitempty"`
+}
+
 // SlackAttachment Slack添付構造体
 type SlackAttachment struct {
 	Color  string `json:"color,omitempty"`
@@ -75,10 +89,7 @@ func (g *slackGateway) SendMessage(ctx context.Context, webhookURL string, messa
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		// エラーレスポンスを読み取る
-		body := make([]byte, 1024)
-		n, _ := resp.Body.Read(body)
-		return fmt.Errorf("slack webhook returned non-200 status: %d, body: %s", resp.StatusCode, string(body[:n]))
+		return newHTTPStatusError("slack webhook", resp)
 	}
 
 	return nil
@@ -98,17 +109,22 @@ type MonthlyComparison struct {
 
 // BuildWeeklyReportMessage 週次レポートメッセージを構築
 func BuildWeeklyReportMessage(
+	l *i18n.Localizer,
 	username string,
 	userCommits int,
 	rivals []RivalCommitSummary,
-	startDate, endDate string,
+	rangeStart, rangeEnd time.Time,
 ) *SlackMessage {
-	headerText := ":chart_with_upwards_trend: *Commitly 週次レポート*"
+	title := l.T("report.weekly.title", nil)
+	headerText := fmt.Sprintf("%s *%s*", l.Emoji("report_weekly"), title)
 
 	// 自分のコミット数
 	userStatsText := fmt.Sprintf(
-		":bust_in_silhouette: *%s* の今週のコミット数: *%d*\n_（%s 〜 %s）_",
-		username, userCommits, startDate, endDate,
+		"%s %s: *%d*\n_%s_",
+		l.Emoji("user"),
+		l.T("report.weekly.user_commits", i18n.Vars{"Username": "*" + username + "*"}),
+		userCommits,
+		l.FormatRange(rangeStart, rangeEnd),
 	)
 
 	blocks := []SlackBlock{
@@ -116,7 +132,7 @@ func BuildWeeklyReportMessage(
 			Type: "header",
 			Text: &SlackText{
 				Type: "plain_text",
-				Text: "Commitly 週次レポート",
+				Text: title,
 			},
 		},
 		{
@@ -129,36 +145,8 @@ func BuildWeeklyReportMessage(
 	}
 
 	// ライバルの情報
-	if len(rivals) > 0 {
-		blocks = append(blocks, SlackBlock{
-			Type: "divider",
-		})
-
-		rivalText := ":crossed_swords: *ライバルの今週のコミット数*\n"
-		for i, rival := range rivals {
-			emoji := getComparisonEmoji(userCommits, rival.Commits)
-			rivalText += fmt.Sprintf("%d. %s %s: *%d* コミット\n", i+1, emoji, rival.Username, rival.Commits)
-		}
-
-		blocks = append(blocks, SlackBlock{
-			Type: "section",
-			Text: &SlackText{
-				Type: "mrkdwn",
-				Text: rivalText,
-			},
-		})
-	}
-
-	// フッター
-	blocks = append(blocks, SlackBlock{
-		Type: "context",
-		Elements: []SlackText{
-			{
-				Type: "mrkdwn",
-				Text: fmt.Sprintf("_Sent by Commitly at %s_", time.Now().Format("2006-01-02 15:04")),
-			},
-		},
-	})
+	blocks = append(blocks, buildSlackRivalBlocks(l, l.T("report.weekly.rivals_title", nil), userCommits, rivals)...)
+	blocks = append(blocks, buildSlackFooter(l))
 
 	return &SlackMessage{
 		Text:   headerText,
@@ -168,49 +156,28 @@ func BuildWeeklyReportMessage(
 
 // BuildMonthlyReportMessage 月次レポートメッセージを構築
 func BuildMonthlyReportMessage(
+	l *i18n.Localizer,
 	username string,
 	comparison MonthlyComparison,
 	rivals []RivalCommitSummary,
-	monthLabel string,
+	month time.Time,
 ) *SlackMessage {
-	headerText := ":calendar: *Commitly 月次レポート*"
+	monthLabel := l.FormatMonth(month)
+	title := l.T("report.monthly.title", i18n.Vars{"Month": monthLabel})
+	headerText := fmt.Sprintf("%s *%s*", l.Emoji("report_monthly"), title)
 
 	// 前月との比較
-	diff := comparison.CurrentMonth - comparison.PreviousMonth
-	var diffText string
-	var trendEmoji string
-
-	if diff > 0 {
-		trendEmoji = ":arrow_up:"
-		diffText = fmt.Sprintf("+%d", diff)
-	} else if diff < 0 {
-		trendEmoji = ":arrow_down:"
-		diffText = fmt.Sprintf("%d", diff)
-	} else {
-		trendEmoji = ":arrow_right:"
-		diffText = "±0"
-	}
-
-	// 成長率
-	var growthRate string
-	if comparison.PreviousMonth > 0 {
-		rate := float64(diff) / float64(comparison.PreviousMonth) * 100
-		growthRate = fmt.Sprintf("（前月比 %+.1f%%）", rate)
-	} else if comparison.CurrentMonth > 0 {
-		growthRate = "（前月: 0コミット）"
-	} else {
-		growthRate = ""
-	}
+	diffText, growthRate := formatMonthlyDiff(l, comparison)
 
 	userStatsText := fmt.Sprintf(
-		":bust_in_silhouette: *%s* の%sのコミット数\n\n"+
-			"• 今月: *%d* コミット\n"+
-			"• 先月: *%d* コミット\n"+
-			"• %s 差分: *%s* %s",
-		username, monthLabel,
-		comparison.CurrentMonth,
-		comparison.PreviousMonth,
-		trendEmoji, diffText, growthRate,
+		"%s %s\n\n"+
+			"• %s: *%d*\n"+
+			"• %s: *%d*\n"+
+			"• %s %s: *%s* %s",
+		l.Emoji("user"), l.T("report.monthly.user_commits", i18n.Vars{"Username": "*" + username + "*", "Month": monthLabel}),
+		l.T("report.monthly.this_month", nil), comparison.CurrentMonth,
+		l.T("report.monthly.last_month", nil), comparison.PreviousMonth,
+		trendEmoji(l, comparison), l.T("report.monthly.diff", nil), diffText, growthRate,
 	)
 
 	blocks := []SlackBlock{
@@ -218,7 +185,7 @@ func BuildMonthlyReportMessage(
 			Type: "header",
 			Text: &SlackText{
 				Type: "plain_text",
-				Text: fmt.Sprintf("Commitly 月次レポート（%s）", monthLabel),
+				Text: title,
 			},
 		},
 		{
@@ -231,49 +198,92 @@ func BuildMonthlyReportMessage(
 	}
 
 	// ライバルの情報
-	if len(rivals) > 0 {
-		blocks = append(blocks, SlackBlock{
-			Type: "divider",
-		})
-
-		rivalText := ":crossed_swords: *ライバルの今月のコミット数*\n"
-		for i, rival := range rivals {
-			emoji := getComparisonEmoji(comparison.CurrentMonth, rival.Commits)
-			rivalText += fmt.Sprintf("%d. %s %s: *%d* コミット\n", i+1, emoji, rival.Username, rival.Commits)
-		}
-
-		blocks = append(blocks, SlackBlock{
+	blocks = append(blocks, buildSlackRivalBlocks(l, l.T("report.monthly.rivals_title", nil), comparison.CurrentMonth, rivals)...)
+	blocks = append(blocks, buildSlackFooter(l))
+
+	return &SlackMessage{
+		Text:   headerText,
+		Blocks: blocks,
+	}
+}
+
+// buildSlackRivalBlocks ライバルのコミット数一覧のブロックを構築（ライバルがいない場合は空）
+func buildSlackRivalBlocks(l *i18n.Localizer, title string, userCommits int, rivals []RivalCommitSummary) []SlackBlock {
+	if len(rivals) == 0 {
+		return nil
+	}
+
+	rivalText := fmt.Sprintf("%s *%s*\n", l.Emoji("rivals"), title)
+	for i, rival := range rivals {
+		emoji := comparisonEmoji(l, userCommits, rival.Commits)
+		rivalText += fmt.Sprintf("%d. %s %s: *%s*\n", i+1, emoji, rival.Username, l.Commits(rival.Commits))
+	}
+
+	return []SlackBlock{
+		{Type: "divider"},
+		{
 			Type: "section",
 			Text: &SlackText{
 				Type: "mrkdwn",
 				Text: rivalText,
 			},
-		})
+		},
 	}
+}
 
-	// フッター
-	blocks = append(blocks, SlackBlock{
+// buildSlackFooter 送信日時のフッターを構築
+func buildSlackFooter(l *i18n.Localizer) SlackBlock {
+	return SlackBlock{
 		Type: "context",
 		Elements: []SlackText{
 			{
 				Type: "mrkdwn",
-				Text: fmt.Sprintf("_Sent by Commitly at %s_", time.Now().Format("2006-01-02 15:04")),
+				Text: "_" + l.T("report.footer", i18n.Vars{"SentAt": l.FormatDateTime(time.Now())}) + "_",
 			},
 		},
-	})
+	}
+}
 
-	return &SlackMessage{
-		Text:   headerText,
-		Blocks: blocks,
+// formatMonthlyDiff 前月との差分と成長率の表示用テキストを返す
+func formatMonthlyDiff(l *i18n.Localizer, comparison MonthlyComparison) (diffText string, growthRate string) {
+	diff := comparison.CurrentMonth - comparison.PreviousMonth
+	if diff > 0 {
+		diffText = fmt.Sprintf("+%d", diff)
+	} else if diff < 0 {
+		diffText = fmt.Sprintf("%d", diff)
+	} else {
+		diffText = "±0"
+	}
+
+	// 成長率
+	if comparison.PreviousMonth > 0 {
+		rate := float64(diff) / float64(comparison.PreviousMonth) * 100
+		growthRate = l.T("report.monthly.growth_rate", i18n.Vars{"Rate": fmt.Sprintf("%+.1f%%", rate)})
+	} else if comparison.CurrentMonth > 0 {
+		growthRate = l.T("report.monthly.previous_zero", nil)
+	}
+	return diffText, growthRate
+}
+
+// trendEmoji 前月からの増減に応じた絵文字を返す
+func trendEmoji(l *i18n.Localizer, comparison MonthlyComparison) string {
+	switch diff := comparison.CurrentMonth - comparison.PreviousMonth; {
+	case diff > 0:
+		return l.Emoji("trend_up")
+	case diff < 0:
+		return l.Emoji("trend_down")
+	default:
+		return l.Emoji("trend_flat")
 	}
 }
 
-// getComparisonEmoji 比較結果に応じた絵文字を返す
-func getComparisonEmoji(userCommits, rivalCommits int) string {
+// comparisonEmoji 比較結果に応じた絵文字を返す
+// Discord（Webhook経由）やLINEでは :fire: などのショートコードが変換されないため、どのチャンネルでもUnicode絵文字を使う
+func comparisonEmoji(l *i18n.Localizer, userCommits, rivalCommits int) string {
 	if rivalCommits > userCommits {
-		return ":fire:" // ライバルがリード
+		return l.Emoji("rival_ahead") // ライバルがリード
 	} else if rivalCommits < userCommits {
-		return ":muscle:" // 自分がリード
+		return l.Emoji("user_ahead") // 自分がリード
 	}
-	return ":handshake:" // 同点
+	return l.Emoji("tie") // 同点
 }
@@ -0,0 +1,222 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+)
+
+const (
+	// teamsAdaptiveCardContentType Incoming Webhook・Workflows に Adaptive Card を送るときの添付の種類
+	teamsAdaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+	teamsAdaptiveCardSchema      = "http://adaptivecards.io/schemas/adaptive-card.json"
+	// teamsAdaptiveCardVersion Teams が対応している Adaptive Card のバージョン
+	teamsAdaptiveCardVersion = "1.4"
+)
+
+// ITeamsGateway Microsoft Teamsゲートウェイのインターフェース
+type ITeamsGateway interface {
+	SendMessage(ctx context.Context, webhookURL string, message *TeamsMessage) error
+}
+
+// TeamsMessage Teams Incoming Webhookメッセージ構造体
+type TeamsMessage struct {
+	Type        string            `json:"type"` // 常に "message"
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+// TeamsAttachment Adaptive Card の添付構造体
+type TeamsAttachment struct {
+	ContentType string            `json:"contentType"`
+	Content     TeamsAdaptiveCard `json:"content"`
+}
+
+// TeamsAdaptiveCard Adaptive Card構造体
+type TeamsAdaptiveCard struct {
+	Schema  string              `json:"$schema"`
+	Type    string              `json:"type"` // 常に "AdaptiveCard"
+	Version string              `json:"version"`
+	Body    []TeamsCardElement  `json:"body"`
+	MSTeams *TeamsCardMSTeamsEx `json:"msteams,omitempty"`
+}
+
+// TeamsCardMSTeamsEx Teams固有のカード設定
+type TeamsCardMSTeamsEx struct {
+	Width string `json:"width,omitempty"` // "Full" でチャネルの幅いっぱいに表示する
+}
+
+// TeamsCardElement Adaptive Card の要素（TextBlock / FactSet）
+type TeamsCardElement struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	Size      string          `json:"size,omitempty"`
+	Weight    string          `json:"weight,omitempty"`
+	IsSubtle  bool            `json:"isSubtle,omitempty"`
+	Wrap      bool            `json:"wrap,omitempty"`
+	Separator bool            `json:"separator,omitempty"`
+	Facts     []TeamsCardFact `json:"facts,omitempty"` // FactSet用
+}
+
+// TeamsCardFact FactSet の1行
+type TeamsCardFact struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+}
+
+type teamsGateway struct {
+	httpClient *http.Client
+}
+
+// NewTeamsGateway コンストラクタ
+func NewTeamsGateway() ITeamsGateway {
+	return &teamsGateway{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+func (g *teamsGateway) SendMessage(ctx context.Context, webhookURL string, message *TeamsMessage) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal teams message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send teams message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Incoming Webhook は 200、Workflows は 202 Accepted を返す
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newHTTPStatusError("teams webhook", resp)
+	}
+
+	return nil
+}
+
+// BuildTeamsTextMessage テキストだけのAdaptive Cardを構築
+func BuildTeamsTextMessage(text string) *TeamsMessage {
+	return newTeamsMessage([]TeamsCardElement{
+		{Type: "TextBlock", Text: text, Wrap: true},
+	})
+}
+
+// BuildWeeklyReportTeamsMessage 週次レポートのAdaptive Cardを構築
+func BuildWeeklyReportTeamsMessage(
+	l *i18n.Localizer,
+	username string,
+	userCommits int,
+	rivals []RivalCommitSummary,
+	rangeStart, rangeEnd time.Time,
+) *TeamsMessage {
+	body := []TeamsCardElement{
+		{Type: "TextBlock", Text: l.Emoji("report_weekly") + " " + l.T("report.weekly.title", nil), Size: "Large", Weight: "Bolder", Wrap: true},
+		{Type: "TextBlock", Text: l.FormatRange(rangeStart, rangeEnd), IsSubtle: true, Wrap: true},
+		{
+			Type: "TextBlock",
+			Text: fmt.Sprintf("%s %s: **%d**", l.Emoji("user"), l.T("report.weekly.user_commits", i18n.Vars{"Username": "**" + username + "**"}), userCommits),
+			Wrap: true,
+		},
+	}
+	body = append(body, buildTeamsRivalElements(l, l.T("report.weekly.rivals_title", nil), userCommits, rivals)...)
+	body = append(body, buildTeamsFooter(l))
+
+	return newTeamsMessage(body)
+}
+
+// BuildMonthlyReportTeamsMessage 月次レポートのAdaptive Cardを構築
+func BuildMonthlyReportTeamsMessage(
+	l *i18n.Localizer,
+	username string,
+	comparison MonthlyComparison,
+	rivals []RivalCommitSummary,
+	month time.Time,
+) *TeamsMessage {
+	monthLabel := l.FormatMonth(month)
+	diffText, growthRate := formatMonthlyDiff(l, comparison)
+
+	body := []TeamsCardElement{
+		{Type: "TextBlock", Text: l.Emoji("report_monthly") + " " + l.T("report.monthly.title", i18n.Vars{"Month": monthLabel}), Size: "Large", Weight: "Bolder", Wrap: true},
+		{
+			Type: "TextBlock",
+			Text: l.Emoji("user") + " " + l.T("report.monthly.user_commits", i18n.Vars{"Username": "**" + username + "**", "Month": monthLabel}),
+			Wrap: true,
+		},
+		{
+			Type: "FactSet",
+			Facts: []TeamsCardFact{
+				{Title: l.T("report.monthly.this_month", nil), Value: l.Commits(comparison.CurrentMonth)},
+				{Title: l.T("report.monthly.last_month", nil), Value: l.Commits(comparison.PreviousMonth)},
+				{Title: l.T("report.monthly.diff", nil), Value: fmt.Sprintf("%s %s %s", trendEmoji(l, comparison), diffText, growthRate)},
+			},
+		},
+	}
+	body = append(body, buildTeamsRivalElements(l, l.T("report.monthly.rivals_title", nil), comparison.CurrentMonth, rivals)...)
+	body = append(body, buildTeamsFooter(l))
+
+	return newTeamsMessage(body)
+}
+
+// buildTeamsRivalElements ライバルのコミット数一覧の要素を構築（ライバルがいない場合は空）
+func buildTeamsRivalElements(l *i18n.Localizer, title string, userCommits int, rivals []RivalCommitSummary) []TeamsCardElement {
+	if len(rivals) == 0 {
+		return nil
+	}
+
+	facts := make([]TeamsCardFact, 0, len(rivals))
+	for i, rival := range rivals {
+		facts = append(facts, TeamsCardFact{
+			Title: fmt.Sprintf("%d. %s %s", i+1, comparisonEmoji(l, userCommits, rival.Commits), rival.Username),
+			Value: l.Commits(rival.Commits),
+		})
+	}
+
+	return []TeamsCardElement{
+		{Type: "TextBlock", Text: l.Emoji("rivals") + " " + title, Weight: "Bolder", Wrap: true, Separator: true},
+		{Type: "FactSet", Facts: facts},
+	}
+}
+
+// buildTeamsFooter 送信日時のフッターを構築
+func buildTeamsFooter(l *i18n.Localizer) TeamsCardElement {
+	return TeamsCardElement{
+		Type:     "TextBlock",
+		Text:     l.T("report.footer", i18n.Vars{"SentAt": l.FormatDateTime(time.Now())}),
+		Size:     "Small",
+		IsSubtle: true,
+		Wrap:     true,
+	}
+}
+
+// newTeamsMessage 要素をAdaptive Cardの添付1件のメッセージにまとめる
+func newTeamsMessage(body []TeamsCardElement) *TeamsMessage {
+	return &TeamsMessage{
+		Type: "message",
+		Attachments: []TeamsAttachment{
+			{
+				ContentType: teamsAdaptiveCardContentType,
+				Content: TeamsAdaptiveCard{
+					Schema:  teamsAdaptiveCardSchema,
+					Type:    "AdaptiveCard",
+					Version: teamsAdaptiveCardVersion,
+					Body:    body,
+					MSTeams: &TeamsCardMSTeamsEx{Width: "Full"},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWeeklyReportTeamsMessage(t *testing.T) {
+	l := i18n.For("ja")
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	rivals := []RivalCommitSummary{{Username: "rival1", Commits: 3}}
+
+	msg := BuildWeeklyReportTeamsMessage(l, "user1", 5, rivals, start, start.AddDate(0, 0, 6))
+
+	assert.Equal(t, "message", msg.Type)
+	assert.Len(t, msg.Attachments, 1)
+	assert.Equal(t, teamsAdaptiveCardContentType, msg.Attachments[0].ContentType)
+
+	card := msg.Attachments[0].Content
+	assert.Equal(t, "AdaptiveCard", card.Type)
+	assert.Equal(t, teamsAdaptiveCardVersion, card.Version)
+	assert.Contains(t, card.Body[0].Text, "週次レポート")
+
+	var rivalFacts []TeamsCardFact
+	for _, el := range card.Body {
+		if el.Type == "FactSet" {
+			rivalFacts = el.Facts
+		}
+	}
+	assert.Len(t, rivalFacts, 1)
+	assert.Contains(t, rivalFacts[0].Title, "rival1")
+
+	// Adaptive Card のスキーマキーは "$schema" で出力される
+	payload, err := json.Marshal(msg)
+	assert.NoError(t, err)
+	assert.Contains(t, string(payload), `"$schema":"http://adaptivecards.io/schemas/adaptive-card.json"`)
+}
+
+func TestBuildMonthlyReportTeamsMessage_NoRivals(t *testing.T) {
+	l := i18n.For("en")
+	comparison := MonthlyComparison{CurrentMonth: 10, PreviousMonth: 5}
+
+	msg := BuildMonthlyReportTeamsMessage(l, "user1", comparison, nil, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	body := msg.Attachments[0].Content.Body
+	factSets := 0
+	for _, el := range body {
+		if el.Type == "FactSet" {
+			factSets++
+		}
+	}
+	assert.Equal(t, 1, factSets) // 今月・先月・差分のみ
+}
+
+func TestTeamsGateway_SendMessage(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"incoming webhook ok", http.StatusOK, false},
+		{"workflows accepted", http.StatusAccepted, false},
+		{"not found", http.StatusNotFound, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			g := &teamsGateway{httpClient: server.Client()}
+			err := g.SendMessage(context.Background(), server.URL, BuildTeamsTextMessage("hello"))
+
+			if tt.wantErr {
+				var statusErr *HTTPStatusError
+				assert.True(t, errors.As(err, &statusErr))
+				assert.Equal(t, tt.status, statusErr.StatusCode)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
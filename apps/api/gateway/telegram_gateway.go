@@ -0,0 +1,204 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+)
+
+// DefaultTelegramAPIBaseURL Telegram Bot APIのベースURL
+const DefaultTelegramAPIBaseURL = "https://api.telegram.org"
+
+// TelegramParseModeMarkdownV2 MarkdownV2で整形するときの parse_mode
+const TelegramParseModeMarkdownV2 = "MarkdownV2"
+
+// telegramMarkdownV2Replacer MarkdownV2で予約されている文字をエスケープする
+var telegramMarkdownV2Replacer = strings.NewReplacer(
+	`\`, `\\`,
+	"_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`,
+	"~", `\~`, "`", "\\`", ">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`,
+	"=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+)
+
+// ITelegramGateway Telegramゲートウェイのインターフェース
+type ITelegramGateway interface {
+	SendMessage(ctx context.Context, chatID string, message *TelegramMessage) error
+}
+
+// TelegramMessage sendMessage のパラメータ（chat_id は送信時に付与する）
+type TelegramMessage struct {
+	Text      string `json:"text"`
+	ParseMode string `json:"parse_mode,omitempty"` // 空の場合はプレーンテキスト
+}
+
+type telegramGateway struct {
+	httpClient *http.Client
+	baseURL    string
+	botToken   string
+}
+
+// NewTelegramGateway コンストラクタ（baseURLが空の場合は本番APIを使う）
+func NewTelegramGateway(baseURL, botToken string) ITelegramGateway {
+	if baseURL == "" {
+		baseURL = DefaultTelegramAPIBaseURL
+	}
+	return &telegramGateway{
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		baseURL:  strings.TrimRight(baseURL, "/"),
+		botToken: botToken,
+	}
+}
+
+func (g *telegramGateway) SendMessage(ctx context.Context, chatID string, message *TelegramMessage) error {
+	if g.botToken == "" {
+		return fmt.Errorf("Telegram bot token is not configured")
+	}
+
+	payload, err := json.Marshal(struct {
+		ChatID string `json:"chat_id"`
+		*TelegramMessage
+	}{ChatID: chatID, TelegramMessage: message})
+	if err != nil {
+		return fmt.Errorf("failed to marshal telegram message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.baseURL+"/bot"+g.botToken+"/sendMessage", bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		// URLにボットトークンが含まれるため、通知ログに残るエラーからは除く
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("failed to send telegram message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return newHTTPStatusError("telegram api", resp)
+	}
+
+	return nil
+}
+
+// VerifyTelegramSecretToken Webhookリクエストの X-Telegram-Bot-Api-Secret-Token を検証する
+func VerifyTelegramSecretToken(secret, token string) bool {
+	if secret == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(token)) == 1
+}
+
+// EscapeTelegramMarkdownV2 MarkdownV2の本文として送れるようにエスケープする
+func EscapeTelegramMarkdownV2(text string) string {
+	return telegramMarkdownV2Replacer.Replace(text)
+}
+
+// BuildTelegramTextMessage プレーンテキストのメッセージを構築
+func BuildTelegramTextMessage(text string) *TelegramMessage {
+	return &TelegramMessage{Text: text}
+}
+
+// BuildWeeklyReportTelegramMessage 週次レポートのMarkdownV2メッセージを構築
+func BuildWeeklyReportTelegramMessage(
+	l *i18n.Localizer,
+	username string,
+	userCommits int,
+	rivals []RivalCommitSummary,
+	rangeStart, rangeEnd time.Time,
+) *TelegramMessage {
+	lines := []string{
+		telegramBold(l.Emoji("report_weekly") + " " + l.T("report.weekly.title", nil)),
+		telegramItalic(l.FormatRange(rangeStart, rangeEnd)),
+		"",
+		fmt.Sprintf("%s %s: %s",
+			l.Emoji("user"),
+			EscapeTelegramMarkdownV2(l.T("report.weekly.user_commits", i18n.Vars{"Username": username})),
+			telegramBold(l.Commits(userCommits)),
+		),
+	}
+	lines = append(lines, buildTelegramRivalLines(l, l.T("report.weekly.rivals_title", nil), userCommits, rivals)...)
+	lines = append(lines, "", buildTelegramFooter(l))
+
+	return &TelegramMessage{Text: strings.Join(lines, "\n"), ParseMode: TelegramParseModeMarkdownV2}
+}
+
+// BuildMonthlyReportTelegramMessage 月次レポートのMarkdownV2メッセージを構築
+func BuildMonthlyReportTelegramMessage(
+	l *i18n.Localizer,
+	username string,
+	comparison MonthlyComparison,
+	rivals []RivalCommitSummary,
+	month time.Time,
+) *TelegramMessage {
+	monthLabel := l.FormatMonth(month)
+	diffText, growthRate := formatMonthlyDiff(l, comparison)
+
+	lines := []string{
+		telegramBold(l.Emoji("report_monthly") + " " + l.T("report.monthly.title", i18n.Vars{"Month": monthLabel})),
+		"",
+		l.Emoji("user") + " " + EscapeTelegramMarkdownV2(l.T("report.monthly.user_commits", i18n.Vars{"Username": username, "Month": monthLabel})),
+		EscapeTelegramMarkdownV2(l.T("report.monthly.this_month", nil)+": ") + telegramBold(l.Commits(comparison.CurrentMonth)),
+		EscapeTelegramMarkdownV2(l.T("report.monthly.last_month", nil)+": ") + telegramBold(l.Commits(comparison.PreviousMonth)),
+		fmt.Sprintf("%s%s %s %s",
+			EscapeTelegramMarkdownV2(l.T("report.monthly.diff", nil)+": "),
+			trendEmoji(l, comparison),
+			telegramBold(diffText),
+			EscapeTelegramMarkdownV2(growthRate),
+		),
+	}
+	lines = append(lines, buildTelegramRivalLines(l, l.T("report.monthly.rivals_title", nil), comparison.CurrentMonth, rivals)...)
+	lines = append(lines, "", buildTelegramFooter(l))
+
+	return &TelegramMessage{Text: strings.Join(lines, "\n"), ParseMode: TelegramParseModeMarkdownV2}
+}
+
+// buildTelegramRivalLines ライバルのコミット数一覧の行を構築（ライバルがいない場合は空）
+func buildTelegramRivalLines(l *i18n.Localizer, title string, userCommits int, rivals []RivalCommitSummary) []string {
+	if len(rivals) == 0 {
+		return nil
+	}
+
+	lines := []string{"", telegramBold(l.Emoji("rivals") + " " + title)}
+	for i, rival := range rivals {
+		lines = append(lines, fmt.Sprintf("%s %s %s: %s",
+			EscapeTelegramMarkdownV2(fmt.Sprintf("%d.", i+1)),
+			comparisonEmoji(l, userCommits, rival.Commits),
+			EscapeTelegramMarkdownV2(rival.Username),
+			telegramBold(l.Commits(rival.Commits)),
+		))
+	}
+	return lines
+}
+
+// buildTelegramFooter 送信日時のフッターを構築
+func buildTelegramFooter(l *i18n.Localizer) string {
+	return telegramItalic(l.T("report.footer", i18n.Vars{"SentAt": l.FormatDateTime(time.Now())}))
+}
+
+// telegramBold エスケープしたうえで太字にする
+func telegramBold(text string) string {
+	return "*" + EscapeTelegramMarkdownV2(text) + "*"
+}
+
+// telegramItalic エスケープしたうえで斜体にする
+func telegramItalic(text string) string {
+	return "_" + EscapeTelegramMarkdownV2(text) + "_"
+}
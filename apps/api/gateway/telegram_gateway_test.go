@@ -0,0 +1,109 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeTelegramMarkdownV2(t *testing.T) {
+	assert.Equal(t, `user\_1 \(\+12\.5%\)\!`, EscapeTelegramMarkdownV2("user_1 (+12.5%)!"))
+	assert.Equal(t, `a\\b`, EscapeTelegramMarkdownV2(`a\b`))
+	assert.Equal(t, "コミット数", EscapeTelegramMarkdownV2("コミット数"))
+}
+
+func TestBuildWeeklyReportTelegramMessage(t *testing.T) {
+	l := i18n.For("ja")
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	rivals := []RivalCommitSummary{{Username: "rival-1", Commits: 3}}
+
+	msg := BuildWeeklyReportTelegramMessage(l, "user_1", 5, rivals, start, start.AddDate(0, 0, 6))
+
+	assert.Equal(t, TelegramParseModeMarkdownV2, msg.ParseMode)
+	lines := strings.Split(msg.Text, "\n")
+	assert.True(t, strings.HasPrefix(lines[0], "*"))
+	assert.Contains(t, lines[0], "週次レポート")
+	assert.Contains(t, msg.Text, `user\_1`)
+	assert.Contains(t, msg.Text, `1\. `)
+	assert.Contains(t, msg.Text, `rival\-1`)
+}
+
+func TestBuildMonthlyReportTelegramMessage(t *testing.T) {
+	l := i18n.For("en")
+	comparison := MonthlyComparison{CurrentMonth: 15, PreviousMonth: 10}
+
+	msg := BuildMonthlyReportTelegramMessage(l, "user1", comparison, nil, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, TelegramParseModeMarkdownV2, msg.ParseMode)
+	assert.Contains(t, msg.Text, `*\+5*`)
+	assert.Contains(t, msg.Text, `50\.0%`)
+	assert.NotContains(t, msg.Text, "⚔️") // ライバルがいない場合は一覧を出さない
+}
+
+func TestTelegramGateway_SendMessage(t *testing.T) {
+	var gotPath string
+	var gotBody map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		body, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(body, &gotBody)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	defer server.Close()
+
+	g := NewTelegramGateway(server.URL+"/", "123:abc")
+	err := g.SendMessage(context.Background(), "12345", &TelegramMessage{Text: "*hi*", ParseMode: TelegramParseModeMarkdownV2})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "/bot123:abc/sendMessage", gotPath)
+	assert.Equal(t, "12345", gotBody["chat_id"])
+	assert.Equal(t, "*hi*", gotBody["text"])
+	assert.Equal(t, "MarkdownV2", gotBody["parse_mode"])
+}
+
+func TestTelegramGateway_SendMessageErrors(t *testing.T) {
+	t.Run("blocked by user", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`))
+		}))
+		defer server.Close()
+
+		err := NewTelegramGateway(server.URL, "123:abc").SendMessage(context.Background(), "12345", BuildTelegramTextMessage("hi"))
+
+		var statusErr *HTTPStatusError
+		assert.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, http.StatusForbidden, statusErr.StatusCode)
+	})
+
+	t.Run("token not configured", func(t *testing.T) {
+		err := NewTelegramGateway("", "").SendMessage(context.Background(), "12345", BuildTelegramTextMessage("hi"))
+		assert.Error(t, err)
+	})
+
+	t.Run("connection error does not leak token", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		server.Close()
+
+		err := NewTelegramGateway(server.URL, "123:secret-token").SendMessage(context.Background(), "12345", BuildTelegramTextMessage("hi"))
+
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), "secret-token")
+	})
+}
+
+func TestVerifyTelegramSecretToken(t *testing.T) {
+	assert.True(t, VerifyTelegramSecretToken("s3cret", "s3cret"))
+	assert.False(t, VerifyTelegramSecretToken("s3cret", "other"))
+	assert.False(t, VerifyTelegramSecretToken("", ""))
+}
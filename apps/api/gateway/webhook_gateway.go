@@ -0,0 +1,126 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// WebhookReportVersion レポートドキュメントのバージョン（互換性のない変更をしたら上げる）
+const WebhookReportVersion = "1"
+
+// WebhookSignatureHeader 署名ヘッダー名
+const WebhookSignatureHeader = "X-Commitly-Signature"
+
+// WebhookTestEvent テスト通知のイベント名（レポートの項目は空で、Message に本文が入る）
+const WebhookTestEvent = "test"
+
+// WebhookRivalAlertEvent ライバルアラートのイベント名（Range と Commits は今週の値で、Alert に内容が入る）
+const WebhookRivalAlertEvent = "rival_alert"
+
+// WebhookDailyNudgeEvent 今日まだコミットしていないときのリマインダーのイベント名（Range は今日で、Message に本文が入る）
+const WebhookDailyNudgeEvent = "daily_nudge"
+
+// IWebhookGateway 汎用Webhookゲートウェイのインターフェース
+type IWebhookGateway interface {
+	SendReport(ctx context.Context, url, secret string, document *WebhookReportDocument) error
+}
+
+// WebhookReportDocument Webhookで送信するレポートドキュメント
+type WebhookReportDocument struct {
+	Version         string               `json:"version"`
+	Event           string               `json:"event"` // report.weekly / report.monthly / test / rival_alert
+	Period          string               `json:"period"`
+	GeneratedAt     time.Time            `json:"generated_at"`
+	User            WebhookReportUser    `json:"user"`
+	Range           WebhookReportRange   `json:"range"`
+	Commits         int                  `json:"commits"`
+	PreviousCommits *int                 `json:"previous_commits,omitempty"` // 月次のみ
+	Rivals          []WebhookReportRival `json:"rivals"`
+	Alert           *WebhookAlert        `json:"alert,omitempty"`   // ライバルアラートのみ
+	Message         string               `json:"message,omitempty"` // テスト通知・ライバルアラートのみ
+}
+
+// WebhookAlert ライバルアラートの内容
+type WebhookAlert struct {
+	Kind   string `json:"kind"` // overtaken / reclaimed / streak_broken
+	Rival  string `json:"rival"`
+	Streak int    `json:"streak,omitempty"` // 途切れた連続コミット日数（streak_broken のみ）
+}
+
+// WebhookReportUser レポート対象ユーザー
+type WebhookReportUser struct {
+	GithubUsername string `json:"github_username"`
+}
+
+// WebhookReportRange 集計期間（YYYY-MM-DD、両端を含む）
+type WebhookReportRange struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+}
+
+// WebhookReportRival ライバルのコミット数
+type WebhookReportRival struct {
+	GithubUsername string `json:"github_username"`
+	Commits        int    `json:"commits"`
+}
+
+type webhookGateway struct {
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+// NewWebhookGateway コンストラクタ（送信先はユーザーが指定するため、内部向けのアドレスには接続しない）
+func NewWebhookGateway() IWebhookGateway {
+	return &webhookGateway{
+		httpClient: newOutboundHTTPClient(10 * time.Second),
+		now:        time.Now,
+	}
+}
+
+func (g *webhookGateway) SendReport(ctx context.Context, url, secret string, document *WebhookReportDocument) error {
+	payload, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook document: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Commitly-Webhook/"+WebhookReportVersion)
+	req.Header.Set("X-Commitly-Event", document.Event)
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(secret, g.now().Unix(), payload))
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newHTTPStatusError("webhook", resp)
+	}
+
+	return nil
+}
+
+// SignWebhookPayload 署名ヘッダーの値を生成する（"t=<unix秒>,v1=<hex>"）
+// 署名対象は "<unix秒>.<リクエストボディ>" のHMAC-SHA256で、受信側はタイムスタンプが古いリクエストを拒否することでリプレイを防げる
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	ts := strconv.FormatInt(timestamp, 10)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%s,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
+}
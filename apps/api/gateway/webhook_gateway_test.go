@@ -0,0 +1,66 @@
+package gateway
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	body := []byte(`{"version":"1"}`)
+
+	mac := hmac.New(sha256.New, []byte("whsec_test"))
+	mac.Write([]byte("1700000000." + string(body)))
+	expected := "t=1700000000,v1=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.Equal(t, expected, SignWebhookPayload("whsec_test", 1700000000, body))
+}
+
+func TestWebhookGateway_SendReportSignsBody(t *testing.T) {
+	var gotBody []byte
+	var gotHeader http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotBody, _ = io.ReadAll(r.Body)
+		gotHeader = r.Header
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	g := &webhookGateway{
+		httpClient: server.Client(),
+		now:        func() time.Time { return time.Unix(1700000000, 0) },
+	}
+	err := g.SendReport(context.Background(), server.URL, "whsec_test", &WebhookReportDocument{
+		Version: WebhookReportVersion,
+		Event:   "report.weekly",
+		Period:  "weekly",
+		Rivals:  []WebhookReportRival{},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "report.weekly", gotHeader.Get("X-Commitly-Event"))
+	assert.Equal(t, SignWebhookPayload("whsec_test", 1700000000, gotBody), gotHeader.Get(WebhookSignatureHeader))
+	assert.True(t, strings.HasPrefix(gotHeader.Get(WebhookSignatureHeader), "t=1700000000,v1="))
+}
+
+func TestWebhookGateway_SendReportNon2xx(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	g := &webhookGateway{httpClient: server.Client(), now: time.Now}
+	err := g.SendReport(context.Background(), server.URL, "whsec_test", &WebhookReportDocument{})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "500")
+}
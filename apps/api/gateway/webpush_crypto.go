@@ -0,0 +1,213 @@
+package gateway
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// webPushRecordSize aes128gcm のレコードサイズ（1レコードに収まるペイロードだけを送る）
+	webPushRecordSize = 4096
+	// WebPushMaxPayloadSize 暗号化前のペイロードの上限（レコードサイズから区切り1バイトとタグ16バイトを除く）
+	WebPushMaxPayloadSize = webPushRecordSize - 1 - 16
+	// vapidTokenTTL VAPIDのJWTの有効期間（RFC 8292 では24時間以内）
+	vapidTokenTTL = 12 * time.Hour
+)
+
+// VAPIDKeys アプリケーションサーバーの鍵ペアと連絡先（RFC 8292）
+type VAPIDKeys struct {
+	PrivateKey *ecdsa.PrivateKey
+	Subject    string // mailto: または https: の連絡先（プッシュサービスが問題発生時に使う）
+}
+
+// LoadVAPIDKeys 環境変数からVAPIDの鍵を読み込む（秘密鍵が未設定の場合はnil）
+func LoadVAPIDKeys(getenv func(string) string) (*VAPIDKeys, error) {
+	privateKey := getenv("WEB_PUSH_VAPID_PRIVATE_KEY")
+	if privateKey == "" {
+		return nil, nil
+	}
+	raw, err := decodeWebPushKey(privateKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WEB_PUSH_VAPID_PRIVATE_KEY: %w", err)
+	}
+	key, err := ecdsa.ParseRawPrivateKey(elliptic.P256(), raw)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WEB_PUSH_VAPID_PRIVATE_KEY: %w", err)
+	}
+
+	subject := getenv("WEB_PUSH_VAPID_SUBJECT")
+	if !strings.HasPrefix(subject, "mailto:") && !strings.HasPrefix(subject, "https://") {
+		return nil, fmt.Errorf("invalid WEB_PUSH_VAPID_SUBJECT: %q (must start with mailto: or https://)", subject)
+	}
+
+	return &VAPIDKeys{PrivateKey: key, Subject: subject}, nil
+}
+
+// GenerateVAPIDKeys 新しいVAPIDの鍵ペアを生成する（どちらもbase64url）
+func GenerateVAPIDKeys() (privateKey, publicKey string, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	raw, err := key.Bytes()
+	if err != nil {
+		return "", "", err
+	}
+	keys := &VAPIDKeys{PrivateKey: key}
+	return base64.RawURLEncoding.EncodeToString(raw), keys.PublicKey(), nil
+}
+
+// PublicKey ブラウザの pushManager.subscribe に渡す公開鍵（非圧縮形式のbase64url）
+func (k *VAPIDKeys) PublicKey() string {
+	raw, err := k.PrivateKey.PublicKey.Bytes()
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// Authorization プッシュサービスに送る Authorization ヘッダー（vapid t=<JWT>, k=<公開鍵>）
+func (k *VAPIDKeys) Authorization(endpoint string, now time.Time) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return "", fmt.Errorf("invalid push endpoint")
+	}
+
+	header := base64.RawURLEncoding.EncodeToString([]byte(`{"typ":"JWT","alg":"ES256"}`))
+	claims, err := json.Marshal(map[string]interface{}{
+		"aud": u.Scheme + "://" + u.Host,
+		"exp": now.Add(vapidTokenTTL).Unix(),
+		"sub": k.Subject,
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := header + "." + base64.RawURLEncoding.EncodeToString(claims)
+
+	// ES256 の署名は ASN.1 ではなく r || s（各32バイト）
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, k.PrivateKey, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign vapid token: %w", err)
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	token := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return "vapid t=" + token + ", k=" + k.PublicKey(), nil
+}
+
+// EncryptWebPushPayload 購読の公開鍵と認証シークレットでペイロードを暗号化する（RFC 8291 / aes128gcm）
+func EncryptWebPushPayload(p256dh, auth string, plaintext []byte) ([]byte, error) {
+	serverKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	return encryptWebPushPayload(p256dh, auth, plaintext, serverKey, salt)
+}
+
+// encryptWebPushPayload 一時鍵とソルトを指定して暗号化する（テストベクターでの検証用に分けている）
+func encryptWebPushPayload(p256dh, auth string, plaintext []byte, serverKey *ecdh.PrivateKey, salt []byte) ([]byte, error) {
+	if len(plaintext) > WebPushMaxPayloadSize {
+		return nil, fmt.Errorf("web push payload too large: %d bytes", len(plaintext))
+	}
+
+	rawUAPublic, err := decodeWebPushKey(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(rawUAPublic)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh: %w", err)
+	}
+	authSecret, err := decodeWebPushKey(auth)
+	if err != nil || len(authSecret) != 16 {
+		return nil, fmt.Errorf("invalid auth secret")
+	}
+
+	ecdhSecret, err := serverKey.ECDH(uaPublic)
+	if err != nil {
+		return nil, err
+	}
+	serverPublic := serverKey.PublicKey().Bytes()
+
+	// IKM = HKDF(auth_secret, ecdh_secret, "WebPush: info" || 0x00 || ua_public || as_public, 32)
+	keyInfo := "WebPush: info\x00" + string(rawUAPublic) + string(serverPublic)
+	ikm, err := hkdf.Key(sha256.New, ecdhSecret, authSecret, keyInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+
+	// コンテンツ暗号鍵とナンス（RFC 8188）
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	// 最後のレコードは区切り 0x02 で終える（パディングなし）
+	record := append(append([]byte{}, plaintext...), 0x02)
+
+	// ヘッダー: salt(16) || rs(4) || idlen(1) || keyid(サーバーの一時公開鍵)
+	body := make([]byte, 0, 16+4+1+len(serverPublic)+len(record)+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, webPushRecordSize)
+	body = append(body, byte(len(serverPublic)))
+	body = append(body, serverPublic...)
+	return gcm.Seal(body, nonce, record, nil), nil
+}
+
+// ValidateWebPushSubscriptionKeys 購読の公開鍵（P-256の点）と認証シークレット（16バイト）を検証する
+func ValidateWebPushSubscriptionKeys(p256dh, auth string) error {
+	rawUAPublic, err := decodeWebPushKey(p256dh)
+	if err != nil {
+		return fmt.Errorf("invalid p256dh: %w", err)
+	}
+	if _, err := ecdh.P256().NewPublicKey(rawUAPublic); err != nil {
+		return fmt.Errorf("invalid p256dh: %w", err)
+	}
+	authSecret, err := decodeWebPushKey(auth)
+	if err != nil || len(authSecret) != 16 {
+		return fmt.Errorf("invalid auth secret")
+	}
+	return nil
+}
+
+// decodeWebPushKey base64url（パディングの有無を問わない）をデコードする
+func decodeWebPushKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
@@ -0,0 +1,223 @@
+package gateway
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 8291 Appendix A のテストベクター
+const (
+	rfc8291ASPrivateKey = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+	rfc8291UAPrivateKey = "q1dXpw3UpT5VOmu_cf_v6ih07Aems3njxI-JWgLcM94"
+	rfc8291UAPublicKey  = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	rfc8291AuthSecret   = "BTBZMqHH6r4Tts7J_aSIgg"
+	rfc8291Salt         = "DGv6ra1nlYgDCS1FRnbzlw"
+	rfc8291Plaintext    = "When I grow up, I want to be a watermelon"
+	rfc8291Ciphertext   = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+)
+
+func TestEncryptWebPushPayload_RFC8291Vector(t *testing.T) {
+	serverKey, err := ecdh.P256().NewPrivateKey(mustDecodeWebPushKey(t, rfc8291ASPrivateKey))
+	if err != nil {
+		t.Fatalf("failed to load server key: %v", err)
+	}
+
+	body, err := encryptWebPushPayload(rfc8291UAPublicKey, rfc8291AuthSecret, []byte(rfc8291Plaintext), serverKey, mustDecodeWebPushKey(t, rfc8291Salt))
+
+	assert.NoError(t, err)
+	assert.Equal(t, rfc8291Ciphertext, base64.RawURLEncoding.EncodeToString(body))
+}
+
+func TestEncryptWebPushPayload_RoundTrip(t *testing.T) {
+	// ブラウザによってはパディング付きのbase64urlで鍵を渡してくる
+	body, err := EncryptWebPushPayload(rfc8291UAPublicKey+"=", rfc8291AuthSecret+"==", []byte(`{"title":"hi"}`))
+	assert.NoError(t, err)
+
+	plaintext, err := decryptWebPushPayload(body)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"title":"hi"}`, string(plaintext))
+}
+
+func TestEncryptWebPushPayload_InvalidInput(t *testing.T) {
+	_, err := EncryptWebPushPayload("not-a-key", rfc8291AuthSecret, []byte("hi"))
+	assert.ErrorContains(t, err, "p256dh")
+
+	_, err = EncryptWebPushPayload(rfc8291UAPublicKey, "c2hvcnQ", []byte("hi"))
+	assert.ErrorContains(t, err, "auth secret")
+
+	_, err = EncryptWebPushPayload(rfc8291UAPublicKey, rfc8291AuthSecret, make([]byte, WebPushMaxPayloadSize+1))
+	assert.ErrorContains(t, err, "too large")
+}
+
+func TestValidateWebPushSubscriptionKeys(t *testing.T) {
+	assert.NoError(t, ValidateWebPushSubscriptionKeys(rfc8291UAPublicKey, rfc8291AuthSecret))
+	assert.Error(t, ValidateWebPushSubscriptionKeys(rfc8291UAPublicKey[:20], rfc8291AuthSecret))
+	assert.Error(t, ValidateWebPushSubscriptionKeys(rfc8291UAPublicKey, rfc8291UAPublicKey))
+	assert.Error(t, ValidateWebPushSubscriptionKeys("", ""))
+}
+
+func TestLoadVAPIDKeys(t *testing.T) {
+	env := func(values map[string]string) func(string) string {
+		return func(key string) string { return values[key] }
+	}
+
+	keys, err := LoadVAPIDKeys(env(nil))
+	assert.NoError(t, err)
+	assert.Nil(t, keys) // 未設定の場合はWeb Pushを無効にする
+
+	privateKey, publicKey, err := GenerateVAPIDKeys()
+	assert.NoError(t, err)
+	keys, err = LoadVAPIDKeys(env(map[string]string{
+		"WEB_PUSH_VAPID_PRIVATE_KEY": privateKey,
+		"WEB_PUSH_VAPID_SUBJECT":     "mailto:admin@example.com",
+	}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, publicKey, keys.PublicKey())
+		assert.Equal(t, "mailto:admin@example.com", keys.Subject)
+	}
+
+	_, err = LoadVAPIDKeys(env(map[string]string{
+		"WEB_PUSH_VAPID_PRIVATE_KEY": privateKey,
+		"WEB_PUSH_VAPID_SUBJECT":     "admin@example.com",
+	}))
+	assert.ErrorContains(t, err, "WEB_PUSH_VAPID_SUBJECT")
+
+	_, err = LoadVAPIDKeys(env(map[string]string{
+		"WEB_PUSH_VAPID_PRIVATE_KEY": "invalid",
+		"WEB_PUSH_VAPID_SUBJECT":     "mailto:admin@example.com",
+	}))
+	assert.ErrorContains(t, err, "WEB_PUSH_VAPID_PRIVATE_KEY")
+}
+
+func TestVAPIDKeys_Authorization(t *testing.T) {
+	keys := testVAPIDKeys(t)
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+
+	authorization, err := keys.Authorization("https://fcm.googleapis.com/fcm/send/abc123", now)
+	assert.NoError(t, err)
+
+	token, publicKey, ok := strings.Cut(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+	assert.True(t, strings.HasPrefix(authorization, "vapid t="))
+	assert.True(t, ok)
+	assert.Equal(t, keys.PublicKey(), publicKey)
+
+	// JWT の署名を公開鍵で検証する（ES256: r || s）
+	parts := strings.Split(token, ".")
+	if !assert.Len(t, parts, 3) {
+		return
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if assert.NoError(t, err) && assert.Len(t, signature, 64) {
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		r := new(big.Int).SetBytes(signature[:32])
+		s := new(big.Int).SetBytes(signature[32:])
+		assert.True(t, ecdsa.Verify(&keys.PrivateKey.PublicKey, digest[:], r, s))
+	}
+
+	var claims struct {
+		Aud string `json:"aud"`
+		Exp int64  `json:"exp"`
+		Sub string `json:"sub"`
+	}
+	assert.NoError(t, json.Unmarshal(mustDecodeWebPushKey(t, parts[1]), &claims))
+	assert.Equal(t, "https://fcm.googleapis.com", claims.Aud)
+	assert.Equal(t, now.Add(12*time.Hour).Unix(), claims.Exp)
+	assert.Equal(t, "mailto:admin@example.com", claims.Sub)
+
+	_, err = keys.Authorization("not a url", now)
+	assert.Error(t, err)
+}
+
+// testVAPIDKeys テスト用の鍵ペアを生成する
+func testVAPIDKeys(t *testing.T) *VAPIDKeys {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return &VAPIDKeys{PrivateKey: key, Subject: "mailto:admin@example.com"}
+}
+
+func mustDecodeWebPushKey(t *testing.T, key string) []byte {
+	t.Helper()
+	raw, err := decodeWebPushKey(key)
+	if err != nil {
+		t.Fatalf("failed to decode %q: %v", key, err)
+	}
+	return raw
+}
+
+// decryptWebPushPayload RFC 8291 のテストベクターのブラウザ側の鍵で復号する
+func decryptWebPushPayload(body []byte) ([]byte, error) {
+	if len(body) < 21 || len(body) < 21+int(body[20]) {
+		return nil, errors.New("body too short")
+	}
+	salt := body[:16]
+	idlen := int(body[20])
+	serverPublic := body[21 : 21+idlen]
+	ciphertext := body[21+idlen:]
+
+	rawUAPrivate, _ := decodeWebPushKey(rfc8291UAPrivateKey)
+	uaPrivate, err := ecdh.P256().NewPrivateKey(rawUAPrivate)
+	if err != nil {
+		return nil, err
+	}
+	asPublic, err := ecdh.P256().NewPublicKey(serverPublic)
+	if err != nil {
+		return nil, err
+	}
+	ecdhSecret, err := uaPrivate.ECDH(asPublic)
+	if err != nil {
+		return nil, err
+	}
+	authSecret, _ := decodeWebPushKey(rfc8291AuthSecret)
+
+	ikm, err := hkdf.Key(sha256.New, ecdhSecret, authSecret, "WebPush: info\x00"+string(uaPrivate.PublicKey().Bytes())+string(serverPublic), 32)
+	if err != nil {
+		return nil, err
+	}
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, err
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(record) == 0 || record[len(record)-1] != 0x02 {
+		return nil, errors.New("missing last record delimiter")
+	}
+	return record[:len(record)-1], nil
+}
@@ -0,0 +1,172 @@
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+)
+
+// webPushTTL プッシュサービスが端末がオフラインの間に通知を保持する秒数
+const webPushTTL = 24 * time.Hour
+
+// 通知をクリックしたときに開くWebアプリのページ
+const (
+	WebPushDashboardPath = "/dashboard"
+	WebPushRivalsPath    = "/rivals"
+	WebPushSettingsPath  = "/settings/notifications"
+)
+
+// IWebPushGateway Web Pushゲートウェイのインターフェース
+type IWebPushGateway interface {
+	Send(ctx context.Context, subscription WebPushTarget, message *WebPushMessage) error
+}
+
+// WebPushTarget 送信先の購読（ブラウザの PushSubscription）
+type WebPushTarget struct {
+	Endpoint string
+	P256dh   string // ブラウザの公開鍵（base64url）
+	Auth     string // 認証シークレット（base64url）
+}
+
+// WebPushMessage Service Worker が通知として表示するペイロード
+type WebPushMessage struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+	URL   string `json:"url,omitempty"` // 通知をクリックしたときに開くURL
+	Tag   string `json:"tag,omitempty"` // 同じタグの通知は端末上で置き換える
+}
+
+type webPushGateway struct {
+	httpClient *http.Client
+	keys       *VAPIDKeys
+	now        func() time.Time
+}
+
+// NewWebPushGateway コンストラクタ（keysがnilの場合は送信時にエラーを返す）
+// エンドポイントはブラウザから登録されるため、内部向けのアドレスには接続しない
+func NewWebPushGateway(keys *VAPIDKeys) IWebPushGateway {
+	return &webPushGateway{
+		httpClient: newOutboundHTTPClient(10 * time.Second),
+		keys:       keys,
+		now:        time.Now,
+	}
+}
+
+func (g *webPushGateway) Send(ctx context.Context, subscription WebPushTarget, message *WebPushMessage) error {
+	if g.keys == nil {
+		return fmt.Errorf("VAPID keys are not configured")
+	}
+
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal web push message: %w", err)
+	}
+	body, err := EncryptWebPushPayload(subscription.P256dh, subscription.Auth, payload)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt web push message: %w", err)
+	}
+	authorization, err := g.keys.Authorization(subscription.Endpoint, g.now())
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("TTL", strconv.Itoa(int(webPushTTL.Seconds())))
+	req.Header.Set("Authorization", authorization)
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send web push message: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// プッシュサービスは 201 Created を返す
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return newHTTPStatusError("web push", resp)
+	}
+
+	return nil
+}
+
+// IsWebPushSubscriptionGone 購読が解除・失効している（404 / 410）かどうか
+func IsWebPushSubscriptionGone(err error) bool {
+	var statusErr *HTTPStatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.StatusCode == http.StatusNotFound || statusErr.StatusCode == http.StatusGone
+}
+
+// BuildWeeklyReportWebPushMessage 週次レポートの通知を構築
+func BuildWeeklyReportWebPushMessage(
+	l *i18n.Localizer,
+	username string,
+	userCommits int,
+	rivals []RivalCommitSummary,
+	rangeStart, rangeEnd time.Time,
+) *WebPushMessage {
+	lines := []string{
+		fmt.Sprintf("%s %s: %s", l.Emoji("user"), l.T("report.weekly.user_commits", i18n.Vars{"Username": username}), l.Commits(userCommits)),
+	}
+	if len(rivals) > 0 {
+		lines = append(lines, l.Emoji("rivals")+" "+buildWebPushRivalSummary(l, rivals))
+	}
+	lines = append(lines, l.FormatRange(rangeStart, rangeEnd))
+
+	return &WebPushMessage{
+		Title: l.Emoji("report_weekly") + " " + l.T("report.weekly.title", nil),
+		Body:  strings.Join(lines, "\n"),
+		URL:   WebPushDashboardPath,
+		Tag:   "report-weekly",
+	}
+}
+
+// BuildMonthlyReportWebPushMessage 月次レポートの通知を構築
+func BuildMonthlyReportWebPushMessage(
+	l *i18n.Localizer,
+	username string,
+	comparison MonthlyComparison,
+	rivals []RivalCommitSummary,
+	month time.Time,
+) *WebPushMessage {
+	monthLabel := l.FormatMonth(month)
+	diffText, growthRate := formatMonthlyDiff(l, comparison)
+
+	lines := []string{
+		fmt.Sprintf("%s %s: %s", l.Emoji("user"), l.T("report.monthly.user_commits", i18n.Vars{"Username": username, "Month": monthLabel}), l.Commits(comparison.CurrentMonth)),
+		strings.TrimSpace(fmt.Sprintf("%s %s: %s %s", trendEmoji(l, comparison), l.T("report.monthly.diff", nil), diffText, growthRate)),
+	}
+	if len(rivals) > 0 {
+		lines = append(lines, l.Emoji("rivals")+" "+buildWebPushRivalSummary(l, rivals))
+	}
+
+	return &WebPushMessage{
+		Title: l.Emoji("report_monthly") + " " + l.T("report.monthly.title", i18n.Vars{"Month": monthLabel}),
+		Body:  strings.Join(lines, "\n"),
+		URL:   WebPushDashboardPath,
+		Tag:   "report-monthly",
+	}
+}
+
+// buildWebPushRivalSummary ライバルのコミット数を1行にまとめる（通知は表示できる行数が少ないため）
+func buildWebPushRivalSummary(l *i18n.Localizer, rivals []RivalCommitSummary) string {
+	parts := make([]string, 0, len(rivals))
+	for _, rival := range rivals {
+		parts = append(parts, fmt.Sprintf("%s %s", rival.Username, l.Commits(rival.Commits)))
+	}
+	return strings.Join(parts, " / ")
+}
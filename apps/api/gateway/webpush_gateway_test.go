@@ -0,0 +1,120 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWeeklyReportWebPushMessage(t *testing.T) {
+	l := i18n.For("ja")
+	start := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	rivals := []RivalCommitSummary{{Username: "rival1", Commits: 3}, {Username: "rival2", Commits: 8}}
+
+	msg := BuildWeeklyReportWebPushMessage(l, "user1", 5, rivals, start, start.AddDate(0, 0, 6))
+
+	assert.Contains(t, msg.Title, "週次レポート")
+	assert.Contains(t, msg.Body, "user1")
+	assert.Contains(t, msg.Body, "rival1")
+	assert.Contains(t, msg.Body, " / rival2")
+	assert.Equal(t, WebPushDashboardPath, msg.URL)
+	assert.Equal(t, "report-weekly", msg.Tag)
+}
+
+func TestBuildMonthlyReportWebPushMessage(t *testing.T) {
+	l := i18n.For("en")
+	comparison := MonthlyComparison{CurrentMonth: 15, PreviousMonth: 10}
+
+	msg := BuildMonthlyReportWebPushMessage(l, "user1", comparison, nil, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC))
+
+	assert.Contains(t, msg.Body, "+5")
+	assert.Contains(t, msg.Body, "50.0%")
+	assert.Len(t, strings.Split(msg.Body, "\n"), 2) // ライバルがいない場合は一覧を出さない
+	assert.Equal(t, "report-monthly", msg.Tag)
+}
+
+func TestWebPushGateway_Send(t *testing.T) {
+	var gotHeader http.Header
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	keys := testVAPIDKeys(t)
+	g := &webPushGateway{httpClient: server.Client(), keys: keys, now: time.Now}
+	err := g.Send(context.Background(), WebPushTarget{
+		Endpoint: server.URL + "/push/abc",
+		P256dh:   rfc8291UAPublicKey,
+		Auth:     rfc8291AuthSecret,
+	}, &WebPushMessage{Title: "Commitly", Body: "hi", URL: WebPushDashboardPath})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "aes128gcm", gotHeader.Get("Content-Encoding"))
+	assert.Equal(t, "application/octet-stream", gotHeader.Get("Content-Type"))
+	assert.Equal(t, "86400", gotHeader.Get("TTL"))
+	assert.True(t, strings.HasPrefix(gotHeader.Get("Authorization"), "vapid t="))
+	assert.True(t, strings.HasSuffix(gotHeader.Get("Authorization"), ", k="+keys.PublicKey()))
+
+	// ブラウザの鍵で復号すると Service Worker に渡るJSONになる
+	plaintext, err := decryptWebPushPayload(gotBody)
+	if assert.NoError(t, err) {
+		var message WebPushMessage
+		assert.NoError(t, json.Unmarshal(plaintext, &message))
+		assert.Equal(t, WebPushMessage{Title: "Commitly", Body: "hi", URL: WebPushDashboardPath}, message)
+	}
+}
+
+func TestWebPushGateway_SendGone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusGone)
+		_, _ = w.Write([]byte("push subscription has unsubscribed or expired"))
+	}))
+	defer server.Close()
+
+	g := &webPushGateway{httpClient: server.Client(), keys: testVAPIDKeys(t), now: time.Now}
+	err := g.Send(context.Background(), WebPushTarget{
+		Endpoint: server.URL,
+		P256dh:   rfc8291UAPublicKey,
+		Auth:     rfc8291AuthSecret,
+	}, &WebPushMessage{Title: "Commitly", Body: "hi"})
+
+	assert.Error(t, err)
+	assert.True(t, IsWebPushSubscriptionGone(err))
+	assert.False(t, IsWebPushSubscriptionGone(&HTTPStatusError{StatusCode: http.StatusTooManyRequests}))
+}
+
+func TestWebPushGateway_RefusesInternalEndpoint(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	err := NewWebPushGateway(testVAPIDKeys(t)).Send(context.Background(), WebPushTarget{
+		Endpoint: server.URL + "/push/abc",
+		P256dh:   rfc8291UAPublicKey,
+		Auth:     rfc8291AuthSecret,
+	}, &WebPushMessage{Title: "Commitly", Body: "hi"})
+
+	// ループバックのエンドポイントには接続しない
+	assert.ErrorIs(t, err, ErrDisallowedAddress)
+	assert.False(t, called)
+}
+
+func TestWebPushGateway_SendWithoutKeys(t *testing.T) {
+	g := NewWebPushGateway(nil)
+	err := g.Send(context.Background(), WebPushTarget{Endpoint: "https://push.example.com"}, &WebPushMessage{})
+
+	assert.ErrorContains(t, err, "VAPID keys are not configured")
+}
@@ -0,0 +1,138 @@
+package i18n
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"log"
+	"text/template"
+	"time"
+)
+
+//go:embed locales/*.json
+var localeFS embed.FS
+
+// Vars メッセージのテンプレートに渡す値
+type Vars map[string]interface{}
+
+// catalog 1言語分のメッセージ（値は text/template）
+type catalog map[string]*template.Template
+
+var catalogs = mustLoadCatalogs()
+
+// mustLoadCatalogs 対応している言語のメッセージを読み込む
+func mustLoadCatalogs() map[Locale]catalog {
+	loaded := make(map[Locale]catalog, len(SupportedLocales))
+	for _, locale := range SupportedLocales {
+		c, err := loadCatalog(locale)
+		if err != nil {
+			panic(err)
+		}
+		loaded[locale] = c
+	}
+	return loaded
+}
+
+func loadCatalog(locale Locale) (catalog, error) {
+	raw, err := localeFS.ReadFile("locales/" + string(locale) + ".json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s messages: %w", locale, err)
+	}
+	var messages map[string]string
+	if err := json.Unmarshal(raw, &messages); err != nil {
+		return nil, fmt.Errorf("failed to parse %s messages: %w", locale, err)
+	}
+
+	c := make(catalog, len(messages))
+	for key, message := range messages {
+		tmpl, err := template.New(key).Option("missingkey=error").Parse(message)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s message %q: %w", locale, key, err)
+		}
+		c[key] = tmpl
+	}
+	return c, nil
+}
+
+// Localizer ある言語でメッセージ・絵文字・日付を整形する
+type Localizer struct {
+	locale Locale
+}
+
+// For ユーザーの言語の Localizer を返す（未設定・未対応の場合はデフォルトの言語）
+func For(locale string) *Localizer {
+	return &Localizer{locale: Normalize(locale)}
+}
+
+// Locale 言語
+func (l *Localizer) Locale() Locale {
+	return l.locale
+}
+
+// T メッセージを整形する（その言語にない場合はデフォルトの言語で、どちらにもない場合はキーを返す）
+func (l *Localizer) T(key string, vars Vars) string {
+	tmpl, ok := catalogs[l.locale][key]
+	if !ok {
+		tmpl, ok = catalogs[DefaultLocale][key]
+	}
+	if !ok {
+		log.Printf("i18n: missing message %q", key)
+		return key
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, vars); err != nil {
+		log.Printf("i18n: failed to render %s message %q: %v", l.locale, key, err)
+		return key
+	}
+	return buf.String()
+}
+
+// Emoji 絵文字を返す（name は "rival_ahead" など）
+func (l *Localizer) Emoji(name string) string {
+	return l.T("emoji."+name, nil)
+}
+
+// Commits "12 コミット" のようにコミット数を整形する
+func (l *Localizer) Commits(count int) string {
+	return l.T("report.commits", Vars{"Count": count})
+}
+
+// FormatDate 日付を整形する
+func (l *Localizer) FormatDate(t time.Time) string {
+	return t.Format(l.T("format.date", nil))
+}
+
+// FormatMonth 年月を整形する
+func (l *Localizer) FormatMonth(t time.Time) string {
+	return t.Format(l.T("format.month", nil))
+}
+
+// FormatDateTime 日時を整形する
+func (l *Localizer) FormatDateTime(t time.Time) string {
+	return t.Format(l.T("format.datetime", nil))
+}
+
+// FormatRange 期間を整形する（両端を含む）
+func (l *Localizer) FormatRange(start, end time.Time) string {
+	return l.T("report.range", Vars{"Start": l.FormatDate(start), "End": l.FormatDate(end)})
+}
+
+// TemplateFunc テンプレートから {{t "key" "Name" value ...}} の形で呼ぶための関数
+func (l *Localizer) TemplateFunc() func(key string, pairs ...interface{}) (string, error) {
+	return func(key string, pairs ...interface{}) (string, error) {
+		if len(pairs)%2 != 0 {
+			return "", fmt.Errorf("t %q: odd number of arguments", key)
+		}
+		vars := make(Vars, len(pairs)/2)
+		for i := 0; i < len(pairs); i += 2 {
+			name, ok := pairs[i].(string)
+			if !ok {
+				return "", fmt.Errorf("t %q: argument name must be a string", key)
+			}
+			vars[name] = pairs[i+1]
+		}
+		return l.T(key, vars), nil
+	}
+}
@@ -0,0 +1,81 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for _, locale := range SupportedLocales {
+		for key := range catalogs[DefaultLocale] {
+			_, ok := catalogs[locale][key]
+			assert.True(t, ok, "%s is missing %q", locale, key)
+		}
+		for key := range catalogs[locale] {
+			_, ok := catalogs[DefaultLocale][key]
+			assert.True(t, ok, "%s has unknown key %q", locale, key)
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	assert.Equal(t, LocaleEN, Normalize("en-US"))
+	assert.Equal(t, LocaleJA, Normalize("ja_JP"))
+	assert.Equal(t, DefaultLocale, Normalize("fr"))
+	assert.Equal(t, DefaultLocale, Normalize(""))
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected Locale
+		ok       bool
+	}{
+		{header: "en-US,en;q=0.9,ja;q=0.8", expected: LocaleEN, ok: true},
+		{header: "fr-FR,ja;q=0.5,en;q=0.7", expected: LocaleEN, ok: true},
+		{header: "ja", expected: LocaleJA, ok: true},
+		{header: "en;q=0,ja;q=0.1", expected: LocaleJA, ok: true},
+		{header: "fr-FR,de", expected: DefaultLocale, ok: false},
+		{header: "", expected: DefaultLocale, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.header, func(t *testing.T) {
+			locale, ok := FromAcceptLanguage(tt.header)
+			assert.Equal(t, tt.expected, locale)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestLocalizer(t *testing.T) {
+	start := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC)
+
+	ja := For("ja")
+	assert.Equal(t, "12 コミット", ja.Commits(12))
+	assert.Equal(t, "2026/10/05 〜 2026/10/11", ja.FormatRange(start, end))
+	assert.Equal(t, "2026年10月", ja.FormatMonth(start))
+
+	en := For("en")
+	assert.Equal(t, "1 commit", en.Commits(1))
+	assert.Equal(t, "12 commits", en.Commits(12))
+	assert.Equal(t, "Oct 5, 2026 – Oct 11, 2026", en.FormatRange(start, end))
+	assert.Equal(t, "October 2026", en.FormatMonth(start))
+
+	// 未知のキーはキーをそのまま返す
+	assert.Equal(t, "unknown.key", en.T("unknown.key", nil))
+}
+
+func TestTemplateFunc(t *testing.T) {
+	fn := For("en").TemplateFunc()
+
+	text, err := fn("report.weekly.user_commits", "Username", "octocat")
+	assert.NoError(t, err)
+	assert.Equal(t, "octocat's commits this week", text)
+
+	_, err = fn("report.weekly.user_commits", "Username")
+	assert.Error(t, err)
+}
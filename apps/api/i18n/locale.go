@@ -0,0 +1,88 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locale ユーザーの言語
+type Locale string
+
+const (
+	LocaleJA Locale = "ja"
+	LocaleEN Locale = "en"
+)
+
+// DefaultLocale 未設定・未対応の言語の場合に使う言語
+const DefaultLocale = LocaleJA
+
+// SupportedLocales 対応している言語
+var SupportedLocales = []Locale{LocaleJA, LocaleEN}
+
+// IsSupported 対応している言語かどうか
+func (l Locale) IsSupported() bool {
+	for _, supported := range SupportedLocales {
+		if l == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseLocale "en-US" のような言語タグを対応している言語に変換する
+func ParseLocale(tag string) (Locale, bool) {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	locale := Locale(tag)
+	return locale, locale.IsSupported()
+}
+
+// Normalize 言語タグを対応している言語に変換する（未対応の場合はデフォルト）
+func Normalize(tag string) Locale {
+	if locale, ok := ParseLocale(tag); ok {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// FromAcceptLanguage Accept-Language ヘッダーから、優先度が最も高い対応言語を選ぶ
+func FromAcceptLanguage(header string) (Locale, bool) {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+
+	var candidates []candidate
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		if fields[0] == "" {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "q=") {
+				if q, err := strconv.ParseFloat(param[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{tag: fields[0], quality: quality})
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	for _, c := range candidates {
+		if locale, ok := ParseLocale(c.tag); ok {
+			return locale, true
+		}
+	}
+	return DefaultLocale, false
+}
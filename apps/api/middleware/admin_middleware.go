@@ -0,0 +1,39 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// AdminMiddleware 管理者認証ミドルウェア
+// Authorization: Bearer <token> ヘッダーのトークンを ADMIN_API_TOKEN と照合する
+func AdminMiddleware(adminToken string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// トークン未設定の環境では管理APIを無効化
+			if adminToken == "" {
+				return c.JSON(http.StatusForbidden, map[string]string{
+					"error": "管理APIは無効です",
+				})
+			}
+
+			token, ok := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+			if !ok || token == "" {
+				return c.JSON(http.StatusUnauthorized, map[string]string{
+					"error": "認証が必要です",
+				})
+			}
+
+			if subtle.ConstantTimeCompare([]byte(token), []byte(adminToken)) != 1 {
+				return c.JSON(http.StatusUnauthorized, map[string]string{
+					"error": "認証情報が不正です",
+				})
+			}
+
+			return next(c)
+		}
+	}
+}
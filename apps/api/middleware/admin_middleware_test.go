@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func runAdminMiddleware(adminToken, authorization string) *httptest.ResponseRecorder {
+	e := echo.New()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+	}
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	handler := AdminMiddleware(adminToken)(func(c echo.Context) error {
+		return c.String(http.StatusOK, "OK")
+	})
+	_ = handler(c)
+
+	return rec
+}
+
+func TestAdminMiddleware_Success(t *testing.T) {
+	rec := runAdminMiddleware("secret", "Bearer secret")
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAdminMiddleware_MissingHeader(t *testing.T) {
+	rec := runAdminMiddleware("secret", "")
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAdminMiddleware_InvalidToken(t *testing.T) {
+	rec := runAdminMiddleware("secret", "Bearer wrong")
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAdminMiddleware_TokenNotConfigured(t *testing.T) {
+	rec := runAdminMiddleware("", "Bearer ")
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+// BatchRunStatus バッチ実行ステータス
+type BatchRunStatus string
+
+const (
+	BatchRunStatusRunning        BatchRunStatus = "running"
+	BatchRunStatusSuccess        BatchRunStatus = "success"
+	BatchRunStatusPartialFailure BatchRunStatus = "partial_failure"
+	BatchRunStatusFailed         BatchRunStatus = "failed"
+)
+
+// StringList JSON配列として保存する文字列リスト
+type StringList []string
+
+// Value driver.Valuer インターフェースの実装
+func (s StringList) Value() (driver.Value, error) {
+	if s == nil {
+		return nil, nil
+	}
+	return json.Marshal(s)
+}
+
+// Scan sql.Scanner インターフェースの実装
+func (s *StringList) Scan(value interface{}) error {
+	if value == nil {
+		*s = nil
+		return nil
+	}
+
+	bytes, ok := value.([]byte)
+	if !ok {
+		return errors.New("type assertion to []byte failed")
+	}
+
+	return json.Unmarshal(bytes, s)
+}
+
+// BatchRun バッチ実行履歴
+type BatchRun struct {
+	ID           uint64         `gorm:"primaryKey;autoIncrement"`
+	Command      string         `gorm:"size:50;index;not null"` // sync-commits / send-notifications
+	Args         JSONPayload    `gorm:"type:jsonb"`             // 実行時の引数
+	Status       BatchRunStatus `gorm:"size:20;not null"`       // running / success / partial_failure / failed
+	StartedAt    time.Time      `gorm:"index;not null"`         // 開始日時
+	FinishedAt   *time.Time     // 終了日時、nilは実行中
+	SuccessCount int            `gorm:"not null;default:0"` // 成功した対象数
+	FailureCount int            `gorm:"not null;default:0"` // 失敗した対象数
+	SkipCount    int            `gorm:"not null;default:0"` // スキップした対象数
+	ErrorSamples StringList     `gorm:"type:jsonb"`         // 失敗時のエラーメッセージ（先頭数件）
+	ErrorMessage string         `gorm:"type:text"`          // バッチ自体が失敗した場合のエラーメッセージ
+	CreatedAt    time.Time      `gorm:"autoCreateTime"`
+}
@@ -4,7 +4,22 @@ package models
 type ChannelType string
 
 const (
-	ChannelTypeLINE    ChannelType = "line"
-	ChannelTypeSlack   ChannelType = "slack"
-	ChannelTypeDiscord ChannelType = "discord"
+	ChannelTypeLINE       ChannelType = "line"
+	ChannelTypeSlack      ChannelType = "slack"
+	ChannelTypeDiscord    ChannelType = "discord"
+	ChannelTypeWebhook    ChannelType = "webhook"
+	ChannelTypeEmail      ChannelType = "email"
+	ChannelTypeTeams      ChannelType = "teams"
+	ChannelTypeMattermost ChannelType = "mattermost"
+	ChannelTypeTelegram   ChannelType = "telegram"
+	ChannelTypeWebPush    ChannelType = "webpush"
 )
+
+// IsValid 既知の通知チャンネルタイプかどうか
+func (c ChannelType) IsValid() bool {
+	switch c {
+	case ChannelTypeLINE, ChannelTypeSlack, ChannelTypeDiscord, ChannelTypeWebhook, ChannelTypeEmail, ChannelTypeTeams, ChannelTypeMattermost, ChannelTypeTelegram, ChannelTypeWebPush:
+		return true
+	}
+	return false
+}
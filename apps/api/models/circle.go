@@ -12,8 +12,9 @@ type Circle struct {
 	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
 
 	// Relations
-	Owner   User           `gorm:"foreignKey:OwnerUserID;references:ID"`
-	Members []CircleMember `gorm:"foreignKey:CircleID"`
+	Owner               User                       `gorm:"foreignKey:OwnerUserID;references:ID"`
+	Members             []CircleMember             `gorm:"foreignKey:CircleID"`
+	NotificationSetting *CircleNotificationSetting `gorm:"foreignKey:CircleID"`
 }
 
 // CircleMember サークルメンバー
@@ -0,0 +1,46 @@
+package models
+
+import "time"
+
+// CircleNotificationSetting サークルの週次ダイジェストの配信設定（1サークル1設定）
+type CircleNotificationSetting struct {
+	ID           uint64      `gorm:"primaryKey;autoIncrement"`
+	CircleID     uint64      `gorm:"uniqueIndex;not null"`                  // FK → circles.id
+	ChannelType  ChannelType `gorm:"size:50;not null"`                      // slack / discord
+	WebhookURL   string      `gorm:"size:512;not null"`                     // 投稿先の Incoming Webhook URL
+	Weekday      int         `gorm:"type:smallint;not null;default:1"`      // 配信する曜日（0=日曜〜6=土曜）
+	DeliveryTime string      `gorm:"size:5;not null;default:'09:00'"`       // 配信時刻（HH:MM、Timezone の現地時刻）
+	Timezone     string      `gorm:"size:64;not null;default:'Asia/Tokyo'"` // IANAタイムゾーン名
+	IsEnabled    bool        `gorm:"not null;default:true"`
+	CreatedAt    time.Time   `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time   `gorm:"autoUpdateTime"`
+
+	// Relations
+	Circle Circle `gorm:"foreignKey:CircleID;references:ID"`
+}
+
+// Schedule 配信スケジュールとして扱う（ユーザーのレポートと同じ判定を使うため）
+func (s *CircleNotificationSetting) Schedule() *NotificationSchedule {
+	return &NotificationSchedule{
+		WeeklyWeekday: s.Weekday,
+		DeliveryTime:  s.DeliveryTime,
+		Timezone:      s.Timezone,
+	}
+}
+
+// CircleDigestLog サークルの週次ダイジェストの配信ログ
+type CircleDigestLog struct {
+	ID           uint64             `gorm:"primaryKey;autoIncrement"`
+	CircleID     uint64             `gorm:"not null;uniqueIndex:idx_circle_digest_logs_period,priority:1"`           // FK → circles.id
+	PeriodStart  time.Time          `gorm:"type:date;not null;uniqueIndex:idx_circle_digest_logs_period,priority:2"` // 集計期間の開始日（サークルと合わせて配信の冪等キー）
+	ChannelType  ChannelType        `gorm:"size:50;not null"`                                                        // slack / discord
+	Status       NotificationStatus `gorm:"size:20;not null"`                                                        // success / failed
+	Payload      JSONPayload        `gorm:"type:jsonb"`                                                              // 送信したメッセージ内容
+	ErrorMessage string             `gorm:"type:text"`                                                               // 失敗時のエラーメッセージ
+	SentAt       time.Time          `gorm:"not null"`
+	CreatedAt    time.Time          `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time          `gorm:"autoUpdateTime"`
+
+	// Relations
+	Circle Circle `gorm:"foreignKey:CircleID;references:ID"`
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// DailyNudgeStatus 「今日はまだコミットしていない」リマインダーの結果
+type DailyNudgeStatus string
+
+const (
+	DailyNudgeStatusSent      DailyNudgeStatus = "sent"      // 1つ以上のチャンネルに送信できた
+	DailyNudgeStatusFailed    DailyNudgeStatus = "failed"    // すべてのチャンネルで送信に失敗した（再送しない）
+	DailyNudgeStatusCommitted DailyNudgeStatus = "committed" // 今日すでにコミットしていたため送らなかった
+)
+
+// DailyNudgeChannel リマインダーを受け取るチャンネル（ユーザーがチャンネルごとにオプトインする）
+type DailyNudgeChannel struct {
+	ID          uint64      `gorm:"primaryKey;autoIncrement"`
+	UserID      uint64      `gorm:"not null;uniqueIndex:idx_daily_nudge_channels_user_channel,priority:1"` // FK → users.id
+	ChannelType ChannelType `gorm:"size:50;not null;uniqueIndex:idx_daily_nudge_channels_user_channel,priority:2"`
+	CreatedAt   time.Time   `gorm:"autoCreateTime"`
+}
+
+// DailyNudgeLog ユーザーごとの1日1回のリマインダーの記録（同じ日に二度送らないための冪等キー）
+type DailyNudgeLog struct {
+	ID              uint64           `gorm:"primaryKey;autoIncrement"`
+	UserID          uint64           `gorm:"not null;uniqueIndex:idx_daily_nudge_logs_date,priority:1"`           // FK → users.id
+	Date            time.Time        `gorm:"type:date;not null;uniqueIndex:idx_daily_nudge_logs_date,priority:2"` // ユーザーのタイムゾーンでの日付
+	Status          DailyNudgeStatus `gorm:"size:20;not null"`                                                    // sent / failed / committed
+	Streak          int              `gorm:"not null;default:0"`                                                  // 前日までの連続コミット日数
+	RivalsCommitted int              `gorm:"not null;default:0"`                                                  // その日すでにコミットしていたライバルの数
+	CreatedAt       time.Time        `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// EmailNotificationSetting メール通知設定
+type EmailNotificationSetting struct {
+	ID         uint64     `gorm:"primaryKey;autoIncrement"`
+	UserID     uint64     `gorm:"uniqueIndex;not null"` // 1ユーザー1設定
+	Email      string     `gorm:"size:255;not null"`    // 確認を行ったアドレス（users.email から取得）
+	VerifiedAt *time.Time // 確認リンクを開いた日時、未確認の間は送信しない
+	IsEnabled  bool       `gorm:"not null;default:true"`
+	CreatedAt  time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `gorm:"autoUpdateTime"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// InAppNotificationType アプリ内通知の種類（ペイロードの形は種類ごとに決まる）
+type InAppNotificationType string
+
+const (
+	InAppNotificationRivalAlert         InAppNotificationType = "rival_alert"          // ライバルアラート
+	InAppNotificationCircleMemberJoined InAppNotificationType = "circle_member_joined" // 招待コードで自分のサークルに誰かが参加した
+	InAppNotificationSyncFailed         InAppNotificationType = "sync_failed"          // コミットの同期に失敗した
+	InAppNotificationReportDelivered    InAppNotificationType = "report_delivered"     // 週次・月次レポートを配信した
+)
+
+// InAppNotification アプリ内の受信箱に表示する通知
+type InAppNotification struct {
+	ID        uint64                `gorm:"primaryKey;autoIncrement"`
+	UserID    uint64                `gorm:"index;not null;uniqueIndex:idx_in_app_notifications_dedupe,priority:1"` // FK → users.id
+	Type      InAppNotificationType `gorm:"size:30;not null"`
+	Payload   JSONPayload           `gorm:"type:jsonb"`
+	DedupeKey *string               `gorm:"size:100;uniqueIndex:idx_in_app_notifications_dedupe,priority:2"` // 同じ出来事を二度載せないためのキー（nilの場合は重複を判定しない）
+	ReadAt    *time.Time            // 既読にした日時（未読はnil）
+	CreatedAt time.Time             `gorm:"autoCreateTime"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
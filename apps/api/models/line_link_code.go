@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// LineLinkCodeTTL 連携コードの有効期間
+const LineLinkCodeTTL = 30 * time.Minute
+
+// LineLinkCode LINE公式アカウントを友だち追加したユーザーに発行する連携コード
+// Commitlyの設定画面でコードを入力すると、LINEユーザーIDがユーザーに紐づく
+type LineLinkCode struct {
+	ID         uint64    `gorm:"primaryKey;autoIncrement"`
+	LineUserID string    `gorm:"size:255;uniqueIndex;not null"` // 1LINEユーザー1コード
+	Code       string    `gorm:"size:16;uniqueIndex;not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	CreatedAt  time.Time `gorm:"autoCreateTime"`
+}
@@ -11,8 +11,11 @@ import (
 type NotificationStatus string
 
 const (
-	NotificationStatusSuccess NotificationStatus = "success"
-	NotificationStatusFailed  NotificationStatus = "failed"
+	NotificationStatusSuccess    NotificationStatus = "success"
+	NotificationStatusFailed     NotificationStatus = "failed"      // 失敗（NextRetryAt に再送する）
+	NotificationStatusDeadLetter NotificationStatus = "dead_letter" // 恒久的な失敗、またはリトライ上限に達したため再送しない
+	NotificationStatusSkipped    NotificationStatus = "skipped"     // スヌーズ中のため送らない
+	NotificationStatusDeferred   NotificationStatus = "deferred"    // 通知を止める時間帯のため NextRetryAt まで送るのを遅らせる
 )
 
 // JSONPayload JSON形式のペイロード
@@ -44,14 +47,19 @@ func (j *JSONPayload) Scan(value interface{}) error {
 // NotificationLog 通知ログ
 type NotificationLog struct {
 	ID           uint64             `gorm:"primaryKey;autoIncrement"`
-	UserID       uint64             `gorm:"index;not null"`   // FK → users.id
-	ChannelType  ChannelType        `gorm:"size:50;not null"` // line / slack / discord
-	Period       string             `gorm:"size:20;not null"` // weekly / monthly
-	Status       NotificationStatus `gorm:"size:20;not null"` // success / failed
-	Payload      JSONPayload        `gorm:"type:jsonb"`       // 送信したメッセージ内容
-	ErrorMessage string             `gorm:"type:text"`        // 失敗時のエラーメッセージ
-	SentAt       time.Time          `gorm:"not null"`         // 送信日時
+	UserID       uint64             `gorm:"index;not null;uniqueIndex:idx_notification_logs_delivery,priority:1"`   // FK → users.id
+	ChannelType  ChannelType        `gorm:"size:50;not null;uniqueIndex:idx_notification_logs_delivery,priority:2"` // line / slack / discord / webhook / email
+	Period       string             `gorm:"size:20;not null;uniqueIndex:idx_notification_logs_delivery,priority:3"` // weekly / monthly
+	PeriodStart  *time.Time         `gorm:"type:date;uniqueIndex:idx_notification_logs_delivery,priority:4"`        // 集計期間の開始日（ユーザー・チャンネル・期間と合わせて配信の冪等キー。導入前のログはnull）
+	Status       NotificationStatus `gorm:"size:20;not null"`                                                       // success / failed / dead_letter / skipped / deferred
+	Payload      JSONPayload        `gorm:"type:jsonb"`                                                             // 送信したメッセージ内容
+	ErrorMessage string             `gorm:"type:text"`                                                              // 失敗時のエラーメッセージ
+	FailureKind  string             `gorm:"size:20"`                                                                // 失敗時の種類（retryable / permanent）
+	Attempts     int                `gorm:"not null;default:1"`                                                     // 送信を試みた回数
+	NextRetryAt  *time.Time         `gorm:"index"`                                                                  // 次の再送予定日時（再送しない場合はnil）
+	SentAt       time.Time          `gorm:"not null"`                                                               // 初回の送信日時（レポートの集計期間の基準）
 	CreatedAt    time.Time          `gorm:"autoCreateTime"`
+	UpdatedAt    time.Time          `gorm:"autoUpdateTime"`
 
 	// Relations
 	User User `gorm:"foreignKey:UserID;references:ID"`
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// 通知スケジュールのデフォルト値（スケジュール未設定のユーザーに使う）
+const (
+	DefaultNotificationWeekday      = int(time.Monday)
+	DefaultNotificationMonthlyDay   = 1
+	DefaultNotificationDeliveryTime = "09:00"
+	DefaultNotificationTimezone     = "Asia/Tokyo"
+	DefaultDailyNudgeTime           = "20:00"
+)
+
+// NotificationSchedule ユーザーごとのレポート配信スケジュール
+type NotificationSchedule struct {
+	ID            uint64 `gorm:"primaryKey;autoIncrement"`
+	UserID        uint64 `gorm:"uniqueIndex;not null"`                  // FK → users.id
+	WeeklyWeekday int    `gorm:"type:smallint;not null;default:1"`      // 週次レポートの曜日（0=日曜〜6=土曜）
+	MonthlyDay    int    `gorm:"type:smallint;not null;default:1"`      // 月次レポートの日（1〜31、月末を超える場合は月末日）
+	DeliveryTime  string `gorm:"size:5;not null;default:'09:00'"`       // 配信時刻（HH:MM、Timezone の現地時刻）
+	Timezone      string `gorm:"size:64;not null;default:'Asia/Tokyo'"` // IANAタイムゾーン名
+	// 通知を止める時間帯（HH:MM、Timezone の現地時刻。開始が終了より遅い場合は日をまたぐ。どちらも空の場合は設定なし）
+	QuietHoursStart string `gorm:"size:5;not null;default:''"`
+	QuietHoursEnd   string `gorm:"size:5;not null;default:''"`
+	// この日（Timezone の現地日付、当日を含む）まで通知を止める
+	SnoozeUntil *time.Time `gorm:"type:date"`
+	// 「今日はまだコミットしていない」リマインダーの時刻（HH:MM、Timezone の現地時刻）
+	DailyNudgeTime string    `gorm:"size:5;not null;default:'20:00'"`
+	CreatedAt      time.Time `gorm:"autoCreateTime"`
+	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
+
+// DefaultNotificationSchedule スケジュール未設定のユーザーに使うスケジュール
+func DefaultNotificationSchedule(userID uint64) *NotificationSchedule {
+	return &NotificationSchedule{
+		UserID:         userID,
+		WeeklyWeekday:  DefaultNotificationWeekday,
+		MonthlyDay:     DefaultNotificationMonthlyDay,
+		DeliveryTime:   DefaultNotificationDeliveryTime,
+		Timezone:       DefaultNotificationTimezone,
+		DailyNudgeTime: DefaultDailyNudgeTime,
+	}
+}
+
+// Location 配信時刻のタイムゾーンを取得
+func (s *NotificationSchedule) Location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return nil, fmt.Errorf("timezone is empty")
+	}
+	return time.LoadLocation(s.Timezone)
+}
+
+// DeliveryClock 配信時刻（HH:MM）を時・分に分解する
+func (s *NotificationSchedule) DeliveryClock() (hour, minute int, err error) {
+	t, err := time.Parse("15:04", s.DeliveryTime)
+	if err != nil || t.Format("15:04") != s.DeliveryTime {
+		return 0, 0, fmt.Errorf("invalid delivery time %q (must be HH:MM)", s.DeliveryTime)
+	}
+	return t.Hour(), t.Minute(), nil
+}
+
+// HasQuietHours 通知を止める時間帯が設定されているか
+func (s *NotificationSchedule) HasQuietHours() bool {
+	return s.QuietHoursStart != "" && s.QuietHoursEnd != ""
+}
+
+// QuietHoursClock 通知を止める時間帯の開始・終了を、0時からの分に変換する
+func (s *NotificationSchedule) QuietHoursClock() (start, end int, err error) {
+	start, err = parseClockMinutes(s.QuietHoursStart)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err = parseClockMinutes(s.QuietHoursEnd)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// QuietUntil t が通知を止める時間帯に入っていれば、その時間帯が終わる時刻を返す
+func (s *NotificationSchedule) QuietUntil(t time.Time) (time.Time, bool) {
+	if !s.HasQuietHours() {
+		return time.Time{}, false
+	}
+	loc, err := s.Location()
+	if err != nil {
+		return time.Time{}, false
+	}
+	start, end, err := s.QuietHoursClock()
+	if err != nil || start == end {
+		return time.Time{}, false
+	}
+
+	local := t.In(loc)
+	now := local.Hour()*60 + local.Minute()
+	inQuiet := start <= now && now < end
+	if start > end {
+		// 22:00〜07:00 のように日をまたぐ
+		inQuiet = now >= start || now < end
+	}
+	if !inQuiet {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end/60, end%60, 0, 0, loc)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until, true
+}
+
+// IsSnoozed t がスヌーズの期限の日（Timezone の現地日付）以前か
+func (s *NotificationSchedule) IsSnoozed(t time.Time) bool {
+	if s.SnoozeUntil == nil {
+		return false
+	}
+	loc, err := s.Location()
+	if err != nil {
+		return false
+	}
+	// date 型はタイムゾーンが異なることがあるため日付の文字列で比べる
+	return t.In(loc).Format("2006-01-02") <= s.SnoozeUntil.Format("2006-01-02")
+}
+
+// parseClockMinutes HH:MM を0時からの分に変換する
+func parseClockMinutes(clock string) (int, error) {
+	t, err := time.Parse("15:04", clock)
+	if err != nil || t.Format("15:04") != clock {
+		return 0, fmt.Errorf("invalid clock %q (must be HH:MM)", clock)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// DailyNudgeClock リマインダーの時刻（HH:MM）を時・分に分解する
+func (s *NotificationSchedule) DailyNudgeClock() (hour, minute int, err error) {
+	minutes, err := parseClockMinutes(s.DailyNudgeTime)
+	if err != nil {
+		return 0, 0, err
+	}
+	return minutes / 60, minutes % 60, nil
+}
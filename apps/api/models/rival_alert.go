@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// RivalAlertKind ライバルアラートの種類
+type RivalAlertKind string
+
+const (
+	RivalAlertOvertaken    RivalAlertKind = "overtaken"     // ライバルに今週のコミット数で抜かれた
+	RivalAlertReclaimed    RivalAlertKind = "reclaimed"     // 抜かれていたライバルを抜き返した
+	RivalAlertStreakBroken RivalAlertKind = "streak_broken" // ライバルの連続コミットが途切れた
+)
+
+// RivalAlertStatus ライバルアラートの送信結果
+type RivalAlertStatus string
+
+const (
+	RivalAlertStatusSent       RivalAlertStatus = "sent"       // 1つ以上のチャンネルに送信できた
+	RivalAlertStatusFailed     RivalAlertStatus = "failed"     // すべてのチャンネルで送信に失敗した（再送しない）
+	RivalAlertStatusSuppressed RivalAlertStatus = "suppressed" // ユーザーごとの送信数の上限に達したため送らなかった
+)
+
+// RivalAlert ライバルアラートの送信記録（同じイベントを二度送らないための冪等キーと、送信数の上限の判定に使う）
+type RivalAlert struct {
+	ID                  uint64           `gorm:"primaryKey;autoIncrement"`
+	UserID              uint64           `gorm:"index;not null;uniqueIndex:idx_rival_alerts_event,priority:1"` // FK → users.id
+	RivalGithubUserID   uint64           `gorm:"not null;uniqueIndex:idx_rival_alerts_event,priority:2"`
+	Kind                RivalAlertKind   `gorm:"size:20;not null;uniqueIndex:idx_rival_alerts_event,priority:3"`  // overtaken / reclaimed / streak_broken
+	EventKey            string           `gorm:"size:100;not null;uniqueIndex:idx_rival_alerts_event,priority:4"` // イベントを識別するキー（週の開始日とコミット数、途切れた日など）
+	RivalGithubUsername string           `gorm:"size:255;not null"`
+	Status              RivalAlertStatus `gorm:"size:20;not null"` // sent / failed / suppressed
+	UserCommits         int              `gorm:"not null;default:0"`
+	RivalCommits        int              `gorm:"not null;default:0"`
+	Streak              int              `gorm:"not null;default:0"` // 途切れた連続コミット日数（streak_broken のみ）
+	CreatedAt           time.Time        `gorm:"autoCreateTime;index"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
+
+// RivalStanding ユーザーとライバルの今週のコミット数で、最後にどちらがリードしていたか（抜いた・抜かれたの判定に使う）
+type RivalStanding struct {
+	ID                uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID            uint64    `gorm:"not null;uniqueIndex:idx_rival_standings_pair,priority:1"` // FK → users.id
+	RivalGithubUserID uint64    `gorm:"not null;uniqueIndex:idx_rival_standings_pair,priority:2"`
+	WeekStart         time.Time `gorm:"type:date;not null"` // 比較した週の開始日（月曜日）
+	RivalAhead        bool      `gorm:"not null"`           // ライバルがリードしていたか（同数の間は直前のリードを保つ）
+	UpdatedAt         time.Time `gorm:"autoUpdateTime"`
+}
@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// SlackLinkCodeTTL 連携コードの有効期間
+const SlackLinkCodeTTL = 30 * time.Minute
+
+// SlackUserLink SlackユーザーとCommitlyユーザーの紐づけ（スラッシュコマンドの利用者の特定に使う）
+type SlackUserLink struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID      uint64    `gorm:"uniqueIndex;not null"`                                               // 1ユーザー1連携
+	SlackTeamID string    `gorm:"size:32;not null;uniqueIndex:idx_slack_user_links_slack,priority:1"` // ワークスペースID（T...）
+	SlackUserID string    `gorm:"size:32;not null;uniqueIndex:idx_slack_user_links_slack,priority:2"` // ワークスペース内のユーザーID（U...）
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `gorm:"autoUpdateTime"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
+
+// SlackLinkCode 未連携のSlackユーザーがスラッシュコマンドを使ったときに発行する連携コード
+// Commitlyの設定画面でコードを入力すると、SlackユーザーがCommitlyユーザーに紐づく
+type SlackLinkCode struct {
+	ID          uint64    `gorm:"primaryKey;autoIncrement"`
+	SlackTeamID string    `gorm:"size:32;not null;uniqueIndex:idx_slack_link_codes_slack,priority:1"`
+	SlackUserID string    `gorm:"size:32;not null;uniqueIndex:idx_slack_link_codes_slack,priority:2"` // 1Slackユーザー1コード
+	Code        string    `gorm:"size:16;uniqueIndex;not null"`
+	ExpiresAt   time.Time `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"autoCreateTime"`
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// TelegramLinkTokenTTL 連携トークンの有効期間
+const TelegramLinkTokenTTL = 30 * time.Minute
+
+// TelegramLinkToken Commitlyの設定画面で発行するTelegram連携トークン
+// ボットに /start <トークン> が届くと、そのチャットIDがユーザーに紐づく
+type TelegramLinkToken struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID    uint64    `gorm:"uniqueIndex;not null"` // 1ユーザー1トークン
+	Token     string    `gorm:"size:64;uniqueIndex;not null"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+}
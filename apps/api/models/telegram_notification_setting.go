@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// TelegramNotificationSetting Telegram通知設定
+type TelegramNotificationSetting struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID    uint64    `gorm:"uniqueIndex;not null"` // 1ユーザー1設定
+	ChatID    int64     `gorm:"index;not null"`       // ボットとのチャットID
+	IsEnabled bool      `gorm:"not null;default:true"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
@@ -4,13 +4,16 @@ import "time"
 
 // User ユーザー情報
 type User struct {
-	ID             uint64    `gorm:"primaryKey;autoIncrement"`
-	GithubUserID   uint64    `gorm:"uniqueIndex;not null"` // Github User ID
-	GithubUsername string    `gorm:"size:255;not null"`    // Githubユーザー名
-	Email          string    `gorm:"size:255"`             // メールアドレス
-	AvatarURL      string    `gorm:"size:512"`             // Githubアバター URL
-	CreatedAt      time.Time `gorm:"autoCreateTime"`
-	UpdatedAt      time.Time `gorm:"autoUpdateTime"`
+	ID                  uint64     `gorm:"primaryKey;autoIncrement"`
+	GithubUserID        uint64     `gorm:"uniqueIndex;not null"`          // Github User ID
+	GithubUsername      string     `gorm:"size:255;not null"`             // Githubユーザー名
+	Email               string     `gorm:"size:255"`                      // メールアドレス
+	AvatarURL           string     `gorm:"size:512"`                      // Githubアバター URL
+	Locale              string     `gorm:"size:10;not null;default:'ja'"` // 通知メッセージの言語（ja / en）
+	LocaleSetAt         *time.Time // ユーザーが言語を明示的に設定した日時（未設定の間はログイン時のブラウザの言語に合わせる）
+	NotificationAlertAt *time.Time // 送信先エラーが続いて通知チャンネルが自動で無効化された日時（確認されるまで残す）
+	CreatedAt           time.Time  `gorm:"autoCreateTime"`
+	UpdatedAt           time.Time  `gorm:"autoUpdateTime"`
 
 	// Relations
 	Rivals                     []Rival                     `gorm:"foreignKey:UserID"`
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// WebPushSubscription ブラウザのプッシュ購読（端末・ブラウザごとに1件）
+type WebPushSubscription struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID    uint64    `gorm:"index;not null"`
+	Endpoint  string    `gorm:"size:2048;uniqueIndex;not null"` // プッシュサービスのURL（購読ごとに一意）
+	P256dh    string    `gorm:"size:128;not null"`              // ブラウザの公開鍵（base64url）
+	Auth      string    `gorm:"size:32;not null"`               // 認証シークレット（base64url）
+	UserAgent string    `gorm:"size:255"`                       // 設定画面で端末を見分けるための表示用
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// WebhookNotificationSetting 汎用Webhook通知設定
+type WebhookNotificationSetting struct {
+	ID        uint64    `gorm:"primaryKey;autoIncrement"`
+	UserID    uint64    `gorm:"uniqueIndex;not null"` // 1ユーザー1設定
+	URL       string    `gorm:"size:512;not null"`
+	Secret    string    `gorm:"size:128;not null"` // X-Commitly-Signature の署名に使う
+	IsEnabled bool      `gorm:"not null;default:true"`
+	CreatedAt time.Time `gorm:"autoCreateTime"`
+	UpdatedAt time.Time `gorm:"autoUpdateTime"`
+
+	// Relations
+	User User `gorm:"foreignKey:UserID;references:ID"`
+}
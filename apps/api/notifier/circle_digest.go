@@ -0,0 +1,59 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/keeee21/commitly/api/models"
+)
+
+// ICircleDigestPoster サークルの週次ダイジェストをサークルのWebhookに投稿するインターフェース
+type ICircleDigestPoster interface {
+	Render(setting *models.CircleNotificationSetting, digest *gateway.CircleDigest) (*Message, error)
+	Post(ctx context.Context, setting *models.CircleNotificationSetting, message *Message) error
+}
+
+type circleDigestPoster struct {
+	slackGateway   gateway.ISlackGateway
+	discordGateway gateway.IDiscordGateway
+}
+
+// NewCircleDigestPoster コンストラクタ
+func NewCircleDigestPoster(slackGateway gateway.ISlackGateway, discordGateway gateway.IDiscordGateway) ICircleDigestPoster {
+	return &circleDigestPoster{
+		slackGateway:   slackGateway,
+		discordGateway: discordGateway,
+	}
+}
+
+// Render ダイジェストをサークルオーナーの言語でチャンネル向けにレンダリングする
+func (p *circleDigestPoster) Render(setting *models.CircleNotificationSetting, digest *gateway.CircleDigest) (*Message, error) {
+	l := i18n.For(setting.Circle.Owner.Locale)
+	switch setting.ChannelType {
+	case models.ChannelTypeSlack:
+		message := gateway.BuildCircleDigestSlackMessage(l, digest)
+		return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text, "blocks": message.Blocks}}, nil
+	case models.ChannelTypeDiscord:
+		message := gateway.BuildCircleDigestDiscordMessage(l, digest)
+		return &Message{Body: message, Payload: models.JSONPayload{"embeds": message.Embeds}}, nil
+	}
+	return nil, fmt.Errorf("unsupported channel type for circle digest: %s", setting.ChannelType)
+}
+
+func (p *circleDigestPoster) Post(ctx context.Context, setting *models.CircleNotificationSetting, message *Message) error {
+	switch body := message.Body.(type) {
+	case *gateway.SlackMessage:
+		if err := p.slackGateway.SendMessage(ctx, setting.WebhookURL, body); err != nil {
+			return fmt.Errorf("failed to send slack message: %w", err)
+		}
+	case *gateway.DiscordMessage:
+		if err := p.discordGateway.SendMessage(ctx, setting.WebhookURL, body); err != nil {
+			return fmt.Errorf("failed to send discord message: %w", err)
+		}
+	default:
+		return fmt.Errorf("unexpected message type for circle digest: %T", message.Body)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"time"
+
+	"github.com/keeee21/commitly/api/i18n"
+)
+
+// Nudge チャンネルに依存しない「今日はまだコミットしていない」リマインダーの内容
+type Nudge struct {
+	Username        string
+	Date            time.Time // ユーザーのタイムゾーンでの今日
+	Streak          int       // 前日までの連続コミット日数
+	Rivals          int       // ライバルの数
+	RivalsCommitted int       // 今日すでにコミットしたライバルの数
+}
+
+// NudgeText リマインダーの本文
+func NudgeText(l *i18n.Localizer, nudge *Nudge) string {
+	text := l.Emoji("nudge") + " "
+	if nudge.Streak > 0 {
+		text += l.T("nudge.streak", i18n.Vars{"Streak": nudge.Streak})
+	} else {
+		text += l.T("nudge.no_streak", nil)
+	}
+	if nudge.Rivals > 0 {
+		text += l.T("nudge.rivals", i18n.Vars{"Committed": nudge.RivalsCommitted, "Rivals": nudge.Rivals})
+	}
+	return text
+}
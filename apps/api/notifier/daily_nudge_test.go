@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"testing"
+
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNudgeText(t *testing.T) {
+	tests := []struct {
+		name     string
+		locale   string
+		nudge    Nudge
+		expected string
+	}{
+		{
+			name:     "streak and rivals",
+			locale:   "ja",
+			nudge:    Nudge{Streak: 4, Rivals: 3, RivalsCommitted: 2},
+			expected: "⏰ 今日はまだコミットしていません。4 日連続のコミットを途切れさせないようにしましょう！（ライバル 3 人中 2 人が今日すでにコミットしています）",
+		},
+		{
+			name:     "no streak and no rivals",
+			locale:   "ja",
+			nudge:    Nudge{},
+			expected: "⏰ 今日はまだコミットしていません。1コミットから始めましょう！",
+		},
+		{
+			name:     "english",
+			locale:   "en",
+			nudge:    Nudge{Streak: 1, Rivals: 2, RivalsCommitted: 0},
+			expected: "⏰ No commits yet today. Keep your 1-day streak alive! (0 of your 2 rivals have already committed today)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, NudgeText(i18n.For(tt.locale), &tt.nudge))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+type discordNotifier struct {
+	discordRepo    repository.IDiscordNotificationSettingRepository
+	discordGateway gateway.IDiscordGateway
+}
+
+// NewDiscordNotifier コンストラクタ
+func NewDiscordNotifier(discordRepo repository.IDiscordNotificationSettingRepository, discordGateway gateway.IDiscordGateway) INotifier {
+	return &discordNotifier{
+		discordRepo:    discordRepo,
+		discordGateway: discordGateway,
+	}
+}
+
+func (n *discordNotifier) ChannelType() models.ChannelType {
+	return models.ChannelTypeDiscord
+}
+
+func (n *discordNotifier) FindEnabledDestinations(ctx context.Context) ([]Destination, error) {
+	settings, err := n.discordRepo.FindAllEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled Discord notification settings: %w", err)
+	}
+
+	destinations := make([]Destination, 0, len(settings))
+	for _, s := range settings {
+		destinations = append(destinations, discordDestination(s))
+	}
+	return destinations, nil
+}
+
+func (n *discordNotifier) FindDestination(ctx context.Context, userID uint64) (*Destination, error) {
+	setting, err := n.discordRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Discord notification setting: %w", err)
+	}
+	if setting == nil || !setting.IsEnabled {
+		return nil, nil
+	}
+	destination := discordDestination(*setting)
+	return &destination, nil
+}
+
+// discordDestination 設定を送信先に変換
+func discordDestination(s models.DiscordNotificationSetting) Destination {
+	return Destination{
+		UserID:      s.UserID,
+		User:        s.User,
+		ChannelType: models.ChannelTypeDiscord,
+		Address:     s.WebhookURL,
+	}
+}
+
+func (n *discordNotifier) Render(destination Destination, report *Report) (*Message, error) {
+	l := destinationLocalizer(destination)
+	var message *gateway.DiscordMessage
+	if report.Period == "weekly" {
+		message = gateway.BuildWeeklyReportDiscordMessage(l, report.Username, report.UserCommits, report.Rivals, report.RangeStart, report.RangeEnd)
+	} else {
+		message = gateway.BuildMonthlyReportDiscordMessage(
+			l,
+			report.Username,
+			gateway.MonthlyComparison{CurrentMonth: report.UserCommits, PreviousMonth: report.PreviousCommits},
+			report.Rivals,
+			report.RangeStart,
+		)
+	}
+
+	return &Message{Body: message, Payload: models.JSONPayload{"embeds": message.Embeds}}, nil
+}
+
+func (n *discordNotifier) RenderTest(destination Destination) (*Message, error) {
+	message := &gateway.DiscordMessage{Content: TestMessageText(destinationLocalizer(destination))}
+	return &Message{Body: message, Payload: models.JSONPayload{"content": message.Content}}, nil
+}
+
+func (n *discordNotifier) RenderAlert(destination Destination, alert *Alert) (*Message, error) {
+	message := &gateway.DiscordMessage{Content: AlertText(destinationLocalizer(destination), alert)}
+	return &Message{Body: message, Payload: models.JSONPayload{"content": message.Content}}, nil
+}
+
+func (n *discordNotifier) RenderNudge(destination Destination, nudge *Nudge) (*Message, error) {
+	message := &gateway.DiscordMessage{Content: NudgeText(destinationLocalizer(destination), nudge)}
+	return &Message{Body: message, Payload: models.JSONPayload{"content": message.Content}}, nil
+}
+
+func (n *discordNotifier) Deliver(ctx context.Context, destination Destination, message *Message) error {
+	discordMessage, ok := message.Body.(*gateway.DiscordMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type for discord: %T", message.Body)
+	}
+	if err := n.discordGateway.SendMessage(ctx, destination.Address, discordMessage); err != nil {
+		return fmt.Errorf("failed to send discord message: %w", err)
+	}
+	return nil
+}
+
+func (n *discordNotifier) Disable(ctx context.Context, userID uint64) error {
+	return n.discordRepo.UpdateEnabled(ctx, userID, false)
+}
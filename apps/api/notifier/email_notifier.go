@@ -0,0 +1,172 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// メールのリンク先（APIの公開エンドポイント）
+const (
+	EmailVerifyPath      = "/api/email/verify"
+	EmailUnsubscribePath = "/api/email/unsubscribe"
+)
+
+type emailNotifier struct {
+	emailRepo    repository.IEmailNotificationSettingRepository
+	emailGateway gateway.IEmailGateway
+	signer       *EmailTokenSigner
+	baseURL      string
+}
+
+// NewEmailNotifier コンストラクタ（baseURLは配信停止リンクに使うAPIの公開URL）
+func NewEmailNotifier(
+	emailRepo repository.IEmailNotificationSettingRepository,
+	emailGateway gateway.IEmailGateway,
+	signer *EmailTokenSigner,
+	baseURL string,
+) INotifier {
+	return &emailNotifier{
+		emailRepo:    emailRepo,
+		emailGateway: emailGateway,
+		signer:       signer,
+		baseURL:      baseURL,
+	}
+}
+
+func (n *emailNotifier) ChannelType() models.ChannelType {
+	return models.ChannelTypeEmail
+}
+
+func (n *emailNotifier) FindEnabledDestinations(ctx context.Context) ([]Destination, error) {
+	settings, err := n.emailRepo.FindAllEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled email notification settings: %w", err)
+	}
+
+	destinations := make([]Destination, 0, len(settings))
+	for _, s := range settings {
+		// 確認後にGitHub側のメールアドレスが変わった場合は、再確認されるまで送らない
+		if s.Email != s.User.Email {
+			log.Printf("Skipping email notification for user %d: address changed since verification", s.UserID)
+			continue
+		}
+		destinations = append(destinations, emailDestination(s))
+	}
+	return destinations, nil
+}
+
+func (n *emailNotifier) FindDestination(ctx context.Context, userID uint64) (*Destination, error) {
+	setting, err := n.emailRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email notification setting: %w", err)
+	}
+	// FindEnabledDestinations と同じく、確認済みでアドレスが変わっていない場合だけ送る
+	if setting == nil || !setting.IsEnabled || setting.VerifiedAt == nil || setting.Email != setting.User.Email {
+		return nil, nil
+	}
+	destination := emailDestination(*setting)
+	return &destination, nil
+}
+
+// emailDestination 設定を送信先に変換
+func emailDestination(s models.EmailNotificationSetting) Destination {
+	return Destination{
+		UserID:      s.UserID,
+		User:        s.User,
+		ChannelType: models.ChannelTypeEmail,
+		Address:     s.Email,
+	}
+}
+
+func (n *emailNotifier) Render(destination Destination, report *Report) (*Message, error) {
+	token, err := n.signer.Sign(EmailTokenClaims{
+		Purpose: EmailTokenPurposeUnsubscribe,
+		UserID:  destination.UserID,
+		Email:   destination.Address,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign unsubscribe token: %w", err)
+	}
+	unsubscribeURL, err := EmailLinkURL(n.baseURL, EmailUnsubscribePath, token)
+	if err != nil {
+		return nil, err
+	}
+
+	message, err := gateway.BuildReportEmail(destinationLocalizer(destination), destination.Address, gateway.EmailReportData{
+		Period:          report.Period,
+		Username:        report.Username,
+		UserCommits:     report.UserCommits,
+		PreviousCommits: report.PreviousCommits,
+		Rivals:          report.Rivals,
+		RangeStart:      report.RangeStart,
+		RangeEnd:        report.RangeEnd,
+		UnsubscribeURL:  unsubscribeURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// HTMLはテンプレートから再生成できるため、ログには件名とテキスト版のみ残す
+	return &Message{
+		Body:    message,
+		Payload: models.JSONPayload{"subject": message.Subject, "text": message.TextBody},
+	}, nil
+}
+
+func (n *emailNotifier) RenderTest(destination Destination) (*Message, error) {
+	l := destinationLocalizer(destination)
+	message := gateway.BuildTestEmail(l, destination.Address, TestMessageText(l))
+	return &Message{
+		Body:    message,
+		Payload: models.JSONPayload{"subject": message.Subject, "text": message.TextBody},
+	}, nil
+}
+
+func (n *emailNotifier) RenderAlert(destination Destination, alert *Alert) (*Message, error) {
+	l := destinationLocalizer(destination)
+	message := gateway.BuildTextEmail(destination.Address, l.T("alert.email_subject", nil), AlertText(l, alert))
+	return &Message{
+		Body:    message,
+		Payload: models.JSONPayload{"subject": message.Subject, "text": message.TextBody},
+	}, nil
+}
+
+func (n *emailNotifier) RenderNudge(destination Destination, nudge *Nudge) (*Message, error) {
+	l := destinationLocalizer(destination)
+	message := gateway.BuildTextEmail(destination.Address, l.T("nudge.email_subject", nil), NudgeText(l, nudge))
+	return &Message{
+		Body:    message,
+		Payload: models.JSONPayload{"subject": message.Subject, "text": message.TextBody},
+	}, nil
+}
+
+func (n *emailNotifier) Deliver(ctx context.Context, destination Destination, message *Message) error {
+	emailMessage, ok := message.Body.(*gateway.EmailMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type for email: %T", message.Body)
+	}
+	if err := n.emailGateway.Send(ctx, emailMessage); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+func (n *emailNotifier) Disable(ctx context.Context, userID uint64) error {
+	return n.emailRepo.UpdateEnabled(ctx, userID, false)
+}
+
+// EmailLinkURL メールに載せるリンクのURLを組み立てる
+func EmailLinkURL(baseURL, path, token string) (string, error) {
+	if baseURL == "" {
+		return "", errors.New("API_BASE_URL is not configured")
+	}
+	return strings.TrimRight(baseURL, "/") + path + "?token=" + url.QueryEscape(token), nil
+}
@@ -0,0 +1,197 @@
+package notifier
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type emailMockEmailNotificationSettingRepository struct {
+	FindByUserIDFunc   func(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error)
+	FindAllEnabledFunc func(ctx context.Context) ([]models.EmailNotificationSetting, error)
+}
+
+func (m *emailMockEmailNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *emailMockEmailNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.EmailNotificationSetting, error) {
+	if m.FindAllEnabledFunc != nil {
+		return m.FindAllEnabledFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *emailMockEmailNotificationSettingRepository) Upsert(ctx context.Context, setting *models.EmailNotificationSetting) error {
+	return nil
+}
+
+func (m *emailMockEmailNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return nil
+}
+
+func (m *emailMockEmailNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+type mockEmailGateway struct {
+	SendFunc func(ctx context.Context, message *gateway.EmailMessage) error
+}
+
+func (m *mockEmailGateway) Send(ctx context.Context, message *gateway.EmailMessage) error {
+	if m.SendFunc != nil {
+		return m.SendFunc(ctx, message)
+	}
+	return nil
+}
+
+func TestEmailNotifier_FindEnabledDestinations_SkipsChangedAddress(t *testing.T) {
+	verifiedAt := time.Now()
+	repo := &emailMockEmailNotificationSettingRepository{
+		FindAllEnabledFunc: func(ctx context.Context) ([]models.EmailNotificationSetting, error) {
+			return []models.EmailNotificationSetting{
+				{UserID: 1, Email: "user1@example.com", VerifiedAt: &verifiedAt, IsEnabled: true, User: models.User{ID: 1, Email: "user1@example.com"}},
+				{UserID: 2, Email: "old@example.com", VerifiedAt: &verifiedAt, IsEnabled: true, User: models.User{ID: 2, Email: "new@example.com"}},
+			}, nil
+		},
+	}
+
+	n := NewEmailNotifier(repo, &mockEmailGateway{}, NewEmailTokenSigner("secret"), "https://api.example.com")
+	destinations, err := n.FindEnabledDestinations(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, destinations, 1)
+	assert.Equal(t, uint64(1), destinations[0].UserID)
+	assert.Equal(t, "user1@example.com", destinations[0].Address)
+	assert.Equal(t, models.ChannelTypeEmail, destinations[0].ChannelType)
+}
+
+func TestEmailNotifier_FindDestination(t *testing.T) {
+	verifiedAt := time.Now()
+	user := models.User{ID: 1, Email: "user1@example.com"}
+	tests := []struct {
+		name     string
+		setting  *models.EmailNotificationSetting
+		expected bool
+	}{
+		{"verified and enabled", &models.EmailNotificationSetting{UserID: 1, Email: "user1@example.com", VerifiedAt: &verifiedAt, IsEnabled: true, User: user}, true},
+		{"not configured", nil, false},
+		{"disabled", &models.EmailNotificationSetting{UserID: 1, Email: "user1@example.com", VerifiedAt: &verifiedAt, IsEnabled: false, User: user}, false},
+		{"not verified", &models.EmailNotificationSetting{UserID: 1, Email: "user1@example.com", IsEnabled: true, User: user}, false},
+		{"address changed", &models.EmailNotificationSetting{UserID: 1, Email: "old@example.com", VerifiedAt: &verifiedAt, IsEnabled: true, User: user}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &emailMockEmailNotificationSettingRepository{
+				FindByUserIDFunc: func(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error) {
+					return tt.setting, nil
+				},
+			}
+
+			n := NewEmailNotifier(repo, &mockEmailGateway{}, NewEmailTokenSigner("secret"), "https://api.example.com")
+			destination, err := n.FindDestination(context.Background(), 1)
+
+			assert.NoError(t, err)
+			if tt.expected {
+				if assert.NotNil(t, destination) {
+					assert.Equal(t, "user1@example.com", destination.Address)
+					assert.Equal(t, models.ChannelTypeEmail, destination.ChannelType)
+				}
+			} else {
+				assert.Nil(t, destination)
+			}
+		})
+	}
+}
+
+func TestEmailNotifier_RenderIncludesUnsubscribeLink(t *testing.T) {
+	signer := NewEmailTokenSigner("secret")
+	n := NewEmailNotifier(nil, &mockEmailGateway{}, signer, "https://api.example.com/")
+
+	message, err := n.Render(Destination{UserID: 1, Address: "user1@example.com"}, &Report{
+		Period:      "weekly",
+		Username:    "user1",
+		UserCommits: 12,
+		Rivals:      []gateway.RivalCommitSummary{{Username: "rival1", Commits: 8}},
+		RangeStart:  time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local),
+		RangeEnd:    time.Date(2026, 10, 11, 0, 0, 0, 0, time.Local),
+	})
+
+	assert.NoError(t, err)
+	email := message.Body.(*gateway.EmailMessage)
+	assert.Equal(t, "user1@example.com", email.To)
+	assert.Contains(t, email.Subject, "12 コミット")
+	assert.Contains(t, email.TextBody, "rival1")
+	assert.Equal(t, "List-Unsubscribe=One-Click", email.Headers["List-Unsubscribe-Post"])
+
+	link := strings.Trim(email.Headers["List-Unsubscribe"], "<>")
+	assert.True(t, strings.HasPrefix(link, "https://api.example.com"+EmailUnsubscribePath+"?token="))
+	assert.Contains(t, email.HTMLBody, link)
+
+	u, err := url.Parse(link)
+	assert.NoError(t, err)
+	claims, err := signer.Verify(u.Query().Get("token"), EmailTokenPurposeUnsubscribe, time.Now())
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), claims.UserID)
+	assert.Equal(t, "user1@example.com", claims.Email)
+
+	assert.Equal(t, email.Subject, message.Payload["subject"])
+	assert.NotContains(t, message.Payload, "html")
+}
+
+func TestEmailNotifier_RenderRequiresBaseURL(t *testing.T) {
+	n := NewEmailNotifier(nil, &mockEmailGateway{}, NewEmailTokenSigner("secret"), "")
+
+	_, err := n.Render(Destination{UserID: 1, Address: "user1@example.com"}, &Report{Period: "weekly"})
+
+	assert.Error(t, err)
+}
+
+func TestEmailTokenSigner(t *testing.T) {
+	signer := NewEmailTokenSigner("secret")
+	now := time.Now()
+	token, err := signer.Sign(EmailTokenClaims{
+		Purpose:   EmailTokenPurposeVerify,
+		UserID:    1,
+		Email:     "user1@example.com",
+		ExpiresAt: now.Add(time.Hour).Unix(),
+	})
+	assert.NoError(t, err)
+
+	claims, err := signer.Verify(token, EmailTokenPurposeVerify, now)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), claims.UserID)
+
+	tests := []struct {
+		name    string
+		signer  *EmailTokenSigner
+		token   string
+		purpose string
+		now     time.Time
+	}{
+		{"wrong purpose", signer, token, EmailTokenPurposeUnsubscribe, now},
+		{"expired", signer, token, EmailTokenPurposeVerify, now.Add(2 * time.Hour)},
+		{"other secret", NewEmailTokenSigner("other"), token, EmailTokenPurposeVerify, now},
+		{"tampered", signer, "x" + token, EmailTokenPurposeVerify, now},
+		{"malformed", signer, "not-a-token", EmailTokenPurposeVerify, now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.signer.Verify(tt.token, tt.purpose, tt.now)
+			assert.ErrorIs(t, err, ErrInvalidEmailToken)
+		})
+	}
+
+	_, err = NewEmailTokenSigner("").Sign(EmailTokenClaims{Purpose: EmailTokenPurposeVerify})
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package notifier
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+)
+
+// メールのリンクに埋め込む署名付きトークンの用途
+const (
+	EmailTokenPurposeVerify      = "verify-email"
+	EmailTokenPurposeUnsubscribe = "unsubscribe"
+)
+
+// EmailVerificationTTL メールアドレス確認リンクの有効期限
+const EmailVerificationTTL = 24 * time.Hour
+
+// ErrInvalidEmailToken 署名・用途・有効期限のいずれかが不正なトークン
+var ErrInvalidEmailToken = errors.New("invalid email token")
+
+// EmailTokenClaims トークンに含める内容
+type EmailTokenClaims struct {
+	Purpose   string `json:"p"`
+	UserID    uint64 `json:"u"`
+	Email     string `json:"e"`
+	ExpiresAt int64  `json:"x,omitempty"` // unix秒、0の場合は無期限
+}
+
+// EmailTokenSigner メールのリンク用トークンをHMAC-SHA256で署名・検証する
+type EmailTokenSigner struct {
+	secret []byte
+}
+
+// NewEmailTokenSigner コンストラクタ
+func NewEmailTokenSigner(secret string) *EmailTokenSigner {
+	return &EmailTokenSigner{secret: []byte(secret)}
+}
+
+// Sign トークンを発行する（"<base64url(claims)>.<base64url(hmac)>"）
+// メールアドレスを含めるため、アドレスが変わると以前のリンクは使えなくなる
+func (s *EmailTokenSigner) Sign(claims EmailTokenClaims) (string, error) {
+	if len(s.secret) == 0 {
+		return "", errors.New("EMAIL_TOKEN_SECRET is not configured")
+	}
+	raw, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(raw)
+	return payload + "." + s.sign(payload), nil
+}
+
+// Verify トークンを検証し、用途が一致すれば内容を返す
+func (s *EmailTokenSigner) Verify(token, purpose string, now time.Time) (*EmailTokenClaims, error) {
+	if len(s.secret) == 0 {
+		return nil, ErrInvalidEmailToken
+	}
+	payload, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(s.sign(payload))) {
+		return nil, ErrInvalidEmailToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, ErrInvalidEmailToken
+	}
+	var claims EmailTokenClaims
+	if err := json.Unmarshal(raw, &claims); err != nil {
+		return nil, ErrInvalidEmailToken
+	}
+	if claims.Purpose != purpose {
+		return nil, ErrInvalidEmailToken
+	}
+	if claims.ExpiresAt != 0 && now.Unix() > claims.ExpiresAt {
+		return nil, ErrInvalidEmailToken
+	}
+	return &claims, nil
+}
+
+func (s *EmailTokenSigner) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
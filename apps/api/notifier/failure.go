@@ -0,0 +1,42 @@
+package notifier
+
+import (
+	"errors"
+	"net/http"
+	"net/textproto"
+
+	"github.com/keeee21/commitly/api/gateway"
+)
+
+// FailureKind 配信失敗の種類
+type FailureKind string
+
+const (
+	FailureKindRetryable FailureKind = "retryable" // 一時的な障害。時間をおいて再送する
+	FailureKindPermanent FailureKind = "permanent" // 送信先が存在しない・拒否された。再送しても成功しない
+)
+
+// ClassifyFailure 配信エラーをリトライ可能か恒久的かに分類する
+// 判定できないエラー（ネットワーク障害・設定漏れなど）はリトライ可能として扱う
+func ClassifyFailure(err error) FailureKind {
+	var statusErr *gateway.HTTPStatusError
+	if errors.As(err, &statusErr) {
+		switch {
+		case statusErr.StatusCode == http.StatusRequestTimeout,
+			statusErr.StatusCode == http.StatusTooManyRequests:
+			return FailureKindRetryable
+		case statusErr.StatusCode >= 400 && statusErr.StatusCode < 500:
+			// Slack の 404 no_service / 410 channel_is_archived、Discord の 404 Unknown Webhook など
+			return FailureKindPermanent
+		}
+		return FailureKindRetryable
+	}
+
+	// SMTPの5xx応答（宛先のメールボックスが存在しないなど）
+	var smtpErr *textproto.Error
+	if errors.As(err, &smtpErr) && smtpErr.Code >= 500 {
+		return FailureKindPermanent
+	}
+
+	return FailureKindRetryable
+}
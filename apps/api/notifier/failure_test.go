@@ -0,0 +1,35 @@
+package notifier
+
+import (
+	"errors"
+	"fmt"
+	"net/textproto"
+	"testing"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClassifyFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want FailureKind
+	}{
+		{"slack no_service", &gateway.HTTPStatusError{Target: "slack webhook", StatusCode: 404, Body: "no_service"}, FailureKindPermanent},
+		{"slack archived channel", &gateway.HTTPStatusError{Target: "slack webhook", StatusCode: 410, Body: "channel_is_archived"}, FailureKindPermanent},
+		{"discord unknown webhook (wrapped)", fmt.Errorf("failed to send discord message: %w", &gateway.HTTPStatusError{StatusCode: 404}), FailureKindPermanent},
+		{"rate limited", &gateway.HTTPStatusError{StatusCode: 429}, FailureKindRetryable},
+		{"request timeout", &gateway.HTTPStatusError{StatusCode: 408}, FailureKindRetryable},
+		{"server error", &gateway.HTTPStatusError{StatusCode: 500}, FailureKindRetryable},
+		{"smtp mailbox unavailable", fmt.Errorf("failed to send email: %w", &textproto.Error{Code: 550, Msg: "no such user"}), FailureKindPermanent},
+		{"smtp temporary failure", &textproto.Error{Code: 451, Msg: "try again later"}, FailureKindRetryable},
+		{"network error", errors.New("dial tcp: connection refused"), FailureKindRetryable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyFailure(tt.err))
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// IInboxPublisher アプリ内の受信箱に通知を載せるインターフェース（各機能はこれを通して載せる）
+type IInboxPublisher interface {
+	Publish(ctx context.Context, item InboxItem) error
+}
+
+// InboxItem 受信箱に載せる通知
+type InboxItem struct {
+	UserID    uint64
+	Type      models.InAppNotificationType
+	Payload   models.JSONPayload
+	DedupeKey string // 同じキーの通知は一度だけ載せる（空の場合は毎回載せる）
+}
+
+type inboxPublisher struct {
+	inAppNotificationRepo repository.IInAppNotificationRepository
+}
+
+// NewInboxPublisher コンストラクタ
+func NewInboxPublisher(inAppNotificationRepo repository.IInAppNotificationRepository) IInboxPublisher {
+	return &inboxPublisher{
+		inAppNotificationRepo: inAppNotificationRepo,
+	}
+}
+
+func (p *inboxPublisher) Publish(ctx context.Context, item InboxItem) error {
+	notification := &models.InAppNotification{
+		UserID:  item.UserID,
+		Type:    item.Type,
+		Payload: item.Payload,
+	}
+	if item.DedupeKey != "" {
+		notification.DedupeKey = &item.DedupeKey
+	}
+
+	if _, err := p.inAppNotificationRepo.CreateIfNotExists(ctx, notification); err != nil {
+		return fmt.Errorf("failed to publish %s to inbox: %w", item.Type, err)
+	}
+	return nil
+}
+
+// RivalAlertInboxItem 送ったライバルアラート（alertID は rival_alerts のID）
+func RivalAlertInboxItem(userID, alertID uint64, alert *Alert) InboxItem {
+	payload := models.JSONPayload{
+		"kind":                  string(alert.Kind),
+		"rival_github_username": alert.Rival.Username,
+		"user_commits":          alert.UserCommits,
+		"rival_commits":         alert.Rival.Commits,
+		"week_start":            alert.Week.Start.Format("2006-01-02"),
+	}
+	if alert.Kind == models.RivalAlertStreakBroken {
+		payload["streak"] = alert.Streak
+	}
+	return InboxItem{
+		UserID:    userID,
+		Type:      models.InAppNotificationRivalAlert,
+		Payload:   payload,
+		DedupeKey: "rival_alert:" + strconv.FormatUint(alertID, 10),
+	}
+}
+
+// CircleMemberJoinedInboxItem サークルに新しいメンバーが参加した
+func CircleMemberJoinedInboxItem(userID uint64, circle *models.Circle, member *models.User) InboxItem {
+	return InboxItem{
+		UserID: userID,
+		Type:   models.InAppNotificationCircleMemberJoined,
+		Payload: models.JSONPayload{
+			"circle_id":       circle.ID,
+			"circle_name":     circle.Name,
+			"github_username": member.GithubUsername,
+		},
+	}
+}
+
+// SyncFailedInboxItem コミットの同期に失敗した（同じ日の失敗は1件にまとめる）
+func SyncFailedInboxItem(userID uint64, date time.Time) InboxItem {
+	day := date.Format("2006-01-02")
+	return InboxItem{
+		UserID:    userID,
+		Type:      models.InAppNotificationSyncFailed,
+		Payload:   models.JSONPayload{"date": day},
+		DedupeKey: "sync_failed:" + day,
+	}
+}
+
+// ReportDeliveredInboxItem レポートを配信した（同じ集計期間のレポートは1件にまとめる）
+func ReportDeliveredInboxItem(userID uint64, period string, periodStart time.Time, channels []models.ChannelType) InboxItem {
+	start := periodStart.Format("2006-01-02")
+	return InboxItem{
+		UserID: userID,
+		Type:   models.InAppNotificationReportDelivered,
+		Payload: models.JSONPayload{
+			"period":       period,
+			"period_start": start,
+			"channels":     channels,
+		},
+		DedupeKey: "report_delivered:" + period + ":" + start,
+	}
+}
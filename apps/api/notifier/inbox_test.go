@@ -0,0 +1,90 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockInAppNotificationRepository struct {
+	created []*models.InAppNotification
+	err     error
+}
+
+func (m *mockInAppNotificationRepository) CreateIfNotExists(ctx context.Context, notification *models.InAppNotification) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+	m.created = append(m.created, notification)
+	return true, nil
+}
+
+func (m *mockInAppNotificationRepository) FindByUserID(ctx context.Context, userID uint64, beforeID uint64, unreadOnly bool, limit int) ([]models.InAppNotification, error) {
+	return nil, nil
+}
+
+func (m *mockInAppNotificationRepository) CountUnread(ctx context.Context, userID uint64) (int64, error) {
+	return 0, nil
+}
+
+func (m *mockInAppNotificationRepository) MarkRead(ctx context.Context, userID, id uint64, readAt time.Time) (bool, error) {
+	return false, nil
+}
+
+func (m *mockInAppNotificationRepository) MarkAllRead(ctx context.Context, userID uint64, readAt time.Time) error {
+	return nil
+}
+
+func TestInboxPublisher_Publish(t *testing.T) {
+	repo := &mockInAppNotificationRepository{}
+	publisher := NewInboxPublisher(repo)
+
+	err := publisher.Publish(context.Background(), SyncFailedInboxItem(1, time.Date(2026, 10, 19, 3, 0, 0, 0, time.Local)))
+	assert.NoError(t, err)
+	err = publisher.Publish(context.Background(), CircleMemberJoinedInboxItem(2, &models.Circle{ID: 5, Name: "勉強会"}, &models.User{GithubUsername: "newcomer"}))
+	assert.NoError(t, err)
+
+	if assert.Len(t, repo.created, 2) {
+		assert.Equal(t, uint64(1), repo.created[0].UserID)
+		assert.Equal(t, models.InAppNotificationSyncFailed, repo.created[0].Type)
+		if assert.NotNil(t, repo.created[0].DedupeKey) {
+			assert.Equal(t, "sync_failed:2026-10-19", *repo.created[0].DedupeKey)
+		}
+		// 重複キーのない通知は毎回載せる
+		assert.Nil(t, repo.created[1].DedupeKey)
+		assert.Equal(t, "勉強会", repo.created[1].Payload["circle_name"])
+	}
+}
+
+func TestInboxPublisher_PublishError(t *testing.T) {
+	publisher := NewInboxPublisher(&mockInAppNotificationRepository{err: errors.New("db error")})
+
+	err := publisher.Publish(context.Background(), SyncFailedInboxItem(1, time.Now()))
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "sync_failed")
+}
+
+func TestRivalAlertInboxItem(t *testing.T) {
+	week := DateRange{Start: time.Date(2026, 10, 19, 0, 0, 0, 0, time.Local), End: time.Date(2026, 10, 21, 0, 0, 0, 0, time.Local)}
+
+	overtaken := RivalAlertInboxItem(1, 42, &Alert{
+		Kind:        models.RivalAlertOvertaken,
+		UserCommits: 3,
+		Rival:       gateway.RivalCommitSummary{Username: "rival1", Commits: 5},
+		Week:        week,
+	})
+	assert.Equal(t, "rival_alert:42", overtaken.DedupeKey)
+	assert.Equal(t, "rival1", overtaken.Payload["rival_github_username"])
+	assert.Equal(t, 5, overtaken.Payload["rival_commits"])
+	assert.Equal(t, "2026-10-19", overtaken.Payload["week_start"])
+	assert.NotContains(t, overtaken.Payload, "streak")
+
+	broken := RivalAlertInboxItem(1, 43, &Alert{Kind: models.RivalAlertStreakBroken, Rival: gateway.RivalCommitSummary{Username: "rival1"}, Streak: 4, Week: week})
+	assert.Equal(t, 4, broken.Payload["streak"])
+}
@@ -0,0 +1,113 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+type lineNotifier struct {
+	lineRepo    repository.ILineNotificationSettingRepository
+	lineGateway gateway.ILineGateway
+}
+
+// NewLineNotifier コンストラクタ
+func NewLineNotifier(lineRepo repository.ILineNotificationSettingRepository, lineGateway gateway.ILineGateway) INotifier {
+	return &lineNotifier{
+		lineRepo:    lineRepo,
+		lineGateway: lineGateway,
+	}
+}
+
+func (n *lineNotifier) ChannelType() models.ChannelType {
+	return models.ChannelTypeLINE
+}
+
+func (n *lineNotifier) FindEnabledDestinations(ctx context.Context) ([]Destination, error) {
+	settings, err := n.lineRepo.FindAllEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled LINE notification settings: %w", err)
+	}
+
+	destinations := make([]Destination, 0, len(settings))
+	for _, s := range settings {
+		destinations = append(destinations, lineDestination(s))
+	}
+	return destinations, nil
+}
+
+func (n *lineNotifier) FindDestination(ctx context.Context, userID uint64) (*Destination, error) {
+	setting, err := n.lineRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get LINE notification setting: %w", err)
+	}
+	if setting == nil || !setting.IsEnabled {
+		return nil, nil
+	}
+	destination := lineDestination(*setting)
+	return &destination, nil
+}
+
+// lineDestination 設定を送信先に変換
+func lineDestination(s models.LineNotificationSetting) Destination {
+	return Destination{
+		UserID:      s.UserID,
+		User:        s.User,
+		ChannelType: models.ChannelTypeLINE,
+		Address:     s.LineUserID,
+	}
+}
+
+func (n *lineNotifier) Render(destination Destination, report *Report) (*Message, error) {
+	l := destinationLocalizer(destination)
+	var message gateway.LineMessage
+	if report.Period == "weekly" {
+		message = gateway.BuildWeeklyReportLineMessage(l, report.Username, report.UserCommits, report.Rivals, report.RangeStart, report.RangeEnd)
+	} else {
+		message = gateway.BuildMonthlyReportLineMessage(
+			l,
+			report.Username,
+			gateway.MonthlyComparison{CurrentMonth: report.UserCommits, PreviousMonth: report.PreviousCommits},
+			report.Rivals,
+			report.RangeStart,
+		)
+	}
+
+	return &Message{
+		Body:    message,
+		Payload: models.JSONPayload{"altText": message.AltText, "contents": message.Contents},
+	}, nil
+}
+
+func (n *lineNotifier) RenderTest(destination Destination) (*Message, error) {
+	message := gateway.BuildLineTextMessage(TestMessageText(destinationLocalizer(destination)))
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text}}, nil
+}
+
+func (n *lineNotifier) RenderAlert(destination Destination, alert *Alert) (*Message, error) {
+	message := gateway.BuildLineTextMessage(AlertText(destinationLocalizer(destination), alert))
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text}}, nil
+}
+
+func (n *lineNotifier) RenderNudge(destination Destination, nudge *Nudge) (*Message, error) {
+	message := gateway.BuildLineTextMessage(NudgeText(destinationLocalizer(destination), nudge))
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text}}, nil
+}
+
+func (n *lineNotifier) Deliver(ctx context.Context, destination Destination, message *Message) error {
+	lineMessage, ok := message.Body.(gateway.LineMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type for line: %T", message.Body)
+	}
+	if err := n.lineGateway.PushMessage(ctx, destination.Address, []gateway.LineMessage{lineMessage}); err != nil {
+		return fmt.Errorf("failed to push line message: %w", err)
+	}
+	return nil
+}
+
+func (n *lineNotifier) Disable(ctx context.Context, userID uint64) error {
+	return n.lineRepo.UpdateEnabled(ctx, userID, false)
+}
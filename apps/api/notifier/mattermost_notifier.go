@@ -0,0 +1,110 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+type mattermostNotifier struct {
+	mattermostRepo    repository.IMattermostNotificationSettingRepository
+	mattermostGateway gateway.IMattermostGateway
+}
+
+// NewMattermostNotifier コンストラクタ
+func NewMattermostNotifier(mattermostRepo repository.IMattermostNotificationSettingRepository, mattermostGateway gateway.IMattermostGateway) INotifier {
+	return &mattermostNotifier{
+		mattermostRepo:    mattermostRepo,
+		mattermostGateway: mattermostGateway,
+	}
+}
+
+func (n *mattermostNotifier) ChannelType() models.ChannelType {
+	return models.ChannelTypeMattermost
+}
+
+func (n *mattermostNotifier) FindEnabledDestinations(ctx context.Context) ([]Destination, error) {
+	settings, err := n.mattermostRepo.FindAllEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled Mattermost notification settings: %w", err)
+	}
+
+	destinations := make([]Destination, 0, len(settings))
+	for _, s := range settings {
+		destinations = append(destinations, mattermostDestination(s))
+	}
+	return destinations, nil
+}
+
+func (n *mattermostNotifier) FindDestination(ctx context.Context, userID uint64) (*Destination, error) {
+	setting, err := n.mattermostRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Mattermost notification setting: %w", err)
+	}
+	if setting == nil || !setting.IsEnabled {
+		return nil, nil
+	}
+	destination := mattermostDestination(*setting)
+	return &destination, nil
+}
+
+// mattermostDestination 設定を送信先に変換
+func mattermostDestination(s models.MattermostNotificationSetting) Destination {
+	return Destination{
+		UserID:      s.UserID,
+		User:        s.User,
+		ChannelType: models.ChannelTypeMattermost,
+		Address:     s.WebhookURL,
+	}
+}
+
+func (n *mattermostNotifier) Render(destination Destination, report *Report) (*Message, error) {
+	l := destinationLocalizer(destination)
+	var message *gateway.MattermostMessage
+	if report.Period == "weekly" {
+		message = gateway.BuildWeeklyReportMattermostMessage(l, report.Username, report.UserCommits, report.Rivals, report.RangeStart, report.RangeEnd)
+	} else {
+		message = gateway.BuildMonthlyReportMattermostMessage(
+			l,
+			report.Username,
+			gateway.MonthlyComparison{CurrentMonth: report.UserCommits, PreviousMonth: report.PreviousCommits},
+			report.Rivals,
+			report.RangeStart,
+		)
+	}
+
+	return &Message{Body: message, Payload: models.JSONPayload{"attachments": message.Attachments}}, nil
+}
+
+func (n *mattermostNotifier) RenderTest(destination Destination) (*Message, error) {
+	message := &gateway.MattermostMessage{Text: TestMessageText(destinationLocalizer(destination))}
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text}}, nil
+}
+
+func (n *mattermostNotifier) RenderAlert(destination Destination, alert *Alert) (*Message, error) {
+	message := &gateway.MattermostMessage{Text: AlertText(destinationLocalizer(destination), alert)}
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text}}, nil
+}
+
+func (n *mattermostNotifier) RenderNudge(destination Destination, nudge *Nudge) (*Message, error) {
+	message := &gateway.MattermostMessage{Text: NudgeText(destinationLocalizer(destination), nudge)}
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text}}, nil
+}
+
+func (n *mattermostNotifier) Deliver(ctx context.Context, destination Destination, message *Message) error {
+	mattermostMessage, ok := message.Body.(*gateway.MattermostMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type for mattermost: %T", message.Body)
+	}
+	if err := n.mattermostGateway.SendMessage(ctx, destination.Address, mattermostMessage); err != nil {
+		return fmt.Errorf("failed to send mattermost message: %w", err)
+	}
+	return nil
+}
+
+func (n *mattermostNotifier) Disable(ctx context.Context, userID uint64) error {
+	return n.mattermostRepo.UpdateEnabled(ctx, userID, false)
+}
@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/keeee21/commitly/api/models"
+)
+
+// Report チャンネルに依存しないレポートの内容
+type Report struct {
+	Period      string // "weekly" or "monthly"
+	Username    string
+	UserCommits int
+	Rivals      []gateway.RivalCommitSummary
+	RangeStart  time.Time // 集計期間の初日
+	RangeEnd    time.Time // 集計期間の最終日
+	// 月次レポート用
+	PreviousCommits int
+}
+
+// TestMessageText 送信先の確認用に送るテスト通知の本文
+func TestMessageText(l *i18n.Localizer) string {
+	return l.Emoji("test") + " " + l.T("test.message", nil)
+}
+
+// destinationLocalizer 送信先ユーザーの言語の Localizer
+func destinationLocalizer(destination Destination) *i18n.Localizer {
+	return i18n.For(destination.User.Locale)
+}
+
+// Destination 通知の送信先（あるユーザーの有効な1チャンネル）
+type Destination struct {
+	UserID      uint64
+	User        models.User
+	ChannelType models.ChannelType
+	Address     string // Webhook URL / LINEユーザーID などチャンネルごとの宛先
+	Secret      string // 署名用のシークレット（汎用Webhookのみ）
+}
+
+// Message チャンネル向けにレンダリングしたメッセージ
+type Message struct {
+	Body    interface{}        // チャンネル固有のメッセージ（ドライランではそのまま書き出す）
+	Payload models.JSONPayload // 通知ログに保存する内容
+}
+
+// INotifier 通知チャンネルのインターフェース
+// レポートを送信先ごとにチャンネル固有の形式へレンダリングし、配信する
+type INotifier interface {
+	ChannelType() models.ChannelType
+	FindEnabledDestinations(ctx context.Context) ([]Destination, error)
+	FindDestination(ctx context.Context, userID uint64) (*Destination, error) // ユーザーの有効な送信先（なければnil）
+	Render(destination Destination, report *Report) (*Message, error)
+	RenderTest(destination Destination) (*Message, error) // 送信先の確認用の短いテスト通知
+	RenderAlert(destination Destination, alert *Alert) (*Message, error)
+	RenderNudge(destination Destination, nudge *Nudge) (*Message, error) // 今日まだコミットしていないユーザーへのリマインダー
+	Deliver(ctx context.Context, destination Destination, message *Message) error
+	Disable(ctx context.Context, userID uint64) error // 恒久的な失敗が続いたときに設定を無効化する
+}
@@ -0,0 +1,42 @@
+package notifier
+
+import "github.com/keeee21/commitly/api/models"
+
+// Registry チャンネルタイプごとのNotifierを保持する
+type Registry struct {
+	notifiers map[models.ChannelType]INotifier
+	order     []models.ChannelType
+}
+
+// NewRegistry コンストラクタ（登録順に配信される）
+func NewRegistry(notifiers ...INotifier) *Registry {
+	r := &Registry{notifiers: make(map[models.ChannelType]INotifier)}
+	for _, n := range notifiers {
+		r.Register(n)
+	}
+	return r
+}
+
+// Register Notifierを登録する（同じチャンネルタイプは後から登録したもので上書き）
+func (r *Registry) Register(n INotifier) {
+	channelType := n.ChannelType()
+	if _, exists := r.notifiers[channelType]; !exists {
+		r.order = append(r.order, channelType)
+	}
+	r.notifiers[channelType] = n
+}
+
+// Get チャンネルタイプに対応するNotifierを返す
+func (r *Registry) Get(channelType models.ChannelType) (INotifier, bool) {
+	n, ok := r.notifiers[channelType]
+	return n, ok
+}
+
+// All 登録済みのNotifierを登録順に返す
+func (r *Registry) All() []INotifier {
+	notifiers := make([]INotifier, 0, len(r.order))
+	for _, channelType := range r.order {
+		notifiers = append(notifiers, r.notifiers[channelType])
+	}
+	return notifiers
+}
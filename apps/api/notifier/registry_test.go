@@ -0,0 +1,80 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type stubNotifier struct {
+	channelType models.ChannelType
+}
+
+func (n *stubNotifier) ChannelType() models.ChannelType {
+	return n.channelType
+}
+
+func (n *stubNotifier) FindEnabledDestinations(ctx context.Context) ([]Destination, error) {
+	return nil, nil
+}
+
+func (n *stubNotifier) FindDestination(ctx context.Context, userID uint64) (*Destination, error) {
+	return nil, nil
+}
+
+func (n *stubNotifier) Render(destination Destination, report *Report) (*Message, error) {
+	return &Message{}, nil
+}
+
+func (n *stubNotifier) RenderTest(destination Destination) (*Message, error) {
+	return &Message{}, nil
+}
+
+func (n *stubNotifier) RenderAlert(destination Destination, alert *Alert) (*Message, error) {
+	return &Message{}, nil
+}
+
+func (n *stubNotifier) RenderNudge(destination Destination, nudge *Nudge) (*Message, error) {
+	return &Message{}, nil
+}
+
+func (n *stubNotifier) Deliver(ctx context.Context, destination Destination, message *Message) error {
+	return nil
+}
+
+func (n *stubNotifier) Disable(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func TestRegistry_AllKeepsRegistrationOrder(t *testing.T) {
+	slack := &stubNotifier{channelType: models.ChannelTypeSlack}
+	discord := &stubNotifier{channelType: models.ChannelTypeDiscord}
+	line := &stubNotifier{channelType: models.ChannelTypeLINE}
+
+	registry := NewRegistry(line, slack, discord)
+
+	assert.Equal(t, []INotifier{line, slack, discord}, registry.All())
+}
+
+func TestRegistry_RegisterReplacesSameChannel(t *testing.T) {
+	first := &stubNotifier{channelType: models.ChannelTypeSlack}
+	second := &stubNotifier{channelType: models.ChannelTypeSlack}
+
+	registry := NewRegistry(first)
+	registry.Register(second)
+
+	got, ok := registry.Get(models.ChannelTypeSlack)
+	assert.True(t, ok)
+	assert.Same(t, second, got)
+	assert.Len(t, registry.All(), 1)
+}
+
+func TestRegistry_GetUnknownChannel(t *testing.T) {
+	registry := NewRegistry()
+
+	got, ok := registry.Get(models.ChannelTypeDiscord)
+	assert.False(t, ok)
+	assert.Nil(t, got)
+}
@@ -0,0 +1,198 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// DateRange 日付範囲
+type DateRange struct {
+	Start time.Time
+	End   time.Time
+}
+
+// WeeklyRange 週次レポートの日付範囲を計算（anchor の前日までの7日間）
+func WeeklyRange(anchor time.Time) DateRange {
+	today := time.Date(anchor.Year(), anchor.Month(), anchor.Day(), 0, 0, 0, 0, time.Local)
+	return DateRange{
+		Start: today.AddDate(0, 0, -7),
+		End:   today.AddDate(0, 0, -1),
+	}
+}
+
+// MonthlyRange 月次レポートの日付範囲を計算（anchor の前月と前々月）
+func MonthlyRange(anchor time.Time) (current DateRange, previous DateRange) {
+	// 先月の範囲
+	firstOfThisMonth := time.Date(anchor.Year(), anchor.Month(), 1, 0, 0, 0, 0, time.Local)
+	lastOfLastMonth := firstOfThisMonth.AddDate(0, 0, -1)
+	firstOfLastMonth := time.Date(lastOfLastMonth.Year(), lastOfLastMonth.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	current = DateRange{
+		Start: firstOfLastMonth,
+		End:   lastOfLastMonth,
+	}
+
+	// 先々月の範囲
+	lastOfTwoMonthsAgo := firstOfLastMonth.AddDate(0, 0, -1)
+	firstOfTwoMonthsAgo := time.Date(lastOfTwoMonthsAgo.Year(), lastOfTwoMonthsAgo.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	previous = DateRange{
+		Start: firstOfTwoMonthsAgo,
+		End:   lastOfTwoMonthsAgo,
+	}
+
+	return current, previous
+}
+
+// PeriodStart 集計期間の開始日（配信の冪等キー）を計算
+// 週次は配信する曜日や再実行した日によらず同じキーになるよう、集計期間の初日を含む週の月曜日にする
+func PeriodStart(period string, anchor time.Time) time.Time {
+	if period == "weekly" {
+		start := WeeklyRange(anchor).Start
+		return start.AddDate(0, 0, -((int(start.Weekday()) + 6) % 7))
+	}
+	current, _ := MonthlyRange(anchor)
+	return current.Start
+}
+
+// PeriodAnchor 集計期間の開始日から、同じ集計期間になる基準日時を求める（PeriodStart の逆）
+func PeriodAnchor(period string, periodStart time.Time) time.Time {
+	start := time.Date(periodStart.Year(), periodStart.Month(), periodStart.Day(), 0, 0, 0, 0, time.Local)
+	if period == "weekly" {
+		return start.AddDate(0, 0, 7)
+	}
+	return start.AddDate(0, 1, 0)
+}
+
+// LogReportAnchor 通知ログのレポートと同じ集計期間になる基準日時
+func LogReportAnchor(notificationLog *models.NotificationLog) time.Time {
+	if notificationLog.PeriodStart == nil || notificationLog.Period == "weekly" {
+		// 冪等キー導入前のログと、冪等キーの週からは集計期間が決まらない週次のログは送信日時を基準にする
+		return notificationLog.SentAt.In(time.Local)
+	}
+	return PeriodAnchor(notificationLog.Period, *notificationLog.PeriodStart)
+}
+
+// IReportBuilder レポート集計のインターフェース
+type IReportBuilder interface {
+	Build(ctx context.Context, period string, userID uint64, user models.User, anchor time.Time) (*Report, error)
+}
+
+type reportBuilder struct {
+	rivalRepo       repository.IRivalRepository
+	commitStatsRepo repository.ICommitStatsRepository
+}
+
+// NewReportBuilder コンストラクタ
+func NewReportBuilder(rivalRepo repository.IRivalRepository, commitStatsRepo repository.ICommitStatsRepository) IReportBuilder {
+	return &reportBuilder{
+		rivalRepo:       rivalRepo,
+		commitStatsRepo: commitStatsRepo,
+	}
+}
+
+// Build 期間に応じたレポートの内容を集計する（集計期間は anchor を基準に決める）
+func (b *reportBuilder) Build(ctx context.Context, period string, userID uint64, user models.User, anchor time.Time) (*Report, error) {
+	if period == "weekly" {
+		return b.buildWeekly(ctx, userID, user, anchor)
+	}
+	return b.buildMonthly(ctx, userID, user, anchor)
+}
+
+// buildWeekly 週次レポートの内容を集計
+func (b *reportBuilder) buildWeekly(ctx context.Context, userID uint64, user models.User, anchor time.Time) (*Report, error) {
+	dateRange := WeeklyRange(anchor)
+
+	// ユーザーのコミット統計を取得
+	userStats, err := b.commitStatsRepo.FindByGithubUserIDAndDateRange(ctx, user.GithubUserID, dateRange.Start, dateRange.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user commit stats: %w", err)
+	}
+
+	// ライバルのコミット統計を取得
+	rivalSummaries, err := b.rivalSummaries(ctx, userID, dateRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rival summaries: %w", err)
+	}
+
+	return &Report{
+		Period:      "weekly",
+		Username:    user.GithubUsername,
+		UserCommits: SumCommits(userStats),
+		Rivals:      rivalSummaries,
+		RangeStart:  dateRange.Start,
+		RangeEnd:    dateRange.End,
+	}, nil
+}
+
+// buildMonthly 月次レポートの内容を集計
+func (b *reportBuilder) buildMonthly(ctx context.Context, userID uint64, user models.User, anchor time.Time) (*Report, error) {
+	currentRange, previousRange := MonthlyRange(anchor)
+
+	// 今月のコミット統計を取得
+	currentStats, err := b.commitStatsRepo.FindByGithubUserIDAndDateRange(ctx, user.GithubUserID, currentRange.Start, currentRange.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current month commit stats: %w", err)
+	}
+
+	// 先月のコミット統計を取得
+	previousStats, err := b.commitStatsRepo.FindByGithubUserIDAndDateRange(ctx, user.GithubUserID, previousRange.Start, previousRange.End)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get previous month commit stats: %w", err)
+	}
+
+	// ライバルのコミット統計を取得（今月分）
+	rivalSummaries, err := b.rivalSummaries(ctx, userID, currentRange)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rival summaries: %w", err)
+	}
+
+	return &Report{
+		Period:          "monthly",
+		Username:        user.GithubUsername,
+		UserCommits:     SumCommits(currentStats),
+		PreviousCommits: SumCommits(previousStats),
+		Rivals:          rivalSummaries,
+		RangeStart:      currentRange.Start,
+		RangeEnd:        currentRange.End,
+	}, nil
+}
+
+// rivalSummaries ライバルのコミットサマリーを取得
+func (b *reportBuilder) rivalSummaries(ctx context.Context, userID uint64, dateRange DateRange) ([]gateway.RivalCommitSummary, error) {
+	rivals, err := b.rivalRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []gateway.RivalCommitSummary
+	for _, rival := range rivals {
+		rivalStats, err := b.commitStatsRepo.FindByGithubUserIDAndDateRange(ctx, rival.RivalGithubUserID, dateRange.Start, dateRange.End)
+		if err != nil {
+			log.Printf("Failed to get rival %s commit stats: %v", rival.RivalGithubUsername, err)
+			continue
+		}
+
+		summaries = append(summaries, gateway.RivalCommitSummary{
+			Username: rival.RivalGithubUsername,
+			Commits:  SumCommits(rivalStats),
+		})
+	}
+
+	return summaries, nil
+}
+
+// SumCommits コミット数を合計する
+func SumCommits(stats []models.CommitStats) int {
+	total := 0
+	for _, s := range stats {
+		total += s.CommitCount
+	}
+	return total
+}
@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeeklyRange(t *testing.T) {
+	dateRange := WeeklyRange(time.Now())
+
+	assert.True(t, dateRange.Start.Before(dateRange.End))
+	assert.Equal(t, 6, int(dateRange.End.Sub(dateRange.Start).Hours()/24)) // 7日間の差
+}
+
+func TestMonthlyRange(t *testing.T) {
+	current, previous := MonthlyRange(time.Now())
+
+	// 今月の範囲は先月を指す
+	assert.True(t, current.Start.Before(current.End) || current.Start.Equal(current.End))
+	// 先月の範囲は先々月を指す
+	assert.True(t, previous.Start.Before(previous.End) || previous.Start.Equal(previous.End))
+	// 先月は先々月より後
+	assert.True(t, current.Start.After(previous.End))
+}
+
+func TestSumCommits(t *testing.T) {
+	stats := []models.CommitStats{
+		{CommitCount: 5},
+		{CommitCount: 3},
+		{CommitCount: 2},
+	}
+
+	assert.Equal(t, 10, SumCommits(stats))
+	assert.Equal(t, 0, SumCommits([]models.CommitStats{}))
+}
+
+func TestPeriodStart(t *testing.T) {
+	now := time.Date(2026, 10, 12, 9, 0, 0, 0, time.Local)
+
+	assert.Equal(t, time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local), PeriodStart("weekly", now))
+	assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local), PeriodStart("monthly", now))
+}
+
+func TestPeriodStart_WeeklyRerunOnAnotherWeekday(t *testing.T) {
+	// 月曜の配信が一部失敗し、水曜・日曜に再実行しても同じ週（同じ冪等キー）になる
+	monday := PeriodStart("weekly", time.Date(2026, 10, 12, 9, 0, 0, 0, time.Local))
+	wednesday := PeriodStart("weekly", time.Date(2026, 10, 14, 18, 0, 0, 0, time.Local))
+	sunday := PeriodStart("weekly", time.Date(2026, 10, 18, 23, 0, 0, 0, time.Local))
+
+	assert.Equal(t, time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local), monday)
+	assert.Equal(t, monday, wednesday)
+	assert.Equal(t, monday, sunday)
+
+	// 集計期間は配信日の前日までの7日間のまま
+	dateRange := WeeklyRange(time.Date(2026, 10, 14, 18, 0, 0, 0, time.Local))
+	assert.Equal(t, time.Date(2026, 10, 7, 0, 0, 0, 0, time.Local), dateRange.Start)
+	assert.Equal(t, time.Date(2026, 10, 13, 0, 0, 0, 0, time.Local), dateRange.End)
+}
+
+func TestLogReportAnchor(t *testing.T) {
+	// DBから読み込んだ日付はUTCの0時になる
+	// 週次は初回の送信日時を基準にする（水曜に配信したレポートは水曜の前日までの7日間）
+	weeklyStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.UTC)
+	weeklySentAt := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local)
+	weekly := WeeklyRange(LogReportAnchor(&models.NotificationLog{Period: "weekly", PeriodStart: &weeklyStart, SentAt: weeklySentAt}))
+	assert.Equal(t, time.Date(2026, 10, 7, 0, 0, 0, 0, time.Local), weekly.Start)
+
+	monthlyStart := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	monthly, _ := MonthlyRange(LogReportAnchor(&models.NotificationLog{Period: "monthly", PeriodStart: &monthlyStart}))
+	assert.Equal(t, time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local), monthly.Start)
+
+	// 冪等キー導入前のログは送信日時を基準にする
+	sentAt := time.Date(2026, 10, 12, 9, 0, 0, 0, time.Local)
+	assert.Equal(t, sentAt, LogReportAnchor(&models.NotificationLog{Period: "weekly", SentAt: sentAt}))
+}
@@ -0,0 +1,85 @@
+package notifier
+
+import (
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/keeee21/commitly/api/models"
+)
+
+// Alert チャンネルに依存しないライバルアラートの内容
+type Alert struct {
+	Kind        models.RivalAlertKind
+	Username    string
+	UserCommits int                        // 今週のコミット数
+	Rival       gateway.RivalCommitSummary // ライバルと今週のコミット数
+	Streak      int                        // 途切れた連続コミット日数（streak_broken のみ）
+	Week        DateRange                  // 比較した週（月曜日から比較した日まで）
+}
+
+// CurrentWeekRange 今週（月曜日から now の日まで）の日付範囲
+func CurrentWeekRange(now time.Time) DateRange {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	daysSinceMonday := (int(today.Weekday()) + 6) % 7
+	return DateRange{
+		Start: today.AddDate(0, 0, -daysSinceMonday),
+		End:   today,
+	}
+}
+
+// LeadChange レポートと同じライバルとの比較から、抜かれた・抜き返したを判定する
+// previous は今週の前回の比較でライバルがリードしていたか（今週まだどちらもリードしていなければnil）
+// previous がnilの間は比べる基準がないため、最初にリードした側を記録するだけでイベントにしない
+// 同数の間はリードは変わらないものとし、戻り値は今回の比較後のリードと、起きたイベント（なければ空）
+func LeadChange(previous *bool, userCommits int, rival gateway.RivalCommitSummary) (*bool, models.RivalAlertKind) {
+	if rival.Commits == userCommits {
+		return previous, ""
+	}
+
+	rivalAhead := rival.Commits > userCommits
+	switch {
+	case rivalAhead && previous != nil && !*previous:
+		return &rivalAhead, models.RivalAlertOvertaken
+	case !rivalAhead && previous != nil && *previous:
+		return &rivalAhead, models.RivalAlertReclaimed
+	}
+	return &rivalAhead, ""
+}
+
+// BrokenStreak day にコミットがなく、前日まで連続してコミットしていた場合にその日数を返す（途切れていなければ0）
+func BrokenStreak(stats []models.CommitStats, day time.Time) int {
+	committed := make(map[string]bool, len(stats))
+	for _, s := range stats {
+		if s.CommitCount > 0 {
+			committed[s.Date.Format("2006-01-02")] = true
+		}
+	}
+	if committed[day.Format("2006-01-02")] {
+		return 0
+	}
+
+	streak := 0
+	for d := day.AddDate(0, 0, -1); committed[d.Format("2006-01-02")]; d = d.AddDate(0, 0, -1) {
+		streak++
+	}
+	return streak
+}
+
+// AlertText アラートの本文
+func AlertText(l *i18n.Localizer, alert *Alert) string {
+	vars := i18n.Vars{
+		"Rival":        alert.Rival.Username,
+		"RivalCommits": l.Commits(alert.Rival.Commits),
+		"UserCommits":  l.Commits(alert.UserCommits),
+		"Streak":       alert.Streak,
+	}
+	switch alert.Kind {
+	case models.RivalAlertOvertaken:
+		return l.Emoji("rival_ahead") + " " + l.T("alert.overtaken", vars)
+	case models.RivalAlertReclaimed:
+		return l.Emoji("user_ahead") + " " + l.T("alert.reclaimed", vars)
+	default:
+		return l.Emoji("streak_broken") + " " + l.T("alert.streak_broken", vars)
+	}
+}
@@ -0,0 +1,78 @@
+package notifier
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentWeekRange(t *testing.T) {
+	// 2026-10-15 は木曜日
+	week := CurrentWeekRange(time.Date(2026, 10, 15, 21, 30, 0, 0, time.Local))
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local), week.Start)
+	assert.Equal(t, time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local), week.End)
+
+	// 日曜日は前の月曜日から
+	week = CurrentWeekRange(time.Date(2026, 10, 18, 9, 0, 0, 0, time.Local))
+	assert.Equal(t, time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local), week.Start)
+}
+
+func TestLeadChange(t *testing.T) {
+	rivalAhead, userAhead := true, false
+
+	tests := []struct {
+		name         string
+		previous     *bool
+		userCommits  int
+		rivalCommits int
+		expectedLead *bool
+		expectedKind models.RivalAlertKind
+	}{
+		{name: "rival passes the user", previous: &userAhead, userCommits: 3, rivalCommits: 5, expectedLead: &rivalAhead, expectedKind: models.RivalAlertOvertaken},
+		{name: "rival leads first this week", previous: nil, userCommits: 0, rivalCommits: 2, expectedLead: &rivalAhead},
+		{name: "user reclaims the lead", previous: &rivalAhead, userCommits: 6, rivalCommits: 5, expectedLead: &userAhead, expectedKind: models.RivalAlertReclaimed},
+		{name: "user leads first this week", previous: nil, userCommits: 2, rivalCommits: 0, expectedLead: &userAhead},
+		{name: "rival stays ahead", previous: &rivalAhead, userCommits: 1, rivalCommits: 5, expectedLead: &rivalAhead},
+		{name: "tie keeps the previous lead", previous: &rivalAhead, userCommits: 5, rivalCommits: 5, expectedLead: &rivalAhead},
+		{name: "tie before anyone leads", previous: nil, userCommits: 0, rivalCommits: 0, expectedLead: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lead, kind := LeadChange(tt.previous, tt.userCommits, gateway.RivalCommitSummary{Username: "rival1", Commits: tt.rivalCommits})
+			assert.Equal(t, tt.expectedLead, lead)
+			assert.Equal(t, tt.expectedKind, kind)
+		})
+	}
+}
+
+func TestBrokenStreak(t *testing.T) {
+	day := time.Date(2026, 10, 14, 0, 0, 0, 0, time.UTC)
+	stats := []models.CommitStats{
+		{Date: day.AddDate(0, 0, -1), CommitCount: 1},
+		{Date: day.AddDate(0, 0, -2), CommitCount: 3},
+		{Date: day.AddDate(0, 0, -3), CommitCount: 0},
+		{Date: day.AddDate(0, 0, -4), CommitCount: 2},
+	}
+
+	assert.Equal(t, 2, BrokenStreak(stats, day))
+
+	// その日にコミットしていれば途切れていない
+	stats = append(stats, models.CommitStats{Date: day, CommitCount: 1})
+	assert.Equal(t, 0, BrokenStreak(stats, day))
+}
+
+func TestAlertText(t *testing.T) {
+	alert := &Alert{
+		Kind:        models.RivalAlertOvertaken,
+		UserCommits: 1,
+		Rival:       gateway.RivalCommitSummary{Username: "rival1", Commits: 4},
+	}
+
+	assert.Equal(t, "🔥 rival1 just passed you this week (rival1: 4 commits / you: 1 commit)", AlertText(i18n.For("en"), alert))
+	assert.Contains(t, AlertText(i18n.For("ja"), alert), "rival1 に今週のコミット数で抜かれました")
+}
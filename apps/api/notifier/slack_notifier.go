@@ -0,0 +1,114 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+type slackNotifier struct {
+	slackRepo    repository.ISlackNotificationSettingRepository
+	slackGateway gateway.ISlackGateway
+}
+
+// NewSlackNotifier コンストラクタ
+func NewSlackNotifier(slackRepo repository.ISlackNotificationSettingRepository, slackGateway gateway.ISlackGateway) INotifier {
+	return &slackNotifier{
+		slackRepo:    slackRepo,
+		slackGateway: slackGateway,
+	}
+}
+
+func (n *slackNotifier) ChannelType() models.ChannelType {
+	return models.ChannelTypeSlack
+}
+
+func (n *slackNotifier) FindEnabledDestinations(ctx context.Context) ([]Destination, error) {
+	settings, err := n.slackRepo.FindAllEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled Slack notification settings: %w", err)
+	}
+
+	destinations := make([]Destination, 0, len(settings))
+	for _, s := range settings {
+		destinations = append(destinations, slackDestination(s))
+	}
+	return destinations, nil
+}
+
+func (n *slackNotifier) FindDestination(ctx context.Context, userID uint64) (*Destination, error) {
+	setting, err := n.slackRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Slack notification setting: %w", err)
+	}
+	if setting == nil || !setting.IsEnabled {
+		return nil, nil
+	}
+	destination := slackDestination(*setting)
+	return &destination, nil
+}
+
+// slackDestination 設定を送信先に変換
+func slackDestination(s models.SlackNotificationSetting) Destination {
+	return Destination{
+		UserID:      s.UserID,
+		User:        s.User,
+		ChannelType: models.ChannelTypeSlack,
+		Address:     s.WebhookURL,
+	}
+}
+
+func (n *slackNotifier) Render(destination Destination, report *Report) (*Message, error) {
+	l := destinationLocalizer(destination)
+	var message *gateway.SlackMessage
+	if report.Period == "weekly" {
+		message = gateway.BuildWeeklyReportMessage(l, report.Username, report.UserCommits, report.Rivals, report.RangeStart, report.RangeEnd)
+	} else {
+		message = gateway.BuildMonthlyReportMessage(
+			l,
+			report.Username,
+			gateway.MonthlyComparison{CurrentMonth: report.UserCommits, PreviousMonth: report.PreviousCommits},
+			report.Rivals,
+			report.RangeStart,
+		)
+	}
+
+	payload := models.JSONPayload{"blocks": message.Blocks}
+	if message.Text != "" {
+		payload["text"] = message.Text
+	}
+	return &Message{Body: message, Payload: payload}, nil
+}
+
+func (n *slackNotifier) RenderTest(destination Destination) (*Message, error) {
+	message := &gateway.SlackMessage{Text: TestMessageText(destinationLocalizer(destination))}
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text}}, nil
+}
+
+func (n *slackNotifier) RenderAlert(destination Destination, alert *Alert) (*Message, error) {
+	message := &gateway.SlackMessage{Text: AlertText(destinationLocalizer(destination), alert)}
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text}}, nil
+}
+
+func (n *slackNotifier) RenderNudge(destination Destination, nudge *Nudge) (*Message, error) {
+	message := &gateway.SlackMessage{Text: NudgeText(destinationLocalizer(destination), nudge)}
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text}}, nil
+}
+
+func (n *slackNotifier) Deliver(ctx context.Context, destination Destination, message *Message) error {
+	slackMessage, ok := message.Body.(*gateway.SlackMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type for slack: %T", message.Body)
+	}
+	if err := n.slackGateway.SendMessage(ctx, destination.Address, slackMessage); err != nil {
+		return fmt.Errorf("failed to send slack message: %w", err)
+	}
+	return nil
+}
+
+func (n *slackNotifier) Disable(ctx context.Context, userID uint64) error {
+	return n.slackRepo.UpdateEnabled(ctx, userID, false)
+}
@@ -0,0 +1,112 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+type teamsNotifier struct {
+	teamsRepo    repository.ITeamsNotificationSettingRepository
+	teamsGateway gateway.ITeamsGateway
+}
+
+// NewTeamsNotifier コンストラクタ
+func NewTeamsNotifier(teamsRepo repository.ITeamsNotificationSettingRepository, teamsGateway gateway.ITeamsGateway) INotifier {
+	return &teamsNotifier{
+		teamsRepo:    teamsRepo,
+		teamsGateway: teamsGateway,
+	}
+}
+
+func (n *teamsNotifier) ChannelType() models.ChannelType {
+	return models.ChannelTypeTeams
+}
+
+func (n *teamsNotifier) FindEnabledDestinations(ctx context.Context) ([]Destination, error) {
+	settings, err := n.teamsRepo.FindAllEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled Teams notification settings: %w", err)
+	}
+
+	destinations := make([]Destination, 0, len(settings))
+	for _, s := range settings {
+		destinations = append(destinations, teamsDestination(s))
+	}
+	return destinations, nil
+}
+
+func (n *teamsNotifier) FindDestination(ctx context.Context, userID uint64) (*Destination, error) {
+	setting, err := n.teamsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Teams notification setting: %w", err)
+	}
+	if setting == nil || !setting.IsEnabled {
+		return nil, nil
+	}
+	destination := teamsDestination(*setting)
+	return &destination, nil
+}
+
+// teamsDestination 設定を送信先に変換
+func teamsDestination(s models.TeamsNotificationSetting) Destination {
+	return Destination{
+		UserID:      s.UserID,
+		User:        s.User,
+		ChannelType: models.ChannelTypeTeams,
+		Address:     s.WebhookURL,
+	}
+}
+
+func (n *teamsNotifier) Render(destination Destination, report *Report) (*Message, error) {
+	l := destinationLocalizer(destination)
+	var message *gateway.TeamsMessage
+	if report.Period == "weekly" {
+		message = gateway.BuildWeeklyReportTeamsMessage(l, report.Username, report.UserCommits, report.Rivals, report.RangeStart, report.RangeEnd)
+	} else {
+		message = gateway.BuildMonthlyReportTeamsMessage(
+			l,
+			report.Username,
+			gateway.MonthlyComparison{CurrentMonth: report.UserCommits, PreviousMonth: report.PreviousCommits},
+			report.Rivals,
+			report.RangeStart,
+		)
+	}
+
+	return &Message{Body: message, Payload: models.JSONPayload{"attachments": message.Attachments}}, nil
+}
+
+func (n *teamsNotifier) RenderTest(destination Destination) (*Message, error) {
+	return teamsTextMessage(TestMessageText(destinationLocalizer(destination)))
+}
+
+func (n *teamsNotifier) RenderAlert(destination Destination, alert *Alert) (*Message, error) {
+	return teamsTextMessage(AlertText(destinationLocalizer(destination), alert))
+}
+
+func (n *teamsNotifier) RenderNudge(destination Destination, nudge *Nudge) (*Message, error) {
+	return teamsTextMessage(NudgeText(destinationLocalizer(destination), nudge))
+}
+
+func (n *teamsNotifier) Deliver(ctx context.Context, destination Destination, message *Message) error {
+	teamsMessage, ok := message.Body.(*gateway.TeamsMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type for teams: %T", message.Body)
+	}
+	if err := n.teamsGateway.SendMessage(ctx, destination.Address, teamsMessage); err != nil {
+		return fmt.Errorf("failed to send teams message: %w", err)
+	}
+	return nil
+}
+
+func (n *teamsNotifier) Disable(ctx context.Context, userID uint64) error {
+	return n.teamsRepo.UpdateEnabled(ctx, userID, false)
+}
+
+// teamsTextMessage テキストだけのAdaptive Cardのメッセージ（通知ログには本文を保存する）
+func teamsTextMessage(text string) (*Message, error) {
+	return &Message{Body: gateway.BuildTeamsTextMessage(text), Payload: models.JSONPayload{"text": text}}, nil
+}
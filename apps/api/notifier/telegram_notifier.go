@@ -0,0 +1,114 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+type telegramNotifier struct {
+	telegramRepo    repository.ITelegramNotificationSettingRepository
+	telegramGateway gateway.ITelegramGateway
+}
+
+// NewTelegramNotifier コンストラクタ
+func NewTelegramNotifier(telegramRepo repository.ITelegramNotificationSettingRepository, telegramGateway gateway.ITelegramGateway) INotifier {
+	return &telegramNotifier{
+		telegramRepo:    telegramRepo,
+		telegramGateway: telegramGateway,
+	}
+}
+
+func (n *telegramNotifier) ChannelType() models.ChannelType {
+	return models.ChannelTypeTelegram
+}
+
+func (n *telegramNotifier) FindEnabledDestinations(ctx context.Context) ([]Destination, error) {
+	settings, err := n.telegramRepo.FindAllEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled Telegram notification settings: %w", err)
+	}
+
+	destinations := make([]Destination, 0, len(settings))
+	for _, s := range settings {
+		destinations = append(destinations, telegramDestination(s))
+	}
+	return destinations, nil
+}
+
+func (n *telegramNotifier) FindDestination(ctx context.Context, userID uint64) (*Destination, error) {
+	setting, err := n.telegramRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Telegram notification setting: %w", err)
+	}
+	if setting == nil || !setting.IsEnabled {
+		return nil, nil
+	}
+	destination := telegramDestination(*setting)
+	return &destination, nil
+}
+
+// telegramDestination 設定を送信先に変換（チャットIDを宛先にする）
+func telegramDestination(s models.TelegramNotificationSetting) Destination {
+	return Destination{
+		UserID:      s.UserID,
+		User:        s.User,
+		ChannelType: models.ChannelTypeTelegram,
+		Address:     strconv.FormatInt(s.ChatID, 10),
+	}
+}
+
+func (n *telegramNotifier) Render(destination Destination, report *Report) (*Message, error) {
+	l := destinationLocalizer(destination)
+	var message *gateway.TelegramMessage
+	if report.Period == "weekly" {
+		message = gateway.BuildWeeklyReportTelegramMessage(l, report.Username, report.UserCommits, report.Rivals, report.RangeStart, report.RangeEnd)
+	} else {
+		message = gateway.BuildMonthlyReportTelegramMessage(
+			l,
+			report.Username,
+			gateway.MonthlyComparison{CurrentMonth: report.UserCommits, PreviousMonth: report.PreviousCommits},
+			report.Rivals,
+			report.RangeStart,
+		)
+	}
+
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text, "parse_mode": message.ParseMode}}, nil
+}
+
+func (n *telegramNotifier) RenderTest(destination Destination) (*Message, error) {
+	return telegramTextMessage(TestMessageText(destinationLocalizer(destination)))
+}
+
+func (n *telegramNotifier) RenderAlert(destination Destination, alert *Alert) (*Message, error) {
+	return telegramTextMessage(AlertText(destinationLocalizer(destination), alert))
+}
+
+func (n *telegramNotifier) RenderNudge(destination Destination, nudge *Nudge) (*Message, error) {
+	return telegramTextMessage(NudgeText(destinationLocalizer(destination), nudge))
+}
+
+func (n *telegramNotifier) Deliver(ctx context.Context, destination Destination, message *Message) error {
+	telegramMessage, ok := message.Body.(*gateway.TelegramMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type for telegram: %T", message.Body)
+	}
+	if err := n.telegramGateway.SendMessage(ctx, destination.Address, telegramMessage); err != nil {
+		return fmt.Errorf("failed to send telegram message: %w", err)
+	}
+	return nil
+}
+
+func (n *telegramNotifier) Disable(ctx context.Context, userID uint64) error {
+	return n.telegramRepo.UpdateEnabled(ctx, userID, false)
+}
+
+// telegramTextMessage プレーンテキストのメッセージ
+func telegramTextMessage(text string) (*Message, error) {
+	message := gateway.BuildTelegramTextMessage(text)
+	return &Message{Body: message, Payload: models.JSONPayload{"text": message.Text}}, nil
+}
@@ -0,0 +1,162 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+type webhookNotifier struct {
+	webhookRepo    repository.IWebhookNotificationSettingRepository
+	webhookGateway gateway.IWebhookGateway
+}
+
+// NewWebhookNotifier コンストラクタ
+func NewWebhookNotifier(webhookRepo repository.IWebhookNotificationSettingRepository, webhookGateway gateway.IWebhookGateway) INotifier {
+	return &webhookNotifier{
+		webhookRepo:    webhookRepo,
+		webhookGateway: webhookGateway,
+	}
+}
+
+func (n *webhookNotifier) ChannelType() models.ChannelType {
+	return models.ChannelTypeWebhook
+}
+
+func (n *webhookNotifier) FindEnabledDestinations(ctx context.Context) ([]Destination, error) {
+	settings, err := n.webhookRepo.FindAllEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get enabled webhook notification settings: %w", err)
+	}
+
+	destinations := make([]Destination, 0, len(settings))
+	for _, s := range settings {
+		destinations = append(destinations, webhookDestination(s))
+	}
+	return destinations, nil
+}
+
+func (n *webhookNotifier) FindDestination(ctx context.Context, userID uint64) (*Destination, error) {
+	setting, err := n.webhookRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook notification setting: %w", err)
+	}
+	if setting == nil || !setting.IsEnabled {
+		return nil, nil
+	}
+	destination := webhookDestination(*setting)
+	return &destination, nil
+}
+
+// webhookDestination 設定を送信先に変換
+func webhookDestination(s models.WebhookNotificationSetting) Destination {
+	return Destination{
+		UserID:      s.UserID,
+		User:        s.User,
+		ChannelType: models.ChannelTypeWebhook,
+		Address:     s.URL,
+		Secret:      s.Secret,
+	}
+}
+
+func (n *webhookNotifier) Render(destination Destination, report *Report) (*Message, error) {
+	document := &gateway.WebhookReportDocument{
+		Version:     gateway.WebhookReportVersion,
+		Event:       "report." + report.Period,
+		Period:      report.Period,
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		User:        gateway.WebhookReportUser{GithubUsername: report.Username},
+		Range: gateway.WebhookReportRange{
+			Start: report.RangeStart.Format("2006-01-02"),
+			End:   report.RangeEnd.Format("2006-01-02"),
+		},
+		Commits: report.UserCommits,
+		Rivals:  make([]gateway.WebhookReportRival, 0, len(report.Rivals)),
+	}
+	if report.Period == "monthly" {
+		previous := report.PreviousCommits
+		document.PreviousCommits = &previous
+	}
+	for _, rival := range report.Rivals {
+		document.Rivals = append(document.Rivals, gateway.WebhookReportRival{
+			GithubUsername: rival.Username,
+			Commits:        rival.Commits,
+		})
+	}
+
+	return webhookMessage(document)
+}
+
+func (n *webhookNotifier) RenderTest(destination Destination) (*Message, error) {
+	return webhookMessage(&gateway.WebhookReportDocument{
+		Version:     gateway.WebhookReportVersion,
+		Event:       gateway.WebhookTestEvent,
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		User:        gateway.WebhookReportUser{GithubUsername: destination.User.GithubUsername},
+		Rivals:      []gateway.WebhookReportRival{},
+		Message:     TestMessageText(destinationLocalizer(destination)),
+	})
+}
+
+func (n *webhookNotifier) RenderAlert(destination Destination, alert *Alert) (*Message, error) {
+	return webhookMessage(&gateway.WebhookReportDocument{
+		Version:     gateway.WebhookReportVersion,
+		Event:       gateway.WebhookRivalAlertEvent,
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		User:        gateway.WebhookReportUser{GithubUsername: alert.Username},
+		Range: gateway.WebhookReportRange{
+			Start: alert.Week.Start.Format("2006-01-02"),
+			End:   alert.Week.End.Format("2006-01-02"),
+		},
+		Commits: alert.UserCommits,
+		Rivals:  []gateway.WebhookReportRival{{GithubUsername: alert.Rival.Username, Commits: alert.Rival.Commits}},
+		Alert:   &gateway.WebhookAlert{Kind: string(alert.Kind), Rival: alert.Rival.Username, Streak: alert.Streak},
+		Message: AlertText(destinationLocalizer(destination), alert),
+	})
+}
+
+func (n *webhookNotifier) RenderNudge(destination Destination, nudge *Nudge) (*Message, error) {
+	day := nudge.Date.Format("2006-01-02")
+	return webhookMessage(&gateway.WebhookReportDocument{
+		Version:     gateway.WebhookReportVersion,
+		Event:       gateway.WebhookDailyNudgeEvent,
+		GeneratedAt: time.Now().UTC().Truncate(time.Second),
+		User:        gateway.WebhookReportUser{GithubUsername: nudge.Username},
+		Range:       gateway.WebhookReportRange{Start: day, End: day},
+		Message:     NudgeText(destinationLocalizer(destination), nudge),
+	})
+}
+
+// webhookMessage ドキュメントをメッセージに変換（通知ログには送信したドキュメントをそのまま保存する）
+func webhookMessage(document *gateway.WebhookReportDocument) (*Message, error) {
+	raw, err := json.Marshal(document)
+	if err != nil {
+		return nil, err
+	}
+	var payload models.JSONPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, err
+	}
+
+	return &Message{Body: document, Payload: payload}, nil
+}
+
+func (n *webhookNotifier) Deliver(ctx context.Context, destination Destination, message *Message) error {
+	document, ok := message.Body.(*gateway.WebhookReportDocument)
+	if !ok {
+		return fmt.Errorf("unexpected message type for webhook: %T", message.Body)
+	}
+	if err := n.webhookGateway.SendReport(ctx, destination.Address, destination.Secret, document); err != nil {
+		return fmt.Errorf("failed to send webhook: %w", err)
+	}
+	return nil
+}
+
+func (n *webhookNotifier) Disable(ctx context.Context, userID uint64) error {
+	return n.webhookRepo.UpdateEnabled(ctx, userID, false)
+}
@@ -0,0 +1,98 @@
+package notifier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockWebhookGateway struct {
+	SendReportFunc func(ctx context.Context, url, secret string, document *gateway.WebhookReportDocument) error
+}
+
+func (m *mockWebhookGateway) SendReport(ctx context.Context, url, secret string, document *gateway.WebhookReportDocument) error {
+	if m.SendReportFunc != nil {
+		return m.SendReportFunc(ctx, url, secret, document)
+	}
+	return nil
+}
+
+func TestWebhookNotifier_RenderMonthly(t *testing.T) {
+	n := NewWebhookNotifier(nil, &mockWebhookGateway{})
+
+	message, err := n.Render(Destination{}, &Report{
+		Period:          "monthly",
+		Username:        "user1",
+		UserCommits:     20,
+		PreviousCommits: 15,
+		Rivals:          []gateway.RivalCommitSummary{{Username: "rival1", Commits: 30}},
+		RangeStart:      time.Date(2026, 9, 1, 0, 0, 0, 0, time.Local),
+		RangeEnd:        time.Date(2026, 9, 30, 0, 0, 0, 0, time.Local),
+	})
+
+	assert.NoError(t, err)
+	document := message.Body.(*gateway.WebhookReportDocument)
+	assert.Equal(t, gateway.WebhookReportVersion, document.Version)
+	assert.Equal(t, "report.monthly", document.Event)
+	assert.Equal(t, "2026-09-01", document.Range.Start)
+	assert.Equal(t, "2026-09-30", document.Range.End)
+	assert.Equal(t, 15, *document.PreviousCommits)
+	assert.Equal(t, []gateway.WebhookReportRival{{GithubUsername: "rival1", Commits: 30}}, document.Rivals)
+
+	assert.Equal(t, "1", message.Payload["version"])
+	assert.Equal(t, float64(20), message.Payload["commits"])
+}
+
+func TestWebhookNotifier_RenderWeeklyOmitsPreviousCommits(t *testing.T) {
+	n := NewWebhookNotifier(nil, &mockWebhookGateway{})
+
+	message, err := n.Render(Destination{}, &Report{Period: "weekly", Username: "user1", UserCommits: 3})
+
+	assert.NoError(t, err)
+	assert.Nil(t, message.Body.(*gateway.WebhookReportDocument).PreviousCommits)
+	assert.NotContains(t, message.Payload, "previous_commits")
+	assert.Equal(t, []interface{}{}, message.Payload["rivals"])
+}
+
+func TestWebhookNotifier_DeliverUsesDestinationSecret(t *testing.T) {
+	var gotURL, gotSecret string
+	n := NewWebhookNotifier(nil, &mockWebhookGateway{
+		SendReportFunc: func(ctx context.Context, url, secret string, document *gateway.WebhookReportDocument) error {
+			gotURL = url
+			gotSecret = secret
+			return nil
+		},
+	})
+
+	message, err := n.Render(Destination{}, &Report{Period: "weekly", Username: "user1"})
+	assert.NoError(t, err)
+
+	err = n.Deliver(context.Background(), Destination{
+		UserID:      1,
+		ChannelType: models.ChannelTypeWebhook,
+		Address:     "https://n8n.example.com/webhook/abc",
+		Secret:      "whsec_abc",
+	}, message)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://n8n.example.com/webhook/abc", gotURL)
+	assert.Equal(t, "whsec_abc", gotSecret)
+}
+
+func TestWebhookNotifier_RenderTest(t *testing.T) {
+	n := NewWebhookNotifier(nil, &mockWebhookGateway{})
+
+	message, err := n.RenderTest(Destination{User: models.User{GithubUsername: "user1", Locale: "en"}})
+
+	assert.NoError(t, err)
+	document := message.Body.(*gateway.WebhookReportDocument)
+	assert.Equal(t, gateway.WebhookTestEvent, document.Event)
+	assert.Equal(t, "user1", document.User.GithubUsername)
+	assert.Equal(t, TestMessageText(i18n.For("en")), message.Payload["message"])
+	assert.NotContains(t, message.Payload, "previous_commits")
+}
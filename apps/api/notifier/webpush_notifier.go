@@ -0,0 +1,163 @@
+package notifier
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+type webPushNotifier struct {
+	subscriptionRepo repository.IWebPushSubscriptionRepository
+	webPushGateway   gateway.IWebPushGateway
+}
+
+// NewWebPushNotifier コンストラクタ
+func NewWebPushNotifier(subscriptionRepo repository.IWebPushSubscriptionRepository, webPushGateway gateway.IWebPushGateway) INotifier {
+	return &webPushNotifier{
+		subscriptionRepo: subscriptionRepo,
+		webPushGateway:   webPushGateway,
+	}
+}
+
+func (n *webPushNotifier) ChannelType() models.ChannelType {
+	return models.ChannelTypeWebPush
+}
+
+// FindEnabledDestinations 購読している端末が1つ以上あるユーザーを送信先にする
+func (n *webPushNotifier) FindEnabledDestinations(ctx context.Context) ([]Destination, error) {
+	subscriptions, err := n.subscriptionRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get web push subscriptions: %w", err)
+	}
+
+	// FindAll はユーザーID順なので、連続する購読をまとめる
+	destinations := make([]Destination, 0)
+	for _, s := range subscriptions {
+		if len(destinations) > 0 && destinations[len(destinations)-1].UserID == s.UserID {
+			continue
+		}
+		destinations = append(destinations, webPushDestination(s))
+	}
+	return destinations, nil
+}
+
+func (n *webPushNotifier) FindDestination(ctx context.Context, userID uint64) (*Destination, error) {
+	subscriptions, err := n.subscriptionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get web push subscriptions: %w", err)
+	}
+	if len(subscriptions) == 0 {
+		return nil, nil
+	}
+	destination := webPushDestination(subscriptions[0])
+	return &destination, nil
+}
+
+// webPushDestination 購読をユーザー単位の送信先に変換（端末ごとの宛先は配信時に取得する）
+func webPushDestination(s models.WebPushSubscription) Destination {
+	return Destination{
+		UserID:      s.UserID,
+		User:        s.User,
+		ChannelType: models.ChannelTypeWebPush,
+	}
+}
+
+func (n *webPushNotifier) Render(destination Destination, report *Report) (*Message, error) {
+	l := destinationLocalizer(destination)
+	var message *gateway.WebPushMessage
+	if report.Period == "weekly" {
+		message = gateway.BuildWeeklyReportWebPushMessage(l, report.Username, report.UserCommits, report.Rivals, report.RangeStart, report.RangeEnd)
+	} else {
+		message = gateway.BuildMonthlyReportWebPushMessage(
+			l,
+			report.Username,
+			gateway.MonthlyComparison{CurrentMonth: report.UserCommits, PreviousMonth: report.PreviousCommits},
+			report.Rivals,
+			report.RangeStart,
+		)
+	}
+	return webPushMessage(message), nil
+}
+
+func (n *webPushNotifier) RenderTest(destination Destination) (*Message, error) {
+	l := destinationLocalizer(destination)
+	return webPushMessage(&gateway.WebPushMessage{
+		Title: l.T("test.email_subject", nil),
+		Body:  TestMessageText(l),
+		URL:   gateway.WebPushSettingsPath,
+		Tag:   "test",
+	}), nil
+}
+
+func (n *webPushNotifier) RenderAlert(destination Destination, alert *Alert) (*Message, error) {
+	l := destinationLocalizer(destination)
+	return webPushMessage(&gateway.WebPushMessage{
+		Title: l.T("alert.email_subject", nil),
+		Body:  AlertText(l, alert),
+		URL:   gateway.WebPushRivalsPath,
+		Tag:   "alert",
+	}), nil
+}
+
+func (n *webPushNotifier) RenderNudge(destination Destination, nudge *Nudge) (*Message, error) {
+	l := destinationLocalizer(destination)
+	return webPushMessage(&gateway.WebPushMessage{
+		Title: l.T("nudge.email_subject", nil),
+		Body:  NudgeText(l, nudge),
+		URL:   gateway.WebPushDashboardPath,
+		Tag:   "nudge",
+	}), nil
+}
+
+// Deliver ユーザーが購読しているすべての端末に送る
+// 解除・失効した購読（404 / 410）は削除し、1台にでも届けば成功とする
+func (n *webPushNotifier) Deliver(ctx context.Context, destination Destination, message *Message) error {
+	webPushMessage, ok := message.Body.(*gateway.WebPushMessage)
+	if !ok {
+		return fmt.Errorf("unexpected message type for web push: %T", message.Body)
+	}
+
+	subscriptions, err := n.subscriptionRepo.FindByUserID(ctx, destination.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get web push subscriptions: %w", err)
+	}
+
+	delivered := false
+	var lastErr error
+	for _, s := range subscriptions {
+		err := n.webPushGateway.Send(ctx, gateway.WebPushTarget{Endpoint: s.Endpoint, P256dh: s.P256dh, Auth: s.Auth}, webPushMessage)
+		if err == nil {
+			delivered = true
+			continue
+		}
+		if gateway.IsWebPushSubscriptionGone(err) {
+			if err := n.subscriptionRepo.DeleteByID(ctx, s.ID); err != nil {
+				return fmt.Errorf("failed to delete expired web push subscription: %w", err)
+			}
+			continue
+		}
+		lastErr = err
+	}
+
+	if delivered {
+		return nil
+	}
+	if lastErr != nil {
+		return fmt.Errorf("failed to send web push message: %w", lastErr)
+	}
+	// すべての購読が失効していた場合は恒久的な失敗として扱う
+	return fmt.Errorf("no active web push subscriptions: %w", &gateway.HTTPStatusError{Target: "web push", StatusCode: http.StatusGone})
+}
+
+func (n *webPushNotifier) Disable(ctx context.Context, userID uint64) error {
+	return n.subscriptionRepo.DeleteByUserID(ctx, userID)
+}
+
+// webPushMessage 通知ログには表示される内容だけを残す
+func webPushMessage(message *gateway.WebPushMessage) *Message {
+	return &Message{Body: message, Payload: models.JSONPayload{"title": message.Title, "body": message.Body, "url": message.URL}}
+}
@@ -0,0 +1,137 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type mockWebPushSubscriptionRepository struct {
+	subscriptions []models.WebPushSubscription
+	deletedIDs    []uint64
+	deletedUserID uint64
+}
+
+func (m *mockWebPushSubscriptionRepository) FindByUserID(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error) {
+	var subscriptions []models.WebPushSubscription
+	for _, s := range m.subscriptions {
+		if s.UserID == userID {
+			subscriptions = append(subscriptions, s)
+		}
+	}
+	return subscriptions, nil
+}
+
+func (m *mockWebPushSubscriptionRepository) FindAll(ctx context.Context) ([]models.WebPushSubscription, error) {
+	return m.subscriptions, nil
+}
+
+func (m *mockWebPushSubscriptionRepository) Upsert(ctx context.Context, subscription *models.WebPushSubscription) error {
+	return nil
+}
+
+func (m *mockWebPushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, userID uint64, endpoint string) error {
+	return nil
+}
+
+func (m *mockWebPushSubscriptionRepository) DeleteByID(ctx context.Context, id uint64) error {
+	m.deletedIDs = append(m.deletedIDs, id)
+	return nil
+}
+
+func (m *mockWebPushSubscriptionRepository) DeleteByUserID(ctx context.Context, userID uint64) error {
+	m.deletedUserID = userID
+	return nil
+}
+
+type mockWebPushGateway struct {
+	SendFunc func(ctx context.Context, subscription gateway.WebPushTarget, message *gateway.WebPushMessage) error
+}
+
+func (m *mockWebPushGateway) Send(ctx context.Context, subscription gateway.WebPushTarget, message *gateway.WebPushMessage) error {
+	if m.SendFunc != nil {
+		return m.SendFunc(ctx, subscription, message)
+	}
+	return nil
+}
+
+func TestWebPushNotifier_FindEnabledDestinationsGroupsByUser(t *testing.T) {
+	repo := &mockWebPushSubscriptionRepository{subscriptions: []models.WebPushSubscription{
+		{ID: 1, UserID: 1, Endpoint: "https://push.example.com/a"},
+		{ID: 2, UserID: 1, Endpoint: "https://push.example.com/b"},
+		{ID: 3, UserID: 2, Endpoint: "https://push.example.com/c"},
+	}}
+	n := NewWebPushNotifier(repo, &mockWebPushGateway{})
+
+	destinations, err := n.FindEnabledDestinations(context.Background())
+
+	assert.NoError(t, err)
+	if assert.Len(t, destinations, 2) {
+		assert.Equal(t, uint64(1), destinations[0].UserID)
+		assert.Equal(t, uint64(2), destinations[1].UserID)
+		assert.Equal(t, models.ChannelTypeWebPush, destinations[0].ChannelType)
+	}
+
+	destination, err := n.FindDestination(context.Background(), 3)
+	assert.NoError(t, err)
+	assert.Nil(t, destination)
+}
+
+func TestWebPushNotifier_DeliverFansOutAndCleansUpExpired(t *testing.T) {
+	repo := &mockWebPushSubscriptionRepository{subscriptions: []models.WebPushSubscription{
+		{ID: 1, UserID: 1, Endpoint: "https://push.example.com/expired"},
+		{ID: 2, UserID: 1, Endpoint: "https://push.example.com/ok", P256dh: "p256dh", Auth: "auth"},
+	}}
+	var sent []gateway.WebPushTarget
+	n := NewWebPushNotifier(repo, &mockWebPushGateway{
+		SendFunc: func(ctx context.Context, subscription gateway.WebPushTarget, message *gateway.WebPushMessage) error {
+			if subscription.Endpoint == "https://push.example.com/expired" {
+				return &gateway.HTTPStatusError{Target: "web push", StatusCode: 404}
+			}
+			sent = append(sent, subscription)
+			return nil
+		},
+	})
+
+	message, err := n.RenderTest(Destination{UserID: 1})
+	assert.NoError(t, err)
+	err = n.Deliver(context.Background(), Destination{UserID: 1}, message)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []gateway.WebPushTarget{{Endpoint: "https://push.example.com/ok", P256dh: "p256dh", Auth: "auth"}}, sent)
+	assert.Equal(t, []uint64{1}, repo.deletedIDs)
+}
+
+func TestWebPushNotifier_DeliverFailures(t *testing.T) {
+	repo := &mockWebPushSubscriptionRepository{subscriptions: []models.WebPushSubscription{
+		{ID: 1, UserID: 1, Endpoint: "https://push.example.com/a"},
+		{ID: 2, UserID: 1, Endpoint: "https://push.example.com/b"},
+	}}
+	n := NewWebPushNotifier(repo, &mockWebPushGateway{
+		SendFunc: func(ctx context.Context, subscription gateway.WebPushTarget, message *gateway.WebPushMessage) error {
+			if subscription.Endpoint == "https://push.example.com/a" {
+				return &gateway.HTTPStatusError{Target: "web push", StatusCode: 410}
+			}
+			return errors.New("connection reset")
+		},
+	})
+	message, _ := n.RenderNudge(Destination{UserID: 1}, &Nudge{})
+
+	// 失効していない購読への送信エラーはリトライ対象
+	err := n.Deliver(context.Background(), Destination{UserID: 1}, message)
+	assert.ErrorContains(t, err, "connection reset")
+	assert.Equal(t, FailureKindRetryable, ClassifyFailure(err))
+
+	// すべての購読が失効していた場合は恒久的な失敗
+	repo.subscriptions = repo.subscriptions[:1]
+	err = n.Deliver(context.Background(), Destination{UserID: 1}, message)
+	assert.Error(t, err)
+	assert.Equal(t, FailureKindPermanent, ClassifyFailure(err))
+
+	assert.NoError(t, n.Disable(context.Background(), 1))
+	assert.Equal(t, uint64(1), repo.deletedUserID)
+}
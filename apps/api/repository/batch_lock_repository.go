@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"hash/fnv"
+	"log"
+
+	"gorm.io/gorm"
+)
+
+// batchLockNamespace アドバイザリロックのキーを他用途と衝突させないための接頭辞
+const batchLockNamespace = "commitly:batch:"
+
+// IBatchLockRepository バッチの多重実行を防ぐロックのリポジトリのインターフェース
+// ロックはDBセッションに紐づくため、プロセスが落ちた場合も自動的に解放される
+type IBatchLockRepository interface {
+	// TryLock ロックの取得を試みる。他で保持されている場合は acquired=false を返す
+	TryLock(ctx context.Context, name string) (unlock func(), acquired bool, err error)
+	// Lock ロックが解放されるまで待って取得する
+	Lock(ctx context.Context, name string) (unlock func(), err error)
+}
+
+type batchLockRepository struct {
+	db *gorm.DB
+}
+
+// NewBatchLockRepository コンストラクタ
+func NewBatchLockRepository(db *gorm.DB) IBatchLockRepository {
+	return &batchLockRepository{db: db}
+}
+
+func (r *batchLockRepository) TryLock(ctx context.Context, name string) (func(), bool, error) {
+	conn, err := r.conn(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	key := batchLockKey(name)
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", key).Scan(&acquired); err != nil {
+		conn.Close()
+		return nil, false, err
+	}
+	if !acquired {
+		conn.Close()
+		return nil, false, nil
+	}
+	return unlockFunc(conn, key), true, nil
+}
+
+func (r *batchLockRepository) Lock(ctx context.Context, name string) (func(), error) {
+	conn, err := r.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	key := batchLockKey(name)
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", key); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return unlockFunc(conn, key), nil
+}
+
+// conn セッション単位のロックを同じ接続で解放できるよう、接続を専有する
+func (r *batchLockRepository) conn(ctx context.Context) (*sql.Conn, error) {
+	sqlDB, err := r.db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return sqlDB.Conn(ctx)
+}
+
+func unlockFunc(conn *sql.Conn, key int64) func() {
+	return func() {
+		if _, err := conn.ExecContext(context.Background(), "SELECT pg_advisory_unlock($1)", key); err != nil {
+			log.Printf("Failed to release advisory lock: %v", err)
+			// Close はセッションをプールに戻すだけでロックは残るため、物理接続ごと破棄してセッションを終わらせる
+			conn.Raw(func(any) error {
+				return driver.ErrBadConn
+			})
+			return
+		}
+		conn.Close()
+	}
+}
+
+// batchLockKey ロック名から pg_advisory_lock 用の64bitキーを生成する
+func batchLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(batchLockNamespace + name))
+	return int64(h.Sum64())
+}
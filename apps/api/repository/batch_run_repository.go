@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+)
+
+// IBatchRunRepository バッチ実行履歴リポジトリのインターフェース
+type IBatchRunRepository interface {
+	Create(ctx context.Context, run *models.BatchRun) error
+	Update(ctx context.Context, run *models.BatchRun) error
+	FindRecent(ctx context.Context, limit int) ([]models.BatchRun, error)
+	FindLatest(ctx context.Context, command string, args map[string]string) (*models.BatchRun, error)
+}
+
+type batchRunRepository struct {
+	db *gorm.DB
+}
+
+// NewBatchRunRepository コンストラクタ
+func NewBatchRunRepository(db *gorm.DB) IBatchRunRepository {
+	return &batchRunRepository{db: db}
+}
+
+func (r *batchRunRepository) Create(ctx context.Context, run *models.BatchRun) error {
+	return r.db.WithContext(ctx).Create(run).Error
+}
+
+func (r *batchRunRepository) Update(ctx context.Context, run *models.BatchRun) error {
+	return r.db.WithContext(ctx).Save(run).Error
+}
+
+func (r *batchRunRepository) FindRecent(ctx context.Context, limit int) ([]models.BatchRun, error) {
+	var runs []models.BatchRun
+	query := r.db.WithContext(ctx).Order("started_at DESC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// FindLatest コマンドと引数（部分一致）が一致する直近の実行履歴を取得する
+func (r *batchRunRepository) FindLatest(ctx context.Context, command string, args map[string]string) (*models.BatchRun, error) {
+	var run models.BatchRun
+	query := r.db.WithContext(ctx).Where("command = ?", command)
+
+	if len(args) > 0 {
+		payload := models.JSONPayload{}
+		for k, v := range args {
+			payload[k] = v
+		}
+		query = query.Where("args @> ?", payload)
+	}
+
+	err := query.Order("started_at DESC").First(&run).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &run, nil
+}
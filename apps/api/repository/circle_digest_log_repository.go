@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+)
+
+// ICircleDigestLogRepository サークルのダイジェスト配信ログリポジトリのインターフェース
+type ICircleDigestLogRepository interface {
+	FindByPeriodStart(ctx context.Context, circleID uint64, periodStart time.Time) (*models.CircleDigestLog, error)
+	Create(ctx context.Context, digestLog *models.CircleDigestLog) error
+	Update(ctx context.Context, digestLog *models.CircleDigestLog) error
+}
+
+type circleDigestLogRepository struct {
+	db *gorm.DB
+}
+
+// NewCircleDigestLogRepository コンストラクタ
+func NewCircleDigestLogRepository(db *gorm.DB) ICircleDigestLogRepository {
+	return &circleDigestLogRepository{db: db}
+}
+
+func (r *circleDigestLogRepository) FindByPeriodStart(ctx context.Context, circleID uint64, periodStart time.Time) (*models.CircleDigestLog, error) {
+	var digestLog models.CircleDigestLog
+	err := r.db.WithContext(ctx).
+		Where("circle_id = ? AND period_start = ?", circleID, periodStart.Format("2006-01-02")).
+		First(&digestLog).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &digestLog, nil
+}
+
+func (r *circleDigestLogRepository) Create(ctx context.Context, digestLog *models.CircleDigestLog) error {
+	return r.db.WithContext(ctx).Omit("Circle").Create(digestLog).Error
+}
+
+func (r *circleDigestLogRepository) Update(ctx context.Context, digestLog *models.CircleDigestLog) error {
+	return r.db.WithContext(ctx).Omit("Circle").Save(digestLog).Error
+}
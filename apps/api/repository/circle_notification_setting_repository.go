@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+)
+
+// ICircleNotificationSettingRepository サークルのダイジェスト配信設定リポジトリのインターフェース
+type ICircleNotificationSettingRepository interface {
+	FindByCircleID(ctx context.Context, circleID uint64) (*models.CircleNotificationSetting, error)
+	FindAllEnabled(ctx context.Context) ([]models.CircleNotificationSetting, error)
+	Upsert(ctx context.Context, setting *models.CircleNotificationSetting) error
+	Delete(ctx context.Context, circleID uint64) error
+}
+
+type circleNotificationSettingRepository struct {
+	db *gorm.DB
+}
+
+// NewCircleNotificationSettingRepository コンストラクタ
+func NewCircleNotificationSettingRepository(db *gorm.DB) ICircleNotificationSettingRepository {
+	return &circleNotificationSettingRepository{db: db}
+}
+
+func (r *circleNotificationSettingRepository) FindByCircleID(ctx context.Context, circleID uint64) (*models.CircleNotificationSetting, error) {
+	var setting models.CircleNotificationSetting
+	err := r.db.WithContext(ctx).Where("circle_id = ?", circleID).First(&setting).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *circleNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.CircleNotificationSetting, error) {
+	var settings []models.CircleNotificationSetting
+	err := r.db.WithContext(ctx).
+		Preload("Circle").Preload("Circle.Owner").
+		Preload("Circle.Members").Preload("Circle.Members.User").
+		Where("is_enabled = ?", true).
+		Find(&settings).Error
+	return settings, err
+}
+
+func (r *circleNotificationSettingRepository) Upsert(ctx context.Context, setting *models.CircleNotificationSetting) error {
+	return r.db.WithContext(ctx).Omit("Circle").Save(setting).Error
+}
+
+func (r *circleNotificationSettingRepository) Delete(ctx context.Context, circleID uint64) error {
+	return r.db.WithContext(ctx).Where("circle_id = ?", circleID).Delete(&models.CircleNotificationSetting{}).Error
+}
@@ -76,7 +76,7 @@ func (r *circleRepository) Create(ctx context.Context, circle *models.Circle) er
 }
 
 func (r *circleRepository) Delete(ctx context.Context, id uint64) error {
-	return r.db.WithContext(ctx).Select("Members").Delete(&models.Circle{ID: id}).Error
+	return r.db.WithContext(ctx).Select("Members", "NotificationSetting").Delete(&models.Circle{ID: id}).Error
 }
 
 func (r *circleRepository) AddMember(ctx context.Context, member *models.CircleMember) error {
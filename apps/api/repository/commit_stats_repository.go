@@ -19,6 +19,9 @@ type ICommitStatsRepository interface {
 	Upsert(ctx context.Context, stats *models.CommitStats) error
 	// バッチでコミット統計を保存
 	UpsertBatch(ctx context.Context, statsList []models.CommitStats) error
+	// 期間内の既存統計の読み取り・古い統計の削除・最新統計の保存を1トランザクションで行う
+	// selectDeletes はロックした既存統計を受け取り、削除する統計のIDを返す
+	ApplyReconciliation(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error
 }
 
 type commitStatsRepository struct {
@@ -68,3 +71,30 @@ func (r *commitStatsRepository) UpsertBatch(ctx context.Context, statsList []mod
 		DoUpdates: clause.AssignmentColumns([]string{"commit_count", "primary_hour", "language", "fetched_at"}),
 	}).Create(&statsList).Error
 }
+
+func (r *commitStatsRepository) ApplyReconciliation(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// 差分の計算から保存までの間に並行する同期が既存行を書き換えないようロックする
+		var existing []models.CommitStats
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("github_user_id = ? AND date >= ? AND date <= ?", githubUserID, startDate, endDate).
+			Order("date ASC, repository ASC").
+			Find(&existing).Error; err != nil {
+			return err
+		}
+
+		deleteIDs := selectDeletes(existing)
+		if len(deleteIDs) > 0 {
+			if err := tx.Where("id IN ?", deleteIDs).Delete(&models.CommitStats{}).Error; err != nil {
+				return err
+			}
+		}
+		if len(statsList) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "github_user_id"}, {Name: "date"}, {Name: "repository"}},
+			DoUpdates: clause.AssignmentColumns([]string{"commit_count", "primary_hour", "language", "fetched_at"}),
+		}).Create(&statsList).Error
+	})
+}
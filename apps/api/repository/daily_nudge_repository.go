@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IDailyNudgeRepository 「今日はまだコミットしていない」リマインダーのリポジトリのインターフェース
+type IDailyNudgeRepository interface {
+	FindChannelsByUserID(ctx context.Context, userID uint64) ([]models.DailyNudgeChannel, error)
+	FindAllChannels(ctx context.Context) ([]models.DailyNudgeChannel, error)
+	// ReplaceChannels ユーザーのオプトインしたチャンネルを置き換える
+	ReplaceChannels(ctx context.Context, userID uint64, channelTypes []models.ChannelType) error
+	FindLogByUserIDAndDate(ctx context.Context, userID uint64, date time.Time) (*models.DailyNudgeLog, error)
+	// CreateLogIfNotExists その日の記録がなければ作成する（作成した場合は true）
+	CreateLogIfNotExists(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error)
+	UpdateLogStatus(ctx context.Context, id uint64, status models.DailyNudgeStatus) error
+}
+
+type dailyNudgeRepository struct {
+	db *gorm.DB
+}
+
+// NewDailyNudgeRepository コンストラクタ
+func NewDailyNudgeRepository(db *gorm.DB) IDailyNudgeRepository {
+	return &dailyNudgeRepository{db: db}
+}
+
+func (r *dailyNudgeRepository) FindChannelsByUserID(ctx context.Context, userID uint64) ([]models.DailyNudgeChannel, error) {
+	var channels []models.DailyNudgeChannel
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Order("channel_type ASC").Find(&channels).Error
+	return channels, err
+}
+
+func (r *dailyNudgeRepository) FindAllChannels(ctx context.Context) ([]models.DailyNudgeChannel, error) {
+	var channels []models.DailyNudgeChannel
+	err := r.db.WithContext(ctx).Find(&channels).Error
+	return channels, err
+}
+
+func (r *dailyNudgeRepository) ReplaceChannels(ctx context.Context, userID uint64, channelTypes []models.ChannelType) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.DailyNudgeChannel{}).Error; err != nil {
+			return err
+		}
+		if len(channelTypes) == 0 {
+			return nil
+		}
+		channels := make([]models.DailyNudgeChannel, 0, len(channelTypes))
+		for _, channelType := range channelTypes {
+			channels = append(channels, models.DailyNudgeChannel{UserID: userID, ChannelType: channelType})
+		}
+		return tx.Create(&channels).Error
+	})
+}
+
+func (r *dailyNudgeRepository) FindLogByUserIDAndDate(ctx context.Context, userID uint64, date time.Time) (*models.DailyNudgeLog, error) {
+	var nudgeLog models.DailyNudgeLog
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND date = ?", userID, date.Format("2006-01-02")).
+		First(&nudgeLog).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &nudgeLog, nil
+}
+
+func (r *dailyNudgeRepository) CreateLogIfNotExists(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error) {
+	result := r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "date"}},
+		DoNothing: true,
+	}).Create(nudgeLog)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *dailyNudgeRepository) UpdateLogStatus(ctx context.Context, id uint64, status models.DailyNudgeStatus) error {
+	return r.db.WithContext(ctx).
+		Model(&models.DailyNudgeLog{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+)
+
+// IEmailNotificationSettingRepository メール通知設定リポジトリのインターフェース
+type IEmailNotificationSettingRepository interface {
+	FindByUserID(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error)
+	FindAllEnabled(ctx context.Context) ([]models.EmailNotificationSetting, error)
+	Upsert(ctx context.Context, setting *models.EmailNotificationSetting) error
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+}
+
+type emailNotificationSettingRepository struct {
+	db *gorm.DB
+}
+
+// NewEmailNotificationSettingRepository コンストラクタ
+func NewEmailNotificationSettingRepository(db *gorm.DB) IEmailNotificationSettingRepository {
+	return &emailNotificationSettingRepository{db: db}
+}
+
+func (r *emailNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error) {
+	var setting models.EmailNotificationSetting
+	err := r.db.WithContext(ctx).Preload("User").Where("user_id = ?", userID).First(&setting).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *emailNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.EmailNotificationSetting, error) {
+	var settings []models.EmailNotificationSetting
+	// 確認済みのアドレスのみ送信対象にする
+	err := r.db.WithContext(ctx).Preload("User").Where("is_enabled = ? AND verified_at IS NOT NULL", true).Find(&settings).Error
+	return settings, err
+}
+
+func (r *emailNotificationSettingRepository) Upsert(ctx context.Context, setting *models.EmailNotificationSetting) error {
+	return r.db.WithContext(ctx).Save(setting).Error
+}
+
+func (r *emailNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.EmailNotificationSetting{}).
+		Where("user_id = ?", userID).
+		Update("is_enabled", isEnabled).Error
+}
+
+func (r *emailNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.EmailNotificationSetting{}).Error
+}
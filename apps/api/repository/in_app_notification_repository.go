@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IInAppNotificationRepository アプリ内通知リポジトリのインターフェース
+type IInAppNotificationRepository interface {
+	// CreateIfNotExists 同じ重複キーの通知がなければ作成する（作成した場合は true）
+	CreateIfNotExists(ctx context.Context, notification *models.InAppNotification) (bool, error)
+	// FindByUserID 新しい順に取得する（beforeID が0以外の場合はそれより古い通知だけ）
+	FindByUserID(ctx context.Context, userID uint64, beforeID uint64, unreadOnly bool, limit int) ([]models.InAppNotification, error)
+	CountUnread(ctx context.Context, userID uint64) (int64, error)
+	// MarkRead 既読にする（ユーザーの通知が見つかった場合は true）
+	MarkRead(ctx context.Context, userID, id uint64, readAt time.Time) (bool, error)
+	MarkAllRead(ctx context.Context, userID uint64, readAt time.Time) error
+}
+
+type inAppNotificationRepository struct {
+	db *gorm.DB
+}
+
+// NewInAppNotificationRepository コンストラクタ
+func NewInAppNotificationRepository(db *gorm.DB) IInAppNotificationRepository {
+	return &inAppNotificationRepository{db: db}
+}
+
+func (r *inAppNotificationRepository) CreateIfNotExists(ctx context.Context, notification *models.InAppNotification) (bool, error) {
+	result := r.db.WithContext(ctx).Omit("User").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "dedupe_key"}},
+		DoNothing: true,
+	}).Create(notification)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *inAppNotificationRepository) FindByUserID(ctx context.Context, userID uint64, beforeID uint64, unreadOnly bool, limit int) ([]models.InAppNotification, error) {
+	query := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if beforeID > 0 {
+		query = query.Where("id < ?", beforeID)
+	}
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var notifications []models.InAppNotification
+	err := query.Order("id DESC").Limit(limit).Find(&notifications).Error
+	return notifications, err
+}
+
+func (r *inAppNotificationRepository) CountUnread(ctx context.Context, userID uint64) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.InAppNotification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *inAppNotificationRepository) MarkRead(ctx context.Context, userID, id uint64, readAt time.Time) (bool, error) {
+	// 既読の通知は最初に読んだ日時のままにする
+	result := r.db.WithContext(ctx).
+		Model(&models.InAppNotification{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", readAt))
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *inAppNotificationRepository) MarkAllRead(ctx context.Context, userID uint64, readAt time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.InAppNotification{}).
+		Where("user_id = ? AND read_at IS NULL", userID).
+		Update("read_at", readAt).Error
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ILineLinkCodeRepository LINE連携コードリポジトリのインターフェース
+type ILineLinkCodeRepository interface {
+	FindByCode(ctx context.Context, code string) (*models.LineLinkCode, error)
+	Upsert(ctx context.Context, linkCode *models.LineLinkCode) error
+	DeleteByLineUserID(ctx context.Context, lineUserID string) error
+}
+
+type lineLinkCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewLineLinkCodeRepository コンストラクタ
+func NewLineLinkCodeRepository(db *gorm.DB) ILineLinkCodeRepository {
+	return &lineLinkCodeRepository{db: db}
+}
+
+func (r *lineLinkCodeRepository) FindByCode(ctx context.Context, code string) (*models.LineLinkCode, error) {
+	var linkCode models.LineLinkCode
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&linkCode).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &linkCode, nil
+}
+
+// Upsert 同じLINEユーザーのコードは再発行で置き換える
+func (r *lineLinkCodeRepository) Upsert(ctx context.Context, linkCode *models.LineLinkCode) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "line_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"code", "expires_at"}),
+	}).Create(linkCode).Error
+}
+
+func (r *lineLinkCodeRepository) DeleteByLineUserID(ctx context.Context, lineUserID string) error {
+	return r.db.WithContext(ctx).Where("line_user_id = ?", lineUserID).Delete(&models.LineLinkCode{}).Error
+}
@@ -14,6 +14,7 @@ type ILineNotificationSettingRepository interface {
 	Upsert(ctx context.Context, setting *models.LineNotificationSetting) error
 	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
 	Delete(ctx context.Context, userID uint64) error
+	DisableByLineUserID(ctx context.Context, lineUserID string) error
 }
 
 type lineNotificationSettingRepository struct {
@@ -57,3 +58,10 @@ func (r *lineNotificationSettingRepository) UpdateEnabled(ctx context.Context, u
 func (r *lineNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
 	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.LineNotificationSetting{}).Error
 }
+
+func (r *lineNotificationSettingRepository) DisableByLineUserID(ctx context.Context, lineUserID string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.LineNotificationSetting{}).
+		Where("line_user_id = ?", lineUserID).
+		Update("is_enabled", false).Error
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+)
+
+// IMattermostNotificationSettingRepository Mattermost通知設定リポジトリのインターフェース
+type IMattermostNotificationSettingRepository interface {
+	FindByUserID(ctx context.Context, userID uint64) (*models.MattermostNotificationSetting, error)
+	FindAllEnabled(ctx context.Context) ([]models.MattermostNotificationSetting, error)
+	Upsert(ctx context.Context, setting *models.MattermostNotificationSetting) error
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+}
+
+type mattermostNotificationSettingRepository struct {
+	db *gorm.DB
+}
+
+// NewMattermostNotificationSettingRepository コンストラクタ
+func NewMattermostNotificationSettingRepository(db *gorm.DB) IMattermostNotificationSettingRepository {
+	return &mattermostNotificationSettingRepository{db: db}
+}
+
+func (r *mattermostNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.MattermostNotificationSetting, error) {
+	var setting models.MattermostNotificationSetting
+	err := r.db.WithContext(ctx).Preload("User").Where("user_id = ?", userID).First(&setting).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *mattermostNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.MattermostNotificationSetting, error) {
+	var settings []models.MattermostNotificationSetting
+	err := r.db.WithContext(ctx).Preload("User").Where("is_enabled = ?", true).Find(&settings).Error
+	return settings, err
+}
+
+func (r *mattermostNotificationSettingRepository) Upsert(ctx context.Context, setting *models.MattermostNotificationSetting) error {
+	return r.db.WithContext(ctx).Save(setting).Error
+}
+
+func (r *mattermostNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.MattermostNotificationSetting{}).
+		Where("user_id = ?", userID).
+		Update("is_enabled", isEnabled).Error
+}
+
+func (r *mattermostNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.MattermostNotificationSetting{}).Error
+}
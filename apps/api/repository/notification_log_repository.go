@@ -8,12 +8,23 @@ import (
 	"gorm.io/gorm"
 )
 
+// NotificationLogFilter 通知ログの絞り込み条件（空の項目では絞り込まない）
+type NotificationLogFilter struct {
+	ChannelType models.ChannelType
+	Period      string
+	Status      models.NotificationStatus
+}
+
 // INotificationLogRepository 通知ログリポジトリのインターフェース
 type INotificationLogRepository interface {
 	Create(ctx context.Context, log *models.NotificationLog) error
-	FindByUserID(ctx context.Context, userID uint64, limit int) ([]models.NotificationLog, error)
+	Update(ctx context.Context, log *models.NotificationLog) error
+	FindByID(ctx context.Context, id uint64) (*models.NotificationLog, error)
+	FindByUserID(ctx context.Context, userID uint64, filter NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error)
 	FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]models.NotificationLog, error)
-	FindByPeriodAndDate(ctx context.Context, period string, date time.Time) ([]models.NotificationLog, error)
+	FindByPeriodStart(ctx context.Context, period string, periodStart time.Time) ([]models.NotificationLog, error)
+	FindDueRetries(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error)
+	FindRecentByUserIDAndChannel(ctx context.Context, userID uint64, channelType models.ChannelType, limit int) ([]models.NotificationLog, error)
 }
 
 type notificationLogRepository struct {
@@ -29,20 +40,53 @@ func (r *notificationLogRepository) Create(ctx context.Context, log *models.Noti
 	return r.db.WithContext(ctx).Create(log).Error
 }
 
-func (r *notificationLogRepository) FindByUserID(ctx context.Context, userID uint64, limit int) ([]models.NotificationLog, error) {
-	var logs []models.NotificationLog
+func (r *notificationLogRepository) Update(ctx context.Context, log *models.NotificationLog) error {
+	// Preload したユーザーまで保存しないよう関連は除外する
+	return r.db.WithContext(ctx).Omit("User").Save(log).Error
+}
+
+func (r *notificationLogRepository) FindByID(ctx context.Context, id uint64) (*models.NotificationLog, error) {
+	var log models.NotificationLog
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&log).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &log, nil
+}
+
+// FindByUserID ユーザーの通知ログを新しい順に取得し、絞り込み条件に合う総件数も返す
+func (r *notificationLogRepository) FindByUserID(ctx context.Context, userID uint64, filter NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error) {
 	query := r.db.WithContext(ctx).
-		Where("user_id = ?", userID).
-		Order("sent_at DESC")
+		Model(&models.NotificationLog{}).
+		Where("user_id = ?", userID)
+
+	if filter.ChannelType != "" {
+		query = query.Where("channel_type = ?", filter.ChannelType)
+	}
+	if filter.Period != "" {
+		query = query.Where("period = ?", filter.Period)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
 
+	var logs []models.NotificationLog
+	query = query.Order("sent_at DESC, id DESC").Offset(offset)
 	if limit > 0 {
 		query = query.Limit(limit)
 	}
-
 	if err := query.Find(&logs).Error; err != nil {
-		return nil, err
+		return nil, 0, err
 	}
-	return logs, nil
+	return logs, total, nil
 }
 
 func (r *notificationLogRepository) FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]models.NotificationLog, error) {
@@ -56,14 +100,42 @@ func (r *notificationLogRepository) FindByDateRange(ctx context.Context, startDa
 	return logs, nil
 }
 
-func (r *notificationLogRepository) FindByPeriodAndDate(ctx context.Context, period string, date time.Time) ([]models.NotificationLog, error) {
-	startOfDay := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, date.Location())
-	endOfDay := startOfDay.Add(24 * time.Hour)
+// FindByPeriodStart 同じ集計期間の通知ログを取得（配信済みかどうかの判定に使う）
+func (r *notificationLogRepository) FindByPeriodStart(ctx context.Context, period string, periodStart time.Time) ([]models.NotificationLog, error) {
+	var logs []models.NotificationLog
+	if err := r.db.WithContext(ctx).
+		Where("period = ? AND period_start = ?", period, periodStart.Format("2006-01-02")).
+		Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// FindDueRetries 再送予定日時を過ぎた失敗ログ・遅らせたログを古い順に取得
+func (r *notificationLogRepository) FindDueRetries(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error) {
+	var logs []models.NotificationLog
+	query := r.db.WithContext(ctx).
+		Preload("User").
+		Where("status IN ? AND next_retry_at <= ?", []models.NotificationStatus{models.NotificationStatusFailed, models.NotificationStatusDeferred}, now).
+		Order("next_retry_at ASC")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
 
+// FindRecentByUserIDAndChannel ユーザー・チャンネルごとの直近のログを新しい順に取得
+func (r *notificationLogRepository) FindRecentByUserIDAndChannel(ctx context.Context, userID uint64, channelType models.ChannelType, limit int) ([]models.NotificationLog, error) {
 	var logs []models.NotificationLog
 	if err := r.db.WithContext(ctx).
-		Where("period = ? AND sent_at >= ? AND sent_at < ?", period, startOfDay, endOfDay).
-		Order("sent_at DESC").
+		Where("user_id = ? AND channel_type = ?", userID, channelType).
+		Order("updated_at DESC").
+		Limit(limit).
 		Find(&logs).Error; err != nil {
 		return nil, err
 	}
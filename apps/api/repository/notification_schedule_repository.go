@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+)
+
+// INotificationScheduleRepository 通知スケジュールリポジトリのインターフェース
+type INotificationScheduleRepository interface {
+	FindByUserID(ctx context.Context, userID uint64) (*models.NotificationSchedule, error)
+	FindByUserIDs(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error)
+	Upsert(ctx context.Context, schedule *models.NotificationSchedule) error
+}
+
+type notificationScheduleRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationScheduleRepository コンストラクタ
+func NewNotificationScheduleRepository(db *gorm.DB) INotificationScheduleRepository {
+	return &notificationScheduleRepository{db: db}
+}
+
+func (r *notificationScheduleRepository) FindByUserID(ctx context.Context, userID uint64) (*models.NotificationSchedule, error) {
+	var schedule models.NotificationSchedule
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&schedule).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &schedule, nil
+}
+
+func (r *notificationScheduleRepository) FindByUserIDs(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+	var schedules []models.NotificationSchedule
+	err := r.db.WithContext(ctx).Where("user_id IN ?", userIDs).Find(&schedules).Error
+	return schedules, err
+}
+
+func (r *notificationScheduleRepository) Upsert(ctx context.Context, schedule *models.NotificationSchedule) error {
+	return r.db.WithContext(ctx).Omit("User").Save(schedule).Error
+}
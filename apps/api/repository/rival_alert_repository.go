@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IRivalAlertRepository ライバルアラートリポジトリのインターフェース
+type IRivalAlertRepository interface {
+	// CreateIfNotExists 同じイベントのアラートがなければ作成する（作成した場合は true）
+	CreateIfNotExists(ctx context.Context, alert *models.RivalAlert) (bool, error)
+	UpdateStatus(ctx context.Context, id uint64, status models.RivalAlertStatus) error
+	CountSentSince(ctx context.Context, userID uint64, since time.Time) (int64, error)
+	FindStandingsByUserID(ctx context.Context, userID uint64) ([]models.RivalStanding, error)
+	UpsertStanding(ctx context.Context, standing *models.RivalStanding) error
+}
+
+type rivalAlertRepository struct {
+	db *gorm.DB
+}
+
+// NewRivalAlertRepository コンストラクタ
+func NewRivalAlertRepository(db *gorm.DB) IRivalAlertRepository {
+	return &rivalAlertRepository{db: db}
+}
+
+func (r *rivalAlertRepository) CreateIfNotExists(ctx context.Context, alert *models.RivalAlert) (bool, error) {
+	result := r.db.WithContext(ctx).Omit("User").Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "rival_github_user_id"}, {Name: "kind"}, {Name: "event_key"}},
+		DoNothing: true,
+	}).Create(alert)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *rivalAlertRepository) UpdateStatus(ctx context.Context, id uint64, status models.RivalAlertStatus) error {
+	return r.db.WithContext(ctx).
+		Model(&models.RivalAlert{}).
+		Where("id = ?", id).
+		Update("status", status).Error
+}
+
+func (r *rivalAlertRepository) CountSentSince(ctx context.Context, userID uint64, since time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).
+		Model(&models.RivalAlert{}).
+		Where("user_id = ? AND status = ? AND created_at >= ?", userID, models.RivalAlertStatusSent, since).
+		Count(&count).Error
+	return count, err
+}
+
+func (r *rivalAlertRepository) FindStandingsByUserID(ctx context.Context, userID uint64) ([]models.RivalStanding, error) {
+	var standings []models.RivalStanding
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&standings).Error
+	return standings, err
+}
+
+func (r *rivalAlertRepository) UpsertStanding(ctx context.Context, standing *models.RivalStanding) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}, {Name: "rival_github_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"week_start", "rival_ahead", "updated_at"}),
+	}).Create(standing).Error
+}
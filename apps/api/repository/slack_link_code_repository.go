@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ISlackLinkCodeRepository Slack連携コードリポジトリのインターフェース
+type ISlackLinkCodeRepository interface {
+	FindByCode(ctx context.Context, code string) (*models.SlackLinkCode, error)
+	Upsert(ctx context.Context, linkCode *models.SlackLinkCode) error
+	DeleteBySlackUser(ctx context.Context, slackTeamID, slackUserID string) error
+}
+
+type slackLinkCodeRepository struct {
+	db *gorm.DB
+}
+
+// NewSlackLinkCodeRepository コンストラクタ
+func NewSlackLinkCodeRepository(db *gorm.DB) ISlackLinkCodeRepository {
+	return &slackLinkCodeRepository{db: db}
+}
+
+func (r *slackLinkCodeRepository) FindByCode(ctx context.Context, code string) (*models.SlackLinkCode, error) {
+	var linkCode models.SlackLinkCode
+	err := r.db.WithContext(ctx).Where("code = ?", code).First(&linkCode).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &linkCode, nil
+}
+
+// Upsert 同じSlackユーザーのコードは再発行で置き換える
+func (r *slackLinkCodeRepository) Upsert(ctx context.Context, linkCode *models.SlackLinkCode) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "slack_team_id"}, {Name: "slack_user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"code", "expires_at"}),
+	}).Create(linkCode).Error
+}
+
+func (r *slackLinkCodeRepository) DeleteBySlackUser(ctx context.Context, slackTeamID, slackUserID string) error {
+	return r.db.WithContext(ctx).
+		Where("slack_team_id = ? AND slack_user_id = ?", slackTeamID, slackUserID).
+		Delete(&models.SlackLinkCode{}).Error
+}
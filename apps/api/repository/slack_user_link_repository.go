@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+)
+
+// ISlackUserLinkRepository Slackユーザー連携リポジトリのインターフェース
+type ISlackUserLinkRepository interface {
+	FindBySlackUser(ctx context.Context, slackTeamID, slackUserID string) (*models.SlackUserLink, error)
+	FindByUserID(ctx context.Context, userID uint64) (*models.SlackUserLink, error)
+	Replace(ctx context.Context, link *models.SlackUserLink) error
+	DeleteByUserID(ctx context.Context, userID uint64) error
+}
+
+type slackUserLinkRepository struct {
+	db *gorm.DB
+}
+
+// NewSlackUserLinkRepository コンストラクタ
+func NewSlackUserLinkRepository(db *gorm.DB) ISlackUserLinkRepository {
+	return &slackUserLinkRepository{db: db}
+}
+
+func (r *slackUserLinkRepository) FindBySlackUser(ctx context.Context, slackTeamID, slackUserID string) (*models.SlackUserLink, error) {
+	var link models.SlackUserLink
+	err := r.db.WithContext(ctx).Preload("User").
+		Where("slack_team_id = ? AND slack_user_id = ?", slackTeamID, slackUserID).
+		First(&link).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+func (r *slackUserLinkRepository) FindByUserID(ctx context.Context, userID uint64) (*models.SlackUserLink, error) {
+	var link models.SlackUserLink
+	err := r.db.WithContext(ctx).Where("user_id = ?", userID).First(&link).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &link, nil
+}
+
+// Replace 連携を作成する（同じユーザー、または同じSlackユーザーの既存の連携は置き換える）
+func (r *slackUserLinkRepository) Replace(ctx context.Context, link *models.SlackUserLink) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? OR (slack_team_id = ? AND slack_user_id = ?)", link.UserID, link.SlackTeamID, link.SlackUserID).
+			Delete(&models.SlackUserLink{}).Error; err != nil {
+			return err
+		}
+		return tx.Omit("User").Create(link).Error
+	})
+}
+
+func (r *slackUserLinkRepository) DeleteByUserID(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.SlackUserLink{}).Error
+}
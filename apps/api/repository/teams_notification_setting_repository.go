@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+)
+
+// ITeamsNotificationSettingRepository Microsoft Teams通知設定リポジトリのインターフェース
+type ITeamsNotificationSettingRepository interface {
+	FindByUserID(ctx context.Context, userID uint64) (*models.TeamsNotificationSetting, error)
+	FindAllEnabled(ctx context.Context) ([]models.TeamsNotificationSetting, error)
+	Upsert(ctx context.Context, setting *models.TeamsNotificationSetting) error
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+}
+
+type teamsNotificationSettingRepository struct {
+	db *gorm.DB
+}
+
+// NewTeamsNotificationSettingRepository コンストラクタ
+func NewTeamsNotificationSettingRepository(db *gorm.DB) ITeamsNotificationSettingRepository {
+	return &teamsNotificationSettingRepository{db: db}
+}
+
+func (r *teamsNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.TeamsNotificationSetting, error) {
+	var setting models.TeamsNotificationSetting
+	err := r.db.WithContext(ctx).Preload("User").Where("user_id = ?", userID).First(&setting).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *teamsNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.TeamsNotificationSetting, error) {
+	var settings []models.TeamsNotificationSetting
+	err := r.db.WithContext(ctx).Preload("User").Where("is_enabled = ?", true).Find(&settings).Error
+	return settings, err
+}
+
+func (r *teamsNotificationSettingRepository) Upsert(ctx context.Context, setting *models.TeamsNotificationSetting) error {
+	return r.db.WithContext(ctx).Save(setting).Error
+}
+
+func (r *teamsNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.TeamsNotificationSetting{}).
+		Where("user_id = ?", userID).
+		Update("is_enabled", isEnabled).Error
+}
+
+func (r *teamsNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.TeamsNotificationSetting{}).Error
+}
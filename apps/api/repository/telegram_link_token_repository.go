@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ITelegramLinkTokenRepository Telegram連携トークンリポジトリのインターフェース
+type ITelegramLinkTokenRepository interface {
+	FindByToken(ctx context.Context, token string) (*models.TelegramLinkToken, error)
+	Upsert(ctx context.Context, linkToken *models.TelegramLinkToken) error
+	DeleteByUserID(ctx context.Context, userID uint64) error
+}
+
+type telegramLinkTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewTelegramLinkTokenRepository コンストラクタ
+func NewTelegramLinkTokenRepository(db *gorm.DB) ITelegramLinkTokenRepository {
+	return &telegramLinkTokenRepository{db: db}
+}
+
+func (r *telegramLinkTokenRepository) FindByToken(ctx context.Context, token string) (*models.TelegramLinkToken, error) {
+	var linkToken models.TelegramLinkToken
+	err := r.db.WithContext(ctx).Where("token = ?", token).First(&linkToken).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &linkToken, nil
+}
+
+// Upsert 同じユーザーのトークンは再発行で置き換える
+func (r *telegramLinkTokenRepository) Upsert(ctx context.Context, linkToken *models.TelegramLinkToken) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token", "expires_at"}),
+	}).Create(linkToken).Error
+}
+
+func (r *telegramLinkTokenRepository) DeleteByUserID(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.TelegramLinkToken{}).Error
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+)
+
+// ITelegramNotificationSettingRepository Telegram通知設定リポジトリのインターフェース
+type ITelegramNotificationSettingRepository interface {
+	FindByUserID(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error)
+	FindAllEnabled(ctx context.Context) ([]models.TelegramNotificationSetting, error)
+	Upsert(ctx context.Context, setting *models.TelegramNotificationSetting) error
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+	DisableByChatID(ctx context.Context, chatID int64) error
+}
+
+type telegramNotificationSettingRepository struct {
+	db *gorm.DB
+}
+
+// NewTelegramNotificationSettingRepository コンストラクタ
+func NewTelegramNotificationSettingRepository(db *gorm.DB) ITelegramNotificationSettingRepository {
+	return &telegramNotificationSettingRepository{db: db}
+}
+
+func (r *telegramNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error) {
+	var setting models.TelegramNotificationSetting
+	err := r.db.WithContext(ctx).Preload("User").Where("user_id = ?", userID).First(&setting).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *telegramNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.TelegramNotificationSetting, error) {
+	var settings []models.TelegramNotificationSetting
+	err := r.db.WithContext(ctx).Preload("User").Where("is_enabled = ?", true).Find(&settings).Error
+	return settings, err
+}
+
+func (r *telegramNotificationSettingRepository) Upsert(ctx context.Context, setting *models.TelegramNotificationSetting) error {
+	return r.db.WithContext(ctx).Save(setting).Error
+}
+
+func (r *telegramNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.TelegramNotificationSetting{}).
+		Where("user_id = ?", userID).
+		Update("is_enabled", isEnabled).Error
+}
+
+func (r *telegramNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.TelegramNotificationSetting{}).Error
+}
+
+// DisableByChatID ボットがブロックされたチャットへの通知を止める
+func (r *telegramNotificationSettingRepository) DisableByChatID(ctx context.Context, chatID int64) error {
+	return r.db.WithContext(ctx).
+		Model(&models.TelegramNotificationSetting{}).
+		Where("chat_id = ?", chatID).
+		Update("is_enabled", false).Error
+}
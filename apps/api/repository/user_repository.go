@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/keeee21/commitly/api/models"
 	"gorm.io/gorm"
@@ -14,6 +15,8 @@ type IUserRepository interface {
 	FindAll(ctx context.Context) ([]models.User, error)
 	Create(ctx context.Context, user *models.User) error
 	Update(ctx context.Context, user *models.User) error
+	UpdateNotificationAlert(ctx context.Context, id uint64, alertAt *time.Time) error
+	UpdateLocale(ctx context.Context, id uint64, locale string) error
 }
 
 type userRepository struct {
@@ -56,3 +59,20 @@ func (r *userRepository) FindAll(ctx context.Context) ([]models.User, error) {
 	}
 	return users, nil
 }
+
+func (r *userRepository) UpdateNotificationAlert(ctx context.Context, id uint64, alertAt *time.Time) error {
+	return r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Update("notification_alert_at", alertAt).Error
+}
+
+func (r *userRepository) UpdateLocale(ctx context.Context, id uint64, locale string) error {
+	return r.db.WithContext(ctx).
+		Model(&models.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"locale":        locale,
+			"locale_set_at": time.Now(),
+		}).Error
+}
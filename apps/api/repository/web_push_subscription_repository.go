@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IWebPushSubscriptionRepository Web Push購読リポジトリのインターフェース
+type IWebPushSubscriptionRepository interface {
+	FindByUserID(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error)
+	FindAll(ctx context.Context) ([]models.WebPushSubscription, error)
+	Upsert(ctx context.Context, subscription *models.WebPushSubscription) error
+	DeleteByEndpoint(ctx context.Context, userID uint64, endpoint string) error
+	DeleteByID(ctx context.Context, id uint64) error
+	DeleteByUserID(ctx context.Context, userID uint64) error
+}
+
+type webPushSubscriptionRepository struct {
+	db *gorm.DB
+}
+
+// NewWebPushSubscriptionRepository コンストラクタ
+func NewWebPushSubscriptionRepository(db *gorm.DB) IWebPushSubscriptionRepository {
+	return &webPushSubscriptionRepository{db: db}
+}
+
+func (r *webPushSubscriptionRepository) FindByUserID(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error) {
+	var subscriptions []models.WebPushSubscription
+	err := r.db.WithContext(ctx).Preload("User").Where("user_id = ?", userID).Order("id").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+func (r *webPushSubscriptionRepository) FindAll(ctx context.Context) ([]models.WebPushSubscription, error) {
+	var subscriptions []models.WebPushSubscription
+	err := r.db.WithContext(ctx).Preload("User").Order("user_id, id").Find(&subscriptions).Error
+	return subscriptions, err
+}
+
+// Upsert 同じエンドポイントの購読は鍵を更新する（別のユーザーでログインし直した端末は付け替える）
+func (r *webPushSubscriptionRepository) Upsert(ctx context.Context, subscription *models.WebPushSubscription) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "endpoint"}},
+		DoUpdates: clause.AssignmentColumns([]string{"user_id", "p256dh", "auth", "user_agent", "updated_at"}),
+	}).Create(subscription).Error
+}
+
+func (r *webPushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, userID uint64, endpoint string) error {
+	return r.db.WithContext(ctx).
+		Where("user_id = ? AND endpoint = ?", userID, endpoint).
+		Delete(&models.WebPushSubscription{}).Error
+}
+
+func (r *webPushSubscriptionRepository) DeleteByID(ctx context.Context, id uint64) error {
+	return r.db.WithContext(ctx).Delete(&models.WebPushSubscription{}, id).Error
+}
+
+func (r *webPushSubscriptionRepository) DeleteByUserID(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.WebPushSubscription{}).Error
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"gorm.io/gorm"
+)
+
+// IWebhookNotificationSettingRepository Webhook通知設定リポジトリのインターフェース
+type IWebhookNotificationSettingRepository interface {
+	FindByUserID(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error)
+	FindAllEnabled(ctx context.Context) ([]models.WebhookNotificationSetting, error)
+	Upsert(ctx context.Context, setting *models.WebhookNotificationSetting) error
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+}
+
+type webhookNotificationSettingRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookNotificationSettingRepository コンストラクタ
+func NewWebhookNotificationSettingRepository(db *gorm.DB) IWebhookNotificationSettingRepository {
+	return &webhookNotificationSettingRepository{db: db}
+}
+
+func (r *webhookNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error) {
+	var setting models.WebhookNotificationSetting
+	err := r.db.WithContext(ctx).Preload("User").Where("user_id = ?", userID).First(&setting).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &setting, nil
+}
+
+func (r *webhookNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.WebhookNotificationSetting, error) {
+	var settings []models.WebhookNotificationSetting
+	err := r.db.WithContext(ctx).Preload("User").Where("is_enabled = ?", true).Find(&settings).Error
+	return settings, err
+}
+
+func (r *webhookNotificationSettingRepository) Upsert(ctx context.Context, setting *models.WebhookNotificationSetting) error {
+	return r.db.WithContext(ctx).Save(setting).Error
+}
+
+func (r *webhookNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return r.db.WithContext(ctx).
+		Model(&models.WebhookNotificationSetting{}).
+		Where("user_id = ?", userID).
+		Update("is_enabled", isEnabled).Error
+}
+
+func (r *webhookNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return r.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&models.WebhookNotificationSetting{}).Error
+}
@@ -1,9 +1,13 @@
 package router
 
 import (
+	"log"
+	"os"
+
 	"github.com/keeee21/commitly/api/controller"
 	"github.com/keeee21/commitly/api/gateway"
 	"github.com/keeee21/commitly/api/middleware"
+	"github.com/keeee21/commitly/api/notifier"
 	"github.com/keeee21/commitly/api/repository"
 	"github.com/keeee21/commitly/api/usecase"
 	"github.com/labstack/echo/v4"
@@ -18,18 +22,84 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	commitStatsRepo := repository.NewCommitStatsRepository(db)
 	circleRepo := repository.NewCircleRepository(db)
 	slackNotificationRepo := repository.NewSlackNotificationSettingRepository(db)
+	discordNotificationRepo := repository.NewDiscordNotificationSettingRepository(db)
+	teamsNotificationRepo := repository.NewTeamsNotificationSettingRepository(db)
+	mattermostNotificationRepo := repository.NewMattermostNotificationSettingRepository(db)
+	lineNotificationRepo := repository.NewLineNotificationSettingRepository(db)
+	lineLinkCodeRepo := repository.NewLineLinkCodeRepository(db)
+	telegramNotificationRepo := repository.NewTelegramNotificationSettingRepository(db)
+	telegramLinkTokenRepo := repository.NewTelegramLinkTokenRepository(db)
+	webPushSubscriptionRepo := repository.NewWebPushSubscriptionRepository(db)
+	webhookNotificationRepo := repository.NewWebhookNotificationSettingRepository(db)
+	emailNotificationRepo := repository.NewEmailNotificationSettingRepository(db)
+	notificationScheduleRepo := repository.NewNotificationScheduleRepository(db)
+	dailyNudgeRepo := repository.NewDailyNudgeRepository(db)
+	notificationLogRepo := repository.NewNotificationLogRepository(db)
+	batchRunRepo := repository.NewBatchRunRepository(db)
+	circleNotificationRepo := repository.NewCircleNotificationSettingRepository(db)
+	slackUserLinkRepo := repository.NewSlackUserLinkRepository(db)
+	slackLinkCodeRepo := repository.NewSlackLinkCodeRepository(db)
+	inAppNotificationRepo := repository.NewInAppNotificationRepository(db)
 
 	// Gateways
 	githubGateway := gateway.NewGithubGateway("")
+	slackGateway := gateway.NewSlackGateway()
+	discordGateway := gateway.NewDiscordGateway()
+	teamsGateway := gateway.NewTeamsGateway()
+	mattermostGateway := gateway.NewMattermostGateway()
+	lineGateway := gateway.NewLineGateway(os.Getenv("LINE_API_BASE_URL"), os.Getenv("LINE_CHANNEL_ACCESS_TOKEN"))
+	telegramGateway := gateway.NewTelegramGateway(os.Getenv("TELEGRAM_API_BASE_URL"), os.Getenv("TELEGRAM_BOT_TOKEN"))
+	smtpConfig, err := gateway.LoadSMTPConfig(os.Getenv)
+	if err != nil {
+		log.Fatalf("Failed to load SMTP config: %v", err)
+	}
+	emailGateway := gateway.NewEmailGateway(smtpConfig)
+	vapidKeys, err := gateway.LoadVAPIDKeys(os.Getenv)
+	if err != nil {
+		log.Fatalf("Failed to load VAPID keys: %v", err)
+	}
+	webPushGateway := gateway.NewWebPushGateway(vapidKeys)
+	webhookGateway := gateway.NewWebhookGateway()
+	emailTokenSigner := notifier.NewEmailTokenSigner(os.Getenv("EMAIL_TOKEN_SECRET"))
+
+	// Notifiers
+	notifierRegistry := notifier.NewRegistry(
+		notifier.NewSlackNotifier(slackNotificationRepo, slackGateway),
+		notifier.NewDiscordNotifier(discordNotificationRepo, discordGateway),
+		notifier.NewTeamsNotifier(teamsNotificationRepo, teamsGateway),
+		notifier.NewMattermostNotifier(mattermostNotificationRepo, mattermostGateway),
+		notifier.NewLineNotifier(lineNotificationRepo, lineGateway),
+		notifier.NewTelegramNotifier(telegramNotificationRepo, telegramGateway),
+		notifier.NewWebPushNotifier(webPushSubscriptionRepo, webPushGateway),
+		notifier.NewWebhookNotifier(webhookNotificationRepo, webhookGateway),
+		notifier.NewEmailNotifier(emailNotificationRepo, emailGateway, emailTokenSigner, os.Getenv("API_BASE_URL")),
+	)
+	reportBuilder := notifier.NewReportBuilder(rivalRepo, commitStatsRepo)
 
 	// Usecases
 	userUsecase := usecase.NewUserUsecase(userRepo, githubGateway)
 	rivalUsecase := usecase.NewRivalUsecase(rivalRepo, githubGateway)
 	dashboardUsecase := usecase.NewDashboardUsecase(commitStatsRepo)
 	activityUsecase := usecase.NewActivityUsecase(commitStatsRepo)
-	circleUsecase := usecase.NewCircleUsecase(circleRepo)
+	circleUsecase := usecase.NewCircleUsecase(circleRepo, notifier.NewInboxPublisher(inAppNotificationRepo))
 	signalUsecase := usecase.NewSignalUsecase(circleRepo, commitStatsRepo)
+	circleNotificationUsecase := usecase.NewCircleNotificationUsecase(circleRepo, circleNotificationRepo)
 	slackNotificationUsecase := usecase.NewSlackNotificationUsecase(slackNotificationRepo)
+	discordNotificationUsecase := usecase.NewDiscordNotificationUsecase(discordNotificationRepo)
+	teamsNotificationUsecase := usecase.NewTeamsNotificationUsecase(teamsNotificationRepo)
+	mattermostNotificationUsecase := usecase.NewMattermostNotificationUsecase(mattermostNotificationRepo)
+	lineNotificationUsecase := usecase.NewLineNotificationUsecase(lineNotificationRepo, lineLinkCodeRepo, lineGateway)
+	telegramNotificationUsecase := usecase.NewTelegramNotificationUsecase(telegramNotificationRepo, telegramLinkTokenRepo, userRepo, telegramGateway, os.Getenv("TELEGRAM_BOT_USERNAME"))
+	webPushUsecase := usecase.NewWebPushUsecase(webPushSubscriptionRepo, vapidKeys)
+	webhookNotificationUsecase := usecase.NewWebhookNotificationUsecase(webhookNotificationRepo)
+	emailNotificationUsecase := usecase.NewEmailNotificationUsecase(emailNotificationRepo, emailGateway, emailTokenSigner, os.Getenv("API_BASE_URL"))
+	notificationScheduleUsecase := usecase.NewNotificationScheduleUsecase(notificationScheduleRepo)
+	dailyNudgeUsecase := usecase.NewDailyNudgeUsecase(notificationScheduleRepo, dailyNudgeRepo)
+	notificationHistoryUsecase := usecase.NewNotificationHistoryUsecase(notificationLogRepo, notifierRegistry, reportBuilder)
+	notificationPreviewUsecase := usecase.NewNotificationPreviewUsecase(notifierRegistry, reportBuilder)
+	batchRunUsecase := usecase.NewBatchRunUsecase(batchRunRepo)
+	inboxUsecase := usecase.NewInboxUsecase(inAppNotificationRepo)
+	slackCommandUsecase := usecase.NewSlackCommandUsecase(slackUserLinkRepo, slackLinkCodeRepo, dashboardUsecase, rivalUsecase, slackGateway)
 
 	// Controllers
 	healthCtrl := controller.NewHealthController()
@@ -40,7 +110,23 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	activityCtrl := controller.NewActivityController(activityUsecase, rivalUsecase)
 	circleCtrl := controller.NewCircleController(circleUsecase)
 	signalCtrl := controller.NewSignalController(signalUsecase)
+	circleNotificationCtrl := controller.NewCircleNotificationController(circleNotificationUsecase)
 	slackNotificationCtrl := controller.NewSlackNotificationController(slackNotificationUsecase)
+	discordNotificationCtrl := controller.NewDiscordNotificationController(discordNotificationUsecase)
+	teamsNotificationCtrl := controller.NewTeamsNotificationController(teamsNotificationUsecase)
+	mattermostNotificationCtrl := controller.NewMattermostNotificationController(mattermostNotificationUsecase)
+	lineNotificationCtrl := controller.NewLineNotificationController(lineNotificationUsecase, os.Getenv("LINE_CHANNEL_SECRET"))
+	telegramNotificationCtrl := controller.NewTelegramNotificationController(telegramNotificationUsecase, os.Getenv("TELEGRAM_WEBHOOK_SECRET"))
+	webPushCtrl := controller.NewWebPushController(webPushUsecase)
+	webhookNotificationCtrl := controller.NewWebhookNotificationController(webhookNotificationUsecase)
+	emailNotificationCtrl := controller.NewEmailNotificationController(emailNotificationUsecase)
+	notificationScheduleCtrl := controller.NewNotificationScheduleController(notificationScheduleUsecase)
+	dailyNudgeCtrl := controller.NewDailyNudgeController(dailyNudgeUsecase)
+	notificationHistoryCtrl := controller.NewNotificationHistoryController(notificationHistoryUsecase)
+	notificationPreviewCtrl := controller.NewNotificationPreviewController(notificationPreviewUsecase)
+	batchRunCtrl := controller.NewBatchRunController(batchRunUsecase)
+	inboxCtrl := controller.NewInboxController(inboxUsecase)
+	slackAppCtrl := controller.NewSlackAppController(slackCommandUsecase, os.Getenv("SLACK_SIGNING_SECRET"))
 
 	// Health check
 	e.GET("/health", healthCtrl.HealthCheck)
@@ -53,12 +139,34 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	auth.POST("/callback", authCtrl.Callback)
 	auth.POST("/logout", authCtrl.Logout)
 
+	// LINE webhook (X-Line-Signatureで検証)
+	api.POST("/line/webhook", lineNotificationCtrl.Webhook)
+
+	// Telegram webhook (X-Telegram-Bot-Api-Secret-Tokenで検証)
+	api.POST("/telegram/webhook", telegramNotificationCtrl.Webhook)
+
+	// Slack app (X-Slack-Signatureで検証)
+	api.POST("/slack/commands", slackAppCtrl.Command)
+	api.POST("/slack/interactions", slackAppCtrl.Interaction)
+
+	// Email links (署名付きトークンで検証)
+	api.GET("/email/verify", emailNotificationCtrl.Verify)
+	api.GET("/email/unsubscribe", emailNotificationCtrl.Unsubscribe)
+	api.POST("/email/unsubscribe", emailNotificationCtrl.Unsubscribe)
+
+	// Admin routes (管理者トークンが必要)
+	admin := api.Group("/admin")
+	admin.Use(middleware.AdminMiddleware(os.Getenv("ADMIN_API_TOKEN")))
+	admin.GET("/batch-runs", batchRunCtrl.GetRecentRuns)
+
 	// Protected routes (認証必要)
 	protected := api.Group("")
 	protected.Use(middleware.AuthMiddleware(userUsecase))
 
 	// User routes
 	protected.GET("/me", userCtrl.GetMe)
+	protected.DELETE("/me/notification-alert", userCtrl.DismissNotificationAlert)
+	protected.PUT("/me/locale", userCtrl.UpdateLocale)
 
 	// Rival routes
 	rivals := protected.Group("/rivals")
@@ -82,6 +190,9 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	circles.POST("", circleCtrl.CreateCircle)
 	circles.POST("/join", circleCtrl.JoinCircle)
 	circles.GET("/:id/signals", signalCtrl.GetSignals)
+	circles.GET("/:id/notification", circleNotificationCtrl.GetSetting)
+	circles.PUT("/:id/notification", circleNotificationCtrl.UpdateSetting)
+	circles.DELETE("/:id/notification", circleNotificationCtrl.DeleteSetting)
 	circles.DELETE("/:id/leave", circleCtrl.LeaveCircle)
 	circles.DELETE("/:id", circleCtrl.DeleteCircle)
 
@@ -94,4 +205,91 @@ func SetupRoutes(e *echo.Echo, db *gorm.DB) {
 	slack.POST("", slackNotificationCtrl.Create)
 	slack.PUT("", slackNotificationCtrl.UpdateEnabled)
 	slack.DELETE("", slackNotificationCtrl.Delete)
+
+	// Discord notification routes
+	discord := protected.Group("/notifications/discord")
+	discord.GET("", discordNotificationCtrl.GetSetting)
+	discord.POST("", discordNotificationCtrl.Create)
+	discord.PUT("", discordNotificationCtrl.UpdateEnabled)
+	discord.DELETE("", discordNotificationCtrl.Delete)
+
+	// Microsoft Teams notification routes
+	teams := protected.Group("/notifications/teams")
+	teams.GET("", teamsNotificationCtrl.GetSetting)
+	teams.POST("", teamsNotificationCtrl.Create)
+	teams.PUT("", teamsNotificationCtrl.UpdateEnabled)
+	teams.DELETE("", teamsNotificationCtrl.Delete)
+
+	// Mattermost notification routes
+	mattermost := protected.Group("/notifications/mattermost")
+	mattermost.GET("", mattermostNotificationCtrl.GetSetting)
+	mattermost.POST("", mattermostNotificationCtrl.Create)
+	mattermost.PUT("", mattermostNotificationCtrl.UpdateEnabled)
+	mattermost.DELETE("", mattermostNotificationCtrl.Delete)
+
+	// LINE notification routes
+	line := protected.Group("/notifications/line")
+	line.GET("", lineNotificationCtrl.GetSetting)
+	line.POST("", lineNotificationCtrl.Link)
+	line.PUT("", lineNotificationCtrl.UpdateEnabled)
+	line.DELETE("", lineNotificationCtrl.Delete)
+
+	// Telegram notification routes
+	telegram := protected.Group("/notifications/telegram")
+	telegram.GET("", telegramNotificationCtrl.GetSetting)
+	telegram.POST("/link", telegramNotificationCtrl.IssueLink)
+	telegram.PUT("", telegramNotificationCtrl.UpdateEnabled)
+	telegram.DELETE("", telegramNotificationCtrl.Delete)
+
+	// Web Push notification routes
+	webPush := protected.Group("/notifications/webpush")
+	webPush.GET("", webPushCtrl.GetSetting)
+	webPush.POST("/subscriptions", webPushCtrl.Subscribe)
+	webPush.DELETE("/subscriptions", webPushCtrl.Unsubscribe)
+
+	// Slack app account link routes
+	slackLink := protected.Group("/slack/link")
+	slackLink.GET("", slackAppCtrl.GetLink)
+	slackLink.POST("", slackAppCtrl.Link)
+	slackLink.DELETE("", slackAppCtrl.Unlink)
+
+	// Generic webhook notification routes
+	webhook := protected.Group("/notifications/webhook")
+	webhook.GET("", webhookNotificationCtrl.GetSetting)
+	webhook.POST("", webhookNotificationCtrl.Create)
+	webhook.PUT("", webhookNotificationCtrl.UpdateEnabled)
+	webhook.DELETE("", webhookNotificationCtrl.Delete)
+	webhook.POST("/secret", webhookNotificationCtrl.RotateSecret)
+
+	// Email notification routes
+	email := protected.Group("/notifications/email")
+	email.GET("", emailNotificationCtrl.GetSetting)
+	email.POST("", emailNotificationCtrl.Create)
+	email.PUT("", emailNotificationCtrl.UpdateEnabled)
+	email.DELETE("", emailNotificationCtrl.Delete)
+	email.POST("/verification", emailNotificationCtrl.ResendVerification)
+
+	// Notification schedule routes
+	protected.GET("/notifications/schedule", notificationScheduleCtrl.GetSchedule)
+	protected.PUT("/notifications/schedule", notificationScheduleCtrl.UpdateSchedule)
+	protected.PUT("/notifications/schedule/pause", notificationScheduleCtrl.UpdatePause)
+
+	// Daily nudge routes
+	protected.GET("/notifications/nudge", dailyNudgeCtrl.GetSetting)
+	protected.PUT("/notifications/nudge", dailyNudgeCtrl.UpdateSetting)
+
+	// Notification history routes
+	history := protected.Group("/notifications/history")
+	history.GET("", notificationHistoryCtrl.GetHistory)
+	history.POST("/:id/resend", notificationHistoryCtrl.Resend)
+
+	// Inbox routes
+	inbox := protected.Group("/inbox")
+	inbox.GET("", inboxCtrl.GetInbox)
+	inbox.PUT("/read", inboxCtrl.MarkAllRead)
+	inbox.PUT("/:id/read", inboxCtrl.MarkRead)
+
+	// Notification test-send / preview routes
+	protected.POST("/notifications/:channel/test", notificationPreviewCtrl.SendTest)
+	protected.GET("/notifications/preview", notificationPreviewCtrl.Preview)
 }
@@ -56,15 +56,47 @@ func TestRoutesRegistered(t *testing.T) {
 
 	// 期待されるルートのリスト
 	expectedRoutes := map[string][]string{
-		"/health":                  {http.MethodGet},
-		"/api/auth/callback":       {http.MethodPost},
-		"/api/auth/logout":         {http.MethodPost},
-		"/api/me":                  {http.MethodGet},
-		"/api/rivals":              {http.MethodGet, http.MethodPost},
-		"/api/rivals/:id":          {http.MethodDelete},
-		"/api/dashboard/weekly":    {http.MethodGet},
-		"/api/dashboard/monthly":   {http.MethodGet},
-		"/api/notifications/slack": {http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		"/health":                                  {http.MethodGet},
+		"/api/auth/callback":                       {http.MethodPost},
+		"/api/auth/logout":                         {http.MethodPost},
+		"/api/me":                                  {http.MethodGet},
+		"/api/me/notification-alert":               {http.MethodDelete},
+		"/api/me/locale":                           {http.MethodPut},
+		"/api/rivals":                              {http.MethodGet, http.MethodPost},
+		"/api/rivals/:id":                          {http.MethodDelete},
+		"/api/dashboard/weekly":                    {http.MethodGet},
+		"/api/dashboard/monthly":                   {http.MethodGet},
+		"/api/notifications/slack":                 {http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		"/api/notifications/discord":               {http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		"/api/notifications/teams":                 {http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		"/api/notifications/mattermost":            {http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		"/api/notifications/line":                  {http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		"/api/line/webhook":                        {http.MethodPost},
+		"/api/notifications/telegram":              {http.MethodGet, http.MethodPut, http.MethodDelete},
+		"/api/notifications/telegram/link":         {http.MethodPost},
+		"/api/telegram/webhook":                    {http.MethodPost},
+		"/api/notifications/webpush":               {http.MethodGet},
+		"/api/notifications/webpush/subscriptions": {http.MethodPost, http.MethodDelete},
+		"/api/slack/commands":                      {http.MethodPost},
+		"/api/slack/interactions":                  {http.MethodPost},
+		"/api/slack/link":                          {http.MethodGet, http.MethodPost, http.MethodDelete},
+		"/api/notifications/webhook":               {http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		"/api/notifications/email":                 {http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete},
+		"/api/notifications/email/verification":    {http.MethodPost},
+		"/api/notifications/schedule":              {http.MethodGet, http.MethodPut},
+		"/api/notifications/schedule/pause":        {http.MethodPut},
+		"/api/notifications/nudge":                 {http.MethodGet, http.MethodPut},
+		"/api/notifications/history":               {http.MethodGet},
+		"/api/notifications/history/:id/resend":    {http.MethodPost},
+		"/api/notifications/:channel/test":         {http.MethodPost},
+		"/api/notifications/preview":               {http.MethodGet},
+		"/api/inbox":                               {http.MethodGet},
+		"/api/inbox/read":                          {http.MethodPut},
+		"/api/inbox/:id/read":                      {http.MethodPut},
+		"/api/circles/:id/notification":            {http.MethodGet, http.MethodPut, http.MethodDelete},
+		"/api/email/verify":                        {http.MethodGet},
+		"/api/email/unsubscribe":                   {http.MethodGet, http.MethodPost},
+		"/api/admin/batch-runs":                    {http.MethodGet},
 	}
 
 	// ルートが登録されていることを確認
@@ -162,6 +194,22 @@ func TestSlackNotificationsEndpointWithoutAuth(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, rec.Code)
 }
 
+func TestAdminEndpointWithoutToken(t *testing.T) {
+	t.Setenv("ADMIN_API_TOKEN", "")
+	e := echo.New()
+	db, _ := setupTestDB(t)
+
+	SetupRoutes(e, db)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/admin/batch-runs", nil)
+	rec := httptest.NewRecorder()
+
+	e.ServeHTTP(rec, req)
+
+	// ADMIN_API_TOKEN未設定の場合は管理APIが無効
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
 func TestNotFoundEndpoint(t *testing.T) {
 	e := echo.New()
 	db, _ := setupTestDB(t)
@@ -0,0 +1,19 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// MockBatchRunUsecase is a mock of IBatchRunUsecase interface.
+type MockBatchRunUsecase struct {
+	GetRecentRunsFunc func(ctx context.Context, limit int) ([]models.BatchRun, error)
+}
+
+func (m *MockBatchRunUsecase) GetRecentRuns(ctx context.Context, limit int) ([]models.BatchRun, error) {
+	if m.GetRecentRunsFunc != nil {
+		return m.GetRecentRunsFunc(ctx, limit)
+	}
+	return nil, nil
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// MockCircleNotificationUsecase is a mock of ICircleNotificationUsecase interface.
+type MockCircleNotificationUsecase struct {
+	GetSettingFunc    func(ctx context.Context, userID uint64, circleID uint64) (*models.CircleNotificationSetting, error)
+	UpdateSettingFunc func(ctx context.Context, userID uint64, circleID uint64, channelType models.ChannelType, webhookURL string, weekday int, deliveryTime, timezone string, isEnabled bool) (*models.CircleNotificationSetting, error)
+	DeleteSettingFunc func(ctx context.Context, userID uint64, circleID uint64) error
+}
+
+func (m *MockCircleNotificationUsecase) GetSetting(ctx context.Context, userID uint64, circleID uint64) (*models.CircleNotificationSetting, error) {
+	if m.GetSettingFunc != nil {
+		return m.GetSettingFunc(ctx, userID, circleID)
+	}
+	return nil, nil
+}
+
+func (m *MockCircleNotificationUsecase) UpdateSetting(ctx context.Context, userID uint64, circleID uint64, channelType models.ChannelType, webhookURL string, weekday int, deliveryTime, timezone string, isEnabled bool) (*models.CircleNotificationSetting, error) {
+	if m.UpdateSettingFunc != nil {
+		return m.UpdateSettingFunc(ctx, userID, circleID, channelType, webhookURL, weekday, deliveryTime, timezone, isEnabled)
+	}
+	return nil, nil
+}
+
+func (m *MockCircleNotificationUsecase) DeleteSetting(ctx context.Context, userID uint64, circleID uint64) error {
+	if m.DeleteSettingFunc != nil {
+		return m.DeleteSettingFunc(ctx, userID, circleID)
+	}
+	return nil
+}
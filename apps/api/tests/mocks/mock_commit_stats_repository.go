@@ -13,6 +13,7 @@ type MockCommitStatsRepository struct {
 	FindByGithubUserIDsAndDateRangeFunc func(ctx context.Context, githubUserIDs []uint64, startDate, endDate time.Time) ([]models.CommitStats, error)
 	UpsertFunc                          func(ctx context.Context, stats *models.CommitStats) error
 	UpsertBatchFunc                     func(ctx context.Context, statsList []models.CommitStats) error
+	ApplyReconciliationFunc             func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error
 }
 
 func (m *MockCommitStatsRepository) FindByGithubUserIDAndDateRange(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
@@ -42,3 +43,10 @@ func (m *MockCommitStatsRepository) UpsertBatch(ctx context.Context, statsList [
 	}
 	return nil
 }
+
+func (m *MockCommitStatsRepository) ApplyReconciliation(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error {
+	if m.ApplyReconciliationFunc != nil {
+		return m.ApplyReconciliationFunc(ctx, githubUserID, startDate, endDate, statsList, selectDeletes)
+	}
+	return nil
+}
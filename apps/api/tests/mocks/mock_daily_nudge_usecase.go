@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+)
+
+// MockDailyNudgeUsecase is a mock of IDailyNudgeUsecase interface.
+type MockDailyNudgeUsecase struct {
+	GetSettingFunc    func(ctx context.Context, userID uint64) (*usecase.DailyNudgeSetting, error)
+	UpdateSettingFunc func(ctx context.Context, userID uint64, nudgeTime string, channels []models.ChannelType) (*usecase.DailyNudgeSetting, error)
+}
+
+func (m *MockDailyNudgeUsecase) GetSetting(ctx context.Context, userID uint64) (*usecase.DailyNudgeSetting, error) {
+	if m.GetSettingFunc != nil {
+		return m.GetSettingFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockDailyNudgeUsecase) UpdateSetting(ctx context.Context, userID uint64, nudgeTime string, channels []models.ChannelType) (*usecase.DailyNudgeSetting, error) {
+	if m.UpdateSettingFunc != nil {
+		return m.UpdateSettingFunc(ctx, userID, nudgeTime, channels)
+	}
+	return nil, nil
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// MockDiscordNotificationUsecase is a mock of IDiscordNotificationUsecase interface.
+type MockDiscordNotificationUsecase struct {
+	GetSettingFunc    func(ctx context.Context, userID uint64) (*models.DiscordNotificationSetting, error)
+	CreateFunc        func(ctx context.Context, userID uint64, webhookURL string) (*models.DiscordNotificationSetting, error)
+	UpdateEnabledFunc func(ctx context.Context, userID uint64, isEnabled bool) error
+	DeleteFunc        func(ctx context.Context, userID uint64) error
+}
+
+func (m *MockDiscordNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.DiscordNotificationSetting, error) {
+	if m.GetSettingFunc != nil {
+		return m.GetSettingFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockDiscordNotificationUsecase) Create(ctx context.Context, userID uint64, webhookURL string) (*models.DiscordNotificationSetting, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, userID, webhookURL)
+	}
+	return nil, nil
+}
+
+func (m *MockDiscordNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	if m.UpdateEnabledFunc != nil {
+		return m.UpdateEnabledFunc(ctx, userID, isEnabled)
+	}
+	return nil
+}
+
+func (m *MockDiscordNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, userID)
+	}
+	return nil
+}
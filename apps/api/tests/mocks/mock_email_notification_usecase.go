@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// MockEmailNotificationUsecase is a mock of IEmailNotificationUsecase interface.
+type MockEmailNotificationUsecase struct {
+	GetSettingFunc         func(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error)
+	CreateFunc             func(ctx context.Context, user *models.User) (*models.EmailNotificationSetting, error)
+	ResendVerificationFunc func(ctx context.Context, user *models.User) error
+	VerifyFunc             func(ctx context.Context, token string) error
+	UnsubscribeFunc        func(ctx context.Context, token string) error
+	UpdateEnabledFunc      func(ctx context.Context, userID uint64, isEnabled bool) error
+	DeleteFunc             func(ctx context.Context, userID uint64) error
+}
+
+func (m *MockEmailNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error) {
+	if m.GetSettingFunc != nil {
+		return m.GetSettingFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockEmailNotificationUsecase) Create(ctx context.Context, user *models.User) (*models.EmailNotificationSetting, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, user)
+	}
+	return nil, nil
+}
+
+func (m *MockEmailNotificationUsecase) ResendVerification(ctx context.Context, user *models.User) error {
+	if m.ResendVerificationFunc != nil {
+		return m.ResendVerificationFunc(ctx, user)
+	}
+	return nil
+}
+
+func (m *MockEmailNotificationUsecase) Verify(ctx context.Context, token string) error {
+	if m.VerifyFunc != nil {
+		return m.VerifyFunc(ctx, token)
+	}
+	return nil
+}
+
+func (m *MockEmailNotificationUsecase) Unsubscribe(ctx context.Context, token string) error {
+	if m.UnsubscribeFunc != nil {
+		return m.UnsubscribeFunc(ctx, token)
+	}
+	return nil
+}
+
+func (m *MockEmailNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	if m.UpdateEnabledFunc != nil {
+		return m.UpdateEnabledFunc(ctx, userID, isEnabled)
+	}
+	return nil
+}
+
+func (m *MockEmailNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, userID)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/usecase"
+)
+
+// MockInboxUsecase is a mock of IInboxUsecase interface.
+type MockInboxUsecase struct {
+	GetInboxFunc    func(ctx context.Context, userID uint64, cursor uint64, limit int, unreadOnly bool) (*usecase.InboxPage, error)
+	MarkReadFunc    func(ctx context.Context, userID, id uint64) error
+	MarkAllReadFunc func(ctx context.Context, userID uint64) error
+}
+
+func (m *MockInboxUsecase) GetInbox(ctx context.Context, userID uint64, cursor uint64, limit int, unreadOnly bool) (*usecase.InboxPage, error) {
+	if m.GetInboxFunc != nil {
+		return m.GetInboxFunc(ctx, userID, cursor, limit, unreadOnly)
+	}
+	return &usecase.InboxPage{}, nil
+}
+
+func (m *MockInboxUsecase) MarkRead(ctx context.Context, userID, id uint64) error {
+	if m.MarkReadFunc != nil {
+		return m.MarkReadFunc(ctx, userID, id)
+	}
+	return nil
+}
+
+func (m *MockInboxUsecase) MarkAllRead(ctx context.Context, userID uint64) error {
+	if m.MarkAllReadFunc != nil {
+		return m.MarkAllReadFunc(ctx, userID)
+	}
+	return nil
+}
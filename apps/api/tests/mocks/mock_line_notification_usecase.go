@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// MockLineNotificationUsecase is a mock of ILineNotificationUsecase interface.
+type MockLineNotificationUsecase struct {
+	GetSettingFunc     func(ctx context.Context, userID uint64) (*models.LineNotificationSetting, error)
+	LinkFunc           func(ctx context.Context, userID uint64, linkCode string) (*models.LineNotificationSetting, error)
+	UpdateEnabledFunc  func(ctx context.Context, userID uint64, isEnabled bool) error
+	DeleteFunc         func(ctx context.Context, userID uint64) error
+	HandleFollowFunc   func(ctx context.Context, lineUserID, replyToken string) error
+	HandleUnfollowFunc func(ctx context.Context, lineUserID string) error
+}
+
+func (m *MockLineNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.LineNotificationSetting, error) {
+	if m.GetSettingFunc != nil {
+		return m.GetSettingFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockLineNotificationUsecase) Link(ctx context.Context, userID uint64, linkCode string) (*models.LineNotificationSetting, error) {
+	if m.LinkFunc != nil {
+		return m.LinkFunc(ctx, userID, linkCode)
+	}
+	return nil, nil
+}
+
+func (m *MockLineNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	if m.UpdateEnabledFunc != nil {
+		return m.UpdateEnabledFunc(ctx, userID, isEnabled)
+	}
+	return nil
+}
+
+func (m *MockLineNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockLineNotificationUsecase) HandleFollow(ctx context.Context, lineUserID, replyToken string) error {
+	if m.HandleFollowFunc != nil {
+		return m.HandleFollowFunc(ctx, lineUserID, replyToken)
+	}
+	return nil
+}
+
+func (m *MockLineNotificationUsecase) HandleUnfollow(ctx context.Context, lineUserID string) error {
+	if m.HandleUnfollowFunc != nil {
+		return m.HandleUnfollowFunc(ctx, lineUserID)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// MockMattermostNotificationUsecase is a mock of IMattermostNotificationUsecase interface.
+type MockMattermostNotificationUsecase struct {
+	GetSettingFunc    func(ctx context.Context, userID uint64) (*models.MattermostNotificationSetting, error)
+	CreateFunc        func(ctx context.Context, userID uint64, webhookURL string) (*models.MattermostNotificationSetting, error)
+	UpdateEnabledFunc func(ctx context.Context, userID uint64, isEnabled bool) error
+	DeleteFunc        func(ctx context.Context, userID uint64) error
+}
+
+func (m *MockMattermostNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.MattermostNotificationSetting, error) {
+	if m.GetSettingFunc != nil {
+		return m.GetSettingFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockMattermostNotificationUsecase) Create(ctx context.Context, userID uint64, webhookURL string) (*models.MattermostNotificationSetting, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, userID, webhookURL)
+	}
+	return nil, nil
+}
+
+func (m *MockMattermostNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	if m.UpdateEnabledFunc != nil {
+		return m.UpdateEnabledFunc(ctx, userID, isEnabled)
+	}
+	return nil
+}
+
+func (m *MockMattermostNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, userID)
+	}
+	return nil
+}
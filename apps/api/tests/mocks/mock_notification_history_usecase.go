@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// MockNotificationHistoryUsecase is a mock of INotificationHistoryUsecase interface.
+type MockNotificationHistoryUsecase struct {
+	GetHistoryFunc func(ctx context.Context, userID uint64, filter repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error)
+	ResendFunc     func(ctx context.Context, userID, logID uint64) (*models.NotificationLog, error)
+}
+
+func (m *MockNotificationHistoryUsecase) GetHistory(ctx context.Context, userID uint64, filter repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error) {
+	if m.GetHistoryFunc != nil {
+		return m.GetHistoryFunc(ctx, userID, filter, limit, offset)
+	}
+	return nil, 0, nil
+}
+
+func (m *MockNotificationHistoryUsecase) Resend(ctx context.Context, userID, logID uint64) (*models.NotificationLog, error) {
+	if m.ResendFunc != nil {
+		return m.ResendFunc(ctx, userID, logID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+)
+
+// MockNotificationPreviewUsecase is a mock of INotificationPreviewUsecase interface.
+type MockNotificationPreviewUsecase struct {
+	SendTestFunc func(ctx context.Context, userID uint64, channelType models.ChannelType) error
+	PreviewFunc  func(ctx context.Context, user *models.User, period string) (*usecase.NotificationPreview, error)
+}
+
+func (m *MockNotificationPreviewUsecase) SendTest(ctx context.Context, userID uint64, channelType models.ChannelType) error {
+	if m.SendTestFunc != nil {
+		return m.SendTestFunc(ctx, userID, channelType)
+	}
+	return nil
+}
+
+func (m *MockNotificationPreviewUsecase) Preview(ctx context.Context, user *models.User, period string) (*usecase.NotificationPreview, error) {
+	if m.PreviewFunc != nil {
+		return m.PreviewFunc(ctx, user, period)
+	}
+	return nil, nil
+}
@@ -0,0 +1,35 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// MockNotificationScheduleUsecase is a mock of INotificationScheduleUsecase interface.
+type MockNotificationScheduleUsecase struct {
+	GetScheduleFunc    func(ctx context.Context, userID uint64) (*models.NotificationSchedule, error)
+	UpdateScheduleFunc func(ctx context.Context, userID uint64, weeklyWeekday, monthlyDay int, deliveryTime, timezone string) (*models.NotificationSchedule, error)
+	UpdatePauseFunc    func(ctx context.Context, userID uint64, quietHoursStart, quietHoursEnd, snoozeUntil string) (*models.NotificationSchedule, error)
+}
+
+func (m *MockNotificationScheduleUsecase) GetSchedule(ctx context.Context, userID uint64) (*models.NotificationSchedule, error) {
+	if m.GetScheduleFunc != nil {
+		return m.GetScheduleFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockNotificationScheduleUsecase) UpdateSchedule(ctx context.Context, userID uint64, weeklyWeekday, monthlyDay int, deliveryTime, timezone string) (*models.NotificationSchedule, error) {
+	if m.UpdateScheduleFunc != nil {
+		return m.UpdateScheduleFunc(ctx, userID, weeklyWeekday, monthlyDay, deliveryTime, timezone)
+	}
+	return nil, nil
+}
+
+func (m *MockNotificationScheduleUsecase) UpdatePause(ctx context.Context, userID uint64, quietHoursStart, quietHoursEnd, snoozeUntil string) (*models.NotificationSchedule, error) {
+	if m.UpdatePauseFunc != nil {
+		return m.UpdatePauseFunc(ctx, userID, quietHoursStart, quietHoursEnd, snoozeUntil)
+	}
+	return nil, nil
+}
@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+)
+
+// MockSlackCommandUsecase is a mock of ISlackCommandUsecase interface.
+type MockSlackCommandUsecase struct {
+	HandleCommandFunc func(ctx context.Context, command usecase.SlackCommand) error
+	HandleActionFunc  func(ctx context.Context, action usecase.SlackAction) error
+	GetLinkFunc       func(ctx context.Context, userID uint64) (*models.SlackUserLink, error)
+	LinkFunc          func(ctx context.Context, userID uint64, linkCode string) (*models.SlackUserLink, error)
+	UnlinkFunc        func(ctx context.Context, userID uint64) error
+}
+
+func (m *MockSlackCommandUsecase) HandleCommand(ctx context.Context, command usecase.SlackCommand) error {
+	if m.HandleCommandFunc != nil {
+		return m.HandleCommandFunc(ctx, command)
+	}
+	return nil
+}
+
+func (m *MockSlackCommandUsecase) HandleAction(ctx context.Context, action usecase.SlackAction) error {
+	if m.HandleActionFunc != nil {
+		return m.HandleActionFunc(ctx, action)
+	}
+	return nil
+}
+
+func (m *MockSlackCommandUsecase) GetLink(ctx context.Context, userID uint64) (*models.SlackUserLink, error) {
+	if m.GetLinkFunc != nil {
+		return m.GetLinkFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockSlackCommandUsecase) Link(ctx context.Context, userID uint64, linkCode string) (*models.SlackUserLink, error) {
+	if m.LinkFunc != nil {
+		return m.LinkFunc(ctx, userID, linkCode)
+	}
+	return nil, nil
+}
+
+func (m *MockSlackCommandUsecase) Unlink(ctx context.Context, userID uint64) error {
+	if m.UnlinkFunc != nil {
+		return m.UnlinkFunc(ctx, userID)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// MockTeamsNotificationUsecase is a mock of ITeamsNotificationUsecase interface.
+type MockTeamsNotificationUsecase struct {
+	GetSettingFunc    func(ctx context.Context, userID uint64) (*models.TeamsNotificationSetting, error)
+	CreateFunc        func(ctx context.Context, userID uint64, webhookURL string) (*models.TeamsNotificationSetting, error)
+	UpdateEnabledFunc func(ctx context.Context, userID uint64, isEnabled bool) error
+	DeleteFunc        func(ctx context.Context, userID uint64) error
+}
+
+func (m *MockTeamsNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.TeamsNotificationSetting, error) {
+	if m.GetSettingFunc != nil {
+		return m.GetSettingFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamsNotificationUsecase) Create(ctx context.Context, userID uint64, webhookURL string) (*models.TeamsNotificationSetting, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, userID, webhookURL)
+	}
+	return nil, nil
+}
+
+func (m *MockTeamsNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	if m.UpdateEnabledFunc != nil {
+		return m.UpdateEnabledFunc(ctx, userID, isEnabled)
+	}
+	return nil
+}
+
+func (m *MockTeamsNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, userID)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/usecase"
+)
+
+// MockTelegramNotificationUsecase is a mock of ITelegramNotificationUsecase interface.
+type MockTelegramNotificationUsecase struct {
+	GetSettingFunc     func(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error)
+	IssueLinkTokenFunc func(ctx context.Context, userID uint64) (*usecase.TelegramLink, error)
+	UpdateEnabledFunc  func(ctx context.Context, userID uint64, isEnabled bool) error
+	DeleteFunc         func(ctx context.Context, userID uint64) error
+	HandleStartFunc    func(ctx context.Context, chatID int64, token, languageCode string) error
+	HandleBlockedFunc  func(ctx context.Context, chatID int64) error
+}
+
+func (m *MockTelegramNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error) {
+	if m.GetSettingFunc != nil {
+		return m.GetSettingFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockTelegramNotificationUsecase) IssueLinkToken(ctx context.Context, userID uint64) (*usecase.TelegramLink, error) {
+	if m.IssueLinkTokenFunc != nil {
+		return m.IssueLinkTokenFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockTelegramNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	if m.UpdateEnabledFunc != nil {
+		return m.UpdateEnabledFunc(ctx, userID, isEnabled)
+	}
+	return nil
+}
+
+func (m *MockTelegramNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockTelegramNotificationUsecase) HandleStart(ctx context.Context, chatID int64, token, languageCode string) error {
+	if m.HandleStartFunc != nil {
+		return m.HandleStartFunc(ctx, chatID, token, languageCode)
+	}
+	return nil
+}
+
+func (m *MockTelegramNotificationUsecase) HandleBlocked(ctx context.Context, chatID int64) error {
+	if m.HandleBlockedFunc != nil {
+		return m.HandleBlockedFunc(ctx, chatID)
+	}
+	return nil
+}
@@ -5,17 +5,20 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/keeee21/commitly/api/models"
 )
 
 // MockUserRepository is a mock of IUserRepository interface.
 type MockUserRepository struct {
-	FindByIDFunc           func(ctx context.Context, id uint64) (*models.User, error)
-	FindByGithubUserIDFunc func(ctx context.Context, githubUserID uint64) (*models.User, error)
-	FindAllFunc            func(ctx context.Context) ([]models.User, error)
-	CreateFunc             func(ctx context.Context, user *models.User) error
-	UpdateFunc             func(ctx context.Context, user *models.User) error
+	FindByIDFunc                func(ctx context.Context, id uint64) (*models.User, error)
+	FindByGithubUserIDFunc      func(ctx context.Context, githubUserID uint64) (*models.User, error)
+	FindAllFunc                 func(ctx context.Context) ([]models.User, error)
+	CreateFunc                  func(ctx context.Context, user *models.User) error
+	UpdateFunc                  func(ctx context.Context, user *models.User) error
+	UpdateNotificationAlertFunc func(ctx context.Context, id uint64, alertAt *time.Time) error
+	UpdateLocaleFunc            func(ctx context.Context, id uint64, locale string) error
 }
 
 func (m *MockUserRepository) FindByID(ctx context.Context, id uint64) (*models.User, error) {
@@ -46,6 +49,20 @@ func (m *MockUserRepository) Update(ctx context.Context, user *models.User) erro
 	return nil
 }
 
+func (m *MockUserRepository) UpdateNotificationAlert(ctx context.Context, id uint64, alertAt *time.Time) error {
+	if m.UpdateNotificationAlertFunc != nil {
+		return m.UpdateNotificationAlertFunc(ctx, id, alertAt)
+	}
+	return nil
+}
+
+func (m *MockUserRepository) UpdateLocale(ctx context.Context, id uint64, locale string) error {
+	if m.UpdateLocaleFunc != nil {
+		return m.UpdateLocaleFunc(ctx, id, locale)
+	}
+	return nil
+}
+
 func (m *MockUserRepository) FindAll(ctx context.Context) ([]models.User, error) {
 	if m.FindAllFunc != nil {
 		return m.FindAllFunc(ctx)
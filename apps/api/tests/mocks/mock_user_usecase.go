@@ -8,13 +8,15 @@ import (
 
 // MockUserUsecase is a mock of IUserUsecase interface.
 type MockUserUsecase struct {
-	GetOrCreateUserFunc       func(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL string) (*models.User, error)
-	GetUserByGithubUserIDFunc func(ctx context.Context, githubUserID uint64) (*models.User, error)
+	GetOrCreateUserFunc          func(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL, locale string) (*models.User, error)
+	GetUserByGithubUserIDFunc    func(ctx context.Context, githubUserID uint64) (*models.User, error)
+	DismissNotificationAlertFunc func(ctx context.Context, userID uint64) error
+	UpdateLocaleFunc             func(ctx context.Context, userID uint64, locale string) error
 }
 
-func (m *MockUserUsecase) GetOrCreateUser(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL string) (*models.User, error) {
+func (m *MockUserUsecase) GetOrCreateUser(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL, locale string) (*models.User, error) {
 	if m.GetOrCreateUserFunc != nil {
-		return m.GetOrCreateUserFunc(ctx, githubUserID, githubUsername, email, avatarURL)
+		return m.GetOrCreateUserFunc(ctx, githubUserID, githubUsername, email, avatarURL, locale)
 	}
 	return nil, nil
 }
@@ -25,3 +27,17 @@ func (m *MockUserUsecase) GetUserByGithubUserID(ctx context.Context, githubUserI
 	}
 	return nil, nil
 }
+
+func (m *MockUserUsecase) DismissNotificationAlert(ctx context.Context, userID uint64) error {
+	if m.DismissNotificationAlertFunc != nil {
+		return m.DismissNotificationAlertFunc(ctx, userID)
+	}
+	return nil
+}
+
+func (m *MockUserUsecase) UpdateLocale(ctx context.Context, userID uint64, locale string) error {
+	if m.UpdateLocaleFunc != nil {
+		return m.UpdateLocaleFunc(ctx, userID, locale)
+	}
+	return nil
+}
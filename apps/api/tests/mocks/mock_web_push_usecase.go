@@ -0,0 +1,43 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// MockWebPushUsecase is a mock of IWebPushUsecase interface.
+type MockWebPushUsecase struct {
+	VAPIDPublicKeyFunc   func() string
+	GetSubscriptionsFunc func(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error)
+	SubscribeFunc        func(ctx context.Context, userID uint64, endpoint, p256dh, auth, userAgent string) (*models.WebPushSubscription, error)
+	UnsubscribeFunc      func(ctx context.Context, userID uint64, endpoint string) error
+}
+
+func (m *MockWebPushUsecase) VAPIDPublicKey() string {
+	if m.VAPIDPublicKeyFunc != nil {
+		return m.VAPIDPublicKeyFunc()
+	}
+	return ""
+}
+
+func (m *MockWebPushUsecase) GetSubscriptions(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error) {
+	if m.GetSubscriptionsFunc != nil {
+		return m.GetSubscriptionsFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockWebPushUsecase) Subscribe(ctx context.Context, userID uint64, endpoint, p256dh, auth, userAgent string) (*models.WebPushSubscription, error) {
+	if m.SubscribeFunc != nil {
+		return m.SubscribeFunc(ctx, userID, endpoint, p256dh, auth, userAgent)
+	}
+	return nil, nil
+}
+
+func (m *MockWebPushUsecase) Unsubscribe(ctx context.Context, userID uint64, endpoint string) error {
+	if m.UnsubscribeFunc != nil {
+		return m.UnsubscribeFunc(ctx, userID, endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+)
+
+// MockWebhookNotificationUsecase is a mock of IWebhookNotificationUsecase interface.
+type MockWebhookNotificationUsecase struct {
+	GetSettingFunc    func(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error)
+	CreateFunc        func(ctx context.Context, userID uint64, url string) (*models.WebhookNotificationSetting, error)
+	RotateSecretFunc  func(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error)
+	UpdateEnabledFunc func(ctx context.Context, userID uint64, isEnabled bool) error
+	DeleteFunc        func(ctx context.Context, userID uint64) error
+}
+
+func (m *MockWebhookNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error) {
+	if m.GetSettingFunc != nil {
+		return m.GetSettingFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookNotificationUsecase) Create(ctx context.Context, userID uint64, url string) (*models.WebhookNotificationSetting, error) {
+	if m.CreateFunc != nil {
+		return m.CreateFunc(ctx, userID, url)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookNotificationUsecase) RotateSecret(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error) {
+	if m.RotateSecretFunc != nil {
+		return m.RotateSecretFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *MockWebhookNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	if m.UpdateEnabledFunc != nil {
+		return m.UpdateEnabledFunc(ctx, userID, isEnabled)
+	}
+	return nil
+}
+
+func (m *MockWebhookNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, userID)
+	}
+	return nil
+}
@@ -46,6 +46,10 @@ func (m *activityMockCommitStatsRepository) UpsertBatch(ctx context.Context, sta
 	return nil
 }
 
+func (m *activityMockCommitStatsRepository) ApplyReconciliation(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error {
+	return nil
+}
+
 func TestGetActivityStream_Success(t *testing.T) {
 	ctx := context.Background()
 	now := time.Now()
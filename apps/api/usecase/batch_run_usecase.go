@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// 取得件数の既定値と上限
+const (
+	defaultBatchRunsLimit = 20
+	maxBatchRunsLimit     = 100
+)
+
+// IBatchRunUsecase バッチ実行履歴ユースケースのインターフェース
+type IBatchRunUsecase interface {
+	GetRecentRuns(ctx context.Context, limit int) ([]models.BatchRun, error)
+}
+
+type batchRunUsecase struct {
+	batchRunRepo repository.IBatchRunRepository
+}
+
+// NewBatchRunUsecase コンストラクタ
+func NewBatchRunUsecase(batchRunRepo repository.IBatchRunRepository) IBatchRunUsecase {
+	return &batchRunUsecase{
+		batchRunRepo: batchRunRepo,
+	}
+}
+
+func (u *batchRunUsecase) GetRecentRuns(ctx context.Context, limit int) ([]models.BatchRun, error) {
+	if limit <= 0 {
+		limit = defaultBatchRunsLimit
+	}
+	if limit > maxBatchRunsLimit {
+		limit = maxBatchRunsLimit
+	}
+	return u.batchRunRepo.FindRecent(ctx, limit)
+}
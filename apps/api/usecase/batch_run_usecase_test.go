@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// batchRunMockRepository テスト用のモックリポジトリ
+type batchRunMockRepository struct {
+	FindRecentFunc func(ctx context.Context, limit int) ([]models.BatchRun, error)
+}
+
+func (m *batchRunMockRepository) Create(ctx context.Context, run *models.BatchRun) error {
+	return nil
+}
+
+func (m *batchRunMockRepository) Update(ctx context.Context, run *models.BatchRun) error {
+	return nil
+}
+
+func (m *batchRunMockRepository) FindRecent(ctx context.Context, limit int) ([]models.BatchRun, error) {
+	if m.FindRecentFunc != nil {
+		return m.FindRecentFunc(ctx, limit)
+	}
+	return nil, nil
+}
+
+func (m *batchRunMockRepository) FindLatest(ctx context.Context, command string, args map[string]string) (*models.BatchRun, error) {
+	return nil, nil
+}
+
+func TestGetRecentRuns_LimitIsNormalized(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{name: "未指定は既定値", limit: 0, want: defaultBatchRunsLimit},
+		{name: "指定値をそのまま使う", limit: 5, want: 5},
+		{name: "上限を超える場合は上限", limit: 1000, want: maxBatchRunsLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var captured int
+			repo := &batchRunMockRepository{
+				FindRecentFunc: func(ctx context.Context, limit int) ([]models.BatchRun, error) {
+					captured = limit
+					return []models.BatchRun{}, nil
+				},
+			}
+
+			_, err := NewBatchRunUsecase(repo).GetRecentRuns(context.Background(), tt.limit)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, captured)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// ダイジェストに載せる件数の上限
+const (
+	maxDigestSignals      = 3
+	maxDigestRepositories = 3
+)
+
+// ICircleDigestUsecase サークルの週次ダイジェストユースケースのインターフェース
+type ICircleDigestUsecase interface {
+	BuildDigest(ctx context.Context, circle *models.Circle, anchor time.Time) (*gateway.CircleDigest, error)
+}
+
+type circleDigestUsecase struct {
+	commitStatsRepo repository.ICommitStatsRepository
+	signalUsecase   *signalUsecase
+}
+
+// NewCircleDigestUsecase コンストラクタ
+// シグナルはサークルを受け取って検出するため、サークルリポジトリは使わない
+func NewCircleDigestUsecase(commitStatsRepo repository.ICommitStatsRepository) ICircleDigestUsecase {
+	return &circleDigestUsecase{
+		commitStatsRepo: commitStatsRepo,
+		signalUsecase:   &signalUsecase{commitStatsRepo: commitStatsRepo},
+	}
+}
+
+// BuildDigest anchor の前日までの1週間について、メンバーのコミット数・シグナル・リポジトリを集計する
+func (u *circleDigestUsecase) BuildDigest(ctx context.Context, circle *models.Circle, anchor time.Time) (*gateway.CircleDigest, error) {
+	dateRange := notifier.WeeklyRange(anchor)
+
+	var githubUserIDs []uint64
+	for _, member := range circle.Members {
+		githubUserIDs = append(githubUserIDs, member.User.GithubUserID)
+	}
+	stats, err := u.commitStatsRepo.FindByGithubUserIDsAndDateRange(ctx, githubUserIDs, dateRange.Start, dateRange.End)
+	if err != nil {
+		return nil, err
+	}
+
+	signals, err := u.signalUsecase.circleSignals(ctx, circle)
+	if err != nil {
+		return nil, err
+	}
+	if len(signals) > maxDigestSignals {
+		signals = signals[:maxDigestSignals]
+	}
+
+	digest := &gateway.CircleDigest{
+		CircleName:   circle.Name,
+		RangeStart:   dateRange.Start,
+		RangeEnd:     dateRange.End,
+		Members:      circleLeaderboard(circle, stats),
+		Repositories: topRepositories(stats, maxDigestRepositories),
+	}
+	for _, signal := range signals {
+		digest.Signals = append(digest.Signals, gateway.CircleDigestSignal{
+			Type:      signal.Type,
+			Date:      signal.Date,
+			Usernames: signal.Usernames,
+			Detail:    signal.Detail,
+		})
+	}
+
+	return digest, nil
+}
+
+// circleLeaderboard メンバーごとのコミット数をコミット数の多い順に並べる（コミットのないメンバーも含む）
+func circleLeaderboard(circle *models.Circle, stats []models.CommitStats) []gateway.RivalCommitSummary {
+	commits := make(map[uint64]int)
+	for _, s := range stats {
+		commits[s.GithubUserID] += s.CommitCount
+	}
+
+	leaderboard := make([]gateway.RivalCommitSummary, 0, len(circle.Members))
+	for _, member := range circle.Members {
+		leaderboard = append(leaderboard, gateway.RivalCommitSummary{
+			Username: member.User.GithubUsername,
+			Commits:  commits[member.User.GithubUserID],
+		})
+	}
+	sort.SliceStable(leaderboard, func(i, j int) bool {
+		if leaderboard[i].Commits != leaderboard[j].Commits {
+			return leaderboard[i].Commits > leaderboard[j].Commits
+		}
+		return leaderboard[i].Username < leaderboard[j].Username
+	})
+	return leaderboard
+}
+
+// topRepositories コミット数の多いリポジトリを最大 limit 件返す
+func topRepositories(stats []models.CommitStats, limit int) []gateway.RepositoryCommitSummary {
+	commits := make(map[string]int)
+	for _, s := range stats {
+		if s.CommitCount > 0 {
+			commits[s.Repository] += s.CommitCount
+		}
+	}
+
+	repositories := make([]gateway.RepositoryCommitSummary, 0, len(commits))
+	for name, count := range commits {
+		repositories = append(repositories, gateway.RepositoryCommitSummary{Name: name, Commits: count})
+	}
+	sort.Slice(repositories, func(i, j int) bool {
+		if repositories[i].Commits != repositories[j].Commits {
+			return repositories[i].Commits > repositories[j].Commits
+		}
+		return repositories[i].Name < repositories[j].Name
+	})
+	if len(repositories) > limit {
+		repositories = repositories[:limit]
+	}
+	return repositories
+}
@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDigest_LeaderboardAndRepositories(t *testing.T) {
+	circle := makeCircleWithMembers()
+	circle.Members = append(circle.Members, models.CircleMember{UserID: 3, User: models.User{ID: 3, GithubUserID: 300, GithubUsername: "suzuki"}})
+	anchor := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+
+	mockCommitStatsRepo := &signalMockCommitStatsRepository{
+		FindByGithubUserIDsAndDateRangeFunc: func(ctx context.Context, ids []uint64, start, end time.Time) ([]models.CommitStats, error) {
+			if start.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, time.Local)) {
+				// 先週1週間の集計
+				return []models.CommitStats{
+					{GithubUserID: 100, Date: start, Repository: "me/app", CommitCount: 2},
+					{GithubUserID: 100, Date: end, Repository: "me/app", CommitCount: 1},
+					{GithubUserID: 200, Date: end, Repository: "tanaka/api", CommitCount: 5},
+					{GithubUserID: 200, Date: end, Repository: "tanaka/docs", CommitCount: 1},
+					{GithubUserID: 200, Date: end, Repository: "tanaka/cli", CommitCount: 1},
+				}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	uc := NewCircleDigestUsecase(mockCommitStatsRepo)
+	digest, err := uc.BuildDigest(context.Background(), circle, anchor)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "テストサークル", digest.CircleName)
+	assert.Equal(t, []gateway.RivalCommitSummary{
+		{Username: "tanaka", Commits: 7},
+		{Username: "me", Commits: 3},
+		{Username: "suzuki", Commits: 0},
+	}, digest.Members)
+	assert.Equal(t, []gateway.RepositoryCommitSummary{
+		{Name: "tanaka/api", Commits: 5},
+		{Name: "me/app", Commits: 3},
+		{Name: "tanaka/cli", Commits: 1},
+	}, digest.Repositories)
+}
+
+func TestBuildDigest_MergesSignalsAcrossMembers(t *testing.T) {
+	circle := makeCircleWithMembers()
+	circle.Members = append(circle.Members, models.CircleMember{UserID: 3, User: models.User{ID: 3, GithubUserID: 300, GithubUsername: "suzuki"}})
+	today := time.Now().Truncate(24 * time.Hour)
+	yesterday := today.AddDate(0, 0, -1)
+
+	mockCommitStatsRepo := &signalMockCommitStatsRepository{
+		FindByGithubUserIDsAndDateRangeFunc: func(ctx context.Context, ids []uint64, start, end time.Time) ([]models.CommitStats, error) {
+			return []models.CommitStats{
+				{GithubUserID: 100, Date: today, Repository: "me/repo", CommitCount: 1},
+				{GithubUserID: 200, Date: today, Repository: "tanaka/repo", CommitCount: 1},
+				{GithubUserID: 300, Date: today, Repository: "suzuki/repo", CommitCount: 1},
+				{GithubUserID: 100, Date: yesterday, Repository: "me/repo", CommitCount: 1, Language: "Go"},
+				{GithubUserID: 200, Date: yesterday, Repository: "tanaka/repo", CommitCount: 1, Language: "Go"},
+			}, nil
+		},
+	}
+
+	uc := NewCircleDigestUsecase(mockCommitStatsRepo)
+	digest, err := uc.BuildDigest(context.Background(), circle, time.Now())
+
+	assert.NoError(t, err)
+	if assert.Len(t, digest.Signals, 3) {
+		// 全員がコミットした日が先頭で、メンバーから見た重複はまとめる
+		assert.Equal(t, "same_day", digest.Signals[0].Type)
+		assert.Equal(t, today.Format("2006-01-02"), digest.Signals[0].Date)
+		assert.Equal(t, []string{"me", "suzuki", "tanaka"}, digest.Signals[0].Usernames)
+		assert.Equal(t, []string{"me", "tanaka"}, digest.Signals[1].Usernames)
+		assert.Equal(t, []string{"me", "tanaka"}, digest.Signals[2].Usernames)
+	}
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// ICircleNotificationUsecase サークルの週次ダイジェスト配信設定ユースケースのインターフェース
+type ICircleNotificationUsecase interface {
+	GetSetting(ctx context.Context, userID uint64, circleID uint64) (*models.CircleNotificationSetting, error)
+	UpdateSetting(ctx context.Context, userID uint64, circleID uint64, channelType models.ChannelType, webhookURL string, weekday int, deliveryTime, timezone string, isEnabled bool) (*models.CircleNotificationSetting, error)
+	DeleteSetting(ctx context.Context, userID uint64, circleID uint64) error
+}
+
+type circleNotificationUsecase struct {
+	circleRepo             repository.ICircleRepository
+	circleNotificationRepo repository.ICircleNotificationSettingRepository
+}
+
+// NewCircleNotificationUsecase コンストラクタ
+func NewCircleNotificationUsecase(circleRepo repository.ICircleRepository, circleNotificationRepo repository.ICircleNotificationSettingRepository) ICircleNotificationUsecase {
+	return &circleNotificationUsecase{
+		circleRepo:             circleRepo,
+		circleNotificationRepo: circleNotificationRepo,
+	}
+}
+
+// GetSetting 配信設定を取得する（未設定の場合は nil）
+func (u *circleNotificationUsecase) GetSetting(ctx context.Context, userID uint64, circleID uint64) (*models.CircleNotificationSetting, error) {
+	if err := u.checkOwner(ctx, userID, circleID); err != nil {
+		return nil, err
+	}
+	return u.circleNotificationRepo.FindByCircleID(ctx, circleID)
+}
+
+// UpdateSetting 配信設定を検証して保存する
+func (u *circleNotificationUsecase) UpdateSetting(ctx context.Context, userID uint64, circleID uint64, channelType models.ChannelType, webhookURL string, weekday int, deliveryTime, timezone string, isEnabled bool) (*models.CircleNotificationSetting, error) {
+	if err := u.checkOwner(ctx, userID, circleID); err != nil {
+		return nil, err
+	}
+	if channelType != models.ChannelTypeSlack && channelType != models.ChannelTypeDiscord {
+		return nil, fmt.Errorf("通知先はslackまたはdiscordを指定してください")
+	}
+	if weekday < 0 || weekday > 6 {
+		return nil, fmt.Errorf("曜日は0（日曜）〜6（土曜）で指定してください")
+	}
+
+	setting, err := u.circleNotificationRepo.FindByCircleID(ctx, circleID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		setting = &models.CircleNotificationSetting{CircleID: circleID}
+	}
+	setting.ChannelType = channelType
+	setting.WebhookURL = webhookURL
+	setting.Weekday = weekday
+	setting.DeliveryTime = deliveryTime
+	setting.Timezone = timezone
+	setting.IsEnabled = isEnabled
+
+	schedule := setting.Schedule()
+	if _, _, err := schedule.DeliveryClock(); err != nil {
+		return nil, fmt.Errorf("配信時刻はHH:MM形式で指定してください")
+	}
+	// "Local" はサーバーのタイムゾーンになるため受け付けない
+	if _, err := schedule.Location(); err != nil || timezone == "Local" {
+		return nil, fmt.Errorf("タイムゾーンが不正です")
+	}
+
+	if err := u.circleNotificationRepo.Upsert(ctx, setting); err != nil {
+		return nil, err
+	}
+	return setting, nil
+}
+
+// DeleteSetting 配信設定を削除する
+func (u *circleNotificationUsecase) DeleteSetting(ctx context.Context, userID uint64, circleID uint64) error {
+	if err := u.checkOwner(ctx, userID, circleID); err != nil {
+		return err
+	}
+	return u.circleNotificationRepo.Delete(ctx, circleID)
+}
+
+// checkOwner サークルのオーナーかどうかを確認する（配信設定はオーナーのみ変更できる）
+func (u *circleNotificationUsecase) checkOwner(ctx context.Context, userID uint64, circleID uint64) error {
+	circle, err := u.circleRepo.FindByID(ctx, circleID)
+	if err != nil {
+		return fmt.Errorf("サークルが見つかりません")
+	}
+	if circle.OwnerUserID != userID {
+		return fmt.Errorf("サークルの通知設定を変更する権限がありません")
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type circleNotificationMockSettingRepository struct {
+	FindByCircleIDFunc func(ctx context.Context, circleID uint64) (*models.CircleNotificationSetting, error)
+	FindAllEnabledFunc func(ctx context.Context) ([]models.CircleNotificationSetting, error)
+	UpsertFunc         func(ctx context.Context, setting *models.CircleNotificationSetting) error
+	DeleteFunc         func(ctx context.Context, circleID uint64) error
+}
+
+func (m *circleNotificationMockSettingRepository) FindByCircleID(ctx context.Context, circleID uint64) (*models.CircleNotificationSetting, error) {
+	if m.FindByCircleIDFunc != nil {
+		return m.FindByCircleIDFunc(ctx, circleID)
+	}
+	return nil, nil
+}
+
+func (m *circleNotificationMockSettingRepository) FindAllEnabled(ctx context.Context) ([]models.CircleNotificationSetting, error) {
+	if m.FindAllEnabledFunc != nil {
+		return m.FindAllEnabledFunc(ctx)
+	}
+	return nil, nil
+}
+
+func (m *circleNotificationMockSettingRepository) Upsert(ctx context.Context, setting *models.CircleNotificationSetting) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, setting)
+	}
+	return nil
+}
+
+func (m *circleNotificationMockSettingRepository) Delete(ctx context.Context, circleID uint64) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, circleID)
+	}
+	return nil
+}
+
+// circleNotificationOwnedCircleRepo ユーザー1がオーナーのサークルを返すモック
+func circleNotificationOwnedCircleRepo() *circleMockCircleRepository {
+	return &circleMockCircleRepository{
+		FindByIDFunc: func(ctx context.Context, id uint64) (*models.Circle, error) {
+			return &models.Circle{ID: id, OwnerUserID: 1}, nil
+		},
+	}
+}
+
+func TestUpdateCircleNotificationSetting_Create(t *testing.T) {
+	var saved *models.CircleNotificationSetting
+	settingRepo := &circleNotificationMockSettingRepository{
+		UpsertFunc: func(ctx context.Context, setting *models.CircleNotificationSetting) error {
+			saved = setting
+			return nil
+		},
+	}
+
+	uc := NewCircleNotificationUsecase(circleNotificationOwnedCircleRepo(), settingRepo)
+	setting, err := uc.UpdateSetting(context.Background(), 1, 7, models.ChannelTypeDiscord, "https://discord.com/api/webhooks/1/abc", 5, "18:00", "Asia/Tokyo", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, saved, setting)
+	assert.Equal(t, uint64(7), setting.CircleID)
+	assert.Equal(t, models.ChannelTypeDiscord, setting.ChannelType)
+	assert.Equal(t, 5, setting.Weekday)
+	assert.True(t, setting.IsEnabled)
+}
+
+func TestUpdateCircleNotificationSetting_UpdatesExisting(t *testing.T) {
+	settingRepo := &circleNotificationMockSettingRepository{
+		FindByCircleIDFunc: func(ctx context.Context, circleID uint64) (*models.CircleNotificationSetting, error) {
+			return &models.CircleNotificationSetting{ID: 3, CircleID: circleID, ChannelType: models.ChannelTypeSlack}, nil
+		},
+	}
+
+	uc := NewCircleNotificationUsecase(circleNotificationOwnedCircleRepo(), settingRepo)
+	setting, err := uc.UpdateSetting(context.Background(), 1, 7, models.ChannelTypeSlack, "https://hooks.slack.com/services/new", 1, "09:00", "UTC", false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), setting.ID)
+	assert.Equal(t, "https://hooks.slack.com/services/new", setting.WebhookURL)
+	assert.False(t, setting.IsEnabled)
+}
+
+func TestUpdateCircleNotificationSetting_NotOwner(t *testing.T) {
+	uc := NewCircleNotificationUsecase(circleNotificationOwnedCircleRepo(), &circleNotificationMockSettingRepository{})
+	_, err := uc.UpdateSetting(context.Background(), 2, 7, models.ChannelTypeSlack, "https://hooks.slack.com/services/x", 1, "09:00", "UTC", true)
+
+	assert.EqualError(t, err, "サークルの通知設定を変更する権限がありません")
+}
+
+func TestUpdateCircleNotificationSetting_CircleNotFound(t *testing.T) {
+	circleRepo := &circleMockCircleRepository{
+		FindByIDFunc: func(ctx context.Context, id uint64) (*models.Circle, error) {
+			return nil, errors.New("record not found")
+		},
+	}
+
+	uc := NewCircleNotificationUsecase(circleRepo, &circleNotificationMockSettingRepository{})
+	_, err := uc.GetSetting(context.Background(), 1, 7)
+
+	assert.EqualError(t, err, "サークルが見つかりません")
+}
+
+func TestUpdateCircleNotificationSetting_Validation(t *testing.T) {
+	tests := []struct {
+		name         string
+		channelType  models.ChannelType
+		weekday      int
+		deliveryTime string
+		timezone     string
+		expected     string
+	}{
+		{name: "unsupported channel", channelType: models.ChannelTypeLINE, weekday: 1, deliveryTime: "09:00", timezone: "UTC", expected: "通知先はslackまたはdiscordを指定してください"},
+		{name: "invalid weekday", channelType: models.ChannelTypeSlack, weekday: 7, deliveryTime: "09:00", timezone: "UTC", expected: "曜日は0（日曜）〜6（土曜）で指定してください"},
+		{name: "invalid delivery time", channelType: models.ChannelTypeSlack, weekday: 1, deliveryTime: "9:00", timezone: "UTC", expected: "配信時刻はHH:MM形式で指定してください"},
+		{name: "server local timezone", channelType: models.ChannelTypeSlack, weekday: 1, deliveryTime: "09:00", timezone: "Local", expected: "タイムゾーンが不正です"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settingRepo := &circleNotificationMockSettingRepository{
+				UpsertFunc: func(ctx context.Context, setting *models.CircleNotificationSetting) error {
+					t.Fatal("invalid setting should not be saved")
+					return nil
+				},
+			}
+
+			uc := NewCircleNotificationUsecase(circleNotificationOwnedCircleRepo(), settingRepo)
+			_, err := uc.UpdateSetting(context.Background(), 1, 7, tt.channelType, "https://hooks.slack.com/services/x", tt.weekday, tt.deliveryTime, tt.timezone, true)
+
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestDeleteCircleNotificationSetting_Success(t *testing.T) {
+	var deletedCircleID uint64
+	settingRepo := &circleNotificationMockSettingRepository{
+		DeleteFunc: func(ctx context.Context, circleID uint64) error {
+			deletedCircleID = circleID
+			return nil
+		},
+	}
+
+	uc := NewCircleNotificationUsecase(circleNotificationOwnedCircleRepo(), settingRepo)
+	err := uc.DeleteSetting(context.Background(), 1, 7)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(7), deletedCircleID)
+}
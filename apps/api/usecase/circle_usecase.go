@@ -4,8 +4,10 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"log"
 
 	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
 	"github.com/keeee21/commitly/api/repository"
 )
 
@@ -19,13 +21,15 @@ type ICircleUsecase interface {
 }
 
 type circleUsecase struct {
-	circleRepo repository.ICircleRepository
+	circleRepo     repository.ICircleRepository
+	inboxPublisher notifier.IInboxPublisher
 }
 
 // NewCircleUsecase コンストラクタ
-func NewCircleUsecase(circleRepo repository.ICircleRepository) ICircleUsecase {
+func NewCircleUsecase(circleRepo repository.ICircleRepository, inboxPublisher notifier.IInboxPublisher) ICircleUsecase {
 	return &circleUsecase{
-		circleRepo: circleRepo,
+		circleRepo:     circleRepo,
+		inboxPublisher: inboxPublisher,
 	}
 }
 
@@ -97,7 +101,36 @@ func (u *circleUsecase) JoinCircle(ctx context.Context, userID uint64, inviteCod
 		return nil, err
 	}
 
-	return u.circleRepo.FindByID(ctx, circle.ID)
+	joined, err := u.circleRepo.FindByID(ctx, circle.ID)
+	if err != nil {
+		return nil, err
+	}
+	if joined != nil {
+		u.publishMemberJoined(ctx, joined, userID)
+	}
+	return joined, nil
+}
+
+// publishMemberJoined 参加したユーザー以外のメンバーの受信箱に知らせる（失敗しても参加は取り消さない）
+func (u *circleUsecase) publishMemberJoined(ctx context.Context, circle *models.Circle, joinedUserID uint64) {
+	var joined *models.User
+	for i := range circle.Members {
+		if circle.Members[i].UserID == joinedUserID {
+			joined = &circle.Members[i].User
+		}
+	}
+	if joined == nil {
+		return
+	}
+
+	for _, m := range circle.Members {
+		if m.UserID == joinedUserID {
+			continue
+		}
+		if err := u.inboxPublisher.Publish(ctx, notifier.CircleMemberJoinedInboxItem(m.UserID, circle, joined)); err != nil {
+			log.Printf("Failed to publish circle member joined to inbox for user %d: %v", m.UserID, err)
+		}
+	}
 }
 
 func (u *circleUsecase) LeaveCircle(ctx context.Context, userID uint64, circleID uint64) error {
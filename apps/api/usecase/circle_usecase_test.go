@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -93,6 +94,17 @@ func (m *circleMockCircleRepository) IsMember(ctx context.Context, circleID, use
 	return false, nil
 }
 
+type circleMockInboxPublisher struct {
+	PublishFunc func(ctx context.Context, item notifier.InboxItem) error
+}
+
+func (m *circleMockInboxPublisher) Publish(ctx context.Context, item notifier.InboxItem) error {
+	if m.PublishFunc != nil {
+		return m.PublishFunc(ctx, item)
+	}
+	return nil
+}
+
 func TestGetCircles_Success(t *testing.T) {
 	ctx := context.Background()
 	expected := []models.Circle{
@@ -106,7 +118,7 @@ func TestGetCircles_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	circles, err := uc.GetCircles(ctx, 1)
 
 	assert.NoError(t, err)
@@ -122,7 +134,7 @@ func TestGetCircles_Empty(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	circles, err := uc.GetCircles(ctx, 1)
 
 	assert.NoError(t, err)
@@ -156,7 +168,7 @@ func TestCreateCircle_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	circle, err := uc.CreateCircle(ctx, 1, "テストサークル")
 
 	assert.NoError(t, err)
@@ -173,7 +185,7 @@ func TestCreateCircle_MaxReached(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	circle, err := uc.CreateCircle(ctx, 1, "テストサークル")
 
 	assert.Error(t, err)
@@ -207,7 +219,7 @@ func TestJoinCircle_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	circle, err := uc.JoinCircle(ctx, 1, "abcd1234")
 
 	assert.NoError(t, err)
@@ -215,6 +227,49 @@ func TestJoinCircle_Success(t *testing.T) {
 	assert.Len(t, circle.Members, 2)
 }
 
+func TestJoinCircle_PublishesToOtherMembers(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := &circleMockCircleRepository{
+		FindByInviteCodeFunc: func(ctx context.Context, code string) (*models.Circle, error) {
+			return &models.Circle{ID: 1, Name: "Circle1", OwnerUserID: 2, InviteCode: "abcd1234"}, nil
+		},
+		CountMembersFunc: func(ctx context.Context, circleID uint64) (int64, error) {
+			return 2, nil
+		},
+		FindByIDFunc: func(ctx context.Context, id uint64) (*models.Circle, error) {
+			return &models.Circle{
+				ID: 1, Name: "Circle1", OwnerUserID: 2, InviteCode: "abcd1234",
+				Members: []models.CircleMember{
+					{ID: 1, CircleID: 1, UserID: 2, User: models.User{ID: 2, GithubUsername: "owner"}},
+					{ID: 2, CircleID: 1, UserID: 3, User: models.User{ID: 3, GithubUsername: "member"}},
+					{ID: 3, CircleID: 1, UserID: 1, User: models.User{ID: 1, GithubUsername: "newcomer"}},
+				},
+			}, nil
+		},
+	}
+	var published []notifier.InboxItem
+	publisher := &circleMockInboxPublisher{
+		PublishFunc: func(ctx context.Context, item notifier.InboxItem) error {
+			published = append(published, item)
+			return errors.New("db error")
+		},
+	}
+
+	uc := NewCircleUsecase(mockRepo, publisher)
+	circle, err := uc.JoinCircle(ctx, 1, "abcd1234")
+
+	// 受信箱への保存に失敗しても参加は成功する
+	assert.NoError(t, err)
+	assert.NotNil(t, circle)
+	if assert.Len(t, published, 2) {
+		assert.Equal(t, uint64(2), published[0].UserID)
+		assert.Equal(t, uint64(3), published[1].UserID)
+		assert.Equal(t, models.InAppNotificationCircleMemberJoined, published[0].Type)
+		assert.Equal(t, "newcomer", published[0].Payload["github_username"])
+		assert.Equal(t, "Circle1", published[0].Payload["circle_name"])
+	}
+}
+
 func TestJoinCircle_InvalidCode(t *testing.T) {
 	ctx := context.Background()
 	mockRepo := &circleMockCircleRepository{
@@ -223,7 +278,7 @@ func TestJoinCircle_InvalidCode(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	circle, err := uc.JoinCircle(ctx, 1, "invalid")
 
 	assert.Error(t, err)
@@ -242,7 +297,7 @@ func TestJoinCircle_AlreadyMember(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	circle, err := uc.JoinCircle(ctx, 1, "abcd1234")
 
 	assert.Error(t, err)
@@ -264,7 +319,7 @@ func TestJoinCircle_MaxMembers(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	circle, err := uc.JoinCircle(ctx, 1, "abcd1234")
 
 	assert.Error(t, err)
@@ -286,7 +341,7 @@ func TestLeaveCircle_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	err := uc.LeaveCircle(ctx, 1, 1)
 
 	assert.NoError(t, err)
@@ -300,7 +355,7 @@ func TestLeaveCircle_OwnerCannotLeave(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	err := uc.LeaveCircle(ctx, 1, 1)
 
 	assert.Error(t, err)
@@ -318,7 +373,7 @@ func TestDeleteCircle_Success(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	err := uc.DeleteCircle(ctx, 1, 1)
 
 	assert.NoError(t, err)
@@ -332,7 +387,7 @@ func TestDeleteCircle_NotOwner(t *testing.T) {
 		},
 	}
 
-	uc := NewCircleUsecase(mockRepo)
+	uc := NewCircleUsecase(mockRepo, &circleMockInboxPublisher{})
 	err := uc.DeleteCircle(ctx, 1, 1)
 
 	assert.Error(t, err)
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// DailyNudgeSetting 「今日はまだコミットしていない」リマインダーの設定
+type DailyNudgeSetting struct {
+	NudgeTime string // HH:MM（配信スケジュールのタイムゾーン）
+	Timezone  string
+	Channels  []models.ChannelType // リマインダーを受け取るチャンネル（空の場合は送らない）
+}
+
+// IDailyNudgeUsecase リマインダー設定ユースケースのインターフェース
+type IDailyNudgeUsecase interface {
+	GetSetting(ctx context.Context, userID uint64) (*DailyNudgeSetting, error)
+	UpdateSetting(ctx context.Context, userID uint64, nudgeTime string, channels []models.ChannelType) (*DailyNudgeSetting, error)
+}
+
+type dailyNudgeUsecase struct {
+	scheduleRepo   repository.INotificationScheduleRepository
+	dailyNudgeRepo repository.IDailyNudgeRepository
+}
+
+// NewDailyNudgeUsecase コンストラクタ
+func NewDailyNudgeUsecase(scheduleRepo repository.INotificationScheduleRepository, dailyNudgeRepo repository.IDailyNudgeRepository) IDailyNudgeUsecase {
+	return &dailyNudgeUsecase{
+		scheduleRepo:   scheduleRepo,
+		dailyNudgeRepo: dailyNudgeRepo,
+	}
+}
+
+// GetSetting リマインダーの設定を取得する（配信スケジュールが未設定の場合はデフォルトの時刻）
+func (u *dailyNudgeUsecase) GetSetting(ctx context.Context, userID uint64) (*DailyNudgeSetting, error) {
+	schedule, err := u.findSchedule(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	channels, err := u.dailyNudgeRepo.FindChannelsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	channelTypes := make([]models.ChannelType, 0, len(channels))
+	for _, c := range channels {
+		channelTypes = append(channelTypes, c.ChannelType)
+	}
+	return &DailyNudgeSetting{NudgeTime: schedule.DailyNudgeTime, Timezone: schedule.Timezone, Channels: channelTypes}, nil
+}
+
+// UpdateSetting リマインダーの時刻と受け取るチャンネルを検証して保存する
+func (u *dailyNudgeUsecase) UpdateSetting(ctx context.Context, userID uint64, nudgeTime string, channels []models.ChannelType) (*DailyNudgeSetting, error) {
+	seen := make(map[models.ChannelType]bool, len(channels))
+	for _, c := range channels {
+		if !c.IsValid() {
+			return nil, fmt.Errorf("不明な通知チャンネルです: %s", c)
+		}
+		if seen[c] {
+			return nil, fmt.Errorf("通知チャンネルが重複しています: %s", c)
+		}
+		seen[c] = true
+	}
+
+	schedule, err := u.findSchedule(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	schedule.DailyNudgeTime = nudgeTime
+	if _, _, err := schedule.DailyNudgeClock(); err != nil {
+		return nil, fmt.Errorf("リマインダーの時刻はHH:MM形式で指定してください")
+	}
+
+	if err := u.scheduleRepo.Upsert(ctx, schedule); err != nil {
+		return nil, err
+	}
+	if err := u.dailyNudgeRepo.ReplaceChannels(ctx, userID, channels); err != nil {
+		return nil, err
+	}
+	return &DailyNudgeSetting{NudgeTime: schedule.DailyNudgeTime, Timezone: schedule.Timezone, Channels: channels}, nil
+}
+
+// findSchedule 配信スケジュールを取得する（未設定の場合はデフォルトのスケジュール）
+func (u *dailyNudgeUsecase) findSchedule(ctx context.Context, userID uint64) (*models.NotificationSchedule, error) {
+	schedule, err := u.scheduleRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return models.DefaultNotificationSchedule(userID), nil
+	}
+	return schedule, nil
+}
@@ -0,0 +1,135 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type nudgeMockDailyNudgeRepository struct {
+	FindChannelsByUserIDFunc func(ctx context.Context, userID uint64) ([]models.DailyNudgeChannel, error)
+	ReplaceChannelsFunc      func(ctx context.Context, userID uint64, channelTypes []models.ChannelType) error
+}
+
+func (m *nudgeMockDailyNudgeRepository) FindChannelsByUserID(ctx context.Context, userID uint64) ([]models.DailyNudgeChannel, error) {
+	if m.FindChannelsByUserIDFunc != nil {
+		return m.FindChannelsByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *nudgeMockDailyNudgeRepository) FindAllChannels(ctx context.Context) ([]models.DailyNudgeChannel, error) {
+	return nil, nil
+}
+
+func (m *nudgeMockDailyNudgeRepository) ReplaceChannels(ctx context.Context, userID uint64, channelTypes []models.ChannelType) error {
+	if m.ReplaceChannelsFunc != nil {
+		return m.ReplaceChannelsFunc(ctx, userID, channelTypes)
+	}
+	return nil
+}
+
+func (m *nudgeMockDailyNudgeRepository) FindLogByUserIDAndDate(ctx context.Context, userID uint64, date time.Time) (*models.DailyNudgeLog, error) {
+	return nil, nil
+}
+
+func (m *nudgeMockDailyNudgeRepository) CreateLogIfNotExists(ctx context.Context, nudgeLog *models.DailyNudgeLog) (bool, error) {
+	return true, nil
+}
+
+func (m *nudgeMockDailyNudgeRepository) UpdateLogStatus(ctx context.Context, id uint64, status models.DailyNudgeStatus) error {
+	return nil
+}
+
+func TestDailyNudgeUsecase_GetSetting_Default(t *testing.T) {
+	uc := NewDailyNudgeUsecase(&scheduleMockNotificationScheduleRepository{}, &nudgeMockDailyNudgeRepository{})
+
+	setting, err := uc.GetSetting(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "20:00", setting.NudgeTime)
+	assert.Equal(t, "Asia/Tokyo", setting.Timezone)
+	assert.Empty(t, setting.Channels)
+}
+
+func TestDailyNudgeUsecase_GetSetting_WithChannels(t *testing.T) {
+	scheduleRepo := &scheduleMockNotificationScheduleRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64) (*models.NotificationSchedule, error) {
+			return &models.NotificationSchedule{ID: 2, UserID: userID, Timezone: "UTC", DailyNudgeTime: "21:30"}, nil
+		},
+	}
+	nudgeRepo := &nudgeMockDailyNudgeRepository{
+		FindChannelsByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.DailyNudgeChannel, error) {
+			return []models.DailyNudgeChannel{{UserID: userID, ChannelType: models.ChannelTypeLINE}, {UserID: userID, ChannelType: models.ChannelTypeSlack}}, nil
+		},
+	}
+
+	uc := NewDailyNudgeUsecase(scheduleRepo, nudgeRepo)
+	setting, err := uc.GetSetting(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "21:30", setting.NudgeTime)
+	assert.Equal(t, "UTC", setting.Timezone)
+	assert.Equal(t, []models.ChannelType{models.ChannelTypeLINE, models.ChannelTypeSlack}, setting.Channels)
+}
+
+func TestDailyNudgeUsecase_UpdateSetting_Success(t *testing.T) {
+	var upserted *models.NotificationSchedule
+	var replaced []models.ChannelType
+	scheduleRepo := &scheduleMockNotificationScheduleRepository{
+		UpsertFunc: func(ctx context.Context, schedule *models.NotificationSchedule) error {
+			upserted = schedule
+			return nil
+		},
+	}
+	nudgeRepo := &nudgeMockDailyNudgeRepository{
+		ReplaceChannelsFunc: func(ctx context.Context, userID uint64, channelTypes []models.ChannelType) error {
+			replaced = channelTypes
+			return nil
+		},
+	}
+
+	uc := NewDailyNudgeUsecase(scheduleRepo, nudgeRepo)
+	setting, err := uc.UpdateSetting(context.Background(), 1, "19:45", []models.ChannelType{models.ChannelTypeDiscord})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "19:45", setting.NudgeTime)
+	if assert.NotNil(t, upserted) {
+		assert.Equal(t, "19:45", upserted.DailyNudgeTime)
+		// 未設定の場合はデフォルトの配信スケジュールごと保存する
+		assert.Equal(t, "09:00", upserted.DeliveryTime)
+	}
+	assert.Equal(t, []models.ChannelType{models.ChannelTypeDiscord}, replaced)
+}
+
+func TestDailyNudgeUsecase_UpdateSetting_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		nudgeTime string
+		channels  []models.ChannelType
+		expected  string
+	}{
+		{name: "invalid time", nudgeTime: "8pm", expected: "リマインダーの時刻はHH:MM形式で指定してください"},
+		{name: "unknown channel", nudgeTime: "20:00", channels: []models.ChannelType{"sms"}, expected: "不明な通知チャンネルです: sms"},
+		{name: "duplicate channel", nudgeTime: "20:00", channels: []models.ChannelType{models.ChannelTypeSlack, models.ChannelTypeSlack}, expected: "通知チャンネルが重複しています: slack"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduleRepo := &scheduleMockNotificationScheduleRepository{
+				UpsertFunc: func(ctx context.Context, schedule *models.NotificationSchedule) error {
+					t.Fatal("invalid settings should not be saved")
+					return nil
+				},
+			}
+
+			uc := NewDailyNudgeUsecase(scheduleRepo, &nudgeMockDailyNudgeRepository{})
+			_, err := uc.UpdateSetting(context.Background(), 1, tt.nudgeTime, tt.channels)
+
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
@@ -46,6 +46,10 @@ func (m *mockCommitStatsRepository) UpsertBatch(ctx context.Context, statsList [
 	return nil
 }
 
+func (m *mockCommitStatsRepository) ApplyReconciliation(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error {
+	return nil
+}
+
 func TestGetWeeklyDashboard_Success(t *testing.T) {
 	ctx := context.Background()
 
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// IDiscordNotificationUsecase Discord通知ユースケースのインターフェース
+type IDiscordNotificationUsecase interface {
+	GetSetting(ctx context.Context, userID uint64) (*models.DiscordNotificationSetting, error)
+	Create(ctx context.Context, userID uint64, webhookURL string) (*models.DiscordNotificationSetting, error)
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+}
+
+type discordNotificationUsecase struct {
+	discordRepo repository.IDiscordNotificationSettingRepository
+}
+
+// NewDiscordNotificationUsecase コンストラクタ
+func NewDiscordNotificationUsecase(discordRepo repository.IDiscordNotificationSettingRepository) IDiscordNotificationUsecase {
+	return &discordNotificationUsecase{
+		discordRepo: discordRepo,
+	}
+}
+
+func (u *discordNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.DiscordNotificationSetting, error) {
+	return u.discordRepo.FindByUserID(ctx, userID)
+}
+
+func (u *discordNotificationUsecase) Create(ctx context.Context, userID uint64, webhookURL string) (*models.DiscordNotificationSetting, error) {
+	// 既存の設定を取得
+	existing, err := u.discordRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		// 更新
+		existing.WebhookURL = webhookURL
+		existing.IsEnabled = true
+
+		if err := u.discordRepo.Upsert(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	// 新規作成
+	setting := &models.DiscordNotificationSetting{
+		UserID:     userID,
+		WebhookURL: webhookURL,
+		IsEnabled:  true,
+	}
+
+	if err := u.discordRepo.Upsert(ctx, setting); err != nil {
+		return nil, err
+	}
+
+	return setting, nil
+}
+
+func (u *discordNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return u.discordRepo.UpdateEnabled(ctx, userID, isEnabled)
+}
+
+func (u *discordNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	return u.discordRepo.Delete(ctx, userID)
+}
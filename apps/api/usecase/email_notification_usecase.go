@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// IEmailNotificationUsecase メール通知ユースケースのインターフェース
+type IEmailNotificationUsecase interface {
+	GetSetting(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error)
+	Create(ctx context.Context, user *models.User) (*models.EmailNotificationSetting, error)
+	ResendVerification(ctx context.Context, user *models.User) error
+	Verify(ctx context.Context, token string) error
+	Unsubscribe(ctx context.Context, token string) error
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+}
+
+type emailNotificationUsecase struct {
+	emailRepo    repository.IEmailNotificationSettingRepository
+	emailGateway gateway.IEmailGateway
+	signer       *notifier.EmailTokenSigner
+	baseURL      string
+}
+
+// NewEmailNotificationUsecase コンストラクタ（baseURLは確認リンクに使うAPIの公開URL）
+func NewEmailNotificationUsecase(
+	emailRepo repository.IEmailNotificationSettingRepository,
+	emailGateway gateway.IEmailGateway,
+	signer *notifier.EmailTokenSigner,
+	baseURL string,
+) IEmailNotificationUsecase {
+	return &emailNotificationUsecase{
+		emailRepo:    emailRepo,
+		emailGateway: emailGateway,
+		signer:       signer,
+		baseURL:      baseURL,
+	}
+}
+
+func (u *emailNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error) {
+	return u.emailRepo.FindByUserID(ctx, userID)
+}
+
+// Create GitHubのメールアドレス宛ての通知設定を作成し、確認メールを送る
+// 確認済みのアドレスが変わっていなければ、確認をやり直さずに有効化する
+func (u *emailNotificationUsecase) Create(ctx context.Context, user *models.User) (*models.EmailNotificationSetting, error) {
+	if user.Email == "" {
+		return nil, fmt.Errorf("GitHubアカウントのメールアドレスが取得できていません")
+	}
+
+	// 既存の設定を取得
+	existing, err := u.emailRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := existing
+	if setting == nil {
+		setting = &models.EmailNotificationSetting{UserID: user.ID}
+	}
+	if setting.Email != user.Email {
+		setting.Email = user.Email
+		setting.VerifiedAt = nil
+	}
+	setting.IsEnabled = true
+
+	if err := u.emailRepo.Upsert(ctx, setting); err != nil {
+		return nil, err
+	}
+
+	if setting.VerifiedAt == nil {
+		if err := u.sendVerification(ctx, user, setting); err != nil {
+			return nil, err
+		}
+	}
+
+	return setting, nil
+}
+
+// ResendVerification 確認メールを再送する
+func (u *emailNotificationUsecase) ResendVerification(ctx context.Context, user *models.User) error {
+	setting, err := u.emailRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if setting == nil {
+		return fmt.Errorf("メール通知設定が見つかりません")
+	}
+	if setting.VerifiedAt != nil && setting.Email == user.Email {
+		return fmt.Errorf("メールアドレスは確認済みです")
+	}
+
+	// GitHub側でアドレスが変わっていれば新しいアドレスで確認し直す
+	if setting.Email != user.Email {
+		if user.Email == "" {
+			return fmt.Errorf("GitHubアカウントのメールアドレスが取得できていません")
+		}
+		setting.Email = user.Email
+		setting.VerifiedAt = nil
+		if err := u.emailRepo.Upsert(ctx, setting); err != nil {
+			return err
+		}
+	}
+
+	return u.sendVerification(ctx, user, setting)
+}
+
+// Verify 確認リンクのトークンを検証し、アドレスを確認済みにする
+func (u *emailNotificationUsecase) Verify(ctx context.Context, token string) error {
+	claims, err := u.signer.Verify(token, notifier.EmailTokenPurposeVerify, time.Now())
+	if err != nil {
+		return fmt.Errorf("確認リンクが無効か、有効期限が切れています")
+	}
+
+	setting, err := u.emailRepo.FindByUserID(ctx, claims.UserID)
+	if err != nil {
+		return err
+	}
+	if setting == nil || setting.Email != claims.Email {
+		return fmt.Errorf("確認リンクが無効か、有効期限が切れています")
+	}
+	if setting.VerifiedAt != nil {
+		return nil
+	}
+
+	now := time.Now()
+	setting.VerifiedAt = &now
+	return u.emailRepo.Upsert(ctx, setting)
+}
+
+// Unsubscribe 配信停止リンクのトークンを検証し、メール通知を無効にする
+func (u *emailNotificationUsecase) Unsubscribe(ctx context.Context, token string) error {
+	claims, err := u.signer.Verify(token, notifier.EmailTokenPurposeUnsubscribe, time.Now())
+	if err != nil {
+		return fmt.Errorf("配信停止リンクが無効です")
+	}
+
+	setting, err := u.emailRepo.FindByUserID(ctx, claims.UserID)
+	if err != nil {
+		return err
+	}
+	// 設定が削除済み、またはアドレスが変わっている場合は停止済みとして扱う
+	if setting == nil || setting.Email != claims.Email {
+		return nil
+	}
+
+	return u.emailRepo.UpdateEnabled(ctx, claims.UserID, false)
+}
+
+func (u *emailNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return u.emailRepo.UpdateEnabled(ctx, userID, isEnabled)
+}
+
+func (u *emailNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	return u.emailRepo.Delete(ctx, userID)
+}
+
+// sendVerification 確認リンクを載せたメールをユーザーの言語で送る
+func (u *emailNotificationUsecase) sendVerification(ctx context.Context, user *models.User, setting *models.EmailNotificationSetting) error {
+	token, err := u.signer.Sign(notifier.EmailTokenClaims{
+		Purpose:   notifier.EmailTokenPurposeVerify,
+		UserID:    setting.UserID,
+		Email:     setting.Email,
+		ExpiresAt: time.Now().Add(notifier.EmailVerificationTTL).Unix(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to sign verification token: %w", err)
+	}
+	verifyURL, err := notifier.EmailLinkURL(u.baseURL, notifier.EmailVerifyPath, token)
+	if err != nil {
+		return err
+	}
+
+	message, err := gateway.BuildVerificationEmail(i18n.For(user.Locale), setting.Email, verifyURL, notifier.EmailVerificationTTL)
+	if err != nil {
+		return err
+	}
+	if err := u.emailGateway.Send(ctx, message); err != nil {
+		log.Printf("Failed to send verification email for user %d: %v", setting.UserID, err)
+		return fmt.Errorf("確認メールの送信に失敗しました")
+	}
+	return nil
+}
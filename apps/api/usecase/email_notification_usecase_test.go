@@ -0,0 +1,222 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+type emailMockEmailNotificationSettingRepository struct {
+	FindByUserIDFunc  func(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error)
+	UpsertFunc        func(ctx context.Context, setting *models.EmailNotificationSetting) error
+	UpdateEnabledFunc func(ctx context.Context, userID uint64, isEnabled bool) error
+}
+
+func (m *emailMockEmailNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *emailMockEmailNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.EmailNotificationSetting, error) {
+	return nil, nil
+}
+
+func (m *emailMockEmailNotificationSettingRepository) Upsert(ctx context.Context, setting *models.EmailNotificationSetting) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, setting)
+	}
+	return nil
+}
+
+func (m *emailMockEmailNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	if m.UpdateEnabledFunc != nil {
+		return m.UpdateEnabledFunc(ctx, userID, isEnabled)
+	}
+	return nil
+}
+
+func (m *emailMockEmailNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+type emailMockEmailGateway struct {
+	SendFunc func(ctx context.Context, message *gateway.EmailMessage) error
+}
+
+func (m *emailMockEmailGateway) Send(ctx context.Context, message *gateway.EmailMessage) error {
+	if m.SendFunc != nil {
+		return m.SendFunc(ctx, message)
+	}
+	return nil
+}
+
+var emailVerifyLinkPattern = regexp.MustCompile(`https://api\.example\.com/api/email/verify\?token=\S+`)
+
+func TestEmailNotificationUsecase_Create_SendsVerification(t *testing.T) {
+	var upserted *models.EmailNotificationSetting
+	var sent *gateway.EmailMessage
+	repo := &emailMockEmailNotificationSettingRepository{
+		UpsertFunc: func(ctx context.Context, setting *models.EmailNotificationSetting) error {
+			upserted = setting
+			return nil
+		},
+	}
+	gw := &emailMockEmailGateway{
+		SendFunc: func(ctx context.Context, message *gateway.EmailMessage) error {
+			sent = message
+			return nil
+		},
+	}
+
+	signer := notifier.NewEmailTokenSigner("secret")
+	uc := NewEmailNotificationUsecase(repo, gw, signer, "https://api.example.com")
+	setting, err := uc.Create(context.Background(), &models.User{ID: 1, Email: "user1@example.com"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, setting, upserted)
+	assert.Equal(t, "user1@example.com", setting.Email)
+	assert.Nil(t, setting.VerifiedAt)
+	assert.True(t, setting.IsEnabled)
+
+	if assert.NotNil(t, sent) {
+		assert.Equal(t, "user1@example.com", sent.To)
+		link := emailVerifyLinkPattern.FindString(sent.TextBody)
+		assert.NotEmpty(t, link)
+		u, _ := url.Parse(link)
+		claims, err := signer.Verify(u.Query().Get("token"), notifier.EmailTokenPurposeVerify, time.Now())
+		assert.NoError(t, err)
+		assert.Equal(t, uint64(1), claims.UserID)
+		assert.Equal(t, "user1@example.com", claims.Email)
+	}
+}
+
+func TestEmailNotificationUsecase_Create_KeepsVerifiedAddress(t *testing.T) {
+	verifiedAt := time.Now().Add(-time.Hour)
+	repo := &emailMockEmailNotificationSettingRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error) {
+			return &models.EmailNotificationSetting{ID: 1, UserID: userID, Email: "user1@example.com", VerifiedAt: &verifiedAt}, nil
+		},
+	}
+	gw := &emailMockEmailGateway{
+		SendFunc: func(ctx context.Context, message *gateway.EmailMessage) error {
+			t.Fatal("verification email should not be sent")
+			return nil
+		},
+	}
+
+	uc := NewEmailNotificationUsecase(repo, gw, notifier.NewEmailTokenSigner("secret"), "https://api.example.com")
+	setting, err := uc.Create(context.Background(), &models.User{ID: 1, Email: "user1@example.com"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, &verifiedAt, setting.VerifiedAt)
+	assert.True(t, setting.IsEnabled)
+}
+
+func TestEmailNotificationUsecase_Create_WithoutEmail(t *testing.T) {
+	uc := NewEmailNotificationUsecase(&emailMockEmailNotificationSettingRepository{}, &emailMockEmailGateway{}, notifier.NewEmailTokenSigner("secret"), "https://api.example.com")
+	_, err := uc.Create(context.Background(), &models.User{ID: 1})
+
+	assert.EqualError(t, err, "GitHubアカウントのメールアドレスが取得できていません")
+}
+
+func TestEmailNotificationUsecase_Create_SMTPFailureIsHidden(t *testing.T) {
+	gw := &emailMockEmailGateway{
+		SendFunc: func(ctx context.Context, message *gateway.EmailMessage) error {
+			return errors.New("dial tcp smtp.internal:587: connection refused")
+		},
+	}
+
+	uc := NewEmailNotificationUsecase(&emailMockEmailNotificationSettingRepository{}, gw, notifier.NewEmailTokenSigner("secret"), "https://api.example.com")
+	_, err := uc.Create(context.Background(), &models.User{ID: 1, Email: "user1@example.com"})
+
+	assert.EqualError(t, err, "確認メールの送信に失敗しました")
+}
+
+func TestEmailNotificationUsecase_Verify(t *testing.T) {
+	signer := notifier.NewEmailTokenSigner("secret")
+	sign := func(purpose, email string, expiresAt time.Time) string {
+		token, err := signer.Sign(notifier.EmailTokenClaims{Purpose: purpose, UserID: 1, Email: email, ExpiresAt: expiresAt.Unix()})
+		assert.NoError(t, err)
+		return token
+	}
+
+	tests := []struct {
+		name       string
+		token      string
+		wantErr    bool
+		wantVerify bool
+	}{
+		{"valid", sign(notifier.EmailTokenPurposeVerify, "user1@example.com", time.Now().Add(time.Hour)), false, true},
+		{"expired", sign(notifier.EmailTokenPurposeVerify, "user1@example.com", time.Now().Add(-time.Minute)), true, false},
+		{"wrong purpose", sign(notifier.EmailTokenPurposeUnsubscribe, "user1@example.com", time.Now().Add(time.Hour)), true, false},
+		{"address changed", sign(notifier.EmailTokenPurposeVerify, "old@example.com", time.Now().Add(time.Hour)), true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var upserted *models.EmailNotificationSetting
+			repo := &emailMockEmailNotificationSettingRepository{
+				FindByUserIDFunc: func(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error) {
+					return &models.EmailNotificationSetting{ID: 1, UserID: userID, Email: "user1@example.com", IsEnabled: true}, nil
+				},
+				UpsertFunc: func(ctx context.Context, setting *models.EmailNotificationSetting) error {
+					upserted = setting
+					return nil
+				},
+			}
+
+			uc := NewEmailNotificationUsecase(repo, &emailMockEmailGateway{}, signer, "https://api.example.com")
+			err := uc.Verify(context.Background(), tt.token)
+
+			if tt.wantErr {
+				assert.EqualError(t, err, "確認リンクが無効か、有効期限が切れています")
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.wantVerify {
+				assert.NotNil(t, upserted.VerifiedAt)
+			} else {
+				assert.Nil(t, upserted)
+			}
+		})
+	}
+}
+
+func TestEmailNotificationUsecase_Unsubscribe(t *testing.T) {
+	signer := notifier.NewEmailTokenSigner("secret")
+	token, err := signer.Sign(notifier.EmailTokenClaims{Purpose: notifier.EmailTokenPurposeUnsubscribe, UserID: 1, Email: "user1@example.com"})
+	assert.NoError(t, err)
+
+	var disabledUserID uint64
+	var disabledTo *bool
+	repo := &emailMockEmailNotificationSettingRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64) (*models.EmailNotificationSetting, error) {
+			return &models.EmailNotificationSetting{ID: 1, UserID: userID, Email: "user1@example.com", IsEnabled: true}, nil
+		},
+		UpdateEnabledFunc: func(ctx context.Context, userID uint64, isEnabled bool) error {
+			disabledUserID = userID
+			disabledTo = &isEnabled
+			return nil
+		},
+	}
+
+	uc := NewEmailNotificationUsecase(repo, &emailMockEmailGateway{}, signer, "https://api.example.com")
+
+	assert.NoError(t, uc.Unsubscribe(context.Background(), token))
+	assert.Equal(t, uint64(1), disabledUserID)
+	if assert.NotNil(t, disabledTo) {
+		assert.False(t, *disabledTo)
+	}
+
+	assert.EqualError(t, uc.Unsubscribe(context.Background(), "invalid"), "配信停止リンクが無効です")
+}
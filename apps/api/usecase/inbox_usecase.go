@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// 受信箱の取得件数の既定値と上限
+const (
+	defaultInboxLimit = 20
+	maxInboxLimit     = 100
+)
+
+// IInboxUsecase 受信箱ユースケースのインターフェース
+type IInboxUsecase interface {
+	GetInbox(ctx context.Context, userID uint64, cursor uint64, limit int, unreadOnly bool) (*InboxPage, error)
+	MarkRead(ctx context.Context, userID, id uint64) error
+	MarkAllRead(ctx context.Context, userID uint64) error
+}
+
+// InboxPage 受信箱の1ページ
+type InboxPage struct {
+	Items       []models.InAppNotification
+	NextCursor  uint64 // 次のページの取得に使うカーソル（最後のページの場合は0）
+	UnreadCount int64
+}
+
+type inboxUsecase struct {
+	inAppNotificationRepo repository.IInAppNotificationRepository
+}
+
+// NewInboxUsecase コンストラクタ
+func NewInboxUsecase(inAppNotificationRepo repository.IInAppNotificationRepository) IInboxUsecase {
+	return &inboxUsecase{
+		inAppNotificationRepo: inAppNotificationRepo,
+	}
+}
+
+// GetInbox 通知を新しい順に取得する（cursor が0以外の場合はそのカーソルより古い通知）
+func (u *inboxUsecase) GetInbox(ctx context.Context, userID uint64, cursor uint64, limit int, unreadOnly bool) (*InboxPage, error) {
+	if limit <= 0 {
+		limit = defaultInboxLimit
+	}
+	if limit > maxInboxLimit {
+		limit = maxInboxLimit
+	}
+
+	// 1件多く取得して次のページがあるかを判定する
+	items, err := u.inAppNotificationRepo.FindByUserID(ctx, userID, cursor, unreadOnly, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	page := &InboxPage{Items: items}
+	if len(items) > limit {
+		page.Items = items[:limit]
+		page.NextCursor = page.Items[limit-1].ID
+	}
+
+	page.UnreadCount, err = u.inAppNotificationRepo.CountUnread(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func (u *inboxUsecase) MarkRead(ctx context.Context, userID, id uint64) error {
+	found, err := u.inAppNotificationRepo.MarkRead(ctx, userID, id, time.Now())
+	if err != nil {
+		return err
+	}
+	if !found {
+		return fmt.Errorf("通知が見つかりません")
+	}
+	return nil
+}
+
+func (u *inboxUsecase) MarkAllRead(ctx context.Context, userID uint64) error {
+	return u.inAppNotificationRepo.MarkAllRead(ctx, userID, time.Now())
+}
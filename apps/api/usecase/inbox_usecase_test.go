@@ -0,0 +1,143 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type inboxMockInAppNotificationRepository struct {
+	FindByUserIDFunc func(ctx context.Context, userID uint64, beforeID uint64, unreadOnly bool, limit int) ([]models.InAppNotification, error)
+	CountUnreadFunc  func(ctx context.Context, userID uint64) (int64, error)
+	MarkReadFunc     func(ctx context.Context, userID, id uint64, readAt time.Time) (bool, error)
+	MarkAllReadFunc  func(ctx context.Context, userID uint64, readAt time.Time) error
+}
+
+func (m *inboxMockInAppNotificationRepository) CreateIfNotExists(ctx context.Context, notification *models.InAppNotification) (bool, error) {
+	return true, nil
+}
+
+func (m *inboxMockInAppNotificationRepository) FindByUserID(ctx context.Context, userID uint64, beforeID uint64, unreadOnly bool, limit int) ([]models.InAppNotification, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID, beforeID, unreadOnly, limit)
+	}
+	return nil, nil
+}
+
+func (m *inboxMockInAppNotificationRepository) CountUnread(ctx context.Context, userID uint64) (int64, error) {
+	if m.CountUnreadFunc != nil {
+		return m.CountUnreadFunc(ctx, userID)
+	}
+	return 0, nil
+}
+
+func (m *inboxMockInAppNotificationRepository) MarkRead(ctx context.Context, userID, id uint64, readAt time.Time) (bool, error) {
+	if m.MarkReadFunc != nil {
+		return m.MarkReadFunc(ctx, userID, id, readAt)
+	}
+	return false, nil
+}
+
+func (m *inboxMockInAppNotificationRepository) MarkAllRead(ctx context.Context, userID uint64, readAt time.Time) error {
+	if m.MarkAllReadFunc != nil {
+		return m.MarkAllReadFunc(ctx, userID, readAt)
+	}
+	return nil
+}
+
+// inboxNotifications id が from から1ずつ小さくなる通知を n 件作る
+func inboxNotifications(from uint64, n int) []models.InAppNotification {
+	notifications := make([]models.InAppNotification, 0, n)
+	for i := 0; i < n; i++ {
+		notifications = append(notifications, models.InAppNotification{ID: from - uint64(i), UserID: 1})
+	}
+	return notifications
+}
+
+func TestGetInbox_HasNextPage(t *testing.T) {
+	var gotBeforeID uint64
+	var gotLimit int
+	repo := &inboxMockInAppNotificationRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64, beforeID uint64, unreadOnly bool, limit int) ([]models.InAppNotification, error) {
+			gotBeforeID, gotLimit = beforeID, limit
+			return inboxNotifications(30, limit), nil
+		},
+		CountUnreadFunc: func(ctx context.Context, userID uint64) (int64, error) {
+			return 7, nil
+		},
+	}
+
+	page, err := NewInboxUsecase(repo).GetInbox(context.Background(), 1, 31, 3, false)
+
+	// 次のページがあるかを判定するため1件多く取得する
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(31), gotBeforeID)
+	assert.Equal(t, 4, gotLimit)
+	assert.Len(t, page.Items, 3)
+	assert.Equal(t, uint64(28), page.NextCursor)
+	assert.Equal(t, int64(7), page.UnreadCount)
+}
+
+func TestGetInbox_LastPage(t *testing.T) {
+	repo := &inboxMockInAppNotificationRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64, beforeID uint64, unreadOnly bool, limit int) ([]models.InAppNotification, error) {
+			return inboxNotifications(2, 2), nil
+		},
+	}
+
+	page, err := NewInboxUsecase(repo).GetInbox(context.Background(), 1, 0, 3, false)
+
+	assert.NoError(t, err)
+	assert.Len(t, page.Items, 2)
+	assert.Zero(t, page.NextCursor)
+}
+
+func TestGetInbox_ClampsLimit(t *testing.T) {
+	var limits []int
+	repo := &inboxMockInAppNotificationRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64, beforeID uint64, unreadOnly bool, limit int) ([]models.InAppNotification, error) {
+			limits = append(limits, limit)
+			return nil, nil
+		},
+	}
+	uc := NewInboxUsecase(repo)
+
+	_, err := uc.GetInbox(context.Background(), 1, 0, 0, false)
+	assert.NoError(t, err)
+	_, err = uc.GetInbox(context.Background(), 1, 0, 1000, true)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []int{defaultInboxLimit + 1, maxInboxLimit + 1}, limits)
+}
+
+func TestMarkInboxRead_NotFound(t *testing.T) {
+	repo := &inboxMockInAppNotificationRepository{
+		MarkReadFunc: func(ctx context.Context, userID, id uint64, readAt time.Time) (bool, error) {
+			return false, nil
+		},
+	}
+
+	err := NewInboxUsecase(repo).MarkRead(context.Background(), 1, 99)
+
+	assert.Error(t, err)
+	assert.Equal(t, "通知が見つかりません", err.Error())
+}
+
+func TestMarkInboxRead_Success(t *testing.T) {
+	var gotUserID, gotID uint64
+	repo := &inboxMockInAppNotificationRepository{
+		MarkReadFunc: func(ctx context.Context, userID, id uint64, readAt time.Time) (bool, error) {
+			gotUserID, gotID = userID, id
+			return true, nil
+		},
+	}
+
+	err := NewInboxUsecase(repo).MarkRead(context.Background(), 1, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), gotUserID)
+	assert.Equal(t, uint64(10), gotID)
+}
@@ -0,0 +1,131 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// ILineNotificationUsecase LINE通知ユースケースのインターフェース
+type ILineNotificationUsecase interface {
+	GetSetting(ctx context.Context, userID uint64) (*models.LineNotificationSetting, error)
+	Link(ctx context.Context, userID uint64, linkCode string) (*models.LineNotificationSetting, error)
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+	HandleFollow(ctx context.Context, lineUserID, replyToken string) error
+	HandleUnfollow(ctx context.Context, lineUserID string) error
+}
+
+type lineNotificationUsecase struct {
+	lineRepo     repository.ILineNotificationSettingRepository
+	linkCodeRepo repository.ILineLinkCodeRepository
+	lineGateway  gateway.ILineGateway
+}
+
+// NewLineNotificationUsecase コンストラクタ
+func NewLineNotificationUsecase(
+	lineRepo repository.ILineNotificationSettingRepository,
+	linkCodeRepo repository.ILineLinkCodeRepository,
+	lineGateway gateway.ILineGateway,
+) ILineNotificationUsecase {
+	return &lineNotificationUsecase{
+		lineRepo:     lineRepo,
+		linkCodeRepo: linkCodeRepo,
+		lineGateway:  lineGateway,
+	}
+}
+
+func (u *lineNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.LineNotificationSetting, error) {
+	return u.lineRepo.FindByUserID(ctx, userID)
+}
+
+// Link 友だち追加時に発行した連携コードで、LINEユーザーIDをユーザーに紐づける
+func (u *lineNotificationUsecase) Link(ctx context.Context, userID uint64, linkCode string) (*models.LineNotificationSetting, error) {
+	code, err := u.linkCodeRepo.FindByCode(ctx, linkCode)
+	if err != nil {
+		return nil, err
+	}
+	if code == nil || time.Now().After(code.ExpiresAt) {
+		return nil, fmt.Errorf("連携コードが無効か、有効期限が切れています")
+	}
+
+	// 既存の設定を取得
+	existing, err := u.lineRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	setting := existing
+	if setting == nil {
+		setting = &models.LineNotificationSetting{UserID: userID}
+	}
+	setting.LineUserID = code.LineUserID
+	setting.IsEnabled = true
+
+	if err := u.lineRepo.Upsert(ctx, setting); err != nil {
+		return nil, err
+	}
+
+	// 使用済みのコードは削除する
+	if err := u.linkCodeRepo.DeleteByLineUserID(ctx, code.LineUserID); err != nil {
+		return nil, err
+	}
+
+	return setting, nil
+}
+
+func (u *lineNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return u.lineRepo.UpdateEnabled(ctx, userID, isEnabled)
+}
+
+func (u *lineNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	return u.lineRepo.Delete(ctx, userID)
+}
+
+// HandleFollow 友だち追加されたLINEユーザーに連携コードを発行して返信する
+func (u *lineNotificationUsecase) HandleFollow(ctx context.Context, lineUserID, replyToken string) error {
+	code, err := generateInviteCode()
+	if err != nil {
+		return fmt.Errorf("failed to generate link code: %w", err)
+	}
+
+	linkCode := &models.LineLinkCode{
+		LineUserID: lineUserID,
+		Code:       code,
+		ExpiresAt:  time.Now().Add(models.LineLinkCodeTTL),
+	}
+	if err := u.linkCodeRepo.Upsert(ctx, linkCode); err != nil {
+		return err
+	}
+
+	l := i18n.For(u.followLocale(ctx, lineUserID))
+	message := gateway.BuildLineTextMessage(l.T("line.follow", i18n.Vars{
+		"Code":    code,
+		"Minutes": int(models.LineLinkCodeTTL.Minutes()),
+	}))
+	return u.lineGateway.ReplyMessage(ctx, replyToken, []gateway.LineMessage{message})
+}
+
+// followLocale 友だち追加の返信の言語（まだユーザーと紐づいていないため、LINEアプリの言語設定を使う）
+func (u *lineNotificationUsecase) followLocale(ctx context.Context, lineUserID string) string {
+	profile, err := u.lineGateway.GetProfile(ctx, lineUserID)
+	if err != nil {
+		log.Printf("Failed to get LINE profile for %s: %v", lineUserID, err)
+		return ""
+	}
+	return profile.Language
+}
+
+// HandleUnfollow ブロックされたLINEユーザーへの通知を止める
+func (u *lineNotificationUsecase) HandleUnfollow(ctx context.Context, lineUserID string) error {
+	if err := u.lineRepo.DisableByLineUserID(ctx, lineUserID); err != nil {
+		return err
+	}
+	return u.linkCodeRepo.DeleteByLineUserID(ctx, lineUserID)
+}
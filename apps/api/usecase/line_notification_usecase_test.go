@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type lineMockLineNotificationSettingRepository struct {
+	FindByUserIDFunc        func(ctx context.Context, userID uint64) (*models.LineNotificationSetting, error)
+	UpsertFunc              func(ctx context.Context, setting *models.LineNotificationSetting) error
+	DisableByLineUserIDFunc func(ctx context.Context, lineUserID string) error
+}
+
+func (m *lineMockLineNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.LineNotificationSetting, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *lineMockLineNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.LineNotificationSetting, error) {
+	return nil, nil
+}
+
+func (m *lineMockLineNotificationSettingRepository) Upsert(ctx context.Context, setting *models.LineNotificationSetting) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, setting)
+	}
+	return nil
+}
+
+func (m *lineMockLineNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return nil
+}
+
+func (m *lineMockLineNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func (m *lineMockLineNotificationSettingRepository) DisableByLineUserID(ctx context.Context, lineUserID string) error {
+	if m.DisableByLineUserIDFunc != nil {
+		return m.DisableByLineUserIDFunc(ctx, lineUserID)
+	}
+	return nil
+}
+
+type lineMockLineLinkCodeRepository struct {
+	FindByCodeFunc         func(ctx context.Context, code string) (*models.LineLinkCode, error)
+	UpsertFunc             func(ctx context.Context, linkCode *models.LineLinkCode) error
+	DeleteByLineUserIDFunc func(ctx context.Context, lineUserID string) error
+}
+
+func (m *lineMockLineLinkCodeRepository) FindByCode(ctx context.Context, code string) (*models.LineLinkCode, error) {
+	if m.FindByCodeFunc != nil {
+		return m.FindByCodeFunc(ctx, code)
+	}
+	return nil, nil
+}
+
+func (m *lineMockLineLinkCodeRepository) Upsert(ctx context.Context, linkCode *models.LineLinkCode) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, linkCode)
+	}
+	return nil
+}
+
+func (m *lineMockLineLinkCodeRepository) DeleteByLineUserID(ctx context.Context, lineUserID string) error {
+	if m.DeleteByLineUserIDFunc != nil {
+		return m.DeleteByLineUserIDFunc(ctx, lineUserID)
+	}
+	return nil
+}
+
+type lineMockLineGateway struct {
+	ReplyMessageFunc func(ctx context.Context, replyToken string, messages []gateway.LineMessage) error
+	GetProfileFunc   func(ctx context.Context, lineUserID string) (*gateway.LineProfile, error)
+}
+
+func (m *lineMockLineGateway) PushMessage(ctx context.Context, to string, messages []gateway.LineMessage) error {
+	return nil
+}
+
+func (m *lineMockLineGateway) ReplyMessage(ctx context.Context, replyToken string, messages []gateway.LineMessage) error {
+	if m.ReplyMessageFunc != nil {
+		return m.ReplyMessageFunc(ctx, replyToken, messages)
+	}
+	return nil
+}
+
+func (m *lineMockLineGateway) GetProfile(ctx context.Context, lineUserID string) (*gateway.LineProfile, error) {
+	if m.GetProfileFunc != nil {
+		return m.GetProfileFunc(ctx, lineUserID)
+	}
+	return &gateway.LineProfile{}, nil
+}
+
+func TestLineNotificationUsecase_Link_Success(t *testing.T) {
+	var upserted *models.LineNotificationSetting
+	var deletedCodeFor string
+
+	lineRepo := &lineMockLineNotificationSettingRepository{
+		UpsertFunc: func(ctx context.Context, setting *models.LineNotificationSetting) error {
+			upserted = setting
+			return nil
+		},
+	}
+	linkCodeRepo := &lineMockLineLinkCodeRepository{
+		FindByCodeFunc: func(ctx context.Context, code string) (*models.LineLinkCode, error) {
+			return &models.LineLinkCode{LineUserID: "U111", Code: code, ExpiresAt: time.Now().Add(10 * time.Minute)}, nil
+		},
+		DeleteByLineUserIDFunc: func(ctx context.Context, lineUserID string) error {
+			deletedCodeFor = lineUserID
+			return nil
+		},
+	}
+
+	uc := NewLineNotificationUsecase(lineRepo, linkCodeRepo, &lineMockLineGateway{})
+	setting, err := uc.Link(context.Background(), 1, "ABCD1234")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), setting.UserID)
+	assert.Equal(t, "U111", setting.LineUserID)
+	assert.True(t, setting.IsEnabled)
+	assert.Equal(t, setting, upserted)
+	assert.Equal(t, "U111", deletedCodeFor)
+}
+
+func TestLineNotificationUsecase_Link_InvalidCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		linkCode *models.LineLinkCode
+	}{
+		{"存在しないコード", nil},
+		{"期限切れのコード", &models.LineLinkCode{LineUserID: "U111", Code: "ABCD1234", ExpiresAt: time.Now().Add(-time.Minute)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upserted := false
+			lineRepo := &lineMockLineNotificationSettingRepository{
+				UpsertFunc: func(ctx context.Context, setting *models.LineNotificationSetting) error {
+					upserted = true
+					return nil
+				},
+			}
+			linkCodeRepo := &lineMockLineLinkCodeRepository{
+				FindByCodeFunc: func(ctx context.Context, code string) (*models.LineLinkCode, error) {
+					return tt.linkCode, nil
+				},
+			}
+
+			uc := NewLineNotificationUsecase(lineRepo, linkCodeRepo, &lineMockLineGateway{})
+			setting, err := uc.Link(context.Background(), 1, "ABCD1234")
+
+			assert.Nil(t, setting)
+			assert.EqualError(t, err, "連携コードが無効か、有効期限が切れています")
+			assert.False(t, upserted)
+		})
+	}
+}
+
+func TestLineNotificationUsecase_HandleFollow(t *testing.T) {
+	var savedCode *models.LineLinkCode
+	var replyToken string
+	var replied []gateway.LineMessage
+
+	linkCodeRepo := &lineMockLineLinkCodeRepository{
+		UpsertFunc: func(ctx context.Context, linkCode *models.LineLinkCode) error {
+			savedCode = linkCode
+			return nil
+		},
+	}
+	lineGateway := &lineMockLineGateway{
+		ReplyMessageFunc: func(ctx context.Context, token string, messages []gateway.LineMessage) error {
+			replyToken = token
+			replied = messages
+			return nil
+		},
+	}
+
+	uc := NewLineNotificationUsecase(&lineMockLineNotificationSettingRepository{}, linkCodeRepo, lineGateway)
+	err := uc.HandleFollow(context.Background(), "U111", "reply-token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "U111", savedCode.LineUserID)
+	assert.NotEmpty(t, savedCode.Code)
+	assert.True(t, savedCode.ExpiresAt.After(time.Now()))
+	assert.Equal(t, "reply-token", replyToken)
+	assert.Len(t, replied, 1)
+	assert.Contains(t, replied[0].Text, savedCode.Code)
+	assert.Contains(t, replied[0].Text, "連携コード")
+}
+
+func TestLineNotificationUsecase_HandleFollow_UsesLineLanguage(t *testing.T) {
+	var replied []gateway.LineMessage
+
+	lineGateway := &lineMockLineGateway{
+		GetProfileFunc: func(ctx context.Context, lineUserID string) (*gateway.LineProfile, error) {
+			assert.Equal(t, "U111", lineUserID)
+			return &gateway.LineProfile{DisplayName: "user1", Language: "en"}, nil
+		},
+		ReplyMessageFunc: func(ctx context.Context, token string, messages []gateway.LineMessage) error {
+			replied = messages
+			return nil
+		},
+	}
+
+	uc := NewLineNotificationUsecase(&lineMockLineNotificationSettingRepository{}, &lineMockLineLinkCodeRepository{}, lineGateway)
+	err := uc.HandleFollow(context.Background(), "U111", "reply-token")
+
+	assert.NoError(t, err)
+	if assert.Len(t, replied, 1) {
+		assert.Contains(t, replied[0].Text, "Link code:")
+		assert.NotContains(t, replied[0].Text, "連携コード")
+	}
+}
+
+func TestLineNotificationUsecase_HandleUnfollow(t *testing.T) {
+	var disabled, deleted string
+
+	lineRepo := &lineMockLineNotificationSettingRepository{
+		DisableByLineUserIDFunc: func(ctx context.Context, lineUserID string) error {
+			disabled = lineUserID
+			return nil
+		},
+	}
+	linkCodeRepo := &lineMockLineLinkCodeRepository{
+		DeleteByLineUserIDFunc: func(ctx context.Context, lineUserID string) error {
+			deleted = lineUserID
+			return nil
+		},
+	}
+
+	uc := NewLineNotificationUsecase(lineRepo, linkCodeRepo, &lineMockLineGateway{})
+	err := uc.HandleUnfollow(context.Background(), "U111")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "U111", disabled)
+	assert.Equal(t, "U111", deleted)
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// IMattermostNotificationUsecase Mattermost通知ユースケースのインターフェース
+type IMattermostNotificationUsecase interface {
+	GetSetting(ctx context.Context, userID uint64) (*models.MattermostNotificationSetting, error)
+	Create(ctx context.Context, userID uint64, webhookURL string) (*models.MattermostNotificationSetting, error)
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+}
+
+type mattermostNotificationUsecase struct {
+	mattermostRepo repository.IMattermostNotificationSettingRepository
+}
+
+// NewMattermostNotificationUsecase コンストラクタ
+func NewMattermostNotificationUsecase(mattermostRepo repository.IMattermostNotificationSettingRepository) IMattermostNotificationUsecase {
+	return &mattermostNotificationUsecase{
+		mattermostRepo: mattermostRepo,
+	}
+}
+
+func (u *mattermostNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.MattermostNotificationSetting, error) {
+	return u.mattermostRepo.FindByUserID(ctx, userID)
+}
+
+func (u *mattermostNotificationUsecase) Create(ctx context.Context, userID uint64, webhookURL string) (*models.MattermostNotificationSetting, error) {
+	// 既存の設定を取得
+	existing, err := u.mattermostRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		// 更新
+		existing.WebhookURL = webhookURL
+		existing.IsEnabled = true
+
+		if err := u.mattermostRepo.Upsert(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	// 新規作成
+	setting := &models.MattermostNotificationSetting{
+		UserID:     userID,
+		WebhookURL: webhookURL,
+		IsEnabled:  true,
+	}
+
+	if err := u.mattermostRepo.Upsert(ctx, setting); err != nil {
+		return nil, err
+	}
+
+	return setting, nil
+}
+
+func (u *mattermostNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return u.mattermostRepo.UpdateEnabled(ctx, userID, isEnabled)
+}
+
+func (u *mattermostNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	return u.mattermostRepo.Delete(ctx, userID)
+}
@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// 通知履歴の取得件数の既定値と上限
+const (
+	defaultNotificationHistoryLimit = 20
+	maxNotificationHistoryLimit     = 100
+)
+
+// 通知履歴・テスト送信でユーザーに伝えるエラー
+var (
+	ErrNotificationLogNotFound         = errors.New("通知履歴が見つかりません")
+	ErrNotificationChannelUnsupported  = errors.New("このチャンネルには送信できません")
+	ErrNotificationDestinationNotFound = errors.New("通知先が設定されていないか、無効になっています")
+	ErrNotificationDeliveryFailed      = errors.New("通知の送信に失敗しました")
+)
+
+// INotificationHistoryUsecase 通知履歴ユースケースのインターフェース
+type INotificationHistoryUsecase interface {
+	GetHistory(ctx context.Context, userID uint64, filter repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error)
+	Resend(ctx context.Context, userID, logID uint64) (*models.NotificationLog, error)
+}
+
+type notificationHistoryUsecase struct {
+	notificationLogRepo repository.INotificationLogRepository
+	notifierRegistry    *notifier.Registry
+	reportBuilder       notifier.IReportBuilder
+}
+
+// NewNotificationHistoryUsecase コンストラクタ
+func NewNotificationHistoryUsecase(
+	notificationLogRepo repository.INotificationLogRepository,
+	notifierRegistry *notifier.Registry,
+	reportBuilder notifier.IReportBuilder,
+) INotificationHistoryUsecase {
+	return &notificationHistoryUsecase{
+		notificationLogRepo: notificationLogRepo,
+		notifierRegistry:    notifierRegistry,
+		reportBuilder:       reportBuilder,
+	}
+}
+
+// GetHistory 通知履歴を新しい順に取得する（総件数も返す）
+func (u *notificationHistoryUsecase) GetHistory(ctx context.Context, userID uint64, filter repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error) {
+	if limit <= 0 {
+		limit = defaultNotificationHistoryLimit
+	}
+	if limit > maxNotificationHistoryLimit {
+		limit = maxNotificationHistoryLimit
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	return u.notificationLogRepo.FindByUserID(ctx, userID, filter, limit, offset)
+}
+
+// Resend 過去のレポートを同じ集計期間で作り直し、現在の送信先に送り直す
+func (u *notificationHistoryUsecase) Resend(ctx context.Context, userID, logID uint64) (*models.NotificationLog, error) {
+	notificationLog, err := u.notificationLogRepo.FindByID(ctx, logID)
+	if err != nil {
+		return nil, err
+	}
+	if notificationLog == nil || notificationLog.UserID != userID {
+		return nil, ErrNotificationLogNotFound
+	}
+
+	n, ok := u.notifierRegistry.Get(notificationLog.ChannelType)
+	if !ok {
+		return nil, ErrNotificationChannelUnsupported
+	}
+	destination, err := n.FindDestination(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if destination == nil {
+		return nil, ErrNotificationDestinationNotFound
+	}
+
+	report, err := u.reportBuilder.Build(ctx, notificationLog.Period, userID, destination.User, notifier.LogReportAnchor(notificationLog))
+	if err != nil {
+		return nil, err
+	}
+	message, err := n.Render(*destination, report)
+	if err != nil {
+		return nil, err
+	}
+
+	notificationLog.Attempts++
+	if sendErr := n.Deliver(ctx, *destination, message); sendErr != nil {
+		log.Printf("Failed to resend notification %d for user %d: %v", notificationLog.ID, userID, sendErr)
+		// 送信済みの履歴は失敗で上書きせず、未送信の履歴にだけ今回のエラーを残す
+		if notificationLog.Status != models.NotificationStatusSuccess {
+			notificationLog.ErrorMessage = sendErr.Error()
+			notificationLog.FailureKind = string(notifier.ClassifyFailure(sendErr))
+			if err := u.notificationLogRepo.Update(ctx, notificationLog); err != nil {
+				log.Printf("Failed to save notification log %d: %v", notificationLog.ID, err)
+			}
+		}
+		return nil, fmt.Errorf("%w: %v", ErrNotificationDeliveryFailed, sendErr)
+	}
+
+	notificationLog.Status = models.NotificationStatusSuccess
+	notificationLog.Payload = message.Payload
+	notificationLog.ErrorMessage = ""
+	notificationLog.FailureKind = ""
+	notificationLog.NextRetryAt = nil
+	if err := u.notificationLogRepo.Update(ctx, notificationLog); err != nil {
+		return nil, err
+	}
+	return notificationLog, nil
+}
@@ -0,0 +1,256 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/keeee21/commitly/api/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+type historyMockNotificationLogRepository struct {
+	FindByIDFunc     func(ctx context.Context, id uint64) (*models.NotificationLog, error)
+	FindByUserIDFunc func(ctx context.Context, userID uint64, filter repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error)
+	UpdateFunc       func(ctx context.Context, log *models.NotificationLog) error
+}
+
+func (m *historyMockNotificationLogRepository) Create(ctx context.Context, log *models.NotificationLog) error {
+	return nil
+}
+
+func (m *historyMockNotificationLogRepository) Update(ctx context.Context, log *models.NotificationLog) error {
+	if m.UpdateFunc != nil {
+		return m.UpdateFunc(ctx, log)
+	}
+	return nil
+}
+
+func (m *historyMockNotificationLogRepository) FindByID(ctx context.Context, id uint64) (*models.NotificationLog, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *historyMockNotificationLogRepository) FindByUserID(ctx context.Context, userID uint64, filter repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID, filter, limit, offset)
+	}
+	return nil, 0, nil
+}
+
+func (m *historyMockNotificationLogRepository) FindByDateRange(ctx context.Context, startDate, endDate time.Time) ([]models.NotificationLog, error) {
+	return nil, nil
+}
+
+func (m *historyMockNotificationLogRepository) FindByPeriodStart(ctx context.Context, period string, periodStart time.Time) ([]models.NotificationLog, error) {
+	return nil, nil
+}
+
+func (m *historyMockNotificationLogRepository) FindDueRetries(ctx context.Context, now time.Time, limit int) ([]models.NotificationLog, error) {
+	return nil, nil
+}
+
+func (m *historyMockNotificationLogRepository) FindRecentByUserIDAndChannel(ctx context.Context, userID uint64, channelType models.ChannelType, limit int) ([]models.NotificationLog, error) {
+	return nil, nil
+}
+
+type historyMockNotifier struct {
+	FindDestinationFunc func(ctx context.Context, userID uint64) (*notifier.Destination, error)
+	DeliverFunc         func(ctx context.Context, destination notifier.Destination, message *notifier.Message) error
+}
+
+func (m *historyMockNotifier) ChannelType() models.ChannelType {
+	return models.ChannelTypeSlack
+}
+
+func (m *historyMockNotifier) FindEnabledDestinations(ctx context.Context) ([]notifier.Destination, error) {
+	return nil, nil
+}
+
+func (m *historyMockNotifier) FindDestination(ctx context.Context, userID uint64) (*notifier.Destination, error) {
+	if m.FindDestinationFunc != nil {
+		return m.FindDestinationFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *historyMockNotifier) Render(destination notifier.Destination, report *notifier.Report) (*notifier.Message, error) {
+	return &notifier.Message{Payload: models.JSONPayload{"period": report.Period, "commits": report.UserCommits}}, nil
+}
+
+func (m *historyMockNotifier) RenderTest(destination notifier.Destination) (*notifier.Message, error) {
+	return &notifier.Message{}, nil
+}
+
+func (m *historyMockNotifier) RenderAlert(destination notifier.Destination, alert *notifier.Alert) (*notifier.Message, error) {
+	return &notifier.Message{}, nil
+}
+
+func (m *historyMockNotifier) RenderNudge(destination notifier.Destination, nudge *notifier.Nudge) (*notifier.Message, error) {
+	return &notifier.Message{}, nil
+}
+
+func (m *historyMockNotifier) Deliver(ctx context.Context, destination notifier.Destination, message *notifier.Message) error {
+	if m.DeliverFunc != nil {
+		return m.DeliverFunc(ctx, destination, message)
+	}
+	return nil
+}
+
+func (m *historyMockNotifier) Disable(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+type historyMockReportBuilder struct {
+	BuildFunc func(ctx context.Context, period string, userID uint64, user models.User, anchor time.Time) (*notifier.Report, error)
+}
+
+func (m *historyMockReportBuilder) Build(ctx context.Context, period string, userID uint64, user models.User, anchor time.Time) (*notifier.Report, error) {
+	if m.BuildFunc != nil {
+		return m.BuildFunc(ctx, period, userID, user, anchor)
+	}
+	return &notifier.Report{Period: period}, nil
+}
+
+func historyDestination(userID uint64) *notifier.Destination {
+	return &notifier.Destination{UserID: userID, User: models.User{ID: userID}, ChannelType: models.ChannelTypeSlack, Address: "https://hooks.slack.com/test"}
+}
+
+func TestNotificationHistoryUsecase_GetHistory_ClampsLimit(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int
+		offset        int
+		expectedLimit int
+		expectedOff   int
+	}{
+		{name: "default", limit: 0, offset: 0, expectedLimit: 20, expectedOff: 0},
+		{name: "capped", limit: 500, offset: 40, expectedLimit: 100, expectedOff: 40},
+		{name: "negative offset", limit: 10, offset: -1, expectedLimit: 10, expectedOff: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := repository.NotificationLogFilter{ChannelType: models.ChannelTypeSlack, Status: models.NotificationStatusFailed}
+			repo := &historyMockNotificationLogRepository{
+				FindByUserIDFunc: func(ctx context.Context, userID uint64, f repository.NotificationLogFilter, limit, offset int) ([]models.NotificationLog, int64, error) {
+					assert.Equal(t, uint64(1), userID)
+					assert.Equal(t, filter, f)
+					assert.Equal(t, tt.expectedLimit, limit)
+					assert.Equal(t, tt.expectedOff, offset)
+					return []models.NotificationLog{{ID: 1}}, 42, nil
+				},
+			}
+
+			uc := NewNotificationHistoryUsecase(repo, notifier.NewRegistry(), &historyMockReportBuilder{})
+			logs, total, err := uc.GetHistory(context.Background(), 1, filter, tt.limit, tt.offset)
+
+			assert.NoError(t, err)
+			assert.Len(t, logs, 1)
+			assert.Equal(t, int64(42), total)
+		})
+	}
+}
+
+func TestNotificationHistoryUsecase_Resend_Success(t *testing.T) {
+	periodStart := time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local)
+	sentAt := time.Date(2026, 10, 14, 9, 0, 0, 0, time.Local)
+	var anchor time.Time
+	var updated *models.NotificationLog
+	repo := &historyMockNotificationLogRepository{
+		FindByIDFunc: func(ctx context.Context, id uint64) (*models.NotificationLog, error) {
+			return &models.NotificationLog{
+				ID: id, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "weekly", PeriodStart: &periodStart, SentAt: sentAt,
+				Status: models.NotificationStatusDeadLetter, ErrorMessage: "boom", FailureKind: "permanent", Attempts: 5,
+			}, nil
+		},
+		UpdateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+			updated = log
+			return nil
+		},
+	}
+	builder := &historyMockReportBuilder{
+		BuildFunc: func(ctx context.Context, period string, userID uint64, user models.User, a time.Time) (*notifier.Report, error) {
+			anchor = a
+			return &notifier.Report{Period: period, UserCommits: 12}, nil
+		},
+	}
+	slack := &historyMockNotifier{
+		FindDestinationFunc: func(ctx context.Context, userID uint64) (*notifier.Destination, error) {
+			return historyDestination(userID), nil
+		},
+	}
+
+	uc := NewNotificationHistoryUsecase(repo, notifier.NewRegistry(slack), builder)
+	log, err := uc.Resend(context.Background(), 1, 10)
+
+	assert.NoError(t, err)
+	// 元のレポートと同じ集計期間で作り直す
+	assert.Equal(t, sentAt, anchor)
+	if assert.NotNil(t, updated) {
+		assert.Equal(t, models.NotificationStatusSuccess, updated.Status)
+		assert.Equal(t, 6, updated.Attempts)
+		assert.Empty(t, updated.ErrorMessage)
+		assert.Empty(t, updated.FailureKind)
+		assert.Equal(t, models.JSONPayload{"period": "weekly", "commits": 12}, updated.Payload)
+	}
+	assert.Equal(t, updated, log)
+}
+
+func TestNotificationHistoryUsecase_Resend_OtherUsersLog(t *testing.T) {
+	repo := &historyMockNotificationLogRepository{
+		FindByIDFunc: func(ctx context.Context, id uint64) (*models.NotificationLog, error) {
+			return &models.NotificationLog{ID: id, UserID: 2, ChannelType: models.ChannelTypeSlack, Period: "weekly"}, nil
+		},
+	}
+
+	uc := NewNotificationHistoryUsecase(repo, notifier.NewRegistry(&historyMockNotifier{}), &historyMockReportBuilder{})
+	_, err := uc.Resend(context.Background(), 1, 10)
+
+	assert.ErrorIs(t, err, ErrNotificationLogNotFound)
+}
+
+func TestNotificationHistoryUsecase_Resend_NoDestination(t *testing.T) {
+	repo := &historyMockNotificationLogRepository{
+		FindByIDFunc: func(ctx context.Context, id uint64) (*models.NotificationLog, error) {
+			return &models.NotificationLog{ID: id, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "weekly"}, nil
+		},
+	}
+
+	uc := NewNotificationHistoryUsecase(repo, notifier.NewRegistry(&historyMockNotifier{}), &historyMockReportBuilder{})
+	_, err := uc.Resend(context.Background(), 1, 10)
+
+	assert.ErrorIs(t, err, ErrNotificationDestinationNotFound)
+}
+
+func TestNotificationHistoryUsecase_Resend_DeliverFailureKeepsSuccessfulLog(t *testing.T) {
+	updateCalled := false
+	repo := &historyMockNotificationLogRepository{
+		FindByIDFunc: func(ctx context.Context, id uint64) (*models.NotificationLog, error) {
+			return &models.NotificationLog{ID: id, UserID: 1, ChannelType: models.ChannelTypeSlack, Period: "monthly", Status: models.NotificationStatusSuccess, SentAt: time.Now()}, nil
+		},
+		UpdateFunc: func(ctx context.Context, log *models.NotificationLog) error {
+			updateCalled = true
+			return nil
+		},
+	}
+	slack := &historyMockNotifier{
+		FindDestinationFunc: func(ctx context.Context, userID uint64) (*notifier.Destination, error) {
+			return historyDestination(userID), nil
+		},
+		DeliverFunc: func(ctx context.Context, destination notifier.Destination, message *notifier.Message) error {
+			return errors.New("connection refused")
+		},
+	}
+
+	uc := NewNotificationHistoryUsecase(repo, notifier.NewRegistry(slack), &historyMockReportBuilder{})
+	_, err := uc.Resend(context.Background(), 1, 10)
+
+	assert.ErrorIs(t, err, ErrNotificationDeliveryFailed)
+	assert.False(t, updateCalled)
+}
@@ -0,0 +1,102 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+)
+
+// NotificationChannelPreview チャンネル向けにレンダリングしたレポート
+type NotificationChannelPreview struct {
+	ChannelType models.ChannelType
+	Payload     models.JSONPayload
+}
+
+// NotificationPreview 今送信した場合のレポート
+type NotificationPreview struct {
+	Report   *notifier.Report
+	Channels []NotificationChannelPreview // 有効なチャンネルのみ
+}
+
+// INotificationPreviewUsecase 通知のテスト送信・プレビューユースケースのインターフェース
+type INotificationPreviewUsecase interface {
+	SendTest(ctx context.Context, userID uint64, channelType models.ChannelType) error
+	Preview(ctx context.Context, user *models.User, period string) (*NotificationPreview, error)
+}
+
+type notificationPreviewUsecase struct {
+	notifierRegistry *notifier.Registry
+	reportBuilder    notifier.IReportBuilder
+}
+
+// NewNotificationPreviewUsecase コンストラクタ
+func NewNotificationPreviewUsecase(notifierRegistry *notifier.Registry, reportBuilder notifier.IReportBuilder) INotificationPreviewUsecase {
+	return &notificationPreviewUsecase{
+		notifierRegistry: notifierRegistry,
+		reportBuilder:    reportBuilder,
+	}
+}
+
+// SendTest 送信先の確認用にテスト通知を送る（本番と同じ送信処理を使う）
+func (u *notificationPreviewUsecase) SendTest(ctx context.Context, userID uint64, channelType models.ChannelType) error {
+	n, ok := u.notifierRegistry.Get(channelType)
+	if !ok {
+		return ErrNotificationChannelUnsupported
+	}
+	destination, err := n.FindDestination(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if destination == nil {
+		return ErrNotificationDestinationNotFound
+	}
+
+	message, err := n.RenderTest(*destination)
+	if err != nil {
+		return err
+	}
+	if err := n.Deliver(ctx, *destination, message); err != nil {
+		log.Printf("Failed to send test notification to %s for user %d: %v", channelType, userID, err)
+		return fmt.Errorf("%w: %v", ErrNotificationDeliveryFailed, err)
+	}
+	return nil
+}
+
+// Preview 今送信した場合のレポートを、有効なチャンネルごとにレンダリングして返す
+func (u *notificationPreviewUsecase) Preview(ctx context.Context, user *models.User, period string) (*NotificationPreview, error) {
+	if period != "weekly" && period != "monthly" {
+		return nil, fmt.Errorf("期間はweeklyまたはmonthlyで指定してください")
+	}
+
+	report, err := u.reportBuilder.Build(ctx, period, user.ID, *user, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &NotificationPreview{
+		Report:   report,
+		Channels: []NotificationChannelPreview{},
+	}
+	for _, n := range u.notifierRegistry.All() {
+		destination, err := n.FindDestination(ctx, user.ID)
+		if err != nil {
+			return nil, err
+		}
+		if destination == nil {
+			continue
+		}
+		message, err := n.Render(*destination, report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render %s report: %w", n.ChannelType(), err)
+		}
+		preview.Channels = append(preview.Channels, NotificationChannelPreview{
+			ChannelType: n.ChannelType(),
+			Payload:     message.Payload,
+		})
+	}
+	return preview, nil
+}
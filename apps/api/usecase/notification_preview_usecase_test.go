@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
+	"github.com/stretchr/testify/assert"
+)
+
+type previewMockSlackGateway struct {
+	SendMessageFunc func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error
+}
+
+func (m *previewMockSlackGateway) SendMessage(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(ctx, webhookURL, message)
+	}
+	return nil
+}
+
+type previewMockSlackNotificationSettingRepository struct {
+	setting *models.SlackNotificationSetting
+}
+
+func (m *previewMockSlackNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.SlackNotificationSetting, error) {
+	return m.setting, nil
+}
+
+func (m *previewMockSlackNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.SlackNotificationSetting, error) {
+	return nil, nil
+}
+
+func (m *previewMockSlackNotificationSettingRepository) Upsert(ctx context.Context, setting *models.SlackNotificationSetting) error {
+	return nil
+}
+
+func (m *previewMockSlackNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return nil
+}
+
+func (m *previewMockSlackNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func previewSlackNotifier(setting *models.SlackNotificationSetting, slackGateway gateway.ISlackGateway) notifier.INotifier {
+	return notifier.NewSlackNotifier(&previewMockSlackNotificationSettingRepository{setting: setting}, slackGateway)
+}
+
+func TestNotificationPreviewUsecase_SendTest_UsesProductionDelivery(t *testing.T) {
+	var sentURL string
+	var sent *gateway.SlackMessage
+	slackGateway := &previewMockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			sentURL = webhookURL
+			sent = message
+			return nil
+		},
+	}
+	setting := &models.SlackNotificationSetting{UserID: 1, WebhookURL: "https://hooks.slack.com/services/T/B/X", IsEnabled: true}
+
+	uc := NewNotificationPreviewUsecase(notifier.NewRegistry(previewSlackNotifier(setting, slackGateway)), &historyMockReportBuilder{})
+	err := uc.SendTest(context.Background(), 1, models.ChannelTypeSlack)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/X", sentURL)
+	if assert.NotNil(t, sent) {
+		assert.Equal(t, notifier.TestMessageText(i18n.For("ja")), sent.Text)
+	}
+}
+
+func TestNotificationPreviewUsecase_SendTest_DisabledSetting(t *testing.T) {
+	setting := &models.SlackNotificationSetting{UserID: 1, WebhookURL: "https://hooks.slack.com/services/T/B/X", IsEnabled: false}
+
+	uc := NewNotificationPreviewUsecase(notifier.NewRegistry(previewSlackNotifier(setting, &previewMockSlackGateway{})), &historyMockReportBuilder{})
+	err := uc.SendTest(context.Background(), 1, models.ChannelTypeSlack)
+
+	assert.ErrorIs(t, err, ErrNotificationDestinationNotFound)
+}
+
+func TestNotificationPreviewUsecase_SendTest_ReportsDestinationStatus(t *testing.T) {
+	slackGateway := &previewMockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			return &gateway.HTTPStatusError{Target: "slack webhook", StatusCode: 404, Body: "no_service"}
+		},
+	}
+	setting := &models.SlackNotificationSetting{UserID: 1, WebhookURL: "https://hooks.slack.com/services/T/B/X", IsEnabled: true}
+
+	uc := NewNotificationPreviewUsecase(notifier.NewRegistry(previewSlackNotifier(setting, slackGateway)), &historyMockReportBuilder{})
+	err := uc.SendTest(context.Background(), 1, models.ChannelTypeSlack)
+
+	assert.ErrorIs(t, err, ErrNotificationDeliveryFailed)
+}
+
+func TestNotificationPreviewUsecase_SendTest_UnknownChannel(t *testing.T) {
+	uc := NewNotificationPreviewUsecase(notifier.NewRegistry(), &historyMockReportBuilder{})
+	err := uc.SendTest(context.Background(), 1, models.ChannelTypeLINE)
+
+	assert.ErrorIs(t, err, ErrNotificationChannelUnsupported)
+}
+
+func TestNotificationPreviewUsecase_Preview_RendersEnabledChannels(t *testing.T) {
+	user := &models.User{ID: 1, GithubUsername: "user1"}
+	enabled := &models.SlackNotificationSetting{UserID: 1, WebhookURL: "https://hooks.slack.com/services/T/B/X", IsEnabled: true, User: *user}
+	var builtPeriod string
+	builder := &historyMockReportBuilder{
+		BuildFunc: func(ctx context.Context, period string, userID uint64, u models.User, anchor time.Time) (*notifier.Report, error) {
+			builtPeriod = period
+			assert.Equal(t, "user1", u.GithubUsername)
+			return &notifier.Report{Period: period, Username: u.GithubUsername, UserCommits: 8}, nil
+		},
+	}
+	slackGateway := &previewMockSlackGateway{
+		SendMessageFunc: func(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+			t.Fatal("preview should not send")
+			return nil
+		},
+	}
+
+	uc := NewNotificationPreviewUsecase(notifier.NewRegistry(previewSlackNotifier(enabled, slackGateway)), builder)
+	preview, err := uc.Preview(context.Background(), user, "weekly")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "weekly", builtPeriod)
+	if assert.Len(t, preview.Channels, 1) {
+		assert.Equal(t, models.ChannelTypeSlack, preview.Channels[0].ChannelType)
+		assert.Contains(t, preview.Channels[0].Payload, "blocks")
+	}
+}
+
+func TestNotificationPreviewUsecase_Preview_NoEnabledChannels(t *testing.T) {
+	disabled := &models.SlackNotificationSetting{UserID: 1, WebhookURL: "https://hooks.slack.com/services/T/B/X", IsEnabled: false}
+
+	uc := NewNotificationPreviewUsecase(notifier.NewRegistry(previewSlackNotifier(disabled, &previewMockSlackGateway{})), &historyMockReportBuilder{})
+	preview, err := uc.Preview(context.Background(), &models.User{ID: 1}, "monthly")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "monthly", preview.Report.Period)
+	assert.Empty(t, preview.Channels)
+}
+
+func TestNotificationPreviewUsecase_Preview_InvalidPeriod(t *testing.T) {
+	uc := NewNotificationPreviewUsecase(notifier.NewRegistry(), &historyMockReportBuilder{})
+	_, err := uc.Preview(context.Background(), &models.User{ID: 1}, "daily")
+
+	assert.Error(t, err)
+}
@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// INotificationScheduleUsecase 通知スケジュールユースケースのインターフェース
+type INotificationScheduleUsecase interface {
+	GetSchedule(ctx context.Context, userID uint64) (*models.NotificationSchedule, error)
+	UpdateSchedule(ctx context.Context, userID uint64, weeklyWeekday, monthlyDay int, deliveryTime, timezone string) (*models.NotificationSchedule, error)
+	UpdatePause(ctx context.Context, userID uint64, quietHoursStart, quietHoursEnd, snoozeUntil string) (*models.NotificationSchedule, error)
+}
+
+type notificationScheduleUsecase struct {
+	scheduleRepo repository.INotificationScheduleRepository
+}
+
+// NewNotificationScheduleUsecase コンストラクタ
+func NewNotificationScheduleUsecase(scheduleRepo repository.INotificationScheduleRepository) INotificationScheduleUsecase {
+	return &notificationScheduleUsecase{
+		scheduleRepo: scheduleRepo,
+	}
+}
+
+// GetSchedule 配信スケジュールを取得する（未設定の場合はデフォルトのスケジュールを返す）
+func (u *notificationScheduleUsecase) GetSchedule(ctx context.Context, userID uint64) (*models.NotificationSchedule, error) {
+	schedule, err := u.scheduleRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		return models.DefaultNotificationSchedule(userID), nil
+	}
+	return schedule, nil
+}
+
+// UpdateSchedule 配信スケジュールを検証して保存する
+func (u *notificationScheduleUsecase) UpdateSchedule(ctx context.Context, userID uint64, weeklyWeekday, monthlyDay int, deliveryTime, timezone string) (*models.NotificationSchedule, error) {
+	if weeklyWeekday < 0 || weeklyWeekday > 6 {
+		return nil, fmt.Errorf("曜日は0（日曜）〜6（土曜）で指定してください")
+	}
+	if monthlyDay < 1 || monthlyDay > 31 {
+		return nil, fmt.Errorf("月次レポートの日は1〜31で指定してください")
+	}
+
+	schedule, err := u.scheduleRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if schedule == nil {
+		schedule = &models.NotificationSchedule{UserID: userID}
+	}
+	schedule.WeeklyWeekday = weeklyWeekday
+	schedule.MonthlyDay = monthlyDay
+	schedule.DeliveryTime = deliveryTime
+	schedule.Timezone = timezone
+
+	if _, _, err := schedule.DeliveryClock(); err != nil {
+		return nil, fmt.Errorf("配信時刻はHH:MM形式で指定してください")
+	}
+	// "Local" はサーバーのタイムゾーンになるため受け付けない
+	if _, err := schedule.Location(); err != nil || timezone == "Local" {
+		return nil, fmt.Errorf("タイムゾーンが不正です")
+	}
+
+	if err := u.scheduleRepo.Upsert(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
+
+// UpdatePause 通知を止める時間帯とスヌーズの期限を検証して保存する（空の場合は解除）
+// 時間帯・期限は配信スケジュールのタイムゾーンの現地時刻で判定する
+func (u *notificationScheduleUsecase) UpdatePause(ctx context.Context, userID uint64, quietHoursStart, quietHoursEnd, snoozeUntil string) (*models.NotificationSchedule, error) {
+	if (quietHoursStart == "") != (quietHoursEnd == "") {
+		return nil, fmt.Errorf("通知を止める時間帯は開始と終了の両方を指定してください")
+	}
+
+	schedule, err := u.GetSchedule(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	schedule.QuietHoursStart = quietHoursStart
+	schedule.QuietHoursEnd = quietHoursEnd
+	schedule.SnoozeUntil = nil
+
+	if schedule.HasQuietHours() {
+		start, end, err := schedule.QuietHoursClock()
+		if err != nil {
+			return nil, fmt.Errorf("通知を止める時間帯はHH:MM形式で指定してください")
+		}
+		if start == end {
+			return nil, fmt.Errorf("通知を止める時間帯の開始と終了は別の時刻にしてください")
+		}
+	}
+
+	if snoozeUntil != "" {
+		date, err := time.Parse("2006-01-02", snoozeUntil)
+		if err != nil {
+			return nil, fmt.Errorf("スヌーズの期限はYYYY-MM-DD形式で指定してください")
+		}
+		schedule.SnoozeUntil = &date
+		if !schedule.IsSnoozed(time.Now()) {
+			return nil, fmt.Errorf("スヌーズの期限は今日以降の日付を指定してください")
+		}
+	}
+
+	if err := u.scheduleRepo.Upsert(ctx, schedule); err != nil {
+		return nil, err
+	}
+	return schedule, nil
+}
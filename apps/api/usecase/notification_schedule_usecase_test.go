@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type scheduleMockNotificationScheduleRepository struct {
+	FindByUserIDFunc func(ctx context.Context, userID uint64) (*models.NotificationSchedule, error)
+	UpsertFunc       func(ctx context.Context, schedule *models.NotificationSchedule) error
+}
+
+func (m *scheduleMockNotificationScheduleRepository) FindByUserID(ctx context.Context, userID uint64) (*models.NotificationSchedule, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *scheduleMockNotificationScheduleRepository) FindByUserIDs(ctx context.Context, userIDs []uint64) ([]models.NotificationSchedule, error) {
+	return nil, nil
+}
+
+func (m *scheduleMockNotificationScheduleRepository) Upsert(ctx context.Context, schedule *models.NotificationSchedule) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, schedule)
+	}
+	return nil
+}
+
+func TestNotificationScheduleUsecase_GetSchedule_Default(t *testing.T) {
+	uc := NewNotificationScheduleUsecase(&scheduleMockNotificationScheduleRepository{})
+
+	schedule, err := uc.GetSchedule(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), schedule.ID)
+	assert.Equal(t, 1, schedule.WeeklyWeekday)
+	assert.Equal(t, 1, schedule.MonthlyDay)
+	assert.Equal(t, "09:00", schedule.DeliveryTime)
+	assert.Equal(t, "Asia/Tokyo", schedule.Timezone)
+}
+
+func TestNotificationScheduleUsecase_UpdateSchedule_UpdatesExisting(t *testing.T) {
+	var upserted *models.NotificationSchedule
+	repo := &scheduleMockNotificationScheduleRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64) (*models.NotificationSchedule, error) {
+			return &models.NotificationSchedule{ID: 5, UserID: userID, WeeklyWeekday: 1, MonthlyDay: 1, DeliveryTime: "09:00", Timezone: "Asia/Tokyo"}, nil
+		},
+		UpsertFunc: func(ctx context.Context, schedule *models.NotificationSchedule) error {
+			upserted = schedule
+			return nil
+		},
+	}
+
+	uc := NewNotificationScheduleUsecase(repo)
+	schedule, err := uc.UpdateSchedule(context.Background(), 1, 5, 31, "18:30", "America/New_York")
+
+	assert.NoError(t, err)
+	assert.Equal(t, schedule, upserted)
+	assert.Equal(t, uint64(5), schedule.ID)
+	assert.Equal(t, 5, schedule.WeeklyWeekday)
+	assert.Equal(t, 31, schedule.MonthlyDay)
+	assert.Equal(t, "18:30", schedule.DeliveryTime)
+	assert.Equal(t, "America/New_York", schedule.Timezone)
+}
+
+func TestNotificationScheduleUsecase_UpdateSchedule_Invalid(t *testing.T) {
+	tests := []struct {
+		name          string
+		weeklyWeekday int
+		monthlyDay    int
+		deliveryTime  string
+		timezone      string
+		expected      string
+	}{
+		{"weekday out of range", 7, 1, "09:00", "Asia/Tokyo", "曜日"},
+		{"monthly day zero", 1, 0, "09:00", "Asia/Tokyo", "月次レポートの日"},
+		{"monthly day too large", 1, 32, "09:00", "Asia/Tokyo", "月次レポートの日"},
+		{"time without leading zero", 1, 1, "9:00", "Asia/Tokyo", "配信時刻"},
+		{"time out of range", 1, 1, "24:00", "Asia/Tokyo", "配信時刻"},
+		{"unknown timezone", 1, 1, "09:00", "Mars/Olympus", "タイムゾーン"},
+		{"empty timezone", 1, 1, "09:00", "", "タイムゾーン"},
+		{"server local timezone", 1, 1, "09:00", "Local", "タイムゾーン"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &scheduleMockNotificationScheduleRepository{
+				UpsertFunc: func(ctx context.Context, schedule *models.NotificationSchedule) error {
+					t.Fatal("invalid schedule should not be saved")
+					return nil
+				},
+			}
+
+			uc := NewNotificationScheduleUsecase(repo)
+			_, err := uc.UpdateSchedule(context.Background(), 1, tt.weeklyWeekday, tt.monthlyDay, tt.deliveryTime, tt.timezone)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestNotificationScheduleUsecase_UpdatePause(t *testing.T) {
+	var upserted *models.NotificationSchedule
+	repo := &scheduleMockNotificationScheduleRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64) (*models.NotificationSchedule, error) {
+			return &models.NotificationSchedule{ID: 5, UserID: userID, WeeklyWeekday: 3, MonthlyDay: 1, DeliveryTime: "09:00", Timezone: "Asia/Tokyo"}, nil
+		},
+		UpsertFunc: func(ctx context.Context, schedule *models.NotificationSchedule) error {
+			upserted = schedule
+			return nil
+		},
+	}
+	snoozeUntil := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+
+	uc := NewNotificationScheduleUsecase(repo)
+	schedule, err := uc.UpdatePause(context.Background(), 1, "22:00", "07:00", snoozeUntil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, schedule, upserted)
+	// 配信スケジュールはそのまま
+	assert.Equal(t, 3, schedule.WeeklyWeekday)
+	assert.Equal(t, "22:00", schedule.QuietHoursStart)
+	assert.Equal(t, "07:00", schedule.QuietHoursEnd)
+	if assert.NotNil(t, schedule.SnoozeUntil) {
+		assert.Equal(t, snoozeUntil, schedule.SnoozeUntil.Format("2006-01-02"))
+	}
+
+	// 空の場合は解除する
+	schedule, err = uc.UpdatePause(context.Background(), 1, "", "", "")
+	assert.NoError(t, err)
+	assert.False(t, schedule.HasQuietHours())
+	assert.Nil(t, schedule.SnoozeUntil)
+}
+
+func TestNotificationScheduleUsecase_UpdatePause_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		start       string
+		end         string
+		snoozeUntil string
+		expected    string
+	}{
+		{"start only", "22:00", "", "", "開始と終了の両方"},
+		{"invalid clock", "22:00", "7:00", "", "HH:MM"},
+		{"same start and end", "22:00", "22:00", "", "別の時刻"},
+		{"invalid snooze date", "", "", "2026/10/25", "YYYY-MM-DD"},
+		{"snooze in the past", "", "", "2000-01-01", "今日以降"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &scheduleMockNotificationScheduleRepository{
+				UpsertFunc: func(ctx context.Context, schedule *models.NotificationSchedule) error {
+					t.Fatal("invalid pause should not be saved")
+					return nil
+				},
+			}
+
+			uc := NewNotificationScheduleUsecase(repo)
+			_, err := uc.UpdatePause(context.Background(), 1, tt.start, tt.end, tt.snoozeUntil)
+
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -256,3 +256,60 @@ func (u *signalUsecase) detectSignals(ctx context.Context, userID uint64, circle
 
 	return signals, nil
 }
+
+// circleSignals サークル全体のシグナルを検出する
+// メンバーそれぞれから見た detectSignals の結果を1つにまとめ、関わったメンバーが多い順に並べる
+func (u *signalUsecase) circleSignals(ctx context.Context, circle *models.Circle) ([]Signal, error) {
+	type signalKey struct {
+		typ    string
+		date   string
+		detail string
+	}
+	merged := make(map[signalKey]*Signal)
+	usernames := make(map[signalKey]map[string]bool)
+
+	for _, member := range circle.Members {
+		signals, err := u.detectSignals(ctx, member.UserID, circle)
+		if err != nil {
+			return nil, err
+		}
+		for _, signal := range signals {
+			key := signalKey{typ: signal.Type, date: signal.Date, detail: signal.Detail}
+			if signal.Type == "same_hour" {
+				// 時間帯はメンバーごとに前後するため、同じ日の同時間帯は1件にまとめる
+				key.detail = ""
+			}
+			if merged[key] == nil {
+				s := signal
+				s.Usernames = nil
+				s.AvatarURLs = nil
+				merged[key] = &s
+				usernames[key] = make(map[string]bool)
+			}
+			for _, username := range append(signal.Usernames, member.User.GithubUsername) {
+				usernames[key][username] = true
+			}
+		}
+	}
+
+	result := make([]Signal, 0, len(merged))
+	for key, signal := range merged {
+		for username := range usernames[key] {
+			signal.Usernames = append(signal.Usernames, username)
+		}
+		sort.Strings(signal.Usernames)
+		result = append(result, *signal)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if len(result[i].Usernames) != len(result[j].Usernames) {
+			return len(result[i].Usernames) > len(result[j].Usernames)
+		}
+		if result[i].Date != result[j].Date {
+			return result[i].Date > result[j].Date
+		}
+		return result[i].Type < result[j].Type
+	})
+
+	return result, nil
+}
@@ -116,6 +116,10 @@ func (m *signalMockCommitStatsRepository) UpsertBatch(ctx context.Context, stats
 	return nil
 }
 
+func (m *signalMockCommitStatsRepository) ApplyReconciliation(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error {
+	return nil
+}
+
 func makeCircleWithMembers() *models.Circle {
 	return &models.Circle{
 		ID:   1,
@@ -0,0 +1,257 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// SlackCommand Slackのスラッシュコマンド（`/commitly week` など）
+type SlackCommand struct {
+	TeamID      string
+	UserID      string
+	Text        string // コマンド名より後ろの引数（"week" / "vs alice" など）
+	ResponseURL string
+}
+
+// SlackAction Slackのメッセージ上のボタン操作
+type SlackAction struct {
+	TeamID      string
+	UserID      string
+	ActionID    string
+	ResponseURL string
+}
+
+// ISlackCommandUsecase Slackアプリ（スラッシュコマンド・ボタン操作）ユースケースのインターフェース
+type ISlackCommandUsecase interface {
+	HandleCommand(ctx context.Context, command SlackCommand) error // 応答は response_url に送る
+	HandleAction(ctx context.Context, action SlackAction) error
+	GetLink(ctx context.Context, userID uint64) (*models.SlackUserLink, error)
+	Link(ctx context.Context, userID uint64, linkCode string) (*models.SlackUserLink, error)
+	Unlink(ctx context.Context, userID uint64) error
+}
+
+type slackCommandUsecase struct {
+	linkRepo         repository.ISlackUserLinkRepository
+	linkCodeRepo     repository.ISlackLinkCodeRepository
+	dashboardUsecase IDashboardUsecase
+	rivalUsecase     IRivalUsecase
+	slackGateway     gateway.ISlackGateway
+}
+
+// NewSlackCommandUsecase コンストラクタ
+func NewSlackCommandUsecase(
+	linkRepo repository.ISlackUserLinkRepository,
+	linkCodeRepo repository.ISlackLinkCodeRepository,
+	dashboardUsecase IDashboardUsecase,
+	rivalUsecase IRivalUsecase,
+	slackGateway gateway.ISlackGateway,
+) ISlackCommandUsecase {
+	return &slackCommandUsecase{
+		linkRepo:         linkRepo,
+		linkCodeRepo:     linkCodeRepo,
+		dashboardUsecase: dashboardUsecase,
+		rivalUsecase:     rivalUsecase,
+		slackGateway:     slackGateway,
+	}
+}
+
+// HandleCommand スラッシュコマンドに応答する（集計に失敗した場合もその旨を返す）
+func (u *slackCommandUsecase) HandleCommand(ctx context.Context, command SlackCommand) error {
+	message, err := u.commandMessage(ctx, command.TeamID, command.UserID, strings.Fields(command.Text))
+	if sendErr := u.slackGateway.SendMessage(ctx, command.ResponseURL, message); sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
+// HandleAction ボタン操作に応答する（元のメッセージを置き換える）
+func (u *slackCommandUsecase) HandleAction(ctx context.Context, action SlackAction) error {
+	var args []string
+	switch action.ActionID {
+	case gateway.SlackActionWeek:
+		args = []string{"week"}
+	case gateway.SlackActionMonth:
+		args = []string{"month"}
+	default:
+		return fmt.Errorf("unknown slack action: %s", action.ActionID)
+	}
+
+	message, err := u.commandMessage(ctx, action.TeamID, action.UserID, args)
+	message.ReplaceOriginal = true
+	if sendErr := u.slackGateway.SendMessage(ctx, action.ResponseURL, message); sendErr != nil {
+		return sendErr
+	}
+	return err
+}
+
+// commandMessage コマンドへの応答を組み立てる（エラーの場合も利用者に返すメッセージを返す）
+func (u *slackCommandUsecase) commandMessage(ctx context.Context, teamID, slackUserID string, args []string) (*gateway.SlackMessage, error) {
+	link, err := u.linkRepo.FindBySlackUser(ctx, teamID, slackUserID)
+	if err != nil {
+		return gateway.BuildSlackCommandTextMessage(i18n.For("").T("slack_command.failed", nil)), err
+	}
+	l := i18n.For("")
+	if link != nil {
+		l = i18n.For(link.User.Locale)
+	}
+
+	var name string
+	if len(args) > 0 {
+		name = strings.ToLower(args[0])
+	}
+	if name != "week" && name != "month" && (name != "vs" || len(args) < 2) {
+		return gateway.BuildSlackCommandTextMessage(l.T("slack_command.usage", nil)), nil
+	}
+	if link == nil {
+		return u.linkCodeMessage(ctx, l, teamID, slackUserID)
+	}
+
+	var message *gateway.SlackMessage
+	switch name {
+	case "week":
+		message, err = u.statsMessage(ctx, l, &link.User, "weekly")
+	case "month":
+		message, err = u.statsMessage(ctx, l, &link.User, "monthly")
+	case "vs":
+		message, err = u.versusMessage(ctx, l, &link.User, strings.TrimPrefix(args[1], "@"))
+	}
+	if err != nil {
+		return gateway.BuildSlackCommandTextMessage(l.T("slack_command.failed", nil)), err
+	}
+	return message, nil
+}
+
+// linkCodeMessage 未連携のSlackユーザーに連携コードを発行する
+func (u *slackCommandUsecase) linkCodeMessage(ctx context.Context, l *i18n.Localizer, teamID, slackUserID string) (*gateway.SlackMessage, error) {
+	code, err := generateInviteCode()
+	if err != nil {
+		return gateway.BuildSlackCommandTextMessage(l.T("slack_command.failed", nil)), fmt.Errorf("failed to generate link code: %w", err)
+	}
+
+	linkCode := &models.SlackLinkCode{
+		SlackTeamID: teamID,
+		SlackUserID: slackUserID,
+		Code:        code,
+		ExpiresAt:   time.Now().Add(models.SlackLinkCodeTTL),
+	}
+	if err := u.linkCodeRepo.Upsert(ctx, linkCode); err != nil {
+		return gateway.BuildSlackCommandTextMessage(l.T("slack_command.failed", nil)), err
+	}
+
+	return gateway.BuildSlackCommandTextMessage(l.T("slack_command.link_required", i18n.Vars{
+		"Code":    code,
+		"Minutes": int(models.SlackLinkCodeTTL.Minutes()),
+	})), nil
+}
+
+// statsMessage 自分とライバルのコミット数を返す
+func (u *slackCommandUsecase) statsMessage(ctx context.Context, l *i18n.Localizer, user *models.User, period string) (*gateway.SlackMessage, error) {
+	rivals, err := u.rivalUsecase.GetRivals(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var data *DashboardData
+	if period == "weekly" {
+		data, err = u.dashboardUsecase.GetWeeklyDashboard(ctx, user, rivals)
+	} else {
+		data, err = u.dashboardUsecase.GetMonthlyDashboard(ctx, user, rivals)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]gateway.RivalCommitSummary, 0, len(data.Rivals))
+	for _, rival := range data.Rivals {
+		summaries = append(summaries, gateway.RivalCommitSummary{Username: rival.GithubUsername, Commits: rival.TotalCommits})
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		return summaries[i].Commits > summaries[j].Commits
+	})
+
+	start, end := dashboardRange(data)
+	return gateway.BuildSlackCommandStatsMessage(l, period, user.GithubUsername, data.MyStats.TotalCommits, summaries, start, end), nil
+}
+
+// versusMessage 直近7日間のライバルとのコミット数を比べる
+func (u *slackCommandUsecase) versusMessage(ctx context.Context, l *i18n.Localizer, user *models.User, rivalUsername string) (*gateway.SlackMessage, error) {
+	rivals, err := u.rivalUsecase.GetRivals(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rival *models.Rival
+	for i := range rivals {
+		if strings.EqualFold(rivals[i].RivalGithubUsername, rivalUsername) {
+			rival = &rivals[i]
+			break
+		}
+	}
+	if rival == nil {
+		return gateway.BuildSlackCommandTextMessage(l.T("slack_command.not_rival", i18n.Vars{"Rival": rivalUsername})), nil
+	}
+
+	data, err := u.dashboardUsecase.GetWeeklyDashboard(ctx, user, []models.Rival{*rival})
+	if err != nil {
+		return nil, err
+	}
+
+	summary := gateway.RivalCommitSummary{Username: rival.RivalGithubUsername}
+	if len(data.Rivals) > 0 {
+		summary.Commits = data.Rivals[0].TotalCommits
+	}
+	start, end := dashboardRange(data)
+	return gateway.BuildSlackCommandVersusMessage(l, user.GithubUsername, data.MyStats.TotalCommits, summary, start, end), nil
+}
+
+// GetLink Slackとの連携を取得する（未連携の場合は nil）
+func (u *slackCommandUsecase) GetLink(ctx context.Context, userID uint64) (*models.SlackUserLink, error) {
+	return u.linkRepo.FindByUserID(ctx, userID)
+}
+
+// Link スラッシュコマンドで発行した連携コードで、Slackユーザーをユーザーに紐づける
+func (u *slackCommandUsecase) Link(ctx context.Context, userID uint64, linkCode string) (*models.SlackUserLink, error) {
+	code, err := u.linkCodeRepo.FindByCode(ctx, linkCode)
+	if err != nil {
+		return nil, err
+	}
+	if code == nil || time.Now().After(code.ExpiresAt) {
+		return nil, fmt.Errorf("連携コードが無効か、有効期限が切れています")
+	}
+
+	link := &models.SlackUserLink{
+		UserID:      userID,
+		SlackTeamID: code.SlackTeamID,
+		SlackUserID: code.SlackUserID,
+	}
+	if err := u.linkRepo.Replace(ctx, link); err != nil {
+		return nil, err
+	}
+
+	// 使用済みのコードは削除する
+	if err := u.linkCodeRepo.DeleteBySlackUser(ctx, code.SlackTeamID, code.SlackUserID); err != nil {
+		return nil, err
+	}
+
+	return link, nil
+}
+
+// Unlink Slackとの連携を解除する
+func (u *slackCommandUsecase) Unlink(ctx context.Context, userID uint64) error {
+	return u.linkRepo.DeleteByUserID(ctx, userID)
+}
+
+// dashboardRange ダッシュボードの集計期間を日付に戻す
+func dashboardRange(data *DashboardData) (time.Time, time.Time) {
+	start, _ := time.ParseInLocation("2006-01-02", data.StartDate, time.Local)
+	end, _ := time.ParseInLocation("2006-01-02", data.EndDate, time.Local)
+	return start, end
+}
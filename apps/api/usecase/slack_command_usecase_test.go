@@ -0,0 +1,240 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type slackCommandMockSlackUserLinkRepository struct {
+	FindBySlackUserFunc func(ctx context.Context, teamID, slackUserID string) (*models.SlackUserLink, error)
+	ReplaceFunc         func(ctx context.Context, link *models.SlackUserLink) error
+}
+
+func (m *slackCommandMockSlackUserLinkRepository) FindBySlackUser(ctx context.Context, teamID, slackUserID string) (*models.SlackUserLink, error) {
+	if m.FindBySlackUserFunc != nil {
+		return m.FindBySlackUserFunc(ctx, teamID, slackUserID)
+	}
+	return nil, nil
+}
+
+func (m *slackCommandMockSlackUserLinkRepository) FindByUserID(ctx context.Context, userID uint64) (*models.SlackUserLink, error) {
+	return nil, nil
+}
+
+func (m *slackCommandMockSlackUserLinkRepository) Replace(ctx context.Context, link *models.SlackUserLink) error {
+	if m.ReplaceFunc != nil {
+		return m.ReplaceFunc(ctx, link)
+	}
+	return nil
+}
+
+func (m *slackCommandMockSlackUserLinkRepository) DeleteByUserID(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+type slackCommandMockSlackLinkCodeRepository struct {
+	FindByCodeFunc        func(ctx context.Context, code string) (*models.SlackLinkCode, error)
+	UpsertFunc            func(ctx context.Context, linkCode *models.SlackLinkCode) error
+	DeleteBySlackUserFunc func(ctx context.Context, teamID, slackUserID string) error
+}
+
+func (m *slackCommandMockSlackLinkCodeRepository) FindByCode(ctx context.Context, code string) (*models.SlackLinkCode, error) {
+	if m.FindByCodeFunc != nil {
+		return m.FindByCodeFunc(ctx, code)
+	}
+	return nil, nil
+}
+
+func (m *slackCommandMockSlackLinkCodeRepository) Upsert(ctx context.Context, linkCode *models.SlackLinkCode) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, linkCode)
+	}
+	return nil
+}
+
+func (m *slackCommandMockSlackLinkCodeRepository) DeleteBySlackUser(ctx context.Context, teamID, slackUserID string) error {
+	if m.DeleteBySlackUserFunc != nil {
+		return m.DeleteBySlackUserFunc(ctx, teamID, slackUserID)
+	}
+	return nil
+}
+
+type slackCommandMockSlackGateway struct {
+	url     string
+	message *gateway.SlackMessage
+}
+
+func (m *slackCommandMockSlackGateway) SendMessage(ctx context.Context, webhookURL string, message *gateway.SlackMessage) error {
+	m.url = webhookURL
+	m.message = message
+	return nil
+}
+
+// newSlackCommandTestUsecase 連携済みのユーザー（ライバル1人）を返すユースケースを組み立てる
+func newSlackCommandTestUsecase(linkRepo *slackCommandMockSlackUserLinkRepository, linkCodeRepo *slackCommandMockSlackLinkCodeRepository, slackGateway *slackCommandMockSlackGateway) ISlackCommandUsecase {
+	today := time.Now()
+	commitStatsRepo := &signalMockCommitStatsRepository{
+		FindByGithubUserIDsAndDateRangeFunc: func(ctx context.Context, githubUserIDs []uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+			return []models.CommitStats{
+				{GithubUserID: 12345, Repository: "testuser/app", Date: today, CommitCount: 2},
+				{GithubUserID: 67890, Repository: "alice/app", Date: today, CommitCount: 5},
+			}, nil
+		},
+	}
+	rivalRepo := &rivalMockRivalRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.Rival, error) {
+			return []models.Rival{{ID: 1, UserID: userID, RivalGithubUserID: 67890, RivalGithubUsername: "Alice"}}, nil
+		},
+	}
+
+	return NewSlackCommandUsecase(
+		linkRepo,
+		linkCodeRepo,
+		NewDashboardUsecase(commitStatsRepo),
+		NewRivalUsecase(rivalRepo, &rivalMockGithubGateway{}),
+		slackGateway,
+	)
+}
+
+func linkedSlackUserRepository() *slackCommandMockSlackUserLinkRepository {
+	return &slackCommandMockSlackUserLinkRepository{
+		FindBySlackUserFunc: func(ctx context.Context, teamID, slackUserID string) (*models.SlackUserLink, error) {
+			return &models.SlackUserLink{
+				UserID:      1,
+				SlackTeamID: teamID,
+				SlackUserID: slackUserID,
+				User:        models.User{ID: 1, GithubUserID: 12345, GithubUsername: "testuser", Locale: "en"},
+			}, nil
+		},
+	}
+}
+
+func TestSlackCommandUsecase_HandleCommand_UnlinkedIssuesLinkCode(t *testing.T) {
+	var saved *models.SlackLinkCode
+	linkCodeRepo := &slackCommandMockSlackLinkCodeRepository{
+		UpsertFunc: func(ctx context.Context, linkCode *models.SlackLinkCode) error {
+			saved = linkCode
+			return nil
+		},
+	}
+	slackGateway := &slackCommandMockSlackGateway{}
+	uc := newSlackCommandTestUsecase(&slackCommandMockSlackUserLinkRepository{}, linkCodeRepo, slackGateway)
+
+	err := uc.HandleCommand(context.Background(), SlackCommand{TeamID: "T1", UserID: "U1", Text: "week", ResponseURL: "https://hooks.slack.com/commands/1"})
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, saved) {
+		assert.Equal(t, "T1", saved.SlackTeamID)
+		assert.Equal(t, "U1", saved.SlackUserID)
+		assert.True(t, saved.ExpiresAt.After(time.Now()))
+	}
+	assert.Equal(t, "https://hooks.slack.com/commands/1", slackGateway.url)
+	assert.Contains(t, slackGateway.message.Text, saved.Code)
+}
+
+func TestSlackCommandUsecase_HandleCommand_Week(t *testing.T) {
+	slackGateway := &slackCommandMockSlackGateway{}
+	uc := newSlackCommandTestUsecase(linkedSlackUserRepository(), &slackCommandMockSlackLinkCodeRepository{}, slackGateway)
+
+	err := uc.HandleCommand(context.Background(), SlackCommand{TeamID: "T1", UserID: "U1", Text: "week", ResponseURL: "https://hooks.slack.com/commands/1"})
+
+	assert.NoError(t, err)
+	body, _ := json.Marshal(slackGateway.message)
+	assert.Equal(t, "Commits in the last 7 days: 2 commits", slackGateway.message.Text)
+	assert.Contains(t, string(body), "Alice")
+	assert.Contains(t, string(body), gateway.SlackActionMonth)
+}
+
+func TestSlackCommandUsecase_HandleCommand_Versus(t *testing.T) {
+	slackGateway := &slackCommandMockSlackGateway{}
+	uc := newSlackCommandTestUsecase(linkedSlackUserRepository(), &slackCommandMockSlackLinkCodeRepository{}, slackGateway)
+
+	// ユーザー名の大文字小文字と @ は区別しない
+	err := uc.HandleCommand(context.Background(), SlackCommand{TeamID: "T1", UserID: "U1", Text: "vs @alice", ResponseURL: "https://hooks.slack.com/commands/1"})
+	assert.NoError(t, err)
+	assert.Contains(t, slackGateway.message.Text, "You're 3 commits behind Alice")
+
+	err = uc.HandleCommand(context.Background(), SlackCommand{TeamID: "T1", UserID: "U1", Text: "vs bob", ResponseURL: "https://hooks.slack.com/commands/1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "bob is not one of your rivals", slackGateway.message.Text)
+}
+
+func TestSlackCommandUsecase_HandleCommand_Usage(t *testing.T) {
+	slackGateway := &slackCommandMockSlackGateway{}
+	uc := newSlackCommandTestUsecase(&slackCommandMockSlackUserLinkRepository{}, &slackCommandMockSlackLinkCodeRepository{
+		UpsertFunc: func(ctx context.Context, linkCode *models.SlackLinkCode) error {
+			t.Fatal("link code should not be issued for help")
+			return nil
+		},
+	}, slackGateway)
+
+	err := uc.HandleCommand(context.Background(), SlackCommand{TeamID: "T1", UserID: "U1", Text: "", ResponseURL: "https://hooks.slack.com/commands/1"})
+
+	assert.NoError(t, err)
+	assert.Contains(t, slackGateway.message.Text, "/commitly week")
+}
+
+func TestSlackCommandUsecase_HandleAction_ReplacesOriginal(t *testing.T) {
+	slackGateway := &slackCommandMockSlackGateway{}
+	uc := newSlackCommandTestUsecase(linkedSlackUserRepository(), &slackCommandMockSlackLinkCodeRepository{}, slackGateway)
+
+	err := uc.HandleAction(context.Background(), SlackAction{TeamID: "T1", UserID: "U1", ActionID: gateway.SlackActionMonth, ResponseURL: "https://hooks.slack.com/actions/1"})
+
+	assert.NoError(t, err)
+	assert.True(t, slackGateway.message.ReplaceOriginal)
+	assert.Contains(t, slackGateway.message.Text, "Commits this month")
+}
+
+func TestSlackCommandUsecase_Link(t *testing.T) {
+	var replaced *models.SlackUserLink
+	var deletedUser string
+	linkRepo := &slackCommandMockSlackUserLinkRepository{
+		ReplaceFunc: func(ctx context.Context, link *models.SlackUserLink) error {
+			replaced = link
+			return nil
+		},
+	}
+	linkCodeRepo := &slackCommandMockSlackLinkCodeRepository{
+		FindByCodeFunc: func(ctx context.Context, code string) (*models.SlackLinkCode, error) {
+			return &models.SlackLinkCode{SlackTeamID: "T1", SlackUserID: "U1", Code: code, ExpiresAt: time.Now().Add(time.Minute)}, nil
+		},
+		DeleteBySlackUserFunc: func(ctx context.Context, teamID, slackUserID string) error {
+			deletedUser = slackUserID
+			return nil
+		},
+	}
+	uc := newSlackCommandTestUsecase(linkRepo, linkCodeRepo, &slackCommandMockSlackGateway{})
+
+	link, err := uc.Link(context.Background(), 1, "ABCD1234")
+
+	assert.NoError(t, err)
+	assert.Equal(t, link, replaced)
+	assert.Equal(t, uint64(1), link.UserID)
+	assert.Equal(t, "U1", link.SlackUserID)
+	assert.Equal(t, "U1", deletedUser)
+}
+
+func TestSlackCommandUsecase_Link_ExpiredCode(t *testing.T) {
+	linkCodeRepo := &slackCommandMockSlackLinkCodeRepository{
+		FindByCodeFunc: func(ctx context.Context, code string) (*models.SlackLinkCode, error) {
+			return &models.SlackLinkCode{SlackTeamID: "T1", SlackUserID: "U1", Code: code, ExpiresAt: time.Now().Add(-time.Minute)}, nil
+		},
+	}
+	uc := newSlackCommandTestUsecase(&slackCommandMockSlackUserLinkRepository{
+		ReplaceFunc: func(ctx context.Context, link *models.SlackUserLink) error {
+			t.Fatal("expired code should not link")
+			return nil
+		},
+	}, linkCodeRepo, &slackCommandMockSlackGateway{})
+
+	link, err := uc.Link(context.Background(), 1, "ABCD1234")
+
+	assert.Nil(t, link)
+	assert.EqualError(t, err, "連携コードが無効か、有効期限が切れています")
+}
@@ -2,26 +2,59 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"log"
+	"os"
+	"sort"
 	"time"
 
 	"github.com/keeee21/commitly/api/gateway"
 	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
 	"github.com/keeee21/commitly/api/repository"
 )
 
 // ISyncCommitsUsecase コミット同期ユースケースのインターフェース
 type ISyncCommitsUsecase interface {
 	SyncAllUsers(ctx context.Context) error
-	SyncAllUsersWithDateRange(ctx context.Context, fromDate, toDate *time.Time) error
+	SyncAllUsersWithDateRange(ctx context.Context, fromDate, toDate *time.Time) (*SyncResult, error)
+	SyncAllUsersWithOptions(ctx context.Context, opts SyncOptions) (*SyncResult, error)
 	SyncUser(ctx context.Context, githubUserID uint64, githubUsername string, fromDate, toDate *time.Time) error
 }
 
+// SyncOptions 同期処理のオプション
+type SyncOptions struct {
+	FromDate *time.Time
+	ToDate   *time.Time
+	// Reconcile 期間内の既存統計を最新データで置き換え、存在しなくなった行を削除する
+	Reconcile bool
+	// DryRun DBに書き込まず、書き込む予定の内容（Reconcile時は差分）を出力する
+	DryRun bool
+	// Output ドライラン時の出力先（nilの場合は標準出力）
+	Output io.Writer
+}
+
+// SyncResult 全ユーザー同期の結果
+type SyncResult struct {
+	Succeeded int
+	Skipped   int
+	Failures  []SyncFailure
+}
+
+// SyncFailure 同期に失敗した対象
+type SyncFailure struct {
+	GithubUserID   uint64
+	GithubUsername string
+	Err            error
+}
+
 type syncCommitsUsecase struct {
 	userRepo        repository.IUserRepository
 	rivalRepo       repository.IRivalRepository
 	commitStatsRepo repository.ICommitStatsRepository
 	githubGateway   gateway.IGithubGateway
+	inboxPublisher  notifier.IInboxPublisher
 }
 
 // NewSyncCommitsUsecase コンストラクタ
@@ -30,22 +63,30 @@ func NewSyncCommitsUsecase(
 	rivalRepo repository.IRivalRepository,
 	commitStatsRepo repository.ICommitStatsRepository,
 	githubGateway gateway.IGithubGateway,
+	inboxPublisher notifier.IInboxPublisher,
 ) ISyncCommitsUsecase {
 	return &syncCommitsUsecase{
 		userRepo:        userRepo,
 		rivalRepo:       rivalRepo,
 		commitStatsRepo: commitStatsRepo,
 		githubGateway:   githubGateway,
+		inboxPublisher:  inboxPublisher,
 	}
 }
 
 func (u *syncCommitsUsecase) SyncAllUsers(ctx context.Context) error {
-	return u.SyncAllUsersWithDateRange(ctx, nil, nil)
+	_, err := u.SyncAllUsersWithDateRange(ctx, nil, nil)
+	return err
 }
 
-func (u *syncCommitsUsecase) SyncAllUsersWithDateRange(ctx context.Context, fromDate, toDate *time.Time) error {
+func (u *syncCommitsUsecase) SyncAllUsersWithDateRange(ctx context.Context, fromDate, toDate *time.Time) (*SyncResult, error) {
+	return u.SyncAllUsersWithOptions(ctx, SyncOptions{FromDate: fromDate, ToDate: toDate})
+}
+
+func (u *syncCommitsUsecase) SyncAllUsersWithOptions(ctx context.Context, opts SyncOptions) (*SyncResult, error) {
 	// 同期が必要なGithubユーザーIDを収集（ユーザー + ライバル）
 	syncTargets := make(map[uint64]string) // githubUserID -> username
+	userIDs := make(map[uint64]uint64)     // githubUserID -> userID（登録済みユーザーのみ）
 
 	log.Println("SyncAllUsers: collecting users to sync...")
 
@@ -53,10 +94,11 @@ func (u *syncCommitsUsecase) SyncAllUsersWithDateRange(ctx context.Context, from
 	users, err := u.userRepo.FindAll(ctx)
 	if err != nil {
 		log.Printf("Failed to get all users: %v", err)
-		return err
+		return nil, err
 	}
 	for _, user := range users {
 		syncTargets[user.GithubUserID] = user.GithubUsername
+		userIDs[user.GithubUserID] = user.ID
 	}
 	log.Printf("Found %d users to sync", len(users))
 
@@ -64,7 +106,7 @@ func (u *syncCommitsUsecase) SyncAllUsersWithDateRange(ctx context.Context, from
 	rivals, err := u.rivalRepo.FindAllDistinctRivals(ctx)
 	if err != nil {
 		log.Printf("Failed to get all rivals: %v", err)
-		return err
+		return nil, err
 	}
 	for _, rival := range rivals {
 		// ユーザーと重複していない場合のみ追加
@@ -75,25 +117,46 @@ func (u *syncCommitsUsecase) SyncAllUsersWithDateRange(ctx context.Context, from
 	log.Printf("Total %d unique users/rivals to sync", len(syncTargets))
 
 	// 各ユーザーのコミット情報を同期
-	var syncErrors []error
+	result := &SyncResult{}
 	for githubUserID, username := range syncTargets {
-		if err := u.SyncUser(ctx, githubUserID, username, fromDate, toDate); err != nil {
+		// キャンセル済みの場合は残りの対象をスキップ
+		if ctx.Err() != nil {
+			result.Skipped++
+			continue
+		}
+
+		if err := u.syncUser(ctx, githubUserID, username, opts); err != nil {
 			log.Printf("Failed to sync user %s: %v", username, err)
-			syncErrors = append(syncErrors, err)
+			result.Failures = append(result.Failures, SyncFailure{
+				GithubUserID:   githubUserID,
+				GithubUsername: username,
+				Err:            err,
+			})
+			// 登録済みユーザー自身の同期に失敗した場合は受信箱で知らせる
+			if userID, ok := userIDs[githubUserID]; ok && !opts.DryRun {
+				if err := u.inboxPublisher.Publish(ctx, notifier.SyncFailedInboxItem(userID, time.Now())); err != nil {
+					log.Printf("Failed to publish sync failure to inbox for user %d: %v", userID, err)
+				}
+			}
 			continue
 		}
+		result.Succeeded++
 	}
 
-	if len(syncErrors) > 0 {
-		log.Printf("Sync completed with %d errors", len(syncErrors))
+	if len(result.Failures) > 0 {
+		log.Printf("Sync completed with %d errors", len(result.Failures))
 	} else {
 		log.Println("Sync completed successfully")
 	}
 
-	return nil
+	return result, nil
 }
 
 func (u *syncCommitsUsecase) SyncUser(ctx context.Context, githubUserID uint64, githubUsername string, fromDate, toDate *time.Time) error {
+	return u.syncUser(ctx, githubUserID, githubUsername, SyncOptions{FromDate: fromDate, ToDate: toDate})
+}
+
+func (u *syncCommitsUsecase) syncUser(ctx context.Context, githubUserID uint64, githubUsername string, opts SyncOptions) error {
 	log.Printf("Syncing commits for user: %s", githubUsername)
 
 	// 日付範囲を設定（デフォルトは過去1年）
@@ -101,18 +164,50 @@ func (u *syncCommitsUsecase) SyncUser(ctx context.Context, githubUserID uint64,
 	from := now.AddDate(-1, 0, 0)
 	to := now
 
-	if fromDate != nil {
-		from = *fromDate
+	if opts.FromDate != nil {
+		from = *opts.FromDate
+	}
+	if opts.ToDate != nil {
+		to = *opts.ToDate
+	}
+
+	statsList, failedRepos, err := u.collectCommitStats(ctx, githubUserID, githubUsername, from, to)
+	if err != nil {
+		return err
+	}
+
+	if opts.Reconcile {
+		return u.reconcileUser(ctx, githubUserID, githubUsername, from, to, statsList, failedRepos, opts)
 	}
-	if toDate != nil {
-		to = *toDate
+
+	if opts.DryRun {
+		printCommitStatsUpserts(dryRunOutput(opts), githubUsername, statsList)
+		return nil
+	}
+
+	if len(statsList) > 0 {
+		for _, s := range statsList {
+			log.Printf("  -> %s %s: %d commits", s.Date.Format("2006-01-02"), s.Repository, s.CommitCount)
+		}
+		if err := u.commitStatsRepo.UpsertBatch(ctx, statsList); err != nil {
+			return err
+		}
+		log.Printf("Saved %d commit stats for user: %s", len(statsList), githubUsername)
+	} else {
+		log.Printf("No commit stats to save for user: %s", githubUsername)
 	}
 
+	return nil
+}
+
+// collectCommitStats GitHubから期間内のコミット統計を集計する
+// 取得に失敗したリポジトリ名も併せて返す
+func (u *syncCommitsUsecase) collectCommitStats(ctx context.Context, githubUserID uint64, githubUsername string, from, to time.Time) ([]models.CommitStats, map[string]bool, error) {
 	// ユーザーの公開リポジトリ一覧を取得
 	repos, err := u.githubGateway.GetUserPublicRepos(ctx, githubUsername)
 	if err != nil {
 		log.Printf("Failed to get repos for %s: %v", githubUsername, err)
-		return err
+		return nil, nil, err
 	}
 
 	log.Printf("Found %d public repos for user: %s", len(repos), githubUsername)
@@ -128,11 +223,13 @@ func (u *syncCommitsUsecase) SyncUser(ctx context.Context, githubUserID uint64,
 		hourCounts map[int]int
 	}
 	commitsByKey := make(map[repoDateKey]*commitInfo)
+	failedRepos := make(map[string]bool)
 
 	for _, repo := range repos {
 		commits, err := u.githubGateway.GetRepositoryCommits(ctx, repo.Owner.Login, repo.Name, githubUsername, from, to)
 		if err != nil {
 			log.Printf("Failed to get commits for %s/%s: %v", repo.Owner.Login, repo.Name, err)
+			failedRepos[repo.FullName] = true
 			continue
 		}
 
@@ -155,7 +252,6 @@ func (u *syncCommitsUsecase) SyncUser(ctx context.Context, githubUserID uint64,
 		}
 	}
 
-	// コミット統計を保存
 	var statsList []models.CommitStats
 	for key, info := range commitsByKey {
 		date, err := time.Parse("2006-01-02", key.date)
@@ -175,21 +271,156 @@ func (u *syncCommitsUsecase) SyncUser(ctx context.Context, githubUserID uint64,
 		})
 	}
 
-	if len(statsList) > 0 {
-		for _, s := range statsList {
-			log.Printf("  -> %s %s: %d commits", s.Date.Format("2006-01-02"), s.Repository, s.CommitCount)
-		}
-		if err := u.commitStatsRepo.UpsertBatch(ctx, statsList); err != nil {
+	return statsList, failedRepos, nil
+}
+
+// CommitStatsChange 更新される統計の変更前後
+type CommitStatsChange struct {
+	Before models.CommitStats
+	After  models.CommitStats
+}
+
+// CommitStatsDiff 既存の統計と最新データの差分
+type CommitStatsDiff struct {
+	Added     []models.CommitStats
+	Updated   []CommitStatsChange
+	Removed   []models.CommitStats
+	Unchanged int
+}
+
+// reconcileUser 期間内の統計を最新データで置き換える
+func (u *syncCommitsUsecase) reconcileUser(
+	ctx context.Context,
+	githubUserID uint64,
+	githubUsername string,
+	from, to time.Time,
+	fresh []models.CommitStats,
+	failedRepos map[string]bool,
+	opts SyncOptions,
+) error {
+	// 集計は日付単位のため、期間も日付境界に揃える
+	startDate := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+
+	if opts.DryRun {
+		existing, err := u.commitStatsRepo.FindByGithubUserIDAndDateRange(ctx, githubUserID, startDate, endDate)
+		if err != nil {
 			return err
 		}
-		log.Printf("Saved %d commit stats for user: %s", len(statsList), githubUsername)
-	} else {
-		log.Printf("No commit stats to save for user: %s", githubUsername)
+		printCommitStatsDiff(dryRunOutput(opts), githubUsername, diffCommitStats(existing, fresh, failedRepos))
+		return nil
+	}
+
+	// 既存統計はトランザクション内でロックして読み、その場で差分を取る
+	var diff CommitStatsDiff
+	err := u.commitStatsRepo.ApplyReconciliation(ctx, githubUserID, startDate, endDate, fresh, func(existing []models.CommitStats) []uint64 {
+		diff = diffCommitStats(existing, fresh, failedRepos)
+		deleteIDs := make([]uint64, 0, len(diff.Removed))
+		for _, s := range diff.Removed {
+			deleteIDs = append(deleteIDs, s.ID)
+		}
+		return deleteIDs
+	})
+	if err != nil {
+		return err
 	}
 
+	log.Printf("Reconciled commit stats for user %s (added: %d, updated: %d, removed: %d, unchanged: %d)",
+		githubUsername, len(diff.Added), len(diff.Updated), len(diff.Removed), diff.Unchanged)
 	return nil
 }
 
+// diffCommitStats 既存の統計と最新データを (日付, リポジトリ) 単位で比較する
+// 取得に失敗したリポジトリの既存行は削除対象から除外する
+func diffCommitStats(existing, fresh []models.CommitStats, preservedRepos map[string]bool) CommitStatsDiff {
+	type statsKey struct {
+		date string
+		repo string
+	}
+
+	existingByKey := make(map[statsKey]models.CommitStats, len(existing))
+	for _, s := range existing {
+		existingByKey[statsKey{date: s.Date.Format("2006-01-02"), repo: s.Repository}] = s
+	}
+
+	var diff CommitStatsDiff
+	for _, s := range fresh {
+		key := statsKey{date: s.Date.Format("2006-01-02"), repo: s.Repository}
+		before, exists := existingByKey[key]
+		if !exists {
+			diff.Added = append(diff.Added, s)
+			continue
+		}
+		delete(existingByKey, key)
+
+		if before.CommitCount != s.CommitCount || before.Language != s.Language {
+			diff.Updated = append(diff.Updated, CommitStatsChange{Before: before, After: s})
+		} else {
+			diff.Unchanged++
+		}
+	}
+
+	for _, s := range existingByKey {
+		if preservedRepos[s.Repository] {
+			diff.Unchanged++
+			continue
+		}
+		diff.Removed = append(diff.Removed, s)
+	}
+
+	sortCommitStats(diff.Added)
+	sortCommitStats(diff.Removed)
+	sort.Slice(diff.Updated, func(i, j int) bool {
+		return lessCommitStats(diff.Updated[i].After, diff.Updated[j].After)
+	})
+
+	return diff
+}
+
+func sortCommitStats(stats []models.CommitStats) {
+	sort.Slice(stats, func(i, j int) bool {
+		return lessCommitStats(stats[i], stats[j])
+	})
+}
+
+func lessCommitStats(a, b models.CommitStats) bool {
+	if !a.Date.Equal(b.Date) {
+		return a.Date.Before(b.Date)
+	}
+	return a.Repository < b.Repository
+}
+
+// dryRunOutput ドライランの出力先を返す
+func dryRunOutput(opts SyncOptions) io.Writer {
+	if opts.Output == nil {
+		return os.Stdout
+	}
+	return opts.Output
+}
+
+// printCommitStatsUpserts upsert予定のCommitStatsを人が読める形式で出力する
+func printCommitStatsUpserts(w io.Writer, githubUsername string, statsList []models.CommitStats) {
+	fmt.Fprintf(w, "[dry-run] %s: %d commit stats to upsert\n", githubUsername, len(statsList))
+	for _, s := range statsList {
+		fmt.Fprintf(w, "  * %s %s: %d commits\n", s.Date.Format("2006-01-02"), s.Repository, s.CommitCount)
+	}
+}
+
+// printCommitStatsDiff 差分を人が読める形式で出力する
+func printCommitStatsDiff(w io.Writer, githubUsername string, diff CommitStatsDiff) {
+	fmt.Fprintf(w, "[dry-run] %s: +%d ~%d -%d (unchanged: %d)\n",
+		githubUsername, len(diff.Added), len(diff.Updated), len(diff.Removed), diff.Unchanged)
+	for _, s := range diff.Added {
+		fmt.Fprintf(w, "  + %s %s: %d commits\n", s.Date.Format("2006-01-02"), s.Repository, s.CommitCount)
+	}
+	for _, c := range diff.Updated {
+		fmt.Fprintf(w, "  ~ %s %s: %d -> %d commits\n", c.After.Date.Format("2006-01-02"), c.After.Repository, c.Before.CommitCount, c.After.CommitCount)
+	}
+	for _, s := range diff.Removed {
+		fmt.Fprintf(w, "  - %s %s: %d commits\n", s.Date.Format("2006-01-02"), s.Repository, s.CommitCount)
+	}
+}
+
 func mostFrequentHour(hourCounts map[int]int) int {
 	maxCount := 0
 	maxHour := 0
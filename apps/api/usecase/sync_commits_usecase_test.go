@@ -3,11 +3,13 @@ package usecase
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/keeee21/commitly/api/gateway"
 	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/notifier"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -28,6 +30,14 @@ func (m *syncMockUserRepository) Update(ctx context.Context, user *models.User)
 	return nil
 }
 
+func (m *syncMockUserRepository) UpdateNotificationAlert(ctx context.Context, id uint64, alertAt *time.Time) error {
+	return nil
+}
+
+func (m *syncMockUserRepository) UpdateLocale(ctx context.Context, id uint64, locale string) error {
+	return nil
+}
+
 func (m *syncMockUserRepository) FindByID(ctx context.Context, id uint64) (*models.User, error) {
 	return nil, nil
 }
@@ -77,10 +87,15 @@ func (m *syncMockRivalRepository) FindAllDistinctRivals(ctx context.Context) ([]
 
 // syncMockCommitStatsRepository テスト用のモックリポジトリ
 type syncMockCommitStatsRepository struct {
-	UpsertBatchFunc func(ctx context.Context, statsList []models.CommitStats) error
+	FindByGithubUserIDAndDateRangeFunc func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error)
+	UpsertBatchFunc                    func(ctx context.Context, statsList []models.CommitStats) error
+	ApplyReconciliationFunc            func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error
 }
 
 func (m *syncMockCommitStatsRepository) FindByGithubUserIDAndDateRange(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+	if m.FindByGithubUserIDAndDateRangeFunc != nil {
+		return m.FindByGithubUserIDAndDateRangeFunc(ctx, githubUserID, startDate, endDate)
+	}
 	return nil, nil
 }
 
@@ -99,6 +114,13 @@ func (m *syncMockCommitStatsRepository) UpsertBatch(ctx context.Context, statsLi
 	return nil
 }
 
+func (m *syncMockCommitStatsRepository) ApplyReconciliation(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error {
+	if m.ApplyReconciliationFunc != nil {
+		return m.ApplyReconciliationFunc(ctx, githubUserID, startDate, endDate, statsList, selectDeletes)
+	}
+	return nil
+}
+
 // syncMockGithubGateway テスト用のモックゲートウェイ
 type syncMockGithubGateway struct {
 	GetUserPublicReposFunc   func(ctx context.Context, username string) ([]gateway.GithubRepo, error)
@@ -131,6 +153,17 @@ func (m *syncMockGithubGateway) GetRepositoryCommits(ctx context.Context, owner,
 	return nil, nil
 }
 
+type syncMockInboxPublisher struct {
+	PublishFunc func(ctx context.Context, item notifier.InboxItem) error
+}
+
+func (m *syncMockInboxPublisher) Publish(ctx context.Context, item notifier.InboxItem) error {
+	if m.PublishFunc != nil {
+		return m.PublishFunc(ctx, item)
+	}
+	return nil
+}
+
 func TestSyncAllUsers_Success(t *testing.T) {
 	ctx := context.Background()
 
@@ -191,7 +224,7 @@ func TestSyncAllUsers_Success(t *testing.T) {
 		},
 	}
 
-	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway)
+	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway, &syncMockInboxPublisher{})
 	err := usecase.SyncAllUsers(ctx)
 
 	assert.NoError(t, err)
@@ -215,7 +248,7 @@ func TestSyncAllUsers_NoUsers(t *testing.T) {
 	mockCommitStatsRepo := &syncMockCommitStatsRepository{}
 	mockGithubGateway := &syncMockGithubGateway{}
 
-	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway)
+	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway, &syncMockInboxPublisher{})
 	err := usecase.SyncAllUsers(ctx)
 
 	assert.NoError(t, err)
@@ -234,13 +267,110 @@ func TestSyncAllUsers_UserRepoError(t *testing.T) {
 	mockCommitStatsRepo := &syncMockCommitStatsRepository{}
 	mockGithubGateway := &syncMockGithubGateway{}
 
-	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway)
+	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway, &syncMockInboxPublisher{})
 	err := usecase.SyncAllUsers(ctx)
 
 	assert.Error(t, err)
 	assert.Equal(t, "database error", err.Error())
 }
 
+func TestSyncAllUsersWithDateRange_CollectsFailures(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := &syncMockUserRepository{
+		FindAllFunc: func(ctx context.Context) ([]models.User, error) {
+			return []models.User{
+				{ID: 1, GithubUserID: 100, GithubUsername: "user1"},
+				{ID: 2, GithubUserID: 200, GithubUsername: "user2"},
+			}, nil
+		},
+	}
+
+	mockRivalRepo := &syncMockRivalRepository{}
+	mockCommitStatsRepo := &syncMockCommitStatsRepository{}
+	mockGithubGateway := &syncMockGithubGateway{
+		GetUserPublicReposFunc: func(ctx context.Context, username string) ([]gateway.GithubRepo, error) {
+			if username == "user2" {
+				return nil, errors.New("Github API error: 502")
+			}
+			return []gateway.GithubRepo{}, nil
+		},
+	}
+
+	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway, &syncMockInboxPublisher{})
+	result, err := usecase.SyncAllUsersWithDateRange(ctx, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.Equal(t, 0, result.Skipped)
+	assert.Len(t, result.Failures, 1)
+	assert.Equal(t, uint64(200), result.Failures[0].GithubUserID)
+	assert.Equal(t, "user2", result.Failures[0].GithubUsername)
+}
+
+func TestSyncAllUsersWithOptions_PublishesFailureToInbox(t *testing.T) {
+	ctx := context.Background()
+
+	mockUserRepo := &syncMockUserRepository{
+		FindAllFunc: func(ctx context.Context) ([]models.User, error) {
+			return []models.User{{ID: 2, GithubUserID: 200, GithubUsername: "user2"}}, nil
+		},
+	}
+	mockRivalRepo := &syncMockRivalRepository{
+		FindAllDistinctRivalsFunc: func(ctx context.Context) ([]models.Rival, error) {
+			return []models.Rival{{RivalGithubUserID: 300, RivalGithubUsername: "rival"}}, nil
+		},
+	}
+	mockGithubGateway := &syncMockGithubGateway{
+		GetUserPublicReposFunc: func(ctx context.Context, username string) ([]gateway.GithubRepo, error) {
+			return nil, errors.New("Github API error: 502")
+		},
+	}
+	var published []notifier.InboxItem
+	publisher := &syncMockInboxPublisher{
+		PublishFunc: func(ctx context.Context, item notifier.InboxItem) error {
+			published = append(published, item)
+			return nil
+		},
+	}
+
+	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, &syncMockCommitStatsRepository{}, mockGithubGateway, publisher)
+	result, err := usecase.SyncAllUsersWithOptions(ctx, SyncOptions{})
+
+	// 登録していないライバルの失敗は受信箱に載せない
+	assert.NoError(t, err)
+	assert.Len(t, result.Failures, 2)
+	if assert.Len(t, published, 1) {
+		assert.Equal(t, uint64(2), published[0].UserID)
+		assert.Equal(t, models.InAppNotificationSyncFailed, published[0].Type)
+		assert.Equal(t, "sync_failed:"+time.Now().Format("2006-01-02"), published[0].DedupeKey)
+	}
+
+	published = nil
+	_, err = usecase.SyncAllUsersWithOptions(ctx, SyncOptions{DryRun: true})
+
+	assert.NoError(t, err)
+	assert.Empty(t, published)
+}
+
+func TestSyncAllUsersWithDateRange_SkipsAfterCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	mockUserRepo := &syncMockUserRepository{
+		FindAllFunc: func(ctx context.Context) ([]models.User, error) {
+			return []models.User{{ID: 1, GithubUserID: 100, GithubUsername: "user1"}}, nil
+		},
+	}
+
+	usecase := NewSyncCommitsUsecase(mockUserRepo, &syncMockRivalRepository{}, &syncMockCommitStatsRepository{}, &syncMockGithubGateway{}, &syncMockInboxPublisher{})
+	result, err := usecase.SyncAllUsersWithDateRange(ctx, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.Succeeded)
+	assert.Equal(t, 1, result.Skipped)
+}
+
 func TestSyncUser_Success(t *testing.T) {
 	ctx := context.Background()
 
@@ -301,7 +431,7 @@ func TestSyncUser_Success(t *testing.T) {
 	mockUserRepo := &syncMockUserRepository{}
 	mockRivalRepo := &syncMockRivalRepository{}
 
-	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway)
+	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway, &syncMockInboxPublisher{})
 	err := usecase.SyncUser(ctx, 100, "testuser", nil, nil)
 
 	assert.NoError(t, err)
@@ -321,7 +451,7 @@ func TestSyncUser_NoRepos(t *testing.T) {
 	mockRivalRepo := &syncMockRivalRepository{}
 	mockCommitStatsRepo := &syncMockCommitStatsRepository{}
 
-	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway)
+	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway, &syncMockInboxPublisher{})
 	err := usecase.SyncUser(ctx, 100, "testuser", nil, nil)
 
 	assert.NoError(t, err)
@@ -355,7 +485,7 @@ func TestSyncUser_WithDateRange(t *testing.T) {
 	mockRivalRepo := &syncMockRivalRepository{}
 	mockCommitStatsRepo := &syncMockCommitStatsRepository{}
 
-	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway)
+	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway, &syncMockInboxPublisher{})
 	err := usecase.SyncUser(ctx, 100, "testuser", &from, &to)
 
 	assert.NoError(t, err)
@@ -376,9 +506,164 @@ func TestSyncUser_GitHubAPIError(t *testing.T) {
 	mockRivalRepo := &syncMockRivalRepository{}
 	mockCommitStatsRepo := &syncMockCommitStatsRepository{}
 
-	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway)
+	usecase := NewSyncCommitsUsecase(mockUserRepo, mockRivalRepo, mockCommitStatsRepo, mockGithubGateway, &syncMockInboxPublisher{})
 	err := usecase.SyncUser(ctx, 100, "testuser", nil, nil)
 
 	assert.Error(t, err)
 	assert.Equal(t, "GitHub API error", err.Error())
 }
+
+func TestDiffCommitStats(t *testing.T) {
+	day1 := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	day2 := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	existing := []models.CommitStats{
+		{ID: 1, Date: day1, Repository: "user1/kept", CommitCount: 3},
+		{ID: 2, Date: day1, Repository: "user1/changed", CommitCount: 1},
+		{ID: 3, Date: day2, Repository: "user1/force-pushed", CommitCount: 5},
+		{ID: 4, Date: day2, Repository: "user1/fetch-failed", CommitCount: 2},
+	}
+	fresh := []models.CommitStats{
+		{Date: day1, Repository: "user1/kept", CommitCount: 3},
+		{Date: day1, Repository: "user1/changed", CommitCount: 4},
+		{Date: day2, Repository: "user1/new", CommitCount: 1},
+	}
+
+	diff := diffCommitStats(existing, fresh, map[string]bool{"user1/fetch-failed": true})
+
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, "user1/new", diff.Added[0].Repository)
+	assert.Len(t, diff.Updated, 1)
+	assert.Equal(t, 1, diff.Updated[0].Before.CommitCount)
+	assert.Equal(t, 4, diff.Updated[0].After.CommitCount)
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, uint64(3), diff.Removed[0].ID)
+	assert.Equal(t, 2, diff.Unchanged)
+}
+
+func reconcileTestGateway() *syncMockGithubGateway {
+	return &syncMockGithubGateway{
+		GetUserPublicReposFunc: func(ctx context.Context, username string) ([]gateway.GithubRepo, error) {
+			return []gateway.GithubRepo{
+				{Name: "repo1", FullName: "user1/repo1", Owner: struct {
+					Login string `json:"login"`
+				}{Login: "user1"}},
+			}, nil
+		},
+		GetRepositoryCommitsFunc: func(ctx context.Context, owner, repo, author string, since, until time.Time) ([]gateway.RepositoryCommit, error) {
+			var commit gateway.RepositoryCommit
+			commit.Commit.Author.Date = time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+			return []gateway.RepositoryCommit{commit}, nil
+		},
+	}
+}
+
+func TestSyncAllUsersWithOptions_Reconcile(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+
+	var deletedIDs []uint64
+	var savedStats []models.CommitStats
+	upsertCalled := false
+
+	mockUserRepo := &syncMockUserRepository{
+		FindAllFunc: func(ctx context.Context) ([]models.User, error) {
+			return []models.User{{ID: 1, GithubUserID: 100, GithubUsername: "user1"}}, nil
+		},
+	}
+	mockCommitStatsRepo := &syncMockCommitStatsRepository{
+		FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+			t.Fatal("existing stats should be read inside the reconciliation transaction")
+			return nil, nil
+		},
+		UpsertBatchFunc: func(ctx context.Context, statsList []models.CommitStats) error {
+			upsertCalled = true
+			return nil
+		},
+		ApplyReconciliationFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error {
+			assert.Equal(t, uint64(100), githubUserID)
+			assert.Equal(t, from, startDate)
+			assert.Equal(t, to, endDate)
+			deletedIDs = selectDeletes([]models.CommitStats{
+				{ID: 10, GithubUserID: 100, Date: from, Repository: "user1/repo1", CommitCount: 1},
+				{ID: 11, GithubUserID: 100, Date: from, Repository: "user1/deleted-repo", CommitCount: 7},
+			})
+			savedStats = statsList
+			return nil
+		},
+	}
+
+	usecase := NewSyncCommitsUsecase(mockUserRepo, &syncMockRivalRepository{}, mockCommitStatsRepo, reconcileTestGateway(), &syncMockInboxPublisher{})
+	result, err := usecase.SyncAllUsersWithOptions(ctx, SyncOptions{FromDate: &from, ToDate: &to, Reconcile: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.False(t, upsertCalled)
+	assert.Equal(t, []uint64{11}, deletedIDs)
+	assert.Len(t, savedStats, 1)
+	assert.Equal(t, "user1/repo1", savedStats[0].Repository)
+}
+
+func TestSyncAllUsersWithOptions_ReconcileDryRun(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	applyCalled := false
+
+	mockUserRepo := &syncMockUserRepository{
+		FindAllFunc: func(ctx context.Context) ([]models.User, error) {
+			return []models.User{{ID: 1, GithubUserID: 100, GithubUsername: "user1"}}, nil
+		},
+	}
+	mockCommitStatsRepo := &syncMockCommitStatsRepository{
+		FindByGithubUserIDAndDateRangeFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time) ([]models.CommitStats, error) {
+			return []models.CommitStats{
+				{ID: 11, GithubUserID: 100, Date: from, Repository: "user1/deleted-repo", CommitCount: 7},
+			}, nil
+		},
+		ApplyReconciliationFunc: func(ctx context.Context, githubUserID uint64, startDate, endDate time.Time, statsList []models.CommitStats, selectDeletes func(existing []models.CommitStats) []uint64) error {
+			applyCalled = true
+			return nil
+		},
+	}
+
+	var output strings.Builder
+	usecase := NewSyncCommitsUsecase(mockUserRepo, &syncMockRivalRepository{}, mockCommitStatsRepo, reconcileTestGateway(), &syncMockInboxPublisher{})
+	_, err := usecase.SyncAllUsersWithOptions(ctx, SyncOptions{FromDate: &from, ToDate: &to, Reconcile: true, DryRun: true, Output: &output})
+
+	assert.NoError(t, err)
+	assert.False(t, applyCalled)
+	assert.Contains(t, output.String(), "[dry-run] user1: +1 ~0 -1")
+	assert.Contains(t, output.String(), "+ 2025-06-01 user1/repo1: 1 commits")
+	assert.Contains(t, output.String(), "- 2025-06-01 user1/deleted-repo: 7 commits")
+}
+
+func TestSyncAllUsersWithOptions_DryRun(t *testing.T) {
+	ctx := context.Background()
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 6, 30, 0, 0, 0, 0, time.UTC)
+	upsertCalled := false
+
+	mockUserRepo := &syncMockUserRepository{
+		FindAllFunc: func(ctx context.Context) ([]models.User, error) {
+			return []models.User{{ID: 1, GithubUserID: 100, GithubUsername: "user1"}}, nil
+		},
+	}
+	mockCommitStatsRepo := &syncMockCommitStatsRepository{
+		UpsertBatchFunc: func(ctx context.Context, statsList []models.CommitStats) error {
+			upsertCalled = true
+			return nil
+		},
+	}
+
+	var output strings.Builder
+	usecase := NewSyncCommitsUsecase(mockUserRepo, &syncMockRivalRepository{}, mockCommitStatsRepo, reconcileTestGateway(), &syncMockInboxPublisher{})
+	result, err := usecase.SyncAllUsersWithOptions(ctx, SyncOptions{FromDate: &from, ToDate: &to, DryRun: true, Output: &output})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Succeeded)
+	assert.False(t, upsertCalled)
+	assert.Contains(t, output.String(), "[dry-run] user1: 1 commit stats to upsert")
+	assert.Contains(t, output.String(), "* 2025-06-01 user1/repo1: 1 commits")
+}
@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// ITeamsNotificationUsecase Microsoft Teams通知ユースケースのインターフェース
+type ITeamsNotificationUsecase interface {
+	GetSetting(ctx context.Context, userID uint64) (*models.TeamsNotificationSetting, error)
+	Create(ctx context.Context, userID uint64, webhookURL string) (*models.TeamsNotificationSetting, error)
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+}
+
+type teamsNotificationUsecase struct {
+	teamsRepo repository.ITeamsNotificationSettingRepository
+}
+
+// NewTeamsNotificationUsecase コンストラクタ
+func NewTeamsNotificationUsecase(teamsRepo repository.ITeamsNotificationSettingRepository) ITeamsNotificationUsecase {
+	return &teamsNotificationUsecase{
+		teamsRepo: teamsRepo,
+	}
+}
+
+func (u *teamsNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.TeamsNotificationSetting, error) {
+	return u.teamsRepo.FindByUserID(ctx, userID)
+}
+
+func (u *teamsNotificationUsecase) Create(ctx context.Context, userID uint64, webhookURL string) (*models.TeamsNotificationSetting, error) {
+	// 既存の設定を取得
+	existing, err := u.teamsRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		// 更新
+		existing.WebhookURL = webhookURL
+		existing.IsEnabled = true
+
+		if err := u.teamsRepo.Upsert(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	// 新規作成
+	setting := &models.TeamsNotificationSetting{
+		UserID:     userID,
+		WebhookURL: webhookURL,
+		IsEnabled:  true,
+	}
+
+	if err := u.teamsRepo.Upsert(ctx, setting); err != nil {
+		return nil, err
+	}
+
+	return setting, nil
+}
+
+func (u *teamsNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return u.teamsRepo.UpdateEnabled(ctx, userID, isEnabled)
+}
+
+func (u *teamsNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	return u.teamsRepo.Delete(ctx, userID)
+}
@@ -0,0 +1,162 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// TelegramLink Telegram連携用に発行したトークンとボットへのリンク
+type TelegramLink struct {
+	Token     string
+	DeepLink  string // https://t.me/<ボット>?start=<トークン>
+	ExpiresAt time.Time
+}
+
+// ITelegramNotificationUsecase Telegram通知ユースケースのインターフェース
+type ITelegramNotificationUsecase interface {
+	GetSetting(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error)
+	IssueLinkToken(ctx context.Context, userID uint64) (*TelegramLink, error)
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+	HandleStart(ctx context.Context, chatID int64, token, languageCode string) error
+	HandleBlocked(ctx context.Context, chatID int64) error
+}
+
+type telegramNotificationUsecase struct {
+	telegramRepo    repository.ITelegramNotificationSettingRepository
+	linkTokenRepo   repository.ITelegramLinkTokenRepository
+	userRepo        repository.IUserRepository
+	telegramGateway gateway.ITelegramGateway
+	botUsername     string
+}
+
+// NewTelegramNotificationUsecase コンストラクタ
+func NewTelegramNotificationUsecase(
+	telegramRepo repository.ITelegramNotificationSettingRepository,
+	linkTokenRepo repository.ITelegramLinkTokenRepository,
+	userRepo repository.IUserRepository,
+	telegramGateway gateway.ITelegramGateway,
+	botUsername string,
+) ITelegramNotificationUsecase {
+	return &telegramNotificationUsecase{
+		telegramRepo:    telegramRepo,
+		linkTokenRepo:   linkTokenRepo,
+		userRepo:        userRepo,
+		telegramGateway: telegramGateway,
+		botUsername:     botUsername,
+	}
+}
+
+func (u *telegramNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error) {
+	return u.telegramRepo.FindByUserID(ctx, userID)
+}
+
+// IssueLinkToken ボットに /start で送ってもらう連携トークンを発行する
+func (u *telegramNotificationUsecase) IssueLinkToken(ctx context.Context, userID uint64) (*TelegramLink, error) {
+	if u.botUsername == "" {
+		return nil, fmt.Errorf("Telegram bot username is not configured")
+	}
+
+	token, err := generateTelegramLinkToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate link token: %w", err)
+	}
+
+	linkToken := &models.TelegramLinkToken{
+		UserID:    userID,
+		Token:     token,
+		ExpiresAt: time.Now().Add(models.TelegramLinkTokenTTL),
+	}
+	if err := u.linkTokenRepo.Upsert(ctx, linkToken); err != nil {
+		return nil, err
+	}
+
+	return &TelegramLink{
+		Token:     token,
+		DeepLink:  "https://t.me/" + u.botUsername + "?start=" + token,
+		ExpiresAt: linkToken.ExpiresAt,
+	}, nil
+}
+
+func (u *telegramNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return u.telegramRepo.UpdateEnabled(ctx, userID, isEnabled)
+}
+
+func (u *telegramNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	return u.telegramRepo.Delete(ctx, userID)
+}
+
+// HandleStart /start <トークン> を受け取ったチャットをトークンのユーザーに紐づけて返信する
+func (u *telegramNotificationUsecase) HandleStart(ctx context.Context, chatID int64, token, languageCode string) error {
+	l := i18n.For(languageCode)
+	if token == "" {
+		return u.reply(ctx, chatID, l.T("telegram.start", nil))
+	}
+
+	linkToken, err := u.linkTokenRepo.FindByToken(ctx, token)
+	if err != nil {
+		return err
+	}
+	if linkToken == nil || time.Now().After(linkToken.ExpiresAt) {
+		return u.reply(ctx, chatID, l.T("telegram.link_invalid", nil))
+	}
+
+	user, err := u.userRepo.FindByID(ctx, linkToken.UserID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return u.reply(ctx, chatID, l.T("telegram.link_invalid", nil))
+	}
+
+	// 既存の設定を取得
+	existing, err := u.telegramRepo.FindByUserID(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	setting := existing
+	if setting == nil {
+		setting = &models.TelegramNotificationSetting{UserID: user.ID}
+	}
+	setting.ChatID = chatID
+	setting.IsEnabled = true
+
+	if err := u.telegramRepo.Upsert(ctx, setting); err != nil {
+		return err
+	}
+
+	// 使用済みのトークンは削除する
+	if err := u.linkTokenRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return err
+	}
+
+	return u.reply(ctx, chatID, i18n.For(user.Locale).T("telegram.linked", i18n.Vars{"Username": user.GithubUsername}))
+}
+
+// HandleBlocked ボットをブロックしたチャットへの通知を止める
+func (u *telegramNotificationUsecase) HandleBlocked(ctx context.Context, chatID int64) error {
+	return u.telegramRepo.DisableByChatID(ctx, chatID)
+}
+
+func (u *telegramNotificationUsecase) reply(ctx context.Context, chatID int64, text string) error {
+	return u.telegramGateway.SendMessage(ctx, strconv.FormatInt(chatID, 10), gateway.BuildTelegramTextMessage(text))
+}
+
+// generateTelegramLinkToken /start のパラメータに使えるランダムなトークンを生成する（英数字のみ）
+func generateTelegramLinkToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
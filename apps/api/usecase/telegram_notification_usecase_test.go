@@ -0,0 +1,264 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type telegramMockTelegramNotificationSettingRepository struct {
+	FindByUserIDFunc    func(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error)
+	UpsertFunc          func(ctx context.Context, setting *models.TelegramNotificationSetting) error
+	DisableByChatIDFunc func(ctx context.Context, chatID int64) error
+}
+
+func (m *telegramMockTelegramNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *telegramMockTelegramNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.TelegramNotificationSetting, error) {
+	return nil, nil
+}
+
+func (m *telegramMockTelegramNotificationSettingRepository) Upsert(ctx context.Context, setting *models.TelegramNotificationSetting) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, setting)
+	}
+	return nil
+}
+
+func (m *telegramMockTelegramNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return nil
+}
+
+func (m *telegramMockTelegramNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func (m *telegramMockTelegramNotificationSettingRepository) DisableByChatID(ctx context.Context, chatID int64) error {
+	if m.DisableByChatIDFunc != nil {
+		return m.DisableByChatIDFunc(ctx, chatID)
+	}
+	return nil
+}
+
+type telegramMockTelegramLinkTokenRepository struct {
+	FindByTokenFunc    func(ctx context.Context, token string) (*models.TelegramLinkToken, error)
+	UpsertFunc         func(ctx context.Context, linkToken *models.TelegramLinkToken) error
+	DeleteByUserIDFunc func(ctx context.Context, userID uint64) error
+}
+
+func (m *telegramMockTelegramLinkTokenRepository) FindByToken(ctx context.Context, token string) (*models.TelegramLinkToken, error) {
+	if m.FindByTokenFunc != nil {
+		return m.FindByTokenFunc(ctx, token)
+	}
+	return nil, nil
+}
+
+func (m *telegramMockTelegramLinkTokenRepository) Upsert(ctx context.Context, linkToken *models.TelegramLinkToken) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, linkToken)
+	}
+	return nil
+}
+
+func (m *telegramMockTelegramLinkTokenRepository) DeleteByUserID(ctx context.Context, userID uint64) error {
+	if m.DeleteByUserIDFunc != nil {
+		return m.DeleteByUserIDFunc(ctx, userID)
+	}
+	return nil
+}
+
+type telegramMockUserRepository struct {
+	FindByIDFunc func(ctx context.Context, id uint64) (*models.User, error)
+}
+
+func (m *telegramMockUserRepository) FindByID(ctx context.Context, id uint64) (*models.User, error) {
+	if m.FindByIDFunc != nil {
+		return m.FindByIDFunc(ctx, id)
+	}
+	return nil, nil
+}
+
+func (m *telegramMockUserRepository) FindByGithubUserID(ctx context.Context, githubUserID uint64) (*models.User, error) {
+	return nil, nil
+}
+
+func (m *telegramMockUserRepository) FindAll(ctx context.Context) ([]models.User, error) {
+	return nil, nil
+}
+
+func (m *telegramMockUserRepository) Create(ctx context.Context, user *models.User) error {
+	return nil
+}
+
+func (m *telegramMockUserRepository) Update(ctx context.Context, user *models.User) error {
+	return nil
+}
+
+func (m *telegramMockUserRepository) UpdateNotificationAlert(ctx context.Context, id uint64, alertAt *time.Time) error {
+	return nil
+}
+
+func (m *telegramMockUserRepository) UpdateLocale(ctx context.Context, id uint64, locale string) error {
+	return nil
+}
+
+type telegramMockTelegramGateway struct {
+	SendMessageFunc func(ctx context.Context, chatID string, message *gateway.TelegramMessage) error
+}
+
+func (m *telegramMockTelegramGateway) SendMessage(ctx context.Context, chatID string, message *gateway.TelegramMessage) error {
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(ctx, chatID, message)
+	}
+	return nil
+}
+
+func TestTelegramNotificationUsecase_IssueLinkToken(t *testing.T) {
+	var saved *models.TelegramLinkToken
+	linkTokenRepo := &telegramMockTelegramLinkTokenRepository{
+		UpsertFunc: func(ctx context.Context, linkToken *models.TelegramLinkToken) error {
+			saved = linkToken
+			return nil
+		},
+	}
+
+	uc := NewTelegramNotificationUsecase(&telegramMockTelegramNotificationSettingRepository{}, linkTokenRepo, &telegramMockUserRepository{}, &telegramMockTelegramGateway{}, "commitly_bot")
+	link, err := uc.IssueLinkToken(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), saved.UserID)
+	assert.Len(t, saved.Token, 32)
+	assert.Equal(t, saved.Token, link.Token)
+	assert.Equal(t, "https://t.me/commitly_bot?start="+saved.Token, link.DeepLink)
+	assert.True(t, link.ExpiresAt.After(time.Now()))
+}
+
+func TestTelegramNotificationUsecase_IssueLinkToken_BotNotConfigured(t *testing.T) {
+	uc := NewTelegramNotificationUsecase(&telegramMockTelegramNotificationSettingRepository{}, &telegramMockTelegramLinkTokenRepository{}, &telegramMockUserRepository{}, &telegramMockTelegramGateway{}, "")
+	link, err := uc.IssueLinkToken(context.Background(), 1)
+
+	assert.Nil(t, link)
+	assert.Error(t, err)
+}
+
+func TestTelegramNotificationUsecase_HandleStart_Success(t *testing.T) {
+	var upserted *models.TelegramNotificationSetting
+	var deletedTokenFor uint64
+	var repliedTo string
+	var replied *gateway.TelegramMessage
+
+	telegramRepo := &telegramMockTelegramNotificationSettingRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64) (*models.TelegramNotificationSetting, error) {
+			return &models.TelegramNotificationSetting{ID: 5, UserID: userID, ChatID: 111, IsEnabled: false}, nil
+		},
+		UpsertFunc: func(ctx context.Context, setting *models.TelegramNotificationSetting) error {
+			upserted = setting
+			return nil
+		},
+	}
+	linkTokenRepo := &telegramMockTelegramLinkTokenRepository{
+		FindByTokenFunc: func(ctx context.Context, token string) (*models.TelegramLinkToken, error) {
+			return &models.TelegramLinkToken{UserID: 1, Token: token, ExpiresAt: time.Now().Add(10 * time.Minute)}, nil
+		},
+		DeleteByUserIDFunc: func(ctx context.Context, userID uint64) error {
+			deletedTokenFor = userID
+			return nil
+		},
+	}
+	userRepo := &telegramMockUserRepository{
+		FindByIDFunc: func(ctx context.Context, id uint64) (*models.User, error) {
+			return &models.User{ID: id, GithubUsername: "user1", Locale: "en"}, nil
+		},
+	}
+	telegramGateway := &telegramMockTelegramGateway{
+		SendMessageFunc: func(ctx context.Context, chatID string, message *gateway.TelegramMessage) error {
+			repliedTo = chatID
+			replied = message
+			return nil
+		},
+	}
+
+	uc := NewTelegramNotificationUsecase(telegramRepo, linkTokenRepo, userRepo, telegramGateway, "commitly_bot")
+	err := uc.HandleStart(context.Background(), 222, "0123abcd", "ja")
+
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(5), upserted.ID)
+	assert.Equal(t, int64(222), upserted.ChatID)
+	assert.True(t, upserted.IsEnabled)
+	assert.Equal(t, uint64(1), deletedTokenFor)
+	assert.Equal(t, "222", repliedTo)
+	// 連携後はユーザーの言語設定で返信する
+	assert.True(t, strings.HasPrefix(replied.Text, "Linked to Commitly!"))
+	assert.Contains(t, replied.Text, "user1")
+}
+
+func TestTelegramNotificationUsecase_HandleStart_InvalidToken(t *testing.T) {
+	tests := []struct {
+		name      string
+		token     string
+		linkToken *models.TelegramLinkToken
+		wantText  string
+	}{
+		{"トークンなし", "", nil, "Commitlyの通知設定画面"},
+		{"存在しないトークン", "0123abcd", nil, "連携リンクが無効"},
+		{"期限切れのトークン", "0123abcd", &models.TelegramLinkToken{UserID: 1, Token: "0123abcd", ExpiresAt: time.Now().Add(-time.Minute)}, "連携リンクが無効"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			upserted := false
+			var replied *gateway.TelegramMessage
+
+			telegramRepo := &telegramMockTelegramNotificationSettingRepository{
+				UpsertFunc: func(ctx context.Context, setting *models.TelegramNotificationSetting) error {
+					upserted = true
+					return nil
+				},
+			}
+			linkTokenRepo := &telegramMockTelegramLinkTokenRepository{
+				FindByTokenFunc: func(ctx context.Context, token string) (*models.TelegramLinkToken, error) {
+					return tt.linkToken, nil
+				},
+			}
+			telegramGateway := &telegramMockTelegramGateway{
+				SendMessageFunc: func(ctx context.Context, chatID string, message *gateway.TelegramMessage) error {
+					replied = message
+					return nil
+				},
+			}
+
+			uc := NewTelegramNotificationUsecase(telegramRepo, linkTokenRepo, &telegramMockUserRepository{}, telegramGateway, "commitly_bot")
+			err := uc.HandleStart(context.Background(), 222, tt.token, "ja")
+
+			assert.NoError(t, err)
+			assert.False(t, upserted)
+			assert.Contains(t, replied.Text, tt.wantText)
+		})
+	}
+}
+
+func TestTelegramNotificationUsecase_HandleBlocked(t *testing.T) {
+	var disabled int64
+	telegramRepo := &telegramMockTelegramNotificationSettingRepository{
+		DisableByChatIDFunc: func(ctx context.Context, chatID int64) error {
+			disabled = chatID
+			return nil
+		},
+	}
+
+	uc := NewTelegramNotificationUsecase(telegramRepo, &telegramMockTelegramLinkTokenRepository{}, &telegramMockUserRepository{}, &telegramMockTelegramGateway{}, "commitly_bot")
+	err := uc.HandleBlocked(context.Background(), 222)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(222), disabled)
+}
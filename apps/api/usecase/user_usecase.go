@@ -2,16 +2,20 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/i18n"
 	"github.com/keeee21/commitly/api/models"
 	"github.com/keeee21/commitly/api/repository"
 )
 
 // IUserUsecase ユーザーユースケースのインターフェース
 type IUserUsecase interface {
-	GetOrCreateUser(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL string) (*models.User, error)
+	GetOrCreateUser(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL, locale string) (*models.User, error)
 	GetUserByGithubUserID(ctx context.Context, githubUserID uint64) (*models.User, error)
+	DismissNotificationAlert(ctx context.Context, userID uint64) error
+	UpdateLocale(ctx context.Context, userID uint64, locale string) error
 }
 
 type userUsecase struct {
@@ -27,7 +31,8 @@ func NewUserUsecase(userRepo repository.IUserRepository, githubGateway gateway.I
 	}
 }
 
-func (u *userUsecase) GetOrCreateUser(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL string) (*models.User, error) {
+// GetOrCreateUser ユーザーを取得または作成する（locale はブラウザの言語で、空・未対応の場合はデフォルト）
+func (u *userUsecase) GetOrCreateUser(ctx context.Context, githubUserID uint64, githubUsername, email, avatarURL, locale string) (*models.User, error) {
 	// 既存ユーザーを検索
 	user, err := u.userRepo.FindByGithubUserID(ctx, githubUserID)
 	if err == nil {
@@ -35,6 +40,15 @@ func (u *userUsecase) GetOrCreateUser(ctx context.Context, githubUserID uint64,
 		user.GithubUsername = githubUsername
 		user.Email = email
 		user.AvatarURL = avatarURL
+		// 言語はユーザーが設定したものを優先し、明示的に設定していない間はブラウザの言語に合わせる
+		// （言語の導入前からのユーザーはカラムの既定値が入っているだけのため、ここで置き換える）
+		if user.LocaleSetAt == nil {
+			if parsed, ok := i18n.ParseLocale(locale); ok {
+				user.Locale = string(parsed)
+			} else if user.Locale == "" {
+				user.Locale = string(i18n.DefaultLocale)
+			}
+		}
 		if err := u.userRepo.Update(ctx, user); err != nil {
 			return nil, err
 		}
@@ -47,6 +61,7 @@ func (u *userUsecase) GetOrCreateUser(ctx context.Context, githubUserID uint64,
 		GithubUsername: githubUsername,
 		Email:          email,
 		AvatarURL:      avatarURL,
+		Locale:         string(i18n.Normalize(locale)),
 	}
 	if err := u.userRepo.Create(ctx, newUser); err != nil {
 		return nil, err
@@ -57,3 +72,17 @@ func (u *userUsecase) GetOrCreateUser(ctx context.Context, githubUserID uint64,
 func (u *userUsecase) GetUserByGithubUserID(ctx context.Context, githubUserID uint64) (*models.User, error) {
 	return u.userRepo.FindByGithubUserID(ctx, githubUserID)
 }
+
+// DismissNotificationAlert 通知チャンネルの自動停止のお知らせを確認済みにする
+func (u *userUsecase) DismissNotificationAlert(ctx context.Context, userID uint64) error {
+	return u.userRepo.UpdateNotificationAlert(ctx, userID, nil)
+}
+
+// UpdateLocale 通知メッセージの言語を変更する
+func (u *userUsecase) UpdateLocale(ctx context.Context, userID uint64, locale string) error {
+	parsed, ok := i18n.ParseLocale(locale)
+	if !ok {
+		return fmt.Errorf("対応していない言語です: %s", locale)
+	}
+	return u.userRepo.UpdateLocale(ctx, userID, string(parsed))
+}
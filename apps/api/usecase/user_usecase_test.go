@@ -13,9 +13,11 @@ import (
 
 // userMockUserRepository テスト用のモックリポジトリ
 type userMockUserRepository struct {
-	FindByGithubUserIDFunc func(ctx context.Context, githubUserID uint64) (*models.User, error)
-	CreateFunc             func(ctx context.Context, user *models.User) error
-	UpdateFunc             func(ctx context.Context, user *models.User) error
+	FindByGithubUserIDFunc      func(ctx context.Context, githubUserID uint64) (*models.User, error)
+	CreateFunc                  func(ctx context.Context, user *models.User) error
+	UpdateFunc                  func(ctx context.Context, user *models.User) error
+	UpdateNotificationAlertFunc func(ctx context.Context, id uint64, alertAt *time.Time) error
+	UpdateLocaleFunc            func(ctx context.Context, id uint64, locale string) error
 }
 
 func (m *userMockUserRepository) FindByGithubUserID(ctx context.Context, githubUserID uint64) (*models.User, error) {
@@ -39,6 +41,20 @@ func (m *userMockUserRepository) Update(ctx context.Context, user *models.User)
 	return nil
 }
 
+func (m *userMockUserRepository) UpdateNotificationAlert(ctx context.Context, id uint64, alertAt *time.Time) error {
+	if m.UpdateNotificationAlertFunc != nil {
+		return m.UpdateNotificationAlertFunc(ctx, id, alertAt)
+	}
+	return nil
+}
+
+func (m *userMockUserRepository) UpdateLocale(ctx context.Context, id uint64, locale string) error {
+	if m.UpdateLocaleFunc != nil {
+		return m.UpdateLocaleFunc(ctx, id, locale)
+	}
+	return nil
+}
+
 func (m *userMockUserRepository) FindByID(ctx context.Context, id uint64) (*models.User, error) {
 	return nil, nil
 }
@@ -85,7 +101,7 @@ func TestGetOrCreateUser_NewUser(t *testing.T) {
 
 	usecase := NewUserUsecase(mockUserRepo, mockGithubGateway)
 
-	user, err := usecase.GetOrCreateUser(ctx, 12345, "testuser", "test@example.com", "https://avatar.url")
+	user, err := usecase.GetOrCreateUser(ctx, 12345, "testuser", "test@example.com", "https://avatar.url", "")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -94,16 +110,39 @@ func TestGetOrCreateUser_NewUser(t *testing.T) {
 	assert.Equal(t, "testuser", user.GithubUsername)
 	assert.Equal(t, "test@example.com", user.Email)
 	assert.Equal(t, "https://avatar.url", user.AvatarURL)
+	assert.Equal(t, "ja", user.Locale)
+}
+
+func TestGetOrCreateUser_NewUserWithLocale(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := &userMockUserRepository{
+		FindByGithubUserIDFunc: func(ctx context.Context, githubUserID uint64) (*models.User, error) {
+			return nil, errors.New("not found")
+		},
+		CreateFunc: func(ctx context.Context, user *models.User) error {
+			return nil
+		},
+	}
+
+	usecase := NewUserUsecase(mockUserRepo, &userMockGithubGateway{})
+
+	user, err := usecase.GetOrCreateUser(ctx, 12345, "testuser", "test@example.com", "https://avatar.url", "en-US")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "en", user.Locale)
 }
 
 func TestGetOrCreateUser_ExistingUser(t *testing.T) {
 	ctx := context.Background()
+	localeSetAt := time.Date(2026, 10, 1, 9, 0, 0, 0, time.UTC)
 	existingUser := &models.User{
 		ID:             1,
 		GithubUserID:   12345,
 		GithubUsername: "oldusername",
 		Email:          "old@example.com",
 		AvatarURL:      "https://old.avatar.url",
+		Locale:         "en",
+		LocaleSetAt:    &localeSetAt,
 	}
 
 	mockUserRepo := &userMockUserRepository{
@@ -118,7 +157,7 @@ func TestGetOrCreateUser_ExistingUser(t *testing.T) {
 
 	usecase := NewUserUsecase(mockUserRepo, mockGithubGateway)
 
-	user, err := usecase.GetOrCreateUser(ctx, 12345, "newusername", "new@example.com", "https://new.avatar.url")
+	user, err := usecase.GetOrCreateUser(ctx, 12345, "newusername", "new@example.com", "https://new.avatar.url", "ja")
 
 	assert.NoError(t, err)
 	assert.NotNil(t, user)
@@ -126,6 +165,42 @@ func TestGetOrCreateUser_ExistingUser(t *testing.T) {
 	assert.Equal(t, "newusername", user.GithubUsername)
 	assert.Equal(t, "new@example.com", user.Email)
 	assert.Equal(t, "https://new.avatar.url", user.AvatarURL)
+	// 設定済みの言語はログイン時のブラウザの言語で上書きしない
+	assert.Equal(t, "en", user.Locale)
+}
+
+func TestGetOrCreateUser_ExistingUserWithoutExplicitLocale(t *testing.T) {
+	ctx := context.Background()
+	// 言語の導入前からのユーザーはカラムの既定値（ja）が入っているだけ
+	existingUser := &models.User{ID: 1, GithubUserID: 12345, GithubUsername: "user1", Locale: "ja"}
+	var updated *models.User
+
+	mockUserRepo := &userMockUserRepository{
+		FindByGithubUserIDFunc: func(ctx context.Context, githubUserID uint64) (*models.User, error) {
+			return existingUser, nil
+		},
+		UpdateFunc: func(ctx context.Context, user *models.User) error {
+			updated = user
+			return nil
+		},
+	}
+
+	usecase := NewUserUsecase(mockUserRepo, &userMockGithubGateway{})
+
+	user, err := usecase.GetOrCreateUser(ctx, 12345, "user1", "", "", "en-US")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "en", user.Locale)
+	if assert.NotNil(t, updated) {
+		assert.Equal(t, "en", updated.Locale)
+		assert.Nil(t, updated.LocaleSetAt)
+	}
+
+	// ブラウザの言語が分からない場合は今の言語のまま
+	user, err = usecase.GetOrCreateUser(ctx, 12345, "user1", "", "", "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "en", user.Locale)
 }
 
 func TestGetOrCreateUser_CreateError(t *testing.T) {
@@ -142,7 +217,7 @@ func TestGetOrCreateUser_CreateError(t *testing.T) {
 
 	usecase := NewUserUsecase(mockUserRepo, mockGithubGateway)
 
-	user, err := usecase.GetOrCreateUser(ctx, 12345, "testuser", "test@example.com", "https://avatar.url")
+	user, err := usecase.GetOrCreateUser(ctx, 12345, "testuser", "test@example.com", "https://avatar.url", "")
 
 	assert.Error(t, err)
 	assert.Nil(t, user)
@@ -189,3 +264,38 @@ func TestGetUserByGithubUserID_NotFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, user)
 }
+
+func TestUpdateLocale_Success(t *testing.T) {
+	ctx := context.Background()
+	var updatedLocale string
+	mockUserRepo := &userMockUserRepository{
+		UpdateLocaleFunc: func(ctx context.Context, id uint64, locale string) error {
+			updatedLocale = locale
+			return nil
+		},
+	}
+
+	usecase := NewUserUsecase(mockUserRepo, &userMockGithubGateway{})
+
+	err := usecase.UpdateLocale(ctx, 1, "EN")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "en", updatedLocale)
+}
+
+func TestUpdateLocale_Unsupported(t *testing.T) {
+	ctx := context.Background()
+	mockUserRepo := &userMockUserRepository{
+		UpdateLocaleFunc: func(ctx context.Context, id uint64, locale string) error {
+			t.Fatal("UpdateLocale should not be called")
+			return nil
+		},
+	}
+
+	usecase := NewUserUsecase(mockUserRepo, &userMockGithubGateway{})
+
+	err := usecase.UpdateLocale(ctx, 1, "fr")
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "対応していない言語です")
+}
@@ -0,0 +1,113 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// maxWebPushSubscriptionsPerUser 1ユーザーが登録できる端末（ブラウザ）の上限
+const maxWebPushSubscriptionsPerUser = 10
+
+// IWebPushUsecase Web Push通知ユースケースのインターフェース
+type IWebPushUsecase interface {
+	VAPIDPublicKey() string
+	GetSubscriptions(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error)
+	Subscribe(ctx context.Context, userID uint64, endpoint, p256dh, auth, userAgent string) (*models.WebPushSubscription, error)
+	Unsubscribe(ctx context.Context, userID uint64, endpoint string) error
+}
+
+type webPushUsecase struct {
+	subscriptionRepo repository.IWebPushSubscriptionRepository
+	keys             *gateway.VAPIDKeys
+}
+
+// NewWebPushUsecase コンストラクタ（keysがnilの場合はWeb Pushを無効として扱う）
+func NewWebPushUsecase(subscriptionRepo repository.IWebPushSubscriptionRepository, keys *gateway.VAPIDKeys) IWebPushUsecase {
+	return &webPushUsecase{
+		subscriptionRepo: subscriptionRepo,
+		keys:             keys,
+	}
+}
+
+// VAPIDPublicKey ブラウザが購読に使う公開鍵（未設定の場合は空）
+func (u *webPushUsecase) VAPIDPublicKey() string {
+	if u.keys == nil {
+		return ""
+	}
+	return u.keys.PublicKey()
+}
+
+func (u *webPushUsecase) GetSubscriptions(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error) {
+	return u.subscriptionRepo.FindByUserID(ctx, userID)
+}
+
+// Subscribe ブラウザの購読を登録する（同じエンドポイントは上書き）
+func (u *webPushUsecase) Subscribe(ctx context.Context, userID uint64, endpoint, p256dh, auth, userAgent string) (*models.WebPushSubscription, error) {
+	if u.keys == nil {
+		return nil, fmt.Errorf("Web Push通知は現在利用できません")
+	}
+	if !isWebPushEndpoint(endpoint) {
+		return nil, fmt.Errorf("購読のエンドポイントが不正です")
+	}
+	if err := gateway.ValidateWebPushSubscriptionKeys(p256dh, auth); err != nil {
+		return nil, fmt.Errorf("購読の鍵が不正です")
+	}
+
+	existing, err := u.subscriptionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxWebPushSubscriptionsPerUser && !containsWebPushEndpoint(existing, endpoint) {
+		return nil, fmt.Errorf("Web Push通知を登録できる端末は%d台までです", maxWebPushSubscriptionsPerUser)
+	}
+
+	// User-Agent はカラムの長さに合わせて切り詰める（端末の見分けにしか使わない）
+	if runes := []rune(userAgent); len(runes) > 255 {
+		userAgent = string(runes[:255])
+	}
+
+	subscription := &models.WebPushSubscription{
+		UserID:    userID,
+		Endpoint:  endpoint,
+		P256dh:    p256dh,
+		Auth:      auth,
+		UserAgent: userAgent,
+	}
+	if err := u.subscriptionRepo.Upsert(ctx, subscription); err != nil {
+		return nil, err
+	}
+	return subscription, nil
+}
+
+func (u *webPushUsecase) Unsubscribe(ctx context.Context, userID uint64, endpoint string) error {
+	if endpoint == "" {
+		return fmt.Errorf("購読のエンドポイントを指定してください")
+	}
+	return u.subscriptionRepo.DeleteByEndpoint(ctx, userID, endpoint)
+}
+
+// isWebPushEndpoint プッシュサービスのエンドポイントとして送信できるURLか（httpsのみ、内部向けのホストは不可）
+func isWebPushEndpoint(endpoint string) bool {
+	if len(endpoint) > 2048 {
+		return false
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	return u.Scheme == "https" && u.Host != "" && gateway.IsPublicHost(u.Hostname())
+}
+
+func containsWebPushEndpoint(subscriptions []models.WebPushSubscription, endpoint string) bool {
+	for _, s := range subscriptions {
+		if s.Endpoint == endpoint {
+			return true
+		}
+	}
+	return false
+}
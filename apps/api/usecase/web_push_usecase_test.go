@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/keeee21/commitly/api/gateway"
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// RFC 8291 Appendix A のブラウザ側の鍵
+const (
+	webPushTestP256dh = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+	webPushTestAuth   = "BTBZMqHH6r4Tts7J_aSIgg"
+)
+
+type webPushMockWebPushSubscriptionRepository struct {
+	FindByUserIDFunc     func(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error)
+	UpsertFunc           func(ctx context.Context, subscription *models.WebPushSubscription) error
+	DeleteByEndpointFunc func(ctx context.Context, userID uint64, endpoint string) error
+}
+
+func (m *webPushMockWebPushSubscriptionRepository) FindByUserID(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *webPushMockWebPushSubscriptionRepository) FindAll(ctx context.Context) ([]models.WebPushSubscription, error) {
+	return nil, nil
+}
+
+func (m *webPushMockWebPushSubscriptionRepository) Upsert(ctx context.Context, subscription *models.WebPushSubscription) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, subscription)
+	}
+	return nil
+}
+
+func (m *webPushMockWebPushSubscriptionRepository) DeleteByEndpoint(ctx context.Context, userID uint64, endpoint string) error {
+	if m.DeleteByEndpointFunc != nil {
+		return m.DeleteByEndpointFunc(ctx, userID, endpoint)
+	}
+	return nil
+}
+
+func (m *webPushMockWebPushSubscriptionRepository) DeleteByID(ctx context.Context, id uint64) error {
+	return nil
+}
+
+func (m *webPushMockWebPushSubscriptionRepository) DeleteByUserID(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func newWebPushTestKeys(t *testing.T) *gateway.VAPIDKeys {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	return &gateway.VAPIDKeys{PrivateKey: key, Subject: "mailto:admin@example.com"}
+}
+
+func TestWebPushUsecase_Subscribe(t *testing.T) {
+	var upserted *models.WebPushSubscription
+	repo := &webPushMockWebPushSubscriptionRepository{
+		UpsertFunc: func(ctx context.Context, subscription *models.WebPushSubscription) error {
+			upserted = subscription
+			return nil
+		},
+	}
+	keys := newWebPushTestKeys(t)
+
+	uc := NewWebPushUsecase(repo, keys)
+	subscription, err := uc.Subscribe(context.Background(), 1, "https://fcm.googleapis.com/fcm/send/abc", webPushTestP256dh, webPushTestAuth, strings.Repeat("a", 300))
+
+	assert.NoError(t, err)
+	assert.Equal(t, subscription, upserted)
+	assert.Equal(t, uint64(1), subscription.UserID)
+	assert.Equal(t, webPushTestP256dh, subscription.P256dh)
+	assert.Len(t, subscription.UserAgent, 255)
+	assert.Equal(t, keys.PublicKey(), uc.VAPIDPublicKey())
+}
+
+func TestWebPushUsecase_Subscribe_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		endpoint string
+		p256dh   string
+		auth     string
+		wantErr  string
+	}{
+		{"http endpoint", "http://push.example.com/abc", webPushTestP256dh, webPushTestAuth, "エンドポイント"},
+		{"empty endpoint", "", webPushTestP256dh, webPushTestAuth, "エンドポイント"},
+		{"loopback endpoint", "https://127.0.0.1/push/abc", webPushTestP256dh, webPushTestAuth, "エンドポイント"},
+		{"localhost endpoint", "https://localhost:8443/push/abc", webPushTestP256dh, webPushTestAuth, "エンドポイント"},
+		{"metadata endpoint", "https://169.254.169.254/latest", webPushTestP256dh, webPushTestAuth, "エンドポイント"},
+		{"invalid p256dh", "https://push.example.com/abc", "BCVxsr7N", webPushTestAuth, "鍵"},
+		{"invalid auth", "https://push.example.com/abc", webPushTestP256dh, webPushTestP256dh, "鍵"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &webPushMockWebPushSubscriptionRepository{
+				UpsertFunc: func(ctx context.Context, subscription *models.WebPushSubscription) error {
+					t.Fatal("invalid subscription should not be saved")
+					return nil
+				},
+			}
+
+			uc := NewWebPushUsecase(repo, newWebPushTestKeys(t))
+			_, err := uc.Subscribe(context.Background(), 1, tt.endpoint, tt.p256dh, tt.auth, "")
+
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWebPushUsecase_Subscribe_NotConfigured(t *testing.T) {
+	uc := NewWebPushUsecase(&webPushMockWebPushSubscriptionRepository{}, nil)
+
+	_, err := uc.Subscribe(context.Background(), 1, "https://push.example.com/abc", webPushTestP256dh, webPushTestAuth, "")
+
+	assert.ErrorContains(t, err, "利用できません")
+	assert.Equal(t, "", uc.VAPIDPublicKey())
+}
+
+func TestWebPushUsecase_Subscribe_Limit(t *testing.T) {
+	existing := make([]models.WebPushSubscription, 0, maxWebPushSubscriptionsPerUser)
+	for i := 0; i < maxWebPushSubscriptionsPerUser; i++ {
+		existing = append(existing, models.WebPushSubscription{ID: uint64(i + 1), Endpoint: fmt.Sprintf("https://push.example.com/%d", i)})
+	}
+	repo := &webPushMockWebPushSubscriptionRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64) ([]models.WebPushSubscription, error) {
+			return existing, nil
+		},
+	}
+	uc := NewWebPushUsecase(repo, newWebPushTestKeys(t))
+
+	_, err := uc.Subscribe(context.Background(), 1, "https://push.example.com/new", webPushTestP256dh, webPushTestAuth, "")
+	assert.ErrorContains(t, err, "10台まで")
+
+	// 登録済みの端末は鍵を更新できる
+	_, err = uc.Subscribe(context.Background(), 1, "https://push.example.com/0", webPushTestP256dh, webPushTestAuth, "")
+	assert.NoError(t, err)
+}
+
+func TestWebPushUsecase_Unsubscribe(t *testing.T) {
+	var deletedUserID uint64
+	var deletedEndpoint string
+	repo := &webPushMockWebPushSubscriptionRepository{
+		DeleteByEndpointFunc: func(ctx context.Context, userID uint64, endpoint string) error {
+			deletedUserID = userID
+			deletedEndpoint = endpoint
+			return nil
+		},
+	}
+	uc := NewWebPushUsecase(repo, nil)
+
+	assert.NoError(t, uc.Unsubscribe(context.Background(), 1, "https://push.example.com/abc"))
+	assert.Equal(t, uint64(1), deletedUserID)
+	assert.Equal(t, "https://push.example.com/abc", deletedEndpoint)
+
+	assert.Error(t, uc.Unsubscribe(context.Background(), 1, ""))
+}
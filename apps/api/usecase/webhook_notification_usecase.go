@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/keeee21/commitly/api/repository"
+)
+
+// webhookSecretPrefix 生成するシークレットの接頭辞（ログなどで見分けやすくする）
+const webhookSecretPrefix = "whsec_"
+
+// IWebhookNotificationUsecase 汎用Webhook通知ユースケースのインターフェース
+type IWebhookNotificationUsecase interface {
+	GetSetting(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error)
+	Create(ctx context.Context, userID uint64, url string) (*models.WebhookNotificationSetting, error)
+	RotateSecret(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error)
+	UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error
+	Delete(ctx context.Context, userID uint64) error
+}
+
+type webhookNotificationUsecase struct {
+	webhookRepo repository.IWebhookNotificationSettingRepository
+}
+
+// NewWebhookNotificationUsecase コンストラクタ
+func NewWebhookNotificationUsecase(webhookRepo repository.IWebhookNotificationSettingRepository) IWebhookNotificationUsecase {
+	return &webhookNotificationUsecase{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (u *webhookNotificationUsecase) GetSetting(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error) {
+	return u.webhookRepo.FindByUserID(ctx, userID)
+}
+
+// Create 送信先URLを登録する（既存の設定がある場合はシークレットを引き継ぐ）
+func (u *webhookNotificationUsecase) Create(ctx context.Context, userID uint64, url string) (*models.WebhookNotificationSetting, error) {
+	// 既存の設定を取得
+	existing, err := u.webhookRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing != nil {
+		// 更新
+		existing.URL = url
+		existing.IsEnabled = true
+
+		if err := u.webhookRepo.Upsert(ctx, existing); err != nil {
+			return nil, err
+		}
+		return existing, nil
+	}
+
+	// 新規作成
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	setting := &models.WebhookNotificationSetting{
+		UserID:    userID,
+		URL:       url,
+		Secret:    secret,
+		IsEnabled: true,
+	}
+
+	if err := u.webhookRepo.Upsert(ctx, setting); err != nil {
+		return nil, err
+	}
+
+	return setting, nil
+}
+
+// RotateSecret 署名用のシークレットを再発行する
+func (u *webhookNotificationUsecase) RotateSecret(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error) {
+	setting, err := u.webhookRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if setting == nil {
+		return nil, fmt.Errorf("Webhook通知設定が見つかりません")
+	}
+
+	secret, err := generateWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	setting.Secret = secret
+
+	if err := u.webhookRepo.Upsert(ctx, setting); err != nil {
+		return nil, err
+	}
+
+	return setting, nil
+}
+
+func (u *webhookNotificationUsecase) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return u.webhookRepo.UpdateEnabled(ctx, userID, isEnabled)
+}
+
+func (u *webhookNotificationUsecase) Delete(ctx context.Context, userID uint64) error {
+	return u.webhookRepo.Delete(ctx, userID)
+}
+
+// generateWebhookSecret 署名用のランダムなシークレットを生成する
+func generateWebhookSecret() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return webhookSecretPrefix + hex.EncodeToString(b), nil
+}
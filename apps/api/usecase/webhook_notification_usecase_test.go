@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/keeee21/commitly/api/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type webhookMockWebhookNotificationSettingRepository struct {
+	FindByUserIDFunc func(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error)
+	UpsertFunc       func(ctx context.Context, setting *models.WebhookNotificationSetting) error
+}
+
+func (m *webhookMockWebhookNotificationSettingRepository) FindByUserID(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error) {
+	if m.FindByUserIDFunc != nil {
+		return m.FindByUserIDFunc(ctx, userID)
+	}
+	return nil, nil
+}
+
+func (m *webhookMockWebhookNotificationSettingRepository) FindAllEnabled(ctx context.Context) ([]models.WebhookNotificationSetting, error) {
+	return nil, nil
+}
+
+func (m *webhookMockWebhookNotificationSettingRepository) Upsert(ctx context.Context, setting *models.WebhookNotificationSetting) error {
+	if m.UpsertFunc != nil {
+		return m.UpsertFunc(ctx, setting)
+	}
+	return nil
+}
+
+func (m *webhookMockWebhookNotificationSettingRepository) UpdateEnabled(ctx context.Context, userID uint64, isEnabled bool) error {
+	return nil
+}
+
+func (m *webhookMockWebhookNotificationSettingRepository) Delete(ctx context.Context, userID uint64) error {
+	return nil
+}
+
+func TestWebhookNotificationUsecase_Create_GeneratesSecret(t *testing.T) {
+	var upserted *models.WebhookNotificationSetting
+	repo := &webhookMockWebhookNotificationSettingRepository{
+		UpsertFunc: func(ctx context.Context, setting *models.WebhookNotificationSetting) error {
+			upserted = setting
+			return nil
+		},
+	}
+
+	uc := NewWebhookNotificationUsecase(repo)
+	setting, err := uc.Create(context.Background(), 1, "https://n8n.example.com/webhook/abc")
+
+	assert.NoError(t, err)
+	assert.Equal(t, setting, upserted)
+	assert.Equal(t, "https://n8n.example.com/webhook/abc", setting.URL)
+	assert.True(t, setting.IsEnabled)
+	assert.True(t, strings.HasPrefix(setting.Secret, "whsec_"))
+	assert.Len(t, setting.Secret, len("whsec_")+64)
+}
+
+func TestWebhookNotificationUsecase_Create_KeepsExistingSecret(t *testing.T) {
+	repo := &webhookMockWebhookNotificationSettingRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error) {
+			return &models.WebhookNotificationSetting{ID: 1, UserID: userID, URL: "https://old.example.com", Secret: "whsec_existing", IsEnabled: false}, nil
+		},
+	}
+
+	uc := NewWebhookNotificationUsecase(repo)
+	setting, err := uc.Create(context.Background(), 1, "https://new.example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://new.example.com", setting.URL)
+	assert.Equal(t, "whsec_existing", setting.Secret)
+	assert.True(t, setting.IsEnabled)
+}
+
+func TestWebhookNotificationUsecase_RotateSecret(t *testing.T) {
+	repo := &webhookMockWebhookNotificationSettingRepository{
+		FindByUserIDFunc: func(ctx context.Context, userID uint64) (*models.WebhookNotificationSetting, error) {
+			return &models.WebhookNotificationSetting{ID: 1, UserID: userID, URL: "https://n8n.example.com", Secret: "whsec_existing"}, nil
+		},
+	}
+
+	uc := NewWebhookNotificationUsecase(repo)
+	setting, err := uc.RotateSecret(context.Background(), 1)
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, "whsec_existing", setting.Secret)
+	assert.True(t, strings.HasPrefix(setting.Secret, "whsec_"))
+}
+
+func TestWebhookNotificationUsecase_RotateSecret_NotFound(t *testing.T) {
+	uc := NewWebhookNotificationUsecase(&webhookMockWebhookNotificationSettingRepository{})
+	setting, err := uc.RotateSecret(context.Background(), 1)
+
+	assert.Nil(t, setting)
+	assert.EqualError(t, err, "Webhook通知設定が見つかりません")
+}
@@ -1,5 +1,6 @@
 import NextAuth from "next-auth";
 import GitHub from "next-auth/providers/github";
+import { headers } from "next/headers";
 import { envConfig } from "./env.config";
 
 // GitHub Profile型定義
@@ -11,6 +12,15 @@ interface GitHubProfile {
   avatar_url: string;
 }
 
+// ログイン中のリクエストの Accept-Language（リクエスト外で呼ばれた場合は空）
+async function getAcceptLanguage(): Promise<string> {
+  try {
+    return (await headers()).get("accept-language") ?? "";
+  } catch {
+    return "";
+  }
+}
+
 export const { handlers, auth, signIn, signOut } = NextAuth({
   trustHost: true,
   providers: [
@@ -39,6 +49,8 @@ export const { handlers, auth, signIn, signOut } = NextAuth({
             method: "POST",
             headers: {
               "Content-Type": "application/json",
+              // 新規ユーザーの通知メッセージの言語をブラウザの言語から決める
+              "Accept-Language": await getAcceptLanguage(),
             },
             body: JSON.stringify({
               github_user_id: githubUserId,